@@ -1,10 +1,30 @@
+// docker-credential-fake is a credential helper used by the tests. It implements the get, store, erase and list
+// commands of the docker credential helper protocol.
+//
+// Answers for get are read from the JSON fixture file set in DOCKER_CREDENTIAL_FAKE_FIXTURE:
+//
+//	{
+//	  "hosts": {
+//	    "registry.example.com": { "username": "<token>", "secret": "refresh", "expiresAt": "2030-01-01T00:00:00Z" },
+//	    "slow.example.com": { "username": "user", "secret": "password", "delay": "2s" },
+//	    "error.example.com": { "exitCode": 1, "output": "Error: Not found" }
+//	  },
+//	  "default": { "username": "user", "secret": "password" }
+//	}
+//
+// Stored credentials are kept in DOCKER_CREDENTIAL_FAKE_DIR and take precedence over the fixture.
 package main
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Cred struct {
@@ -13,30 +33,226 @@ type Cred struct {
 	Secret    string `json:"Secret"`
 }
 
-func main() {
-	b, err := io.ReadAll(os.Stdin)
+// Host describes how the helper answers for a hostname
+type Host struct {
+	Username  string `json:"username"`
+	Secret    string `json:"secret"`
+	ExpiresAt string `json:"expiresAt"`
+	Delay     string `json:"delay"`
+	ExitCode  int    `json:"exitCode"`
+	Output    string `json:"output"`
+}
+
+// Fixture maps hostnames to credentials. Default is used for unlisted hostnames
+type Fixture struct {
+	Hosts   map[string]Host `json:"hosts"`
+	Default *Host           `json:"default"`
+}
+
+const notFound = "credentials not found in native keychain"
+
+// builtinFixture is used when no fixture file is configured
+var builtinFixture = Fixture{
+	Hosts: map[string]Host{
+		"error.other.com":    {ExitCode: 1, Output: "Error: Not found"},
+		"notfound.other.com": {ExitCode: 1, Output: notFound},
+	},
+	Default: &Host{Username: "user", Secret: "password"},
+}
+
+// loadFixture reads the fixture file from DOCKER_CREDENTIAL_FAKE_FIXTURE
+func loadFixture() Fixture {
+	path := os.Getenv("DOCKER_CREDENTIAL_FAKE_FIXTURE")
+	if path == "" {
+		return builtinFixture
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		fail("Error: cannot read fixture " + path)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(b, &fixture); err != nil {
+		fail("Error: invalid fixture " + path)
+	}
+	return fixture
+}
+
+// lookup returns the fixture entry of a hostname, if any
+func (f Fixture) lookup(hostname string) (Host, bool) {
+	if host, ok := f.Hosts[hostname]; ok {
+		return host, true
+	}
+	if f.Default != nil {
+		return *f.Default, true
+	}
+	return Host{}, false
+}
+
+// simulate applies the latency and failure configured for a hostname
+func (h Host) simulate() {
+	if h.Delay != "" {
+		delay, err := time.ParseDuration(h.Delay)
+		if err != nil {
+			fail("Error: invalid delay " + h.Delay)
+		}
+		time.Sleep(delay)
+	}
+	if h.ExitCode != 0 {
+		os.Stdout.Write([]byte(h.Output))
+		os.Exit(h.ExitCode)
+	}
+}
+
+// expired reports whether the credential is past its expiry
+func (h Host) expired() bool {
+	if h.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, h.ExpiresAt)
 	if err != nil {
+		fail("Error: invalid expiresAt " + h.ExpiresAt)
+	}
+	return time.Now().After(expiresAt)
+}
+
+// storeDir returns the directory holding stored credentials, one file per server
+func storeDir() string {
+	if dir := os.Getenv("DOCKER_CREDENTIAL_FAKE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "docker-credential-fake")
+}
+
+func credFile(serverURL string) string {
+	return filepath.Join(storeDir(), base64.RawURLEncoding.EncodeToString([]byte(serverURL))+".json")
+}
+
+func fail(message string) {
+	os.Stdout.Write([]byte(message))
+	os.Exit(1)
+}
+
+func writeJSON(v any) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	// keep <token> usernames readable
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
 		os.Exit(2)
 	}
-	hostname := strings.TrimSpace(string(b))
+}
+
+func get(fixture Fixture, input []byte) {
+	hostname := strings.TrimSpace(string(input))
+	host, found := fixture.lookup(hostname)
+	host.simulate()
 
-	// simulate an error for testing
-	if hostname == "error.other.com" {
-	    os.Stdout.Write([]byte("Error: Not found"))
-		os.Exit(1)
+	// stored credentials take precedence over the fixture
+	if b, err := os.ReadFile(credFile(hostname)); err == nil {
+		os.Stdout.Write(b)
+		os.Stdout.Write([]byte("\n"))
+		return
 	}
 
-	cred := Cred{
-		ServerURL: hostname,
-		Username:  "user",
-		Secret:    "password",
+	if !found || host.expired() {
+		fail(notFound)
 	}
+	writeJSON(Cred{
+		ServerURL: hostname,
+		Username:  host.Username,
+		Secret:    host.Secret,
+	})
+}
 
+func store(fixture Fixture, input []byte) {
+	var cred Cred
+	if err := json.Unmarshal(input, &cred); err != nil || cred.ServerURL == "" {
+		fail("Error: invalid credentials payload")
+	}
+	if host, ok := fixture.Hosts[cred.ServerURL]; ok {
+		host.simulate()
+	}
+	if err := os.MkdirAll(storeDir(), 0o700); err != nil {
+		os.Exit(2)
+	}
 	out, err := json.MarshalIndent(cred, "", "  ")
 	if err != nil {
 		os.Exit(2)
 	}
+	// write then rename so concurrent readers never see a partial file
+	tmp, err := os.CreateTemp(storeDir(), "tmp-*")
+	if err != nil {
+		os.Exit(2)
+	}
+	if _, err := tmp.Write(out); err != nil {
+		os.Exit(2)
+	}
+	tmp.Close()
+	if err := os.Rename(tmp.Name(), credFile(cred.ServerURL)); err != nil {
+		os.Remove(tmp.Name())
+		os.Exit(2)
+	}
+}
+
+func erase(fixture Fixture, input []byte) {
+	hostname := strings.TrimSpace(string(input))
+	if host, ok := fixture.Hosts[hostname]; ok {
+		host.simulate()
+	}
+	err := os.Remove(credFile(hostname))
+	if errors.Is(err, fs.ErrNotExist) {
+		fail(notFound)
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+}
+
+func list() {
+	entries := map[string]string{}
+	files, err := os.ReadDir(storeDir())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		os.Exit(2)
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(storeDir(), file.Name()))
+		if err != nil {
+			continue
+		}
+		var cred Cred
+		if json.Unmarshal(b, &cred) == nil {
+			entries[cred.ServerURL] = cred.Username
+		}
+	}
+	writeJSON(entries)
+}
+
+func main() {
+	// default to get for backward compatibility
+	verb := "get"
+	if len(os.Args) > 1 {
+		verb = os.Args[1]
+	}
+
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		os.Exit(2)
+	}
 
-	os.Stdout.Write(out)
-	os.Stdout.Write([]byte("\n"))
+	fixture := loadFixture()
+	switch verb {
+	case "get":
+		get(fixture, b)
+	case "store":
+		store(fixture, b)
+	case "erase":
+		erase(fixture, b)
+	case "list":
+		list()
+	default:
+		fail("Error: unknown command " + verb)
+	}
 }
@@ -0,0 +1,68 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import java.io.PrintWriter;
+import java.util.concurrent.Callable;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine.Mixin;
+import picocli.CommandLine.Model.CommandSpec;
+import picocli.CommandLine.Spec;
+
+/**
+ * Base class of the commands talking to a registry
+ */
+@NullMarked
+public abstract class AbstractRegistryCommand implements Callable<Integer> {
+
+    @Mixin
+    private RegistryOptions registryOptions = new RegistryOptions();
+
+    @Spec
+    private CommandSpec spec;
+
+    /**
+     * Constructor
+     */
+    protected AbstractRegistryCommand() {}
+
+    @Override
+    public Integer call() {
+        call(registryOptions.registry());
+        out().flush();
+        return 0;
+    }
+
+    /**
+     * Run the command
+     * @param registry The registry
+     */
+    protected abstract void call(Registry registry);
+
+    /**
+     * The writer of the command output
+     * @return The writer
+     */
+    protected PrintWriter out() {
+        return spec.commandLine().getOut();
+    }
+}
@@ -0,0 +1,76 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.Manifest;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Option;
+import picocli.CommandLine.Parameters;
+
+/**
+ * Attach files to an existing artifact as a referrer
+ */
+@NullMarked
+@Command(name = "attach", description = "Attach files to an existing artifact")
+public class AttachCommand extends AbstractRegistryCommand {
+
+    @Parameters(index = "0", paramLabel = "<reference>", description = "Subject reference")
+    private String reference;
+
+    @Parameters(
+            index = "1..*",
+            arity = "1..*",
+            paramLabel = "<file>",
+            description = "Files to attach, using the path[:mediaType] syntax")
+    private List<String> files;
+
+    @Option(names = "--artifact-type", required = true, description = "Artifact type")
+    private String artifactType;
+
+    @Option(
+            names = {"-a", "--annotation"},
+            paramLabel = "<key=value>",
+            description = "Manifest annotation")
+    private Map<String, String> annotations = new LinkedHashMap<>();
+
+    /**
+     * Constructor
+     */
+    public AttachCommand() {}
+
+    @Override
+    protected void call(Registry registry) {
+        ContainerRef ref = ContainerRef.parse(reference);
+        Manifest manifest = registry.attachArtifact(
+                ref,
+                ArtifactType.from(artifactType),
+                PushCommand.annotations(annotations),
+                PushCommand.localPaths(files));
+        out().printf("Attached to %s%nDigest: %s%n", ref, manifest.getDigest());
+    }
+}
@@ -0,0 +1,63 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import land.oras.ContainerRef;
+import land.oras.CopyUtils;
+import land.oras.CopyUtils.CopyOptions;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Option;
+import picocli.CommandLine.Parameters;
+
+/**
+ * Copy an artifact between repositories or registries
+ */
+@NullMarked
+@Command(name = "copy", aliases = "cp", description = "Copy an artifact between repositories or registries")
+public class CopyCommand extends AbstractRegistryCommand {
+
+    @Parameters(index = "0", paramLabel = "<source>", description = "Source reference")
+    private String source;
+
+    @Parameters(index = "1", paramLabel = "<target>", description = "Target reference")
+    private String target;
+
+    @Option(
+            names = {"-r", "--recursive"},
+            description = "Also copy the referrers")
+    private boolean recursive;
+
+    /**
+     * Constructor
+     */
+    public CopyCommand() {}
+
+    @Override
+    protected void call(Registry registry) {
+        ContainerRef sourceRef = ContainerRef.parse(source);
+        ContainerRef targetRef = ContainerRef.parse(target);
+        CopyOptions options = recursive ? CopyOptions.deep() : CopyOptions.shallow();
+        CopyUtils.copy(registry, sourceRef, registry, targetRef, options);
+        out().printf("Copied %s to %s%n", sourceRef, targetRef);
+    }
+}
@@ -0,0 +1,83 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.ManifestDescriptor;
+import land.oras.Referrers;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Option;
+import picocli.CommandLine.Parameters;
+
+/**
+ * List the referrers of an artifact
+ */
+@NullMarked
+@Command(name = "discover", description = "List the referrers of an artifact")
+public class DiscoverCommand extends AbstractRegistryCommand {
+
+    /**
+     * Output formats
+     */
+    enum Format {
+        TEXT,
+        JSON
+    }
+
+    @Parameters(index = "0", paramLabel = "<reference>", description = "Subject reference")
+    private String reference;
+
+    @Option(names = "--artifact-type", description = "Only list referrers of this artifact type")
+    private @Nullable String artifactType;
+
+    @Option(
+            names = "--format",
+            defaultValue = "text",
+            description = "Output format, one of ${COMPLETION-CANDIDATES} (default: ${DEFAULT-VALUE})")
+    private Format format;
+
+    /**
+     * Constructor
+     */
+    public DiscoverCommand() {}
+
+    @Override
+    protected void call(Registry registry) {
+        ContainerRef ref = ContainerRef.parse(reference);
+        if (ref.getDigest() == null) {
+            ref = ref.withDigest(registry.getDescriptor(ref).getDigest());
+        }
+        Referrers referrers =
+                registry.getReferrers(ref, artifactType == null ? null : ArtifactType.from(artifactType));
+        if (format == Format.JSON) {
+            out().println(referrers.toJson());
+            return;
+        }
+        out().println(ref);
+        for (ManifestDescriptor descriptor : referrers.getManifests()) {
+            out().printf("└── %s %s%n", descriptor.getArtifactType(), descriptor.getDigest());
+        }
+    }
+}
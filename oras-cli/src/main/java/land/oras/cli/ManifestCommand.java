@@ -0,0 +1,80 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import land.oras.ContainerRef;
+import land.oras.Descriptor;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Model.CommandSpec;
+import picocli.CommandLine.Option;
+import picocli.CommandLine.ParameterException;
+import picocli.CommandLine.Parameters;
+import picocli.CommandLine.Spec;
+
+/**
+ * Manifest commands
+ */
+@NullMarked
+@Command(
+        name = "manifest",
+        description = "Manifest commands",
+        subcommands = {ManifestCommand.Fetch.class})
+public class ManifestCommand implements Runnable {
+
+    @Spec
+    private CommandSpec spec;
+
+    /**
+     * Constructor
+     */
+    public ManifestCommand() {}
+
+    @Override
+    public void run() {
+        throw new ParameterException(spec.commandLine(), "Missing required subcommand");
+    }
+
+    /**
+     * Print a manifest or index as returned by the registry
+     */
+    @Command(name = "fetch", description = "Print a manifest or index")
+    public static class Fetch extends AbstractRegistryCommand {
+
+        @Parameters(index = "0", paramLabel = "<reference>", description = "Manifest reference")
+        private String reference;
+
+        @Option(names = "--descriptor", description = "Print the descriptor instead of the content")
+        private boolean descriptor;
+
+        /**
+         * Constructor
+         */
+        public Fetch() {}
+
+        @Override
+        protected void call(Registry registry) {
+            Descriptor fetched = registry.getDescriptor(ContainerRef.parse(reference));
+            out().println(descriptor ? fetched.toJson() : fetched.getJson());
+        }
+    }
+}
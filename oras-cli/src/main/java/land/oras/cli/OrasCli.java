@@ -0,0 +1,99 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Model.CommandSpec;
+import picocli.CommandLine.ParameterException;
+import picocli.CommandLine.Spec;
+
+/**
+ * The {@code oras-java} command line. A thin layer over the SDK, mostly meant as a reference of its usage
+ */
+@NullMarked
+@Command(
+        name = "oras-java",
+        mixinStandardHelpOptions = true,
+        versionProvider = OrasCli.VersionProvider.class,
+        description = "Manage OCI artifacts in registries",
+        subcommands = {
+            PushCommand.class,
+            PullCommand.class,
+            AttachCommand.class,
+            DiscoverCommand.class,
+            CopyCommand.class,
+            TagCommand.class,
+            ManifestCommand.class
+        })
+public class OrasCli implements Runnable {
+
+    @Spec
+    private CommandSpec spec;
+
+    /**
+     * Constructor
+     */
+    public OrasCli() {}
+
+    @Override
+    public void run() {
+        throw new ParameterException(spec.commandLine(), "Missing required subcommand");
+    }
+
+    /**
+     * Run the CLI and exit with its status
+     * @param args The arguments
+     */
+    public static void main(String[] args) {
+        System.exit(commandLine().execute(args));
+    }
+
+    /**
+     * Create the command line. SDK errors are printed without stack trace and exit with status 1
+     * @return The command line
+     */
+    public static CommandLine commandLine() {
+        return new CommandLine(new OrasCli())
+                .setCaseInsensitiveEnumValuesAllowed(true)
+                .setExecutionExceptionHandler((e, commandLine, parseResult) -> {
+                    if (!(e instanceof OrasException)) {
+                        throw e;
+                    }
+                    commandLine.getErr().println("Error: " + e.getMessage());
+                    commandLine.getErr().flush();
+                    return 1;
+                });
+    }
+
+    /**
+     * Report the version of the CLI
+     */
+    static class VersionProvider implements CommandLine.IVersionProvider {
+        @Override
+        public String[] getVersion() {
+            String version = OrasCli.class.getPackage().getImplementationVersion();
+            return new String[] {"oras-java " + (version == null ? "dev" : version)};
+        }
+    }
+}
@@ -0,0 +1,70 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import land.oras.ContainerRef;
+import land.oras.Registry;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Option;
+import picocli.CommandLine.Parameters;
+
+/**
+ * Pull the files of an OCI artifact
+ */
+@NullMarked
+@Command(name = "pull", description = "Pull the files of an OCI artifact")
+public class PullCommand extends AbstractRegistryCommand {
+
+    @Parameters(index = "0", paramLabel = "<reference>", description = "Artifact reference")
+    private String reference;
+
+    @Option(
+            names = {"-o", "--output"},
+            paramLabel = "<dir>",
+            defaultValue = ".",
+            description = "Output directory (default: ${DEFAULT-VALUE})")
+    private Path output;
+
+    @Option(names = "--overwrite", description = "Overwrite existing files")
+    private boolean overwrite;
+
+    /**
+     * Constructor
+     */
+    public PullCommand() {}
+
+    @Override
+    protected void call(Registry registry) {
+        ContainerRef ref = ContainerRef.parse(reference);
+        try {
+            Files.createDirectories(output);
+        } catch (IOException e) {
+            throw new OrasException("Failed to create directory %s".formatted(output), e);
+        }
+        registry.pullArtifact(ref, output, overwrite);
+        out().printf("Pulled %s into %s%n", ref, output);
+    }
+}
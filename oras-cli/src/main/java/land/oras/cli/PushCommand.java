@@ -0,0 +1,94 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.Annotations;
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.LocalPath;
+import land.oras.Manifest;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Option;
+import picocli.CommandLine.Parameters;
+
+/**
+ * Push files as an OCI artifact
+ */
+@NullMarked
+@Command(name = "push", description = "Push files as an OCI artifact")
+public class PushCommand extends AbstractRegistryCommand {
+
+    @Parameters(index = "0", paramLabel = "<reference>", description = "Target reference")
+    private String reference;
+
+    @Parameters(
+            index = "1..*",
+            arity = "1..*",
+            paramLabel = "<file>",
+            description = "Files to push, using the path[:mediaType] syntax")
+    private List<String> files;
+
+    @Option(names = "--artifact-type", description = "Artifact type")
+    private @Nullable String artifactType;
+
+    @Option(
+            names = {"-a", "--annotation"},
+            paramLabel = "<key=value>",
+            description = "Manifest annotation")
+    private Map<String, String> annotations = new LinkedHashMap<>();
+
+    /**
+     * Constructor
+     */
+    public PushCommand() {}
+
+    @Override
+    protected void call(Registry registry) {
+        ContainerRef ref = ContainerRef.parse(reference);
+        Manifest manifest = registry.pushArtifact(
+                ref, ArtifactType.from(artifactType), annotations(annotations), localPaths(files));
+        out().printf("Pushed %s%nDigest: %s%n", ref, manifest.getDigest());
+    }
+
+    /**
+     * Convert the file arguments to local paths
+     * @param files The files using the path[:mediaType] syntax
+     * @return The local paths
+     */
+    static LocalPath[] localPaths(List<String> files) {
+        return files.stream().map(LocalPath::of).toArray(LocalPath[]::new);
+    }
+
+    /**
+     * Convert annotation options to manifest annotations
+     * @param annotations The annotations
+     * @return The manifest annotations
+     */
+    static Annotations annotations(Map<String, String> annotations) {
+        return annotations.isEmpty() ? Annotations.empty() : Annotations.ofManifest(annotations);
+    }
+}
@@ -0,0 +1,80 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import java.nio.file.Path;
+import land.oras.Registry;
+import land.oras.auth.BearerTokenProvider;
+import land.oras.auth.CredentialChain;
+import land.oras.auth.UsernamePasswordProvider;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import picocli.CommandLine.Option;
+
+/**
+ * Registry connection options shared by all commands. Flags follow the ones of the oras CLI
+ */
+@NullMarked
+public class RegistryOptions {
+
+    @Option(
+            names = {"-u", "--username"},
+            description = "Registry username")
+    private @Nullable String username;
+
+    @Option(
+            names = {"-p", "--password"},
+            description = "Registry password or identity token")
+    private @Nullable String password;
+
+    @Option(names = "--plain-http", description = "Use plain HTTP instead of HTTPS")
+    private boolean plainHttp;
+
+    @Option(names = "--insecure", description = "Skip TLS certificate verification")
+    private boolean insecure;
+
+    @Option(names = "--ca-file", paramLabel = "<file>", description = "CA certificate used to verify the registry")
+    private @Nullable Path caFile;
+
+    /**
+     * Constructor
+     */
+    public RegistryOptions() {}
+
+    /**
+     * Create the registry from the options. Falls back to the docker config when no credentials are given
+     * @return The registry
+     */
+    public Registry registry() {
+        Registry.Builder builder = Registry.builder().withInsecure(plainHttp).withSkipTlsVerify(insecure);
+        if (caFile != null) {
+            builder.withCaFile(caFile);
+        }
+        if (username != null && password != null) {
+            builder.withAuthProvider(new UsernamePasswordProvider(username, password));
+        } else if (password != null) {
+            builder.withAuthProvider(new BearerTokenProvider(password));
+        } else {
+            builder.withAuthProvider(CredentialChain.defaults());
+        }
+        return builder.build();
+    }
+}
@@ -0,0 +1,55 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import java.util.List;
+import land.oras.ContainerRef;
+import land.oras.Registry;
+import org.jspecify.annotations.NullMarked;
+import picocli.CommandLine.Command;
+import picocli.CommandLine.Parameters;
+
+/**
+ * Tag an existing manifest
+ */
+@NullMarked
+@Command(name = "tag", description = "Tag an existing manifest")
+public class TagCommand extends AbstractRegistryCommand {
+
+    @Parameters(index = "0", paramLabel = "<reference>", description = "Existing reference")
+    private String reference;
+
+    @Parameters(index = "1..*", arity = "1..*", paramLabel = "<tag>", description = "New tags")
+    private List<String> tags;
+
+    /**
+     * Constructor
+     */
+    public TagCommand() {}
+
+    @Override
+    protected void call(Registry registry) {
+        ContainerRef ref = ContainerRef.parse(reference);
+        for (String tag : tags) {
+            out().printf("Tagged %s%n", registry.tag(ref, tag));
+        }
+    }
+}
@@ -0,0 +1,108 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.cli;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.PrintWriter;
+import java.io.StringWriter;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.List;
+import land.oras.Referrers;
+import land.oras.utils.FakeRegistry;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class OrasCliTest {
+
+    @TempDir
+    private Path dir;
+
+    @Test
+    void shouldPushTagAttachDiscoverCopyAndPull() throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            String repository = fake.getRegistry() + "/library/artifact";
+            Path file = Files.writeString(dir.resolve("hello.txt"), "hello");
+            Path sbom = Files.writeString(dir.resolve("sbom.json"), "{}");
+
+            Result push = run("push", "--plain-http", repository + ":1.0", file.toString(), "-a", "key=value");
+            Result tag = run("tag", "--plain-http", repository + ":1.0", "latest");
+            Result fetch = run("manifest", "fetch", "--plain-http", repository + ":latest");
+            Result attach = run(
+                    "attach",
+                    "--plain-http",
+                    repository + ":1.0",
+                    "--artifact-type",
+                    "application/vnd.example.sbom",
+                    sbom + ":application/json");
+            Result discover = run("discover", "--plain-http", "--format", "json", repository + ":1.0");
+            Result copy = run("copy", "--plain-http", "-r", repository + ":1.0", repository + "-copy:1.0");
+            Result pull = run("pull", "--plain-http", "-o", dir.resolve("out").toString(), repository + "-copy:1.0");
+
+            // Assertion
+            assertEquals(0, push.status(), push.err());
+            assertTrue(push.out().contains("Digest: sha256:"));
+            assertEquals(0, tag.status(), tag.err());
+            assertEquals(List.of("1.0", "latest"), fake.getTags("library/artifact"));
+            assertEquals(0, fetch.status(), fetch.err());
+            assertTrue(fetch.out().contains("\"key\":\"value\""));
+            assertEquals(0, attach.status(), attach.err());
+            assertEquals(0, discover.status(), discover.err());
+            Referrers referrers = Referrers.fromJson(discover.out());
+            assertEquals(1, referrers.getManifests().size());
+            assertEquals("application/vnd.example.sbom", referrers.getManifests().get(0).getArtifactType());
+            assertEquals(0, copy.status(), copy.err());
+            assertEquals(0, pull.status(), pull.err());
+            assertEquals("hello", Files.readString(dir.resolve("out/hello.txt")));
+        }
+    }
+
+    @Test
+    void shouldReportErrors() throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Result missing = run("manifest", "fetch", "--plain-http", fake.getRegistry() + "/library/missing:1.0");
+            Result usage = run("manifest");
+            Result attach = run("attach", "--plain-http", fake.getRegistry() + "/library/missing:1.0", "file.txt");
+
+            // Assertion
+            assertEquals(1, missing.status());
+            assertTrue(missing.err().startsWith("Error: "), missing.err());
+            assertEquals(2, usage.status());
+            assertTrue(usage.err().contains("Missing required subcommand"), usage.err());
+            assertEquals(2, attach.status());
+            assertTrue(attach.err().contains("--artifact-type"), attach.err());
+        }
+    }
+
+    private static Result run(String... args) {
+        StringWriter out = new StringWriter();
+        StringWriter err = new StringWriter();
+        int status = OrasCli.commandLine().setOut(new PrintWriter(out)).setErr(new PrintWriter(err)).execute(args);
+        return new Result(status, out.toString(), err.toString());
+    }
+
+    private record Result(int status, String out, String err) {}
+}
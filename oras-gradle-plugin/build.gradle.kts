@@ -0,0 +1,45 @@
+plugins {
+    `java-gradle-plugin`
+    `maven-publish`
+}
+
+group = "land.oras"
+version = "0.7.1-SNAPSHOT"
+description = "Push and pull OCI artifacts from Gradle builds with the ORAS Java SDK"
+
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(17)
+    }
+}
+
+repositories {
+    // The SDK of the same version is installed locally with `mvn install` from the repository root
+    mavenLocal()
+    mavenCentral()
+}
+
+dependencies {
+    implementation("land.oras:oras-java-sdk:${project.version}")
+    compileOnly("org.jspecify:jspecify:1.0.0")
+
+    testImplementation(platform("org.junit:junit-bom:6.1.1"))
+    testImplementation("org.junit.jupiter:junit-jupiter")
+    testImplementation("land.oras:oras-java-sdk:${project.version}:test-fixtures")
+    testRuntimeOnly("org.junit.platform:junit-platform-launcher")
+}
+
+gradlePlugin {
+    plugins {
+        create("oras") {
+            id = "land.oras"
+            displayName = "ORAS"
+            description = project.description
+            implementationClass = "land.oras.gradle.OrasPlugin"
+        }
+    }
+}
+
+tasks.test {
+    useJUnitPlatform()
+}
@@ -0,0 +1 @@
+rootProject.name = "oras-gradle-plugin"
@@ -0,0 +1,107 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.gradle;
+
+import land.oras.Registry;
+import land.oras.auth.BearerTokenProvider;
+import land.oras.auth.CredentialChain;
+import land.oras.auth.UsernamePasswordProvider;
+import land.oras.exception.OrasException;
+import org.gradle.api.DefaultTask;
+import org.gradle.api.GradleException;
+import org.gradle.api.provider.Property;
+import org.gradle.api.tasks.Input;
+import org.gradle.api.tasks.Internal;
+import org.gradle.api.tasks.Optional;
+import org.gradle.api.tasks.TaskAction;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Base class of the ORAS tasks. Only uses lazy properties so the tasks are compatible with the configuration cache
+ */
+@NullMarked
+public abstract class AbstractOrasTask extends DefaultTask {
+
+    /**
+     * Constructor
+     */
+    public AbstractOrasTask() {
+        setGroup("oras");
+    }
+
+    /**
+     * Use plain HTTP to reach the registry
+     * @return The property
+     */
+    @Input
+    public abstract Property<Boolean> getInsecure();
+
+    /**
+     * The username
+     * @return The property
+     */
+    @Input
+    @Optional
+    public abstract Property<String> getUsername();
+
+    /**
+     * The password or token. Not an input so it's never stored in the build cache
+     * @return The property
+     */
+    @Internal
+    public abstract Property<String> getPassword();
+
+    /**
+     * Execute the task
+     */
+    @TaskAction
+    public void execute() {
+        try {
+            execute(createRegistry());
+        } catch (OrasException e) {
+            throw new GradleException(e.getMessage(), e);
+        }
+    }
+
+    /**
+     * Execute the task with the configured registry
+     * @param registry The registry
+     */
+    protected abstract void execute(Registry registry);
+
+    /**
+     * Create the registry from the task properties
+     * @return The registry
+     */
+    protected Registry createRegistry() {
+        Registry.Builder builder = Registry.builder().withInsecure(getInsecure().getOrElse(false));
+        String password = getPassword().getOrNull();
+        String username = getUsername().getOrNull();
+        if (password != null && username != null) {
+            builder.withAuthProvider(new UsernamePasswordProvider(username, password));
+        } else if (password != null) {
+            builder.withAuthProvider(new BearerTokenProvider(password));
+        } else {
+            builder.withAuthProvider(CredentialChain.defaults());
+        }
+        return builder.build();
+    }
+}
@@ -0,0 +1,54 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.gradle;
+
+import org.gradle.api.provider.Property;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * The {@code oras} extension holding the settings shared by all ORAS tasks
+ */
+@NullMarked
+public abstract class OrasExtension {
+
+    /**
+     * Constructor
+     */
+    public OrasExtension() {}
+
+    /**
+     * Use plain HTTP to reach the registry. Defaults to false
+     * @return The property
+     */
+    public abstract Property<Boolean> getInsecure();
+
+    /**
+     * The username. Defaults to the {@code orasUsername} Gradle property, then the docker config
+     * @return The property
+     */
+    public abstract Property<String> getUsername();
+
+    /**
+     * The password or token. Defaults to the {@code orasPassword} Gradle property
+     * @return The property
+     */
+    public abstract Property<String> getPassword();
+}
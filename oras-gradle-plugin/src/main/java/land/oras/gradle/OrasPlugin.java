@@ -0,0 +1,69 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.gradle;
+
+import java.util.List;
+import org.gradle.api.Plugin;
+import org.gradle.api.Project;
+import org.gradle.api.provider.ProviderFactory;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Register the {@code oras} extension, the {@code orasPush} and {@code orasPull} tasks and the
+ * {@link OrasPushTask} and {@link OrasPullTask} conventions
+ */
+@NullMarked
+public class OrasPlugin implements Plugin<Project> {
+
+    /**
+     * The name of the extension
+     */
+    public static final String EXTENSION_NAME = "oras";
+
+    /**
+     * Constructor
+     */
+    public OrasPlugin() {}
+
+    @Override
+    public void apply(Project project) {
+        ProviderFactory providers = project.getProviders();
+        OrasExtension extension = project.getExtensions().create(EXTENSION_NAME, OrasExtension.class);
+        extension.getInsecure().convention(false);
+        extension.getUsername().convention(providers.gradleProperty("orasUsername"));
+        extension.getPassword().convention(providers.gradleProperty("orasPassword"));
+
+        project.getTasks().withType(AbstractOrasTask.class).configureEach(task -> {
+            task.getInsecure().convention(extension.getInsecure());
+            task.getUsername().convention(extension.getUsername());
+            task.getPassword().convention(extension.getPassword());
+        });
+        project.getTasks().withType(OrasPushTask.class).configureEach(task -> task.getTags()
+                .convention(List.of("latest")));
+        project.getTasks().withType(OrasPullTask.class).configureEach(task -> {
+            task.getOutputDirectory().convention(project.getLayout().getBuildDirectory().dir("oras"));
+            task.getOverwrite().convention(true);
+        });
+
+        project.getTasks().register("orasPush", OrasPushTask.class);
+        project.getTasks().register("orasPull", OrasPullTask.class);
+    }
+}
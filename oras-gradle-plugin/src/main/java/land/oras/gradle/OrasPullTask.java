@@ -0,0 +1,73 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.gradle;
+
+import land.oras.ContainerRef;
+import land.oras.Registry;
+import org.gradle.api.file.DirectoryProperty;
+import org.gradle.api.provider.Property;
+import org.gradle.api.tasks.Input;
+import org.gradle.api.tasks.OutputDirectory;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Pull the files of an OCI artifact into a directory
+ */
+@NullMarked
+public abstract class OrasPullTask extends AbstractOrasTask {
+
+    /**
+     * Constructor
+     */
+    public OrasPullTask() {
+        setDescription("Pull the files of an OCI artifact");
+        // Tags are mutable, always check the registry
+        getOutputs().upToDateWhen(task -> false);
+    }
+
+    /**
+     * The artifact reference, for example {@code ghcr.io/org/app:1.0}
+     * @return The property
+     */
+    @Input
+    public abstract Property<String> getReference();
+
+    /**
+     * The directory receiving the files. Defaults to {@code build/oras}
+     * @return The property
+     */
+    @OutputDirectory
+    public abstract DirectoryProperty getOutputDirectory();
+
+    /**
+     * Overwrite existing files. Defaults to true
+     * @return The property
+     */
+    @Input
+    public abstract Property<Boolean> getOverwrite();
+
+    @Override
+    protected void execute(Registry registry) {
+        ContainerRef ref = ContainerRef.parse(getReference().get());
+        registry.pullArtifact(ref, getOutputDirectory().get().getAsFile().toPath(), getOverwrite().get());
+        getLogger().lifecycle("Pulled {} into {}", ref, getOutputDirectory().get().getAsFile());
+    }
+}
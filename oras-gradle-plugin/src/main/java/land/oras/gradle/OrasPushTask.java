@@ -0,0 +1,128 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.gradle;
+
+import java.io.File;
+import java.util.List;
+import java.util.Map;
+import land.oras.Annotations;
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.LocalPath;
+import land.oras.Manifest;
+import land.oras.Registry;
+import org.gradle.api.GradleException;
+import org.gradle.api.file.ConfigurableFileCollection;
+import org.gradle.api.provider.ListProperty;
+import org.gradle.api.provider.MapProperty;
+import org.gradle.api.provider.Property;
+import org.gradle.api.tasks.Input;
+import org.gradle.api.tasks.InputFiles;
+import org.gradle.api.tasks.Optional;
+import org.gradle.api.tasks.PathSensitive;
+import org.gradle.api.tasks.PathSensitivity;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Push files as an OCI artifact to a repository, under each configured tag
+ */
+@NullMarked
+public abstract class OrasPushTask extends AbstractOrasTask {
+
+    /**
+     * Constructor
+     */
+    public OrasPushTask() {
+        setDescription("Push files as an OCI artifact");
+    }
+
+    /**
+     * The repository, for example {@code ghcr.io/org/app}
+     * @return The property
+     */
+    @Input
+    public abstract Property<String> getRepository();
+
+    /**
+     * The tags. The artifact is pushed to the first tag and tagged with the others. Defaults to {@code latest}
+     * @return The property
+     */
+    @Input
+    public abstract ListProperty<String> getTags();
+
+    /**
+     * The files to push. The file name is used as title
+     * @return The files
+     */
+    @InputFiles
+    @PathSensitive(PathSensitivity.NAME_ONLY)
+    public abstract ConfigurableFileCollection getFiles();
+
+    /**
+     * The media type of the files. Defaults to the SDK default layer media type
+     * @return The property
+     */
+    @Input
+    @Optional
+    public abstract Property<String> getMediaType();
+
+    /**
+     * The artifact type
+     * @return The property
+     */
+    @Input
+    @Optional
+    public abstract Property<String> getArtifactType();
+
+    /**
+     * The manifest annotations
+     * @return The property
+     */
+    @Input
+    public abstract MapProperty<String, String> getAnnotations();
+
+    @Override
+    protected void execute(Registry registry) {
+        List<String> tags = getTags().get();
+        if (tags.isEmpty()) {
+            throw new GradleException("At least one tag is required");
+        }
+        String mediaType = getMediaType().getOrNull();
+        LocalPath[] paths = getFiles().getFiles().stream()
+                .map(File::toPath)
+                .map(path -> mediaType == null ? LocalPath.of(path) : LocalPath.of(path, mediaType))
+                .toArray(LocalPath[]::new);
+        if (paths.length == 0) {
+            throw new GradleException("No files to push");
+        }
+        Map<String, String> annotations = getAnnotations().get();
+        ContainerRef ref = ContainerRef.parse("%s:%s".formatted(getRepository().get(), tags.get(0)));
+        Manifest manifest = registry.pushArtifact(
+                ref,
+                ArtifactType.from(getArtifactType().getOrNull()),
+                annotations.isEmpty() ? Annotations.empty() : Annotations.ofManifest(annotations),
+                paths);
+        for (String tag : tags.subList(1, tags.size())) {
+            registry.tag(ref, tag);
+        }
+        getLogger().lifecycle("Pushed {}@{} as {}", getRepository().get(), manifest.getDigest(), tags);
+    }
+}
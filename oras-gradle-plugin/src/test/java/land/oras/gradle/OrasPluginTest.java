@@ -0,0 +1,108 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.gradle;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertInstanceOf;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.List;
+import land.oras.utils.FakeRegistry;
+import org.gradle.api.Project;
+import org.gradle.testfixtures.ProjectBuilder;
+import org.gradle.testkit.runner.BuildResult;
+import org.gradle.testkit.runner.GradleRunner;
+import org.gradle.testkit.runner.TaskOutcome;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class OrasPluginTest {
+
+    @TempDir
+    private Path projectDir;
+
+    @Test
+    void shouldRegisterTasksWithConventions() {
+        Project project = ProjectBuilder.builder().withProjectDir(projectDir.toFile()).build();
+        project.getPluginManager().apply("land.oras");
+        project.getExtensions().getByType(OrasExtension.class).getInsecure().set(true);
+
+        // Assertion
+        OrasPushTask push = assertInstanceOf(OrasPushTask.class, project.getTasks().getByName("orasPush"));
+        assertEquals(List.of("latest"), push.getTags().get());
+        assertTrue(push.getInsecure().get());
+        assertEquals("oras", push.getGroup());
+        OrasPullTask pull = assertInstanceOf(OrasPullTask.class, project.getTasks().getByName("orasPull"));
+        assertEquals(
+                projectDir.resolve("build/oras").toFile(),
+                pull.getOutputDirectory().get().getAsFile());
+        assertTrue(pull.getOverwrite().get());
+        assertFalse(pull.getUsername().isPresent());
+    }
+
+    @Test
+    void shouldPushAndPullWithConfigurationCache() throws IOException {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Files.writeString(projectDir.resolve("settings.gradle.kts"), "rootProject.name = \"sample\"\n");
+            Files.writeString(projectDir.resolve("hello.txt"), "hello");
+            Files.writeString(projectDir.resolve("build.gradle.kts"), """
+                    plugins { id("land.oras") }
+                    oras { insecure = true }
+                    tasks.orasPush {
+                        repository = "%1$s/library/sample"
+                        tags = listOf("1.0", "latest")
+                        files.from("hello.txt")
+                        artifactType = "application/vnd.example.sample"
+                        annotations.put("org.opencontainers.image.version", "1.0")
+                    }
+                    tasks.orasPull {
+                        reference = "%1$s/library/sample:1.0"
+                    }
+                    """.formatted(fake.getRegistry()));
+
+            BuildResult push = run("orasPush");
+            BuildResult reused = run("orasPush");
+            BuildResult pull = run("orasPull");
+
+            // Assertion
+            assertEquals(TaskOutcome.SUCCESS, push.task(":orasPush").getOutcome());
+            assertTrue(reused.getOutput().contains("Reusing configuration cache"));
+            assertEquals(List.of("1.0", "latest"), fake.getTags("library/sample"));
+            assertEquals(TaskOutcome.SUCCESS, pull.task(":orasPull").getOutcome());
+            assertEquals("hello", Files.readString(projectDir.resolve("build/oras/hello.txt")));
+        }
+    }
+
+    private BuildResult run(String task) {
+        return GradleRunner.create()
+                .withProjectDir(projectDir.toFile())
+                .withPluginClasspath()
+                .withArguments(task, "--configuration-cache", "--stacktrace")
+                .build();
+    }
+}
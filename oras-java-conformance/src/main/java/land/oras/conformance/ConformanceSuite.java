@@ -0,0 +1,454 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.conformance;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+import static org.junit.jupiter.api.DynamicContainer.dynamicContainer;
+import static org.junit.jupiter.api.DynamicTest.dynamicTest;
+
+import java.io.ByteArrayInputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.Arrays;
+import java.util.EnumSet;
+import java.util.List;
+import java.util.Map;
+import java.util.Optional;
+import java.util.Set;
+import java.util.UUID;
+import java.util.concurrent.atomic.AtomicReference;
+import java.util.stream.Stream;
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.Descriptor;
+import land.oras.Layer;
+import land.oras.Manifest;
+import land.oras.ManifestDescriptor;
+import land.oras.Registry;
+import land.oras.exception.OperationNotSupportedException;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.jspecify.annotations.NullMarked;
+import org.junit.jupiter.api.Assumptions;
+import org.junit.jupiter.api.DynamicNode;
+import org.junit.jupiter.api.DynamicTest;
+import org.junit.jupiter.api.function.Executable;
+
+/**
+ * Scenarios of the OCI distribution conformance tests (pull, push, content discovery and content management) run
+ * against a registry using this SDK as the client.
+ * The scenarios are JUnit 5 dynamic tests grouped by category, to be returned from a {@code @TestFactory}:
+ * <pre>{@code
+ * @TestFactory
+ * Stream<DynamicNode> conformance() {
+ *     Registry registry = Registry.builder().defaults("user", "password").build();
+ *     return ConformanceSuite.builder(registry, ContainerRef.parse("registry.example.com/conformance"))
+ *             .build()
+ *             .tests();
+ * }
+ * }</pre>
+ * Each category pushes the content it needs under tags unique to the run, so categories can be selected
+ * independently. Tag and content deletion is optional in the distribution spec, so scenarios of unsupported
+ * operations are aborted instead of failing.
+ * Requires {@code org.junit.jupiter:junit-jupiter-api} on the classpath.
+ */
+@NullMarked
+public final class ConformanceSuite {
+
+    /**
+     * Artifact type of the pushed test manifests
+     */
+    private static final String ARTIFACT_TYPE = "application/vnd.oras.conformance.test";
+
+    /**
+     * Artifact type of the pushed referrers
+     */
+    private static final String REFERRER_ARTIFACT_TYPE = "application/vnd.oras.conformance.referrer";
+
+    /**
+     * The categories of scenarios, following the workflows of the distribution spec
+     */
+    public enum Category {
+
+        /**
+         * Pull manifests and blobs
+         */
+        PULL,
+
+        /**
+         * Push blobs (monolithic, chunked and from a stream) and manifests
+         */
+        PUSH,
+
+        /**
+         * List tags and referrers
+         */
+        CONTENT_DISCOVERY,
+
+        /**
+         * Delete tags, manifests and blobs
+         */
+        CONTENT_MANAGEMENT,
+    }
+
+    private final Registry registry;
+    private final ContainerRef repository;
+    private final Set<Category> categories;
+    private final long chunkSize;
+    private final String runId;
+
+    private ConformanceSuite(Builder builder) {
+        this.registry = builder.registry;
+        this.repository = builder.repository;
+        this.categories = EnumSet.copyOf(builder.categories);
+        this.chunkSize = builder.chunkSize;
+        this.runId = "conformance-" + UUID.randomUUID().toString().substring(0, 8);
+    }
+
+    /**
+     * Create a builder for a suite pushing to the given repository
+     * @param registry The registry under test
+     * @param repository The repository. The tag or digest is ignored
+     * @return The builder
+     */
+    public static Builder builder(Registry registry, ContainerRef repository) {
+        return new Builder(registry, repository);
+    }
+
+    /**
+     * Get the identifier of the run, used as prefix of all pushed tags
+     * @return The run identifier
+     */
+    public String getRunId() {
+        return runId;
+    }
+
+    /**
+     * Get the scenarios, one container per selected category
+     * @return The dynamic tests
+     */
+    public Stream<DynamicNode> tests() {
+        return categories.stream().map(category -> switch (category) {
+            case PULL -> dynamicContainer("Pull", pullTests());
+            case PUSH -> dynamicContainer("Push", pushTests());
+            case CONTENT_DISCOVERY -> dynamicContainer("Content discovery", discoveryTests());
+            case CONTENT_MANAGEMENT -> dynamicContainer("Content management", managementTests());
+        });
+    }
+
+    private Stream<DynamicTest> pullTests() {
+        AtomicReference<Fixture> holder = new AtomicReference<>();
+        return Stream.of(
+                dynamicTest("Pull manifest by tag", () -> {
+                    Fixture fixture = fixture(holder, "pull");
+                    Manifest manifest = registry.getManifest(fixture.tagRef());
+                    assertEquals(fixture.digest(), manifest.getDescriptor().getDigest());
+                }),
+                dynamicTest("Pull manifest by digest", () -> {
+                    Fixture fixture = fixture(holder, "pull");
+                    Manifest manifest = registry.getManifest(fixture.digestRef());
+                    assertEquals(1, manifest.getLayers().size());
+                    assertEquals(
+                            fixture.layer().getDigest(),
+                            manifest.getLayers().get(0).getDigest());
+                }),
+                dynamicTest("Check manifest existence", () -> {
+                    Fixture fixture = fixture(holder, "pull");
+                    assertTrue(registry.exists(fixture.tagRef()));
+                    Optional<Descriptor> descriptor = registry.findDescriptor(fixture.digestRef());
+                    assertTrue(descriptor.isPresent());
+                    assertEquals(fixture.digest(), descriptor.get().getDigest());
+                }),
+                dynamicTest("Pull blob", () -> {
+                    Fixture fixture = fixture(holder, "pull");
+                    byte[] blob = registry.getBlob(repository.withDigest(fixture.layer().getDigest()));
+                    assertArrayEquals(fixture.content(), blob);
+                }),
+                dynamicTest("Check blob existence", () -> {
+                    Fixture fixture = fixture(holder, "pull");
+                    assertTrue(registry.blobExists(repository, fixture.layer().getDigest()));
+                }),
+                dynamicTest("Pull missing manifest", () -> {
+                    ContainerRef missing = repository.withTag(runId + "-missing");
+                    assertFalse(registry.exists(missing));
+                    assertTrue(registry.findDescriptor(missing).isEmpty());
+                }),
+                dynamicTest("Pull missing blob", () -> {
+                    String digest = SupportedAlgorithm.SHA256.digest(content("missing"));
+                    assertFalse(registry.blobExists(repository, digest));
+                }));
+    }
+
+    private Stream<DynamicTest> pushTests() {
+        return Stream.of(
+                dynamicTest("Push blob monolithically", () -> {
+                    byte[] content = content("monolithic");
+                    Layer layer = registry.pushBlob(repository, content);
+                    assertEquals(SupportedAlgorithm.SHA256.digest(content), layer.getDigest());
+                    assertTrue(registry.blobExists(repository, layer.getDigest()));
+                }),
+                dynamicTest("Push blob in chunks", () -> {
+                    byte[] content = content("chunked", (int) chunkSize * 3 + 1);
+                    String digest = SupportedAlgorithm.SHA256.digest(content);
+                    Layer layer = registry.pushBlobChunked(
+                            repository.withDigest(digest),
+                            new ByteArrayInputStream(content),
+                            content.length,
+                            chunkSize);
+                    assertEquals(digest, layer.getDigest());
+                    assertArrayEquals(content, registry.getBlob(repository.withDigest(digest)));
+                }),
+                dynamicTest("Push blob of unknown length", () -> {
+                    byte[] content = content("stream", (int) chunkSize * 2 + 1);
+                    Layer layer = registry.pushBlob(repository, new ByteArrayInputStream(content));
+                    assertEquals(SupportedAlgorithm.SHA256.digest(content), layer.getDigest());
+                    assertEquals(content.length, layer.getSize());
+                    assertTrue(registry.blobExists(repository, layer.getDigest()));
+                }),
+                dynamicTest("Mount blob from another repository", () -> {
+                    ContainerRef source = ContainerRef.parse("%s/%s-mount-source"
+                            .formatted(repository.getRegistry(), repository.getFullRepository()));
+                    Layer layer = registry.pushBlob(source, content("mount"));
+                    boolean mounted = registry.mountBlob(
+                            source.withDigest(layer.getDigest()), repository.withDigest(layer.getDigest()));
+                    Assumptions.assumeTrue(mounted, "Cross repository blob mount is not supported by the registry");
+                    assertTrue(registry.blobExists(repository, layer.getDigest()));
+                }),
+                dynamicTest("Push manifest by tag", () -> {
+                    Fixture fixture = push("push-tag");
+                    assertTrue(registry.exists(fixture.tagRef()));
+                }),
+                dynamicTest("Push manifest by digest", () -> {
+                    Layer layer = registry.pushBlob(repository, content("push-digest"));
+                    Manifest manifest = testManifest(layer, "push-digest");
+                    String digest =
+                            SupportedAlgorithm.SHA256.digest(manifest.toJson().getBytes(StandardCharsets.UTF_8));
+                    Manifest pushed = registry.pushManifest(repository.withDigest(digest), manifest);
+                    assertEquals(digest, pushed.getDescriptor().getDigest());
+                    assertTrue(registry.findDescriptor(repository.withDigest(digest)).isPresent());
+                }));
+    }
+
+    private Stream<DynamicTest> discoveryTests() {
+        AtomicReference<Fixture> holder = new AtomicReference<>();
+        List<String> tags = List.of(runId + "-discovery-a", runId + "-discovery-b", runId + "-discovery-c");
+        return Stream.of(
+                dynamicTest("List tags", () -> {
+                    Fixture fixture = fixture(holder, "discovery");
+                    registry.pushManifest(repository, fixture.manifest(), tags);
+                    List<String> listed = registry.getTags(repository).tags();
+                    assertTrue(listed.containsAll(tags), "Missing tags in %s".formatted(listed));
+                }),
+                dynamicTest("List tags with pagination", () -> {
+                    fixture(holder, "discovery");
+                    List<String> first = registry.getTags(repository, 1, null).tags();
+                    assertEquals(1, first.size());
+                    List<String> next = registry.getTags(repository, 1, first.get(0)).tags();
+                    assertFalse(next.contains(first.get(0)));
+                }),
+                dynamicTest("List referrers", () -> {
+                    Fixture fixture = fixture(holder, "discovery");
+                    Manifest referrer = pushReferrer(fixture);
+                    List<String> digests = registry.getReferrers(fixture.digestRef(), null).getManifests().stream()
+                            .map(ManifestDescriptor::getDigest)
+                            .toList();
+                    assertTrue(digests.contains(referrer.getDescriptor().getDigest()));
+                }),
+                dynamicTest("List referrers filtered by artifact type", () -> {
+                    Fixture fixture = fixture(holder, "discovery");
+                    pushReferrer(fixture);
+                    List<ManifestDescriptor> filtered = registry.getReferrers(
+                                    fixture.digestRef(), ArtifactType.from(REFERRER_ARTIFACT_TYPE))
+                            .getManifests();
+                    assertFalse(filtered.isEmpty());
+                    filtered.forEach(descriptor -> assertEquals(REFERRER_ARTIFACT_TYPE, descriptor.getArtifactType()));
+                    assertTrue(registry.getReferrers(fixture.digestRef(), ArtifactType.from(ARTIFACT_TYPE))
+                            .getManifests()
+                            .isEmpty());
+                }));
+    }
+
+    private Stream<DynamicTest> managementTests() {
+        AtomicReference<Fixture> holder = new AtomicReference<>();
+        return Stream.of(
+                dynamicTest("Delete tag", () -> {
+                    Fixture fixture = fixture(holder, "management");
+                    ContainerRef extra = repository.withTag(runId + "-management-extra");
+                    registry.pushManifest(extra, fixture.manifest());
+                    optional(() -> registry.deleteTag(extra));
+                    assertFalse(registry.exists(extra));
+                    assertTrue(registry.exists(fixture.digestRef()));
+                }),
+                dynamicTest("Delete manifest", () -> {
+                    Fixture fixture = fixture(holder, "management");
+                    optional(() -> registry.deleteManifest(fixture.digestRef()));
+                    assertTrue(registry.findDescriptor(fixture.digestRef()).isEmpty());
+                }),
+                dynamicTest("Delete blob", () -> {
+                    Fixture fixture = fixture(holder, "management");
+                    optional(() -> registry.deleteBlob(repository.withDigest(fixture.layer().getDigest())));
+                    assertFalse(registry.blobExists(repository, fixture.layer().getDigest()));
+                }));
+    }
+
+    /**
+     * Get the content pushed for a category, pushing it on first use
+     * @param holder The holder of the fixture of the category
+     * @param name The name of the category
+     * @return The fixture
+     */
+    private Fixture fixture(AtomicReference<Fixture> holder, String name) {
+        Fixture fixture = holder.get();
+        if (fixture == null) {
+            fixture = push(name);
+            holder.set(fixture);
+        }
+        return fixture;
+    }
+
+    /**
+     * Push a blob and a manifest referencing it under a tag
+     * @param name The name used for the tag and the content
+     * @return The fixture
+     */
+    private Fixture push(String name) {
+        byte[] content = content(name);
+        Layer layer = registry.pushBlob(repository, content)
+                .withAnnotations(Map.of(Const.ANNOTATION_TITLE, "%s.txt".formatted(name)));
+        ContainerRef tagRef = repository.withTag("%s-%s".formatted(runId, name));
+        Manifest manifest = registry.pushManifest(tagRef, testManifest(layer, name));
+        return new Fixture(tagRef, manifest, layer, content);
+    }
+
+    private Manifest pushReferrer(Fixture fixture) {
+        Manifest referrer = Manifest.empty()
+                .withArtifactType(ArtifactType.from(REFERRER_ARTIFACT_TYPE))
+                .withSubject(fixture.manifest().getDescriptor().toSubject())
+                .withAnnotations(Map.of(Const.ANNOTATION_CREATED, Const.currentTimestamp()));
+        String digest = SupportedAlgorithm.SHA256.digest(referrer.toJson().getBytes(StandardCharsets.UTF_8));
+        return registry.pushManifest(repository.withDigest(digest), referrer);
+    }
+
+    private Manifest testManifest(Layer layer, String name) {
+        return Manifest.empty()
+                .withArtifactType(ArtifactType.from(ARTIFACT_TYPE))
+                .withLayers(List.of(layer))
+                .withAnnotations(Map.of("land.oras.conformance.run", "%s-%s".formatted(runId, name)));
+    }
+
+    private byte[] content(String name) {
+        return "%s %s".formatted(runId, name).getBytes(StandardCharsets.UTF_8);
+    }
+
+    private byte[] content(String name, int size) {
+        byte[] prefix = content(name);
+        byte[] content = Arrays.copyOf(prefix, Math.max(size, prefix.length));
+        for (int i = prefix.length; i < content.length; i++) {
+            content[i] = (byte) ('a' + i % 26);
+        }
+        return content;
+    }
+
+    /**
+     * Run an operation that is optional in the distribution spec, aborting the scenario when it's not supported
+     * @param executable The operation
+     * @throws Throwable If the operation fails for another reason
+     */
+    private static void optional(Executable executable) throws Throwable {
+        try {
+            executable.execute();
+        } catch (OperationNotSupportedException e) {
+            Assumptions.abort("Not supported by the registry: " + e.getMessage());
+        }
+    }
+
+    /**
+     * Content pushed for a category
+     * @param tagRef The tag the manifest was pushed to
+     * @param manifest The pushed manifest
+     * @param layer The layer of the manifest
+     * @param content The content of the layer
+     */
+    private record Fixture(ContainerRef tagRef, Manifest manifest, Layer layer, byte[] content) {
+
+        private String digest() {
+            return manifest.getDescriptor().getDigest();
+        }
+
+        private ContainerRef digestRef() {
+            return tagRef.withDigest(digest());
+        }
+    }
+
+    /**
+     * Builder of the suite
+     */
+    public static final class Builder {
+
+        private final Registry registry;
+        private final ContainerRef repository;
+        private final Set<Category> categories = EnumSet.allOf(Category.class);
+        private long chunkSize = 1024;
+
+        private Builder(Registry registry, ContainerRef repository) {
+            this.registry = registry;
+            this.repository = repository.withoutDigest();
+        }
+
+        /**
+         * Only run the given categories. Default to all categories
+         * @param categories The categories
+         * @return The builder
+         */
+        public Builder withCategories(Category... categories) {
+            if (categories.length == 0) {
+                throw new OrasException("At least one category is required");
+            }
+            this.categories.clear();
+            this.categories.addAll(Arrays.asList(categories));
+            return this;
+        }
+
+        /**
+         * Set the chunk size of the chunked upload scenarios. Default to 1024 bytes
+         * @param chunkSize The chunk size in bytes
+         * @return The builder
+         */
+        public Builder withChunkSize(long chunkSize) {
+            if (chunkSize <= 0 || chunkSize > Integer.MAX_VALUE / 4) {
+                throw new OrasException("Invalid chunk size: %d".formatted(chunkSize));
+            }
+            this.chunkSize = chunkSize;
+            return this;
+        }
+
+        /**
+         * Build the suite
+         * @return The suite
+         */
+        public ConformanceSuite build() {
+            return new ConformanceSuite(this);
+        }
+    }
+}
@@ -0,0 +1,77 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.conformance;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+
+import java.util.List;
+import java.util.stream.Stream;
+import land.oras.ContainerRef;
+import land.oras.Registry;
+import land.oras.exception.OrasException;
+import land.oras.utils.ZotContainer;
+import org.junit.jupiter.api.DynamicContainer;
+import org.junit.jupiter.api.DynamicNode;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.TestFactory;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+import org.testcontainers.junit.jupiter.Container;
+import org.testcontainers.junit.jupiter.Testcontainers;
+
+@Testcontainers(disabledWithoutDocker = true)
+@Execution(ExecutionMode.CONCURRENT)
+class ConformanceSuiteTest {
+
+    @Container
+    private static final ZotContainer registry = new ZotContainer().withStartupAttempts(3);
+
+    @TestFactory
+    Stream<DynamicNode> shouldPassConformanceScenarios() {
+        Registry client = Registry.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .build();
+        return ConformanceSuite.builder(
+                        client, ContainerRef.parse("%s/library/conformance".formatted(registry.getRegistry())))
+                .withChunkSize(64)
+                .build()
+                .tests();
+    }
+
+    @Test
+    void shouldSelectCategories() {
+        Registry client = Registry.builder().insecure().build();
+        ConformanceSuite suite = ConformanceSuite.builder(client, ContainerRef.parse("localhost:5000/conformance"))
+                .withCategories(ConformanceSuite.Category.PULL, ConformanceSuite.Category.CONTENT_DISCOVERY)
+                .build();
+
+        // Assertion
+        List<String> names = suite.tests().map(DynamicNode::getDisplayName).toList();
+        assertEquals(List.of("Pull", "Content discovery"), names);
+        assertEquals(7, ((DynamicContainer) suite.tests().findFirst().orElseThrow()).getChildren().count());
+        assertThrows(OrasException.class, () -> ConformanceSuite.builder(client, ContainerRef.parse("localhost:5000/a"))
+                .withCategories());
+        assertThrows(OrasException.class, () -> ConformanceSuite.builder(client, ContainerRef.parse("localhost:5000/a"))
+                .withChunkSize(0));
+    }
+}
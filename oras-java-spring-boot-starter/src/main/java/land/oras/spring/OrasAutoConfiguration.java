@@ -0,0 +1,159 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.spring;
+
+import io.micrometer.core.instrument.MeterRegistry;
+import java.util.Map;
+import java.util.Objects;
+import land.oras.ContainerRef;
+import land.oras.Registry;
+import land.oras.auth.CredentialChain;
+import land.oras.auth.CredentialHelperProvider;
+import land.oras.auth.HostConfig;
+import land.oras.auth.ProxyConfig;
+import land.oras.auth.RetryPolicy;
+import land.oras.auth.UsernamePasswordProvider;
+import org.jspecify.annotations.NullMarked;
+import org.springframework.beans.factory.ObjectProvider;
+import org.springframework.boot.autoconfigure.AutoConfiguration;
+import org.springframework.boot.autoconfigure.condition.ConditionalOnClass;
+import org.springframework.boot.autoconfigure.condition.ConditionalOnMissingBean;
+import org.springframework.boot.autoconfigure.condition.ConditionalOnProperty;
+import org.springframework.boot.context.properties.EnableConfigurationProperties;
+import org.springframework.context.annotation.Bean;
+import org.springframework.context.annotation.Configuration;
+
+/**
+ * Auto-configuration of a {@link Registry} bean from the {@link OrasProperties}.
+ * Metrics are recorded on the {@link MeterRegistry} bean when present, and a {@link RegistryHealthIndicator} is
+ * registered when {@code oras.health.reference} is set and Spring Boot health is on the classpath.
+ */
+@NullMarked
+@AutoConfiguration
+@ConditionalOnClass(Registry.class)
+@EnableConfigurationProperties(OrasProperties.class)
+public class OrasAutoConfiguration {
+
+    /**
+     * Constructor
+     */
+    public OrasAutoConfiguration() {}
+
+    /**
+     * The registry bean
+     * @param properties The properties
+     * @param meterRegistry The meter registry, if any
+     * @return The registry
+     */
+    @Bean
+    @ConditionalOnMissingBean
+    public Registry orasRegistry(OrasProperties properties, ObjectProvider<MeterRegistry> meterRegistry) {
+        Registry.Builder builder = builder(properties);
+        meterRegistry.ifAvailable(builder::withMeterRegistry);
+        return builder.build();
+    }
+
+    /**
+     * Create a registry builder from the properties
+     * @param properties The properties
+     * @return The builder
+     */
+    static Registry.Builder builder(OrasProperties properties) {
+        Registry.Builder builder = Registry.builder()
+                .withAuthProvider(credentialChain(properties))
+                .withInsecure(properties.insecure())
+                .withSkipTlsVerify(properties.skipTlsVerify());
+        if (properties.registry() != null) {
+            builder.withRegistry(properties.registry());
+        }
+        if (properties.caFile() != null) {
+            builder.withCaFile(properties.caFile());
+        }
+        if (properties.connectTimeout() != null) {
+            builder.withConnectTimeout(properties.connectTimeout());
+        }
+        if (properties.readTimeout() != null) {
+            builder.withReadTimeout(properties.readTimeout());
+        }
+        for (Map.Entry<String, OrasProperties.Host> entry : properties.hosts().entrySet()) {
+            OrasProperties.Host host = entry.getValue();
+            builder.withHostConfig(entry.getKey(), HostConfig.of(host.plainHttp(), host.skipTlsVerify()));
+        }
+        OrasProperties.Proxy proxy = properties.proxy();
+        if (proxy != null) {
+            ProxyConfig proxyConfig =
+                    ProxyConfig.of(proxy.host(), proxy.port()).withNonProxyHosts(proxy.nonProxyHosts());
+            if (proxy.username() != null && proxy.password() != null) {
+                proxyConfig = proxyConfig.withCredentials(proxy.username(), proxy.password());
+            }
+            builder.withProxy(proxyConfig);
+        }
+        OrasProperties.Retry retry = properties.retry();
+        builder.withRetryPolicy(RetryPolicy.defaults()
+                .withMaxAttempts(retry.maxAttempts())
+                .withInitialDelay(retry.initialDelay())
+                .withMaxDelay(retry.maxDelay()));
+        return builder;
+    }
+
+    /**
+     * Create the credential chain: per-host credentials, global credentials, environment, docker config and anonymous
+     * @param properties The properties
+     * @return The credential chain
+     */
+    private static CredentialChain credentialChain(OrasProperties properties) {
+        CredentialChain.Builder chain = CredentialChain.builder();
+        for (Map.Entry<String, OrasProperties.Host> entry : properties.hosts().entrySet()) {
+            OrasProperties.Host host = entry.getValue();
+            if (host.username() != null && host.password() != null) {
+                chain.withHostOverride(entry.getKey(), new UsernamePasswordProvider(host.username(), host.password()));
+            } else if (host.credentialHelper() != null) {
+                chain.withHostOverride(entry.getKey(), new CredentialHelperProvider(host.credentialHelper()));
+            }
+        }
+        if (properties.username() != null && properties.password() != null) {
+            chain.withCredentials(properties.username(), properties.password());
+        }
+        return chain.withEnvironment().withAuthStore().withAnonymous().build();
+    }
+
+    /**
+     * Health indicator configuration, only active when Spring Boot health is on the classpath
+     */
+    @Configuration(proxyBeanMethods = false)
+    @ConditionalOnClass(name = "org.springframework.boot.health.contributor.HealthIndicator")
+    static class HealthConfiguration {
+
+        /**
+         * The health indicator bean
+         * @param registry The registry
+         * @param properties The properties
+         * @return The health indicator
+         */
+        @Bean
+        @ConditionalOnMissingBean(name = "orasHealthIndicator")
+        @ConditionalOnProperty(prefix = OrasProperties.PREFIX + ".health", name = "reference")
+        RegistryHealthIndicator orasHealthIndicator(Registry registry, OrasProperties properties) {
+            return new RegistryHealthIndicator(
+                    registry, ContainerRef.parse(Objects.requireNonNull(properties.health().reference())));
+        }
+    }
+}
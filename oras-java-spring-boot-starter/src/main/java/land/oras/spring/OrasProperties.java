@@ -0,0 +1,115 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.spring;
+
+import java.nio.file.Path;
+import java.time.Duration;
+import java.util.List;
+import java.util.Map;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.springframework.boot.context.properties.ConfigurationProperties;
+import org.springframework.boot.context.properties.bind.DefaultValue;
+
+/**
+ * Configuration of the auto-configured {@link land.oras.Registry} bound from the {@code oras} prefix.
+ * Host keys containing a port or dots must use the bracket notation, for example {@code hosts[localhost:5000]}
+ * @param registry The default registry used for references without registry
+ * @param username The username used for all registries
+ * @param password The password used for all registries
+ * @param insecure Use plain HTTP for all registries
+ * @param skipTlsVerify Skip TLS verification for all registries
+ * @param caFile The PEM file of the CA certificates to trust
+ * @param connectTimeout The connect timeout
+ * @param readTimeout The timeout of a single request
+ * @param hosts The per-host settings
+ * @param proxy The proxy
+ * @param retry The retry policy
+ * @param health The health indicator settings
+ */
+@NullMarked
+@ConfigurationProperties(OrasProperties.PREFIX)
+public record OrasProperties(
+        @Nullable String registry,
+        @Nullable String username,
+        @Nullable String password,
+        @DefaultValue("false") boolean insecure,
+        @DefaultValue("false") boolean skipTlsVerify,
+        @Nullable Path caFile,
+        @Nullable Duration connectTimeout,
+        @Nullable Duration readTimeout,
+        @DefaultValue Map<String, Host> hosts,
+        @Nullable Proxy proxy,
+        @DefaultValue Retry retry,
+        @DefaultValue Health health) {
+
+    /**
+     * The prefix of the properties
+     */
+    public static final String PREFIX = "oras";
+
+    /**
+     * Settings of a registry host
+     * @param plainHttp Use plain HTTP for this host
+     * @param skipTlsVerify Skip TLS verification for this host
+     * @param username The username for this host
+     * @param password The password for this host
+     * @param credentialHelper The docker credential helper for this host, without the {@code docker-credential-} prefix
+     */
+    public record Host(
+            @DefaultValue("false") boolean plainHttp,
+            @DefaultValue("false") boolean skipTlsVerify,
+            @Nullable String username,
+            @Nullable String password,
+            @Nullable String credentialHelper) {}
+
+    /**
+     * Proxy settings
+     * @param host The proxy host
+     * @param port The proxy port
+     * @param username The proxy username
+     * @param password The proxy password
+     * @param nonProxyHosts The hosts reached directly
+     */
+    public record Proxy(
+            String host,
+            int port,
+            @Nullable String username,
+            @Nullable String password,
+            @DefaultValue List<String> nonProxyHosts) {}
+
+    /**
+     * Retry settings of transient failures
+     * @param maxAttempts The maximum number of attempts, 1 to disable retries
+     * @param initialDelay The delay before the first retry
+     * @param maxDelay The maximum delay between retries
+     */
+    public record Retry(
+            @DefaultValue("3") int maxAttempts,
+            @DefaultValue("500ms") Duration initialDelay,
+            @DefaultValue("30s") Duration maxDelay) {}
+
+    /**
+     * Health indicator settings
+     * @param reference The reference probed by the health indicator. No indicator is registered when not set
+     */
+    public record Health(@Nullable String reference) {}
+}
@@ -0,0 +1,62 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.spring;
+
+import land.oras.ContainerRef;
+import land.oras.Descriptor;
+import land.oras.Registry;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.springframework.boot.health.contributor.Health;
+import org.springframework.boot.health.contributor.HealthIndicator;
+
+/**
+ * Health indicator resolving a reference on the registry. Reports up when the reference can be resolved
+ */
+@NullMarked
+public final class RegistryHealthIndicator implements HealthIndicator {
+
+    private final Registry registry;
+    private final ContainerRef reference;
+
+    /**
+     * Create a new health indicator
+     * @param registry The registry
+     * @param reference The reference to resolve
+     */
+    public RegistryHealthIndicator(Registry registry, ContainerRef reference) {
+        this.registry = registry;
+        this.reference = reference;
+    }
+
+    @Override
+    public Health health() {
+        try {
+            Descriptor descriptor = registry.probeDescriptor(reference);
+            return Health.up()
+                    .withDetail("reference", reference.toString())
+                    .withDetail("digest", descriptor.getDigest())
+                    .build();
+        } catch (OrasException e) {
+            return Health.down(e).withDetail("reference", reference.toString()).build();
+        }
+    }
+}
@@ -0,0 +1,117 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.spring;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNotNull;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertSame;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.nio.file.Files;
+import java.nio.file.Path;
+import land.oras.ContainerRef;
+import land.oras.LocalPath;
+import land.oras.Registry;
+import land.oras.auth.HostConfig;
+import land.oras.utils.FakeRegistry;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+import org.springframework.boot.autoconfigure.AutoConfigurations;
+import org.springframework.boot.health.contributor.Status;
+import org.springframework.boot.test.context.runner.ApplicationContextRunner;
+
+@Execution(ExecutionMode.CONCURRENT)
+class OrasAutoConfigurationTest {
+
+    private final ApplicationContextRunner contextRunner =
+            new ApplicationContextRunner().withConfiguration(AutoConfigurations.of(OrasAutoConfiguration.class));
+
+    @Test
+    void shouldConfigureRegistryFromProperties() {
+        contextRunner
+                .withPropertyValues(
+                        "oras.registry=registry.example.com",
+                        "oras.skip-tls-verify=true",
+                        "oras.read-timeout=10s",
+                        "oras.hosts[localhost:5000].plain-http=true",
+                        "oras.hosts[localhost:5000].username=myuser",
+                        "oras.hosts[localhost:5000].password=mypass",
+                        "oras.proxy.host=proxy.example.com",
+                        "oras.proxy.port=3128",
+                        "oras.retry.max-attempts=5")
+                .run(context -> {
+                    Registry registry = context.getBean(Registry.class);
+                    OrasProperties properties = context.getBean(OrasProperties.class);
+
+                    // Assertion
+                    assertEquals("registry.example.com", registry.getRegistry());
+                    assertFalse(registry.isInsecure());
+                    HostConfig hostConfig = registry.getHostConfig("localhost:5000");
+                    assertNotNull(hostConfig);
+                    assertTrue(hostConfig.isPlainHttp());
+                    assertEquals(5, properties.retry().maxAttempts());
+                    assertEquals(3128, properties.proxy().port());
+                    assertNull(properties.health().reference());
+                    assertFalse(context.containsBean("orasHealthIndicator"));
+                });
+    }
+
+    @Test
+    void shouldBackOffWhenRegistryIsDefined() {
+        Registry custom = Registry.builder().build();
+        contextRunner.withBean(Registry.class, () -> custom).run(context -> {
+            // Assertion
+            assertSame(custom, context.getBean(Registry.class));
+        });
+    }
+
+    @Test
+    void shouldReportHealth(@TempDir Path dir) throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            ContainerRef ref = ContainerRef.parse("%s/library/app:latest".formatted(fake.getRegistry()));
+            Path file = dir.resolve("app.txt");
+            Files.writeString(file, "app");
+            Registry.builder().withInsecure(true).build().pushArtifact(ref, LocalPath.of(file));
+
+            contextRunner
+                    .withPropertyValues("oras.insecure=true", "oras.health.reference=" + ref)
+                    .run(context -> {
+                        // Assertion
+                        RegistryHealthIndicator indicator = context.getBean(RegistryHealthIndicator.class);
+                        assertEquals(Status.UP, indicator.health().getStatus());
+                    });
+            contextRunner
+                    .withPropertyValues(
+                            "oras.insecure=true",
+                            "oras.retry.max-attempts=1",
+                            "oras.health.reference=%s/library/missing:latest".formatted(fake.getRegistry()))
+                    .run(context -> {
+                        // Assertion
+                        RegistryHealthIndicator indicator = context.getBean(RegistryHealthIndicator.class);
+                        assertEquals(Status.DOWN, indicator.health().getStatus());
+                    });
+        }
+    }
+}
@@ -0,0 +1,215 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.maven;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import land.oras.Annotations;
+import land.oras.ContainerRef;
+import land.oras.LocalPath;
+import land.oras.Registry;
+import land.oras.auth.AuthProvider;
+import land.oras.auth.BearerTokenProvider;
+import land.oras.auth.CredentialChain;
+import land.oras.auth.UsernamePasswordProvider;
+import land.oras.exception.OrasException;
+import org.apache.maven.artifact.Artifact;
+import org.apache.maven.plugin.AbstractMojo;
+import org.apache.maven.plugin.MojoExecutionException;
+import org.apache.maven.plugins.annotations.Component;
+import org.apache.maven.plugins.annotations.Parameter;
+import org.apache.maven.project.MavenProject;
+import org.apache.maven.settings.Server;
+import org.apache.maven.settings.Settings;
+import org.apache.maven.settings.crypto.DefaultSettingsDecryptionRequest;
+import org.apache.maven.settings.crypto.SettingsDecrypter;
+import org.apache.maven.settings.crypto.SettingsDecryptionResult;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Base class of the ORAS goals. Credentials are read from the {@code settings.xml} server whose id is
+ * {@code serverId}, or the registry host when not set, and fall back to the docker config
+ */
+@NullMarked
+public abstract class AbstractOrasMojo extends AbstractMojo {
+
+    /**
+     * The artifact reference, for example {@code ghcr.io/org/artifact:1.0}
+     */
+    @Parameter(property = "oras.reference", required = true)
+    protected @Nullable String reference;
+
+    /**
+     * The id of the {@code settings.xml} server holding the credentials. Defaults to the registry host
+     */
+    @Parameter(property = "oras.serverId")
+    protected @Nullable String serverId;
+
+    /**
+     * Use plain HTTP to reach the registry
+     */
+    @Parameter(property = "oras.insecure", defaultValue = "false")
+    protected boolean insecure;
+
+    /**
+     * Skip the goal
+     */
+    @Parameter(property = "oras.skip", defaultValue = "false")
+    protected boolean skip;
+
+    /**
+     * The Maven project
+     */
+    @Parameter(defaultValue = "${project}", readonly = true, required = true)
+    protected @Nullable MavenProject project;
+
+    /**
+     * The Maven settings
+     */
+    @Parameter(defaultValue = "${settings}", readonly = true, required = true)
+    protected @Nullable Settings settings;
+
+    /**
+     * Decrypts the server passwords
+     */
+    @Component
+    protected @Nullable SettingsDecrypter settingsDecrypter;
+
+    /**
+     * Constructor
+     */
+    protected AbstractOrasMojo() {}
+
+    @Override
+    public final void execute() throws MojoExecutionException {
+        if (skip) {
+            getLog().info("Skipping ORAS goal");
+            return;
+        }
+        ContainerRef ref = ContainerRef.parse(required(reference, "reference"));
+        try {
+            execute(createRegistry(ref), ref);
+        } catch (OrasException e) {
+            throw new MojoExecutionException("Failed to process %s: %s".formatted(ref, e.getMessage()), e);
+        }
+    }
+
+    /**
+     * Execute the goal
+     * @param registry The registry configured with the credentials of the reference
+     * @param ref The artifact reference
+     * @throws MojoExecutionException if the goal fails
+     */
+    protected abstract void execute(Registry registry, ContainerRef ref) throws MojoExecutionException;
+
+    /**
+     * Create the registry for the given reference
+     * @param ref The reference
+     * @return The registry
+     * @throws MojoExecutionException if the configured server doesn't exist
+     */
+    protected Registry createRegistry(ContainerRef ref) throws MojoExecutionException {
+        AuthProvider authProvider = authProvider(
+                required(settings, "settings"), required(settingsDecrypter, "settingsDecrypter"), serverId, ref);
+        return Registry.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(insecure)
+                .build();
+    }
+
+    /**
+     * Get the files to push: the configured files or the project artifacts
+     * @param files The configured files using the {@code path[:mediaType]} syntax
+     * @return The local paths
+     * @throws MojoExecutionException if no file is configured and the project has no artifact file
+     */
+    protected LocalPath[] localPaths(@Nullable List<String> files) throws MojoExecutionException {
+        if (files != null && !files.isEmpty()) {
+            return files.stream().map(LocalPath::of).toArray(LocalPath[]::new);
+        }
+        List<LocalPath> paths = new ArrayList<>();
+        MavenProject mavenProject = required(project, "project");
+        addArtifact(paths, mavenProject.getArtifact());
+        mavenProject.getAttachedArtifacts().forEach(artifact -> addArtifact(paths, artifact));
+        if (paths.isEmpty()) {
+            throw new MojoExecutionException(
+                    "No files configured and the project has no artifact file. Run the goal after 'package'");
+        }
+        return paths.toArray(LocalPath[]::new);
+    }
+
+    /**
+     * Create the manifest annotations
+     * @param annotations The configured annotations
+     * @return The annotations
+     */
+    protected static Annotations annotations(@Nullable Map<String, String> annotations) {
+        return annotations == null || annotations.isEmpty() ? Annotations.empty() : Annotations.ofManifest(annotations);
+    }
+
+    /**
+     * Resolve the auth provider from the settings server of the reference
+     * @param settings The settings
+     * @param decrypter The settings decrypter
+     * @param serverId The configured server id or null to use the registry host
+     * @param ref The reference
+     * @return The auth provider
+     * @throws MojoExecutionException if the configured server doesn't exist
+     */
+    static AuthProvider authProvider(
+            Settings settings, SettingsDecrypter decrypter, @Nullable String serverId, ContainerRef ref)
+            throws MojoExecutionException {
+        String id = serverId != null ? serverId : ref.getRegistry();
+        Server server = settings.getServer(id);
+        if (server == null) {
+            if (serverId != null) {
+                throw new MojoExecutionException("Server '%s' not found in settings.xml".formatted(serverId));
+            }
+            return CredentialChain.defaults();
+        }
+        SettingsDecryptionResult result = decrypter.decrypt(new DefaultSettingsDecryptionRequest(server));
+        Server decrypted = result.getServer() != null ? result.getServer() : server;
+        String username = decrypted.getUsername();
+        String password = decrypted.getPassword();
+        if (password == null || password.isEmpty()) {
+            throw new MojoExecutionException("Server '%s' has no password or token".formatted(id));
+        }
+        if (username == null || username.isEmpty()) {
+            return new BearerTokenProvider(password);
+        }
+        return new UsernamePasswordProvider(username, password);
+    }
+
+    private static void addArtifact(List<LocalPath> paths, @Nullable Artifact artifact) {
+        if (artifact != null && artifact.getFile() != null && artifact.getFile().isFile()) {
+            paths.add(LocalPath.of(artifact.getFile().toPath()));
+        }
+    }
+
+    private static <T> T required(@Nullable T value, String name) throws MojoExecutionException {
+        if (value == null) {
+            throw new MojoExecutionException("Parameter '%s' is required".formatted(name));
+        }
+        return value;
+    }
+}
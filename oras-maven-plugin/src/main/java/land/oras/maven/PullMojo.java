@@ -0,0 +1,72 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.maven;
+
+import java.io.File;
+import java.io.IOException;
+import java.nio.file.Files;
+import land.oras.ContainerRef;
+import land.oras.Registry;
+import org.apache.maven.plugin.MojoExecutionException;
+import org.apache.maven.plugins.annotations.LifecyclePhase;
+import org.apache.maven.plugins.annotations.Mojo;
+import org.apache.maven.plugins.annotations.Parameter;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Pull the files of an OCI artifact into a directory
+ */
+@NullMarked
+@Mojo(name = "pull", defaultPhase = LifecyclePhase.GENERATE_RESOURCES, threadSafe = true)
+public class PullMojo extends AbstractOrasMojo {
+
+    /**
+     * The directory receiving the files
+     */
+    @Parameter(property = "oras.outputDirectory", defaultValue = "${project.build.directory}/oras")
+    private @Nullable File outputDirectory;
+
+    /**
+     * Overwrite existing files
+     */
+    @Parameter(property = "oras.overwrite", defaultValue = "false")
+    private boolean overwrite;
+
+    /**
+     * Constructor
+     */
+    public PullMojo() {}
+
+    @Override
+    protected void execute(Registry registry, ContainerRef ref) throws MojoExecutionException {
+        if (outputDirectory == null) {
+            throw new MojoExecutionException("Parameter 'outputDirectory' is required");
+        }
+        try {
+            Files.createDirectories(outputDirectory.toPath());
+        } catch (IOException e) {
+            throw new MojoExecutionException("Failed to create directory %s".formatted(outputDirectory), e);
+        }
+        registry.pullArtifact(ref, outputDirectory.toPath(), overwrite);
+        getLog().info("Pulled %s into %s".formatted(ref, outputDirectory));
+    }
+}
@@ -0,0 +1,72 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.maven;
+
+import java.util.List;
+import java.util.Map;
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.Manifest;
+import land.oras.Registry;
+import org.apache.maven.plugin.MojoExecutionException;
+import org.apache.maven.plugins.annotations.LifecyclePhase;
+import org.apache.maven.plugins.annotations.Mojo;
+import org.apache.maven.plugins.annotations.Parameter;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Push files as an OCI artifact. Pushes the project artifacts when no file is configured
+ */
+@NullMarked
+@Mojo(name = "push", defaultPhase = LifecyclePhase.DEPLOY, threadSafe = true)
+public class PushMojo extends AbstractOrasMojo {
+
+    /**
+     * The files to push using the {@code path[:mediaType]} syntax
+     */
+    @Parameter
+    private @Nullable List<String> files;
+
+    /**
+     * The artifact type
+     */
+    @Parameter(property = "oras.artifactType")
+    private @Nullable String artifactType;
+
+    /**
+     * The manifest annotations
+     */
+    @Parameter
+    private @Nullable Map<String, String> annotations;
+
+    /**
+     * Constructor
+     */
+    public PushMojo() {}
+
+    @Override
+    protected void execute(Registry registry, ContainerRef ref) throws MojoExecutionException {
+        Manifest manifest = registry.pushArtifact(
+                ref, ArtifactType.from(artifactType), annotations(annotations), localPaths(files));
+        getLog().info("Pushed %s@%s".formatted(ref, manifest.getDigest()));
+    }
+}
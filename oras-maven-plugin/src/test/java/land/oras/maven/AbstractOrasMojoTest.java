@@ -0,0 +1,120 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.maven;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertInstanceOf;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+
+import java.util.List;
+import land.oras.ContainerRef;
+import land.oras.auth.AuthProvider;
+import land.oras.auth.BearerTokenProvider;
+import land.oras.auth.CredentialChain;
+import land.oras.auth.UsernamePasswordProvider;
+import org.apache.maven.plugin.MojoExecutionException;
+import org.apache.maven.settings.Proxy;
+import org.apache.maven.settings.Server;
+import org.apache.maven.settings.Settings;
+import org.apache.maven.settings.building.SettingsProblem;
+import org.apache.maven.settings.crypto.SettingsDecrypter;
+import org.apache.maven.settings.crypto.SettingsDecryptionResult;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class AbstractOrasMojoTest {
+
+    private static final ContainerRef REF = ContainerRef.parse("registry.example.com/library/artifact:1.0");
+
+    /**
+     * Decrypter returning the servers unchanged
+     */
+    private static final SettingsDecrypter DECRYPTER = request -> new SettingsDecryptionResult() {
+        @Override
+        public Server getServer() {
+            return request.getServers().get(0);
+        }
+
+        @Override
+        public List<Server> getServers() {
+            return request.getServers();
+        }
+
+        @Override
+        public Proxy getProxy() {
+            return null;
+        }
+
+        @Override
+        public List<Proxy> getProxies() {
+            return List.of();
+        }
+
+        @Override
+        public List<SettingsProblem> getProblems() {
+            return List.of();
+        }
+    };
+
+    @Test
+    void shouldResolveCredentialsFromServer() throws Exception {
+        Settings settings = new Settings();
+        settings.addServer(server("registry.example.com", "myuser", "mypass"));
+        settings.addServer(server("ghcr", null, "token"));
+
+        AuthProvider byHost = AbstractOrasMojo.authProvider(settings, DECRYPTER, null, REF);
+        AuthProvider byId = AbstractOrasMojo.authProvider(settings, DECRYPTER, "ghcr", REF);
+
+        // Assertion
+        assertInstanceOf(UsernamePasswordProvider.class, byHost);
+        assertEquals("Basic bXl1c2VyOm15cGFzcw==", byHost.getAuthHeader(REF));
+        assertInstanceOf(BearerTokenProvider.class, byId);
+    }
+
+    @Test
+    void shouldFallBackToDefaultChain() throws Exception {
+        Settings settings = new Settings();
+
+        // Assertion
+        assertInstanceOf(CredentialChain.class, AbstractOrasMojo.authProvider(settings, DECRYPTER, null, REF));
+        assertThrows(
+                MojoExecutionException.class, () -> AbstractOrasMojo.authProvider(settings, DECRYPTER, "missing", REF));
+    }
+
+    @Test
+    void shouldRejectServerWithoutPassword() {
+        Settings settings = new Settings();
+        settings.addServer(server("registry.example.com", "myuser", null));
+
+        // Assertion
+        assertThrows(MojoExecutionException.class, () -> AbstractOrasMojo.authProvider(settings, DECRYPTER, null, REF));
+    }
+
+    private static Server server(String id, String username, String password) {
+        Server server = new Server();
+        server.setId(id);
+        server.setUsername(username);
+        server.setPassword(password);
+        return server;
+    }
+}
@@ -21,6 +21,7 @@
 package land.oras;
 
 import com.fasterxml.jackson.annotation.JsonCreator;
+import java.nio.file.Path;
 import java.util.HashMap;
 import java.util.Map;
 import java.util.stream.Collectors;
@@ -106,6 +107,43 @@ public record Annotations(
         return new Annotations(this.configAnnotations(), this.manifestAnnotations(), newFilesAnnotations);
     }
 
+    /**
+     * Create a new annotations record with the given manifest annotations
+     * @param annotations The manifest annotations
+     * @return The new annotations record
+     */
+    public Annotations withManifestAnnotations(Map<String, String> annotations) {
+        return new Annotations(this.configAnnotations(), annotations, this.filesAnnotations());
+    }
+
+    /**
+     * Create a new annotations record with the given config annotations
+     * @param annotations The config annotations
+     * @return The new annotations record
+     */
+    public Annotations withConfigAnnotations(Map<String, String> annotations) {
+        return new Annotations(annotations, this.manifestAnnotations(), this.filesAnnotations());
+    }
+
+    /**
+     * Merge the given annotations into this one. Annotations from the given record take precedence.
+     * @param other The other annotations
+     * @return The new annotations record
+     */
+    public Annotations merge(Annotations other) {
+        Map<String, String> newConfigAnnotations = new HashMap<>(this.configAnnotations());
+        newConfigAnnotations.putAll(other.configAnnotations());
+        Map<String, String> newManifestAnnotations = new HashMap<>(this.manifestAnnotations());
+        newManifestAnnotations.putAll(other.manifestAnnotations());
+        Map<String, Map<String, String>> newFilesAnnotations = new HashMap<>(this.filesAnnotations());
+        other.filesAnnotations().forEach((key, annotations) -> {
+            Map<String, String> merged = new HashMap<>(newFilesAnnotations.getOrDefault(key, Map.of()));
+            merged.putAll(annotations);
+            newFilesAnnotations.put(key, merged);
+        });
+        return new Annotations(newConfigAnnotations, newManifestAnnotations, newFilesAnnotations);
+    }
+
     /**
      * Annotations file format
      */
@@ -152,6 +190,17 @@ public record Annotations(
         return new Annotations(file.getConfigAnnotations(), file.getManifestAnnotations(), file.getFilesAnnotations());
     }
 
+    /**
+     * Read the annotations from an annotation file using the ORAS CLI format.
+     * Keys are either {@code $manifest}, {@code $config} or the name of the files
+     *
+     * @param path The path to the annotation file
+     * @return The annotations
+     */
+    public static Annotations fromPath(Path path) {
+        return fromJson(JsonUtils.readFile(path));
+    }
+
     /**
      * Convert the annotations to a JSON string
      *
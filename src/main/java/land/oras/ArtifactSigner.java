@@ -0,0 +1,55 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.Map;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Signer invoked after a manifest or index is pushed, for example to attach a Notation signature to every published
+ * artifact. The returned signature is pushed as a referrer of the artifact, and the push fails if signing fails.
+ * Signers might be invoked concurrently from different threads.
+ */
+@NullMarked
+public interface ArtifactSigner {
+
+    /**
+     * Return the artifact type of the signature referrers. Artifacts of this type are not signed
+     * @return The artifact type
+     */
+    String getArtifactType();
+
+    /**
+     * Sign the pushed artifact
+     * @param ref The reference of the artifact, pinned to its digest
+     * @param descriptor The descriptor of the pushed manifest or index
+     * @return The signature to attach
+     */
+    Signature sign(ContainerRef ref, ManifestDescriptor descriptor);
+
+    /**
+     * A signature attached as the single layer of a referrer
+     * @param mediaType The media type of the signature envelope
+     * @param content The signature envelope
+     * @param annotations The annotations of the signature manifest
+     */
+    record Signature(String mediaType, byte[] content, Map<String, String> annotations) {}
+}
@@ -0,0 +1,41 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Verifier invoked on pull with the resolved manifest and access to its referrers, for example to check
+ * Cosign or Notation signatures attached to an image. The pull fails if any verifier rejects the artifact.
+ * Verifiers might be invoked concurrently from different threads.
+ */
+@NullMarked
+@FunctionalInterface
+public interface ArtifactVerifier {
+
+    /**
+     * Verify the pulled artifact
+     * @param context The verification context
+     * @return True if the artifact is accepted, false to reject it. Verifiers can also throw an
+     * {@link land.oras.exception.OrasException} with a detailed reason
+     */
+    boolean verify(VerificationContext context);
+}
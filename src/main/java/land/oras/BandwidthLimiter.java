@@ -0,0 +1,98 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.concurrent.TimeUnit;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Token bucket limiting the number of bytes transferred per second.
+ * A single limiter can be shared between transfers and registries to enforce a global bandwidth limit.
+ * The bucket starts empty and holds at most one second worth of bytes.
+ */
+@NullMarked
+public final class BandwidthLimiter {
+
+    private static final long NANOS_PER_SECOND = TimeUnit.SECONDS.toNanos(1);
+
+    private final long bytesPerSecond;
+    private long available;
+    private long lastRefill;
+
+    private BandwidthLimiter(long bytesPerSecond) {
+        this.bytesPerSecond = bytesPerSecond;
+        this.lastRefill = System.nanoTime();
+    }
+
+    /**
+     * Create a new limiter
+     * @param bytesPerSecond The maximum number of bytes per second. Must be greater than 0
+     * @return The limiter
+     */
+    public static BandwidthLimiter of(long bytesPerSecond) {
+        if (bytesPerSecond <= 0) {
+            throw new OrasException("Bandwidth limit must be greater than 0");
+        }
+        return new BandwidthLimiter(bytesPerSecond);
+    }
+
+    /**
+     * Get the maximum number of bytes per second
+     * @return The bytes per second
+     */
+    public long getBytesPerSecond() {
+        return bytesPerSecond;
+    }
+
+    /**
+     * Block until the given number of bytes can be transferred
+     * @param bytes The number of bytes
+     * @throws InterruptedException If interrupted while waiting
+     */
+    public void acquire(long bytes) throws InterruptedException {
+        long remaining = bytes;
+        while (remaining > 0) {
+            long waitNanos;
+            synchronized (this) {
+                refill();
+                long granted = Math.min(remaining, available);
+                available -= granted;
+                remaining -= granted;
+                if (remaining == 0) {
+                    return;
+                }
+                double seconds = (double) Math.min(remaining, bytesPerSecond) / bytesPerSecond;
+                waitNanos = Math.max(1, (long) (seconds * NANOS_PER_SECOND));
+            }
+            TimeUnit.NANOSECONDS.sleep(waitNanos);
+        }
+    }
+
+    private void refill() {
+        long now = System.nanoTime();
+        long tokens = (long) ((double) (now - lastRefill) / NANOS_PER_SECOND * bytesPerSecond);
+        if (tokens > 0) {
+            available = Math.min(bytesPerSecond, available + tokens);
+            lastRefill = now;
+        }
+    }
+}
@@ -0,0 +1,287 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.StandardCopyOption;
+import java.nio.file.attribute.FileTime;
+import java.util.ArrayList;
+import java.util.Comparator;
+import java.util.List;
+import java.util.Optional;
+import java.util.concurrent.locks.ReentrantLock;
+import java.util.stream.Stream;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.DigestAlgorithms;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * On-disk content-addressed cache of blobs keyed by digest.
+ * Blobs are stored with the same layout than an OCI layout and are only added once verified against their digest.
+ * The least recently used blobs are evicted when the total size exceeds the maximum size.
+ * A single cache directory can be shared between registries and processes on the same host.
+ */
+@NullMarked
+public final class BlobCache {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(BlobCache.class);
+
+    private final Path directory;
+    private final long maxSize;
+
+    /**
+     * Lock held while evicting
+     */
+    private final ReentrantLock evictionLock = new ReentrantLock();
+
+    private BlobCache(Path directory, long maxSize) {
+        this.directory = directory;
+        this.maxSize = maxSize;
+    }
+
+    /**
+     * Create a cache in the given directory
+     * @param directory The cache directory. Created if missing
+     * @param maxSize The maximum total size of cached blobs in bytes. Must be greater than 0
+     * @return The cache
+     */
+    public static BlobCache of(Path directory, long maxSize) {
+        if (maxSize <= 0) {
+            throw new OrasException("Blob cache size must be greater than 0");
+        }
+        try {
+            Files.createDirectories(directory.resolve(Const.OCI_LAYOUT_BLOBS));
+        } catch (IOException e) {
+            throw new OrasException("Failed to create blob cache directory %s".formatted(directory), e);
+        }
+        return new BlobCache(directory.toAbsolutePath(), maxSize);
+    }
+
+    /**
+     * Get the cache directory
+     * @return The directory
+     */
+    public Path getDirectory() {
+        return directory;
+    }
+
+    /**
+     * Get the maximum total size of cached blobs in bytes
+     * @return The maximum size
+     */
+    public long getMaxSize() {
+        return maxSize;
+    }
+
+    /**
+     * Return if a blob is cached
+     * @param digest The digest
+     * @return True if the blob is cached
+     */
+    public boolean contains(String digest) {
+        return Files.isRegularFile(getPath(digest));
+    }
+
+    /**
+     * Get the path of a cached blob and mark it as recently used
+     * @param digest The digest
+     * @return The path or empty if the blob is not cached
+     */
+    public Optional<Path> get(String digest) {
+        Path path = getPath(digest);
+        if (!Files.isRegularFile(path)) {
+            return Optional.empty();
+        }
+        try {
+            Files.setLastModifiedTime(path, FileTime.fromMillis(System.currentTimeMillis()));
+        } catch (IOException e) {
+            // Evicted concurrently or read-only cache, the blob can still be used if it exists
+            LOG.debug("Failed to update last access time of {}", path, e);
+        }
+        return Files.isRegularFile(path) ? Optional.of(path) : Optional.empty();
+    }
+
+    /**
+     * Add a verified file to the cache. The file is copied
+     * @param digest The digest of the file
+     * @param file The file
+     */
+    public void put(String digest, Path file) {
+        Path path = getPath(digest);
+        if (Files.isRegularFile(path)) {
+            return;
+        }
+        Path tempFile = null;
+        try {
+            Files.createDirectories(path.getParent());
+            tempFile = Files.createTempFile(path.getParent(), ".oras-", ".tmp");
+            Files.copy(file, tempFile, StandardCopyOption.REPLACE_EXISTING);
+            move(tempFile, path);
+        } catch (IOException e) {
+            // The cache is best effort, a failure must not fail the pull
+            LOG.warn("Failed to cache blob {}: {}", digest, e.getMessage());
+        } finally {
+            deleteQuietly(tempFile);
+        }
+        evict();
+    }
+
+    /**
+     * Add verified content to the cache
+     * @param digest The digest of the content
+     * @param data The content
+     */
+    public void put(String digest, byte[] data) {
+        Path path = getPath(digest);
+        if (Files.isRegularFile(path)) {
+            return;
+        }
+        Path tempFile = null;
+        try {
+            Files.createDirectories(path.getParent());
+            tempFile = Files.createTempFile(path.getParent(), ".oras-", ".tmp");
+            Files.write(tempFile, data);
+            move(tempFile, path);
+        } catch (IOException e) {
+            LOG.warn("Failed to cache blob {}: {}", digest, e.getMessage());
+        } finally {
+            deleteQuietly(tempFile);
+        }
+        evict();
+    }
+
+    /**
+     * Remove a blob from the cache
+     * @param digest The digest
+     */
+    public void remove(String digest) {
+        deleteQuietly(getPath(digest));
+    }
+
+    /**
+     * Get the total size of cached blobs
+     * @return The size in bytes
+     */
+    public long size() {
+        return listBlobs().stream().mapToLong(BlobCache::size).sum();
+    }
+
+    /**
+     * Remove the least recently used blobs until the total size is below the maximum size
+     */
+    public void evict() {
+        evictionLock.lock();
+        try {
+            List<Path> blobs = listBlobs();
+            long total = blobs.stream().mapToLong(BlobCache::size).sum();
+            if (total <= maxSize) {
+                return;
+            }
+            blobs.sort(Comparator.comparing(BlobCache::lastModified));
+            for (Path blob : blobs) {
+                if (total <= maxSize) {
+                    break;
+                }
+                long size = size(blob);
+                LOG.debug("Evicting {} from blob cache", blob);
+                deleteQuietly(blob);
+                total -= size;
+            }
+        } finally {
+            evictionLock.unlock();
+        }
+    }
+
+    /**
+     * Remove all blobs from the cache
+     */
+    public void clear() {
+        listBlobs().forEach(BlobCache::deleteQuietly);
+    }
+
+    private Path getPath(String digest) {
+        DigestAlgorithm algorithm = DigestAlgorithms.fromDigest(digest);
+        Path blobs = directory.resolve(Const.OCI_LAYOUT_BLOBS);
+        return blobs.resolve(algorithm.getPrefix()).resolve(DigestAlgorithms.getDigest(digest));
+    }
+
+    private List<Path> listBlobs() {
+        Path blobs = directory.resolve(Const.OCI_LAYOUT_BLOBS);
+        if (!Files.isDirectory(blobs)) {
+            return new ArrayList<>();
+        }
+        try (Stream<Path> files = Files.walk(blobs, 2)) {
+            return new ArrayList<>(files.filter(Files::isRegularFile)
+                    .filter(path -> !path.getFileName().toString().startsWith("."))
+                    .toList());
+        } catch (IOException e) {
+            throw new OrasException("Failed to list blob cache %s".formatted(directory), e);
+        }
+    }
+
+    private static void move(Path source, Path target) throws IOException {
+        try {
+            Files.move(source, target, StandardCopyOption.ATOMIC_MOVE);
+        } catch (IOException e) {
+            // Another process cached the same blob first
+            if (!Files.isRegularFile(target)) {
+                throw e;
+            }
+        }
+    }
+
+    private static long size(Path path) {
+        try {
+            return Files.size(path);
+        } catch (IOException e) {
+            return 0;
+        }
+    }
+
+    private static FileTime lastModified(Path path) {
+        try {
+            return Files.getLastModifiedTime(path);
+        } catch (IOException e) {
+            return FileTime.fromMillis(0);
+        }
+    }
+
+    private static void deleteQuietly(@Nullable Path path) {
+        if (path == null) {
+            return;
+        }
+        try {
+            Files.deleteIfExists(path);
+        } catch (IOException e) {
+            LOG.debug("Failed to delete {}", path, e);
+        }
+    }
+}
@@ -0,0 +1,65 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.FilterInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * An input stream failing with an {@link land.oras.exception.OperationCancelledException} once its operation is
+ * cancelled or the reading thread interrupted, so the transfer stops and the stream gets closed
+ */
+@NullMarked
+final class CancellableInputStream extends FilterInputStream {
+
+    private final @Nullable CancellationToken token;
+
+    /**
+     * Constructor
+     * @param in The input stream to wrap
+     * @param token The cancellation token or null to only honor thread interruption
+     */
+    CancellableInputStream(InputStream in, @Nullable CancellationToken token) {
+        super(in);
+        this.token = token;
+    }
+
+    @Override
+    public int read() throws IOException {
+        CancellationToken.check(token);
+        return super.read();
+    }
+
+    @Override
+    public int read(byte[] b, int off, int len) throws IOException {
+        CancellationToken.check(token);
+        return super.read(b, off, len);
+    }
+
+    @Override
+    public long skip(long n) throws IOException {
+        CancellationToken.check(token);
+        return super.skip(n);
+    }
+}
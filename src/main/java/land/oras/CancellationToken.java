@@ -0,0 +1,158 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.concurrent.CompletableFuture;
+import java.util.concurrent.locks.ReentrantLock;
+import land.oras.exception.OperationCancelledException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * Token to cancel in-flight push and pull operations, set with {@link OCI.PushOptions#withCancellation} or
+ * {@link OCI.PullOptions#withCancellation}. Cancelled operations stop transferring at the next read, abort their
+ * upload sessions and remove their partial files, then fail with an {@link OperationCancelledException}.
+ * Operations are also cancelled when the thread running them is interrupted.
+ */
+@NullMarked
+public final class CancellationToken {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(CancellationToken.class);
+
+    private final ReentrantLock lock = new ReentrantLock();
+    private final List<Runnable> callbacks = new ArrayList<>();
+    private volatile boolean cancelled;
+
+    private CancellationToken() {}
+
+    /**
+     * Create a new token, not cancelled
+     * @return The token
+     */
+    public static CancellationToken create() {
+        return new CancellationToken();
+    }
+
+    /**
+     * Cancel the operations using this token. Callbacks are invoked once, on the calling thread
+     */
+    public void cancel() {
+        List<Runnable> toRun;
+        lock.lock();
+        try {
+            if (cancelled) {
+                return;
+            }
+            cancelled = true;
+            toRun = List.copyOf(callbacks);
+            callbacks.clear();
+        } finally {
+            lock.unlock();
+        }
+        toRun.forEach(CancellationToken::run);
+    }
+
+    /**
+     * Return whether the token is cancelled
+     * @return True if cancelled
+     */
+    public boolean isCancelled() {
+        return cancelled;
+    }
+
+    /**
+     * Register a callback invoked when the token is cancelled. Invoked immediately if already cancelled
+     * @param callback The callback
+     */
+    public void onCancel(Runnable callback) {
+        lock.lock();
+        try {
+            if (!cancelled) {
+                callbacks.add(callback);
+                return;
+            }
+        } finally {
+            lock.unlock();
+        }
+        run(callback);
+    }
+
+    /**
+     * Cancel this token when the given future is cancelled, for example with {@link CompletableFuture#cancel}
+     * @param future The future
+     * @param <F> The type of future
+     * @return The future
+     */
+    public <F extends CompletableFuture<?>> F linkTo(F future) {
+        future.whenComplete((result, error) -> {
+            if (future.isCancelled()) {
+                cancel();
+            }
+        });
+        return future;
+    }
+
+    /**
+     * Throw if the token is cancelled or the current thread interrupted
+     * @throws OperationCancelledException If cancelled
+     */
+    public void throwIfCancelled() {
+        check(this);
+    }
+
+    /**
+     * Return whether the operation is cancelled by the token, if any, or by interrupting the current thread
+     * @param token The token or null
+     * @return True if cancelled
+     */
+    static boolean cancelled(@Nullable CancellationToken token) {
+        return Thread.currentThread().isInterrupted() || (token != null && token.isCancelled());
+    }
+
+    /**
+     * Throw if the operation is cancelled by the token, if any, or by interrupting the current thread
+     * @param token The token or null
+     * @throws OperationCancelledException If cancelled
+     */
+    static void check(@Nullable CancellationToken token) {
+        if (token != null && token.isCancelled()) {
+            throw new OperationCancelledException("Operation cancelled");
+        }
+        if (Thread.currentThread().isInterrupted()) {
+            throw new OperationCancelledException("Operation interrupted");
+        }
+    }
+
+    private static void run(Runnable callback) {
+        try {
+            callback.run();
+        } catch (RuntimeException e) {
+            LOG.warn("Cancellation callback failed", e);
+        }
+    }
+}
@@ -31,6 +31,7 @@ import java.util.Map;
 import java.util.Objects;
 import land.oras.utils.Const;
 import land.oras.utils.JsonUtils;
+import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullUnmarked;
 import org.jspecify.annotations.Nullable;
 
@@ -174,6 +175,21 @@ public final class Config extends Descriptor {
         return new Config(mediaType, layer.getDigest(), layer.getSize(), null, Annotations.empty());
     }
 
+    /**
+     * A config with the content of an image config set inline
+     * @param imageConfig The image config
+     * @return The config
+     */
+    public static Config fromImageConfig(ImageConfig imageConfig) {
+        byte[] data = imageConfig.toJson().getBytes(StandardCharsets.UTF_8);
+        return new Config(
+                Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE,
+                SupportedAlgorithm.getDefault().digest(data),
+                data.length,
+                Base64.getEncoder().encodeToString(data),
+                Annotations.empty());
+    }
+
     @Override
     public boolean equals(Object o) {
         if (o == null || getClass() != o.getClass()) return false;
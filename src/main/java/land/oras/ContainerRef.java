@@ -27,9 +27,12 @@ import java.util.List;
 import java.util.Objects;
 import java.util.regex.Matcher;
 import java.util.regex.Pattern;
+import land.oras.auth.HostConfig;
 import land.oras.exception.OrasException;
 import land.oras.policy.Transport;
 import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.DigestAlgorithms;
 import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullMarked;
 import org.jspecify.annotations.Nullable;
@@ -224,14 +227,41 @@ public final class ContainerRef extends Ref<ContainerRef> {
         return new ContainerRef(registry, unqualified, namespace, repository, tag, digest);
     }
 
+    /**
+     * Return a copy of reference without digest
+     * @return The container reference without digest
+     */
+    public ContainerRef withoutDigest() {
+        return new ContainerRef(registry, unqualified, namespace, repository, tag, null);
+    }
+
+    /**
+     * Return a copy of reference pointing to the referrers tag of its digest (e.g. sha256-abc...).
+     * The algorithm is truncated to 32 characters and the encoded part to 64 characters to fit the tag limit.
+     * See https://github.com/opencontainers/distribution-spec/blob/main/spec.md#referrers-tag-schema
+     * @return The container reference with the referrers tag
+     */
+    public ContainerRef withReferrersTag() {
+        if (digest == null) {
+            throw new OrasException("Digest is required to compute the referrers tag");
+        }
+        int separator = digest.indexOf(':');
+        if (separator < 0) {
+            throw new OrasException("Invalid digest to compute the referrers tag: %s".formatted(digest));
+        }
+        String algorithm = digest.substring(0, Math.min(separator, 32));
+        String encoded = digest.substring(separator + 1, Math.min(digest.length(), separator + 1 + 64));
+        return new ContainerRef(registry, unqualified, namespace, repository, algorithm + "-" + encoded, null);
+    }
+
     @Override
-    public SupportedAlgorithm getAlgorithm() {
+    public DigestAlgorithm getAlgorithm() {
         // Default if not set
         if (digest == null) {
             return SupportedAlgorithm.getDefault();
         }
         // See https://github.com/opencontainers/image-spec/blob/main/descriptor.md#digests
-        return SupportedAlgorithm.fromDigest(digest);
+        return DigestAlgorithms.fromDigest(digest);
     }
 
     /**
@@ -264,6 +294,17 @@ public final class ContainerRef extends Ref<ContainerRef> {
         return getRepositoriesPath(null);
     }
 
+    /**
+     * Return the catalog repositories URL
+     * @param target The target registry
+     * @param n The optional number of repositories to return, for pagination
+     * @param last The optional last repository index, for pagination
+     * @return The catalog URL
+     */
+    public String getRepositoriesPath(@Nullable Registry target, @Nullable Integer n, @Nullable String last) {
+        return withPagination(getRepositoriesPath(target), n, last);
+    }
+
     /**
      * Return the tag URL
      * @param target The target registry
@@ -291,10 +332,21 @@ public final class ContainerRef extends Ref<ContainerRef> {
      * @return The tag URL
      */
     public String getTagsPath(@Nullable Registry target, @Nullable Integer n, @Nullable String last) {
+        return withPagination(getTagsPath(target), n, last);
+    }
+
+    /**
+     * Append the pagination query parameters to a URL
+     * @param path The URL without query
+     * @param n The optional number of entries to return
+     * @param last The optional last entry index
+     * @return The URL
+     */
+    private static String withPagination(String path, @Nullable Integer n, @Nullable String last) {
         if (n == null && last == null) {
-            return getTagsPath(target);
+            return path;
         }
-        StringBuilder url = new StringBuilder(getTagsPath(target)).append("?");
+        StringBuilder url = new StringBuilder(path).append("?");
         if (n != null) {
             url.append("n=").append(n);
         }
@@ -456,7 +508,7 @@ public final class ContainerRef extends Ref<ContainerRef> {
 
         // Validate digest algorithm
         if (digest != null) {
-            SupportedAlgorithm.fromDigest(digest);
+            DigestAlgorithms.fromDigest(digest);
         }
 
         return new ContainerRef(registry, unqualified, namespace, repository, tag, digest);
@@ -512,6 +564,11 @@ public final class ContainerRef extends Ref<ContainerRef> {
             return registry.isInsecure();
         }
         String effectiveRegistry = getEffectiveRegistry(registry);
+        HostConfig hostConfig = registry.getHostConfig(effectiveRegistry);
+        if (hostConfig != null) {
+            LOG.debug("Using host configuration {} for registry {}", hostConfig, effectiveRegistry);
+            return hostConfig.isPlainHttp();
+        }
         ContainerRef effectiveRef = forRegistry(effectiveRegistry);
         // Configuration is authoritative over the current registry
         if (registry.getRegistriesConf().isInsecure(registry, effectiveRef)) {
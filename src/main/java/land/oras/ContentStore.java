@@ -0,0 +1,77 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.util.List;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * A source of content that can be pushed as an artifact with
+ * {@link OCI#pushArtifact(Ref, ArtifactType, Annotations, ContentStore)}
+ */
+@NullMarked
+public interface ContentStore {
+
+    /**
+     * Get the layers of the store, in the order they were added
+     * @return The layers
+     */
+    List<Layer> getLayers();
+
+    /**
+     * Get the config of the artifact. Its blob must be part of the store
+     * @return The config or null to use the empty config
+     */
+    default @Nullable Config getConfig() {
+        return null;
+    }
+
+    /**
+     * Check if the store contains a blob
+     * @param digest The digest of the blob
+     * @return True if the blob exists
+     */
+    boolean exists(String digest);
+
+    /**
+     * Open the content of a blob
+     * @param digest The digest of the blob
+     * @return The input stream of the blob
+     */
+    InputStream fetch(String digest);
+
+    /**
+     * Get the content of a blob. Not suitable for large blobs
+     * @param digest The digest of the blob
+     * @return The content of the blob
+     */
+    default byte[] getBlob(String digest) {
+        try (InputStream is = fetch(digest)) {
+            return is.readAllBytes();
+        } catch (IOException e) {
+            throw new OrasException("Failed to read blob %s".formatted(digest), e);
+        }
+    }
+}
@@ -0,0 +1,173 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.StandardOpenOption;
+import java.util.Set;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.locks.ReentrantLock;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Progress of copy operations, so an interrupted copy or mirror can be resumed without transferring the
+ * content again. Keys identify a blob, manifest or index in a target repository, like
+ * {@code registry/repository@digest}, or {@code registry/repository:tag@digest} for tagged manifests.
+ * Implementations can be shared by concurrent copies and must be thread-safe.
+ */
+@NullMarked
+public interface CopyCheckpoint {
+
+    /**
+     * Check if the content was already copied
+     * @param key The key of the content
+     * @return True if the content was already copied
+     */
+    boolean isCompleted(String key);
+
+    /**
+     * Record the content as copied
+     * @param key The key of the content
+     */
+    void markCompleted(String key);
+
+    /**
+     * A checkpoint not recording anything, used by default
+     * @return The checkpoint
+     */
+    static CopyCheckpoint none() {
+        return NoCheckpoint.INSTANCE;
+    }
+
+    /**
+     * A checkpoint kept in memory, to resume copies within the same process
+     * @return The checkpoint
+     */
+    static CopyCheckpoint inMemory() {
+        Set<String> completed = ConcurrentHashMap.newKeySet();
+        return new CopyCheckpoint() {
+            @Override
+            public boolean isCompleted(String key) {
+                return completed.contains(key);
+            }
+
+            @Override
+            public void markCompleted(String key) {
+                completed.add(key);
+            }
+        };
+    }
+
+    /**
+     * A checkpoint persisted to a file, one key per line. Existing keys are loaded, and each completed content
+     * is appended as soon as it's copied
+     * @param file The checkpoint file, created if missing
+     * @return The checkpoint
+     */
+    static CopyCheckpoint file(Path file) {
+        return new FileCheckpoint(file);
+    }
+
+    /**
+     * The no-op checkpoint
+     */
+    final class NoCheckpoint implements CopyCheckpoint {
+
+        private static final NoCheckpoint INSTANCE = new NoCheckpoint();
+
+        private NoCheckpoint() {
+            // Use CopyCheckpoint.none()
+        }
+
+        @Override
+        public boolean isCompleted(String key) {
+            return false;
+        }
+
+        @Override
+        public void markCompleted(String key) {
+            // Nothing to record
+        }
+    }
+
+    /**
+     * The checkpoint persisted to a file
+     */
+    final class FileCheckpoint implements CopyCheckpoint {
+
+        private final Path file;
+        private final Set<String> completed = ConcurrentHashMap.newKeySet();
+        private final ReentrantLock writeLock = new ReentrantLock();
+
+        private FileCheckpoint(Path file) {
+            this.file = file;
+            try {
+                if (Files.exists(file)) {
+                    // A partial last line from an interrupted write never matches a key
+                    Files.readAllLines(file, StandardCharsets.UTF_8).stream()
+                            .map(String::strip)
+                            .filter(line -> !line.isEmpty())
+                            .forEach(completed::add);
+                }
+            } catch (IOException e) {
+                throw new OrasException("Failed to read copy checkpoint %s".formatted(file), e);
+            }
+        }
+
+        /**
+         * Get the checkpoint file
+         * @return The file
+         */
+        public Path getFile() {
+            return file;
+        }
+
+        @Override
+        public boolean isCompleted(String key) {
+            return completed.contains(key);
+        }
+
+        @Override
+        public void markCompleted(String key) {
+            if (!completed.add(key)) {
+                return;
+            }
+            writeLock.lock();
+            try {
+                Files.writeString(
+                        file,
+                        key + "\n",
+                        StandardCharsets.UTF_8,
+                        StandardOpenOption.CREATE,
+                        StandardOpenOption.APPEND,
+                        StandardOpenOption.WRITE);
+            } catch (IOException e) {
+                throw new OrasException("Failed to write copy checkpoint %s".formatted(file), e);
+            } finally {
+                writeLock.unlock();
+            }
+        }
+    }
+}
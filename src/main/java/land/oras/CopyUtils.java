@@ -20,12 +20,17 @@ package land.oras;
  * =LICENSEEND=
  */
 
+import java.nio.charset.StandardCharsets;
 import java.util.HashSet;
+import java.util.LinkedHashMap;
 import java.util.List;
+import java.util.Map;
 import java.util.Objects;
 import java.util.Set;
-import java.util.concurrent.CompletableFuture;
+import java.util.function.Consumer;
+import java.util.function.Supplier;
 import land.oras.exception.OrasException;
+import land.oras.utils.Const;
 import org.jspecify.annotations.NonNull;
 import org.jspecify.annotations.Nullable;
 import org.slf4j.Logger;
@@ -62,10 +67,24 @@ public final class CopyUtils {
 
         private final boolean includeReferrers;
         private final @Nullable Set<Platform> platformFilter;
-
-        private CopyOptions(boolean includeReferrers, @Nullable Set<Platform> platformFilter) {
+        private final int concurrency;
+        private final boolean convertToOci;
+        private final boolean copyNonDistributable;
+        private final CopyCheckpoint checkpoint;
+
+        private CopyOptions(
+                boolean includeReferrers,
+                @Nullable Set<Platform> platformFilter,
+                int concurrency,
+                boolean convertToOci,
+                boolean copyNonDistributable,
+                CopyCheckpoint checkpoint) {
             this.includeReferrers = includeReferrers;
             this.platformFilter = platformFilter;
+            this.concurrency = concurrency;
+            this.convertToOci = convertToOci;
+            this.copyNonDistributable = copyNonDistributable;
+            this.checkpoint = checkpoint;
         }
 
         /**
@@ -73,15 +92,17 @@ public final class CopyUtils {
          * @return The default copy options
          */
         public static CopyOptions shallow() {
-            return new CopyOptions(false, null);
+            return new CopyOptions(false, null, 0, false, false, CopyCheckpoint.none());
         }
 
         /**
          * The copy options with includeReferrers and recursive set to true.
+         * Referrers (signatures, SBOMs, attestations) of the artifact, of an index and of its manifests
+         * are copied transitively.
          * @return The copy options with includeReferrers and recursive set to true
          */
         public static CopyOptions deep() {
-            return new CopyOptions(true, null);
+            return new CopyOptions(true, null, 0, false, false, CopyCheckpoint.none());
         }
 
         /**
@@ -92,7 +113,60 @@ public final class CopyUtils {
          * @return New CopyOptions with the platform filter set
          */
         public CopyOptions withPlatformFilter(Set<Platform> platforms) {
-            return new CopyOptions(includeReferrers, platforms);
+            return new CopyOptions(
+                    includeReferrers, platforms, concurrency, convertToOci, copyNonDistributable, checkpoint);
+        }
+
+        /**
+         * Return a new CopyOptions limiting the number of layers copied in parallel.
+         * The limit is bounded by the executor service of the source. Manifests are always pushed after their layers.
+         * @param concurrency Maximum number of concurrent layer copies. Zero or negative to only use the executor limit
+         * @return New CopyOptions with the concurrency set
+         */
+        public CopyOptions withConcurrency(int concurrency) {
+            return new CopyOptions(
+                    includeReferrers, platformFilter, concurrency, convertToOci, copyNonDistributable, checkpoint);
+        }
+
+        /**
+         * Return a new CopyOptions converting Docker image manifests V2 schema 2 and manifest lists to OCI
+         * image manifests and indexes. Manifest lists reference the converted manifests.
+         * Converted manifests get a new digest, so they should be copied to a tag.
+         * @param convertToOci Whether to convert Docker manifests to OCI manifests
+         * @return New CopyOptions with the conversion set
+         */
+        public CopyOptions withConvertToOci(boolean convertToOci) {
+            return new CopyOptions(
+                    includeReferrers, platformFilter, concurrency, convertToOci, copyNonDistributable, checkpoint);
+        }
+
+        /**
+         * Return a new CopyOptions pushing non-distributable (foreign) layers to the target.
+         * By default, such layers are skipped and only referenced by the copied manifest, as registries
+         * are not expected to store them. Some base images (like Windows) use them.
+         * @param copyNonDistributable Whether to copy non-distributable layers
+         * @return New CopyOptions with the non-distributable layers copy set
+         */
+        public CopyOptions withNonDistributable(boolean copyNonDistributable) {
+            return new CopyOptions(
+                    includeReferrers, platformFilter, concurrency, convertToOci, copyNonDistributable, checkpoint);
+        }
+
+        /**
+         * Return a new CopyOptions recording the copied blobs and manifests in the given checkpoint.
+         * Content already recorded is skipped, so an interrupted copy or mirror can be resumed by copying again
+         * with the same checkpoint.
+         * @param checkpoint The checkpoint, for example {@link CopyCheckpoint#file(java.nio.file.Path)}
+         * @return New CopyOptions with the checkpoint set
+         */
+        public CopyOptions withCheckpoint(CopyCheckpoint checkpoint) {
+            return new CopyOptions(
+                    includeReferrers,
+                    platformFilter,
+                    concurrency,
+                    convertToOci,
+                    copyNonDistributable,
+                    Objects.requireNonNull(checkpoint, "checkpoint"));
         }
 
         /**
@@ -110,6 +184,38 @@ public final class CopyUtils {
         public @Nullable Set<Platform> platformFilter() {
             return platformFilter;
         }
+
+        /**
+         * Return the maximum number of concurrent layer copies.
+         * @return The concurrency, or {@code 0} if only limited by the executor service
+         */
+        public int concurrency() {
+            return concurrency;
+        }
+
+        /**
+         * Return whether Docker manifests are converted to OCI manifests.
+         * @return {@code true} if Docker manifests are converted
+         */
+        public boolean convertToOci() {
+            return convertToOci;
+        }
+
+        /**
+         * Return whether non-distributable layers are pushed to the target.
+         * @return {@code true} if non-distributable layers are copied
+         */
+        public boolean copyNonDistributable() {
+            return copyNonDistributable;
+        }
+
+        /**
+         * Return the checkpoint recording the copied content.
+         * @return The checkpoint, {@link CopyCheckpoint#none()} if not set
+         */
+        public CopyCheckpoint checkpoint() {
+            return checkpoint;
+        }
     }
 
     /**
@@ -141,10 +247,10 @@ public final class CopyUtils {
      * @param target The target OCI
      * @param targetRef The target reference
      * @param contentType The content type (manifest or index media type)
+     * @param options The copy options
      * @param <SourceRefType> The source reference type
      * @param <TargetRefType> The target reference type
      */
-    @SuppressWarnings("unchecked")
     private static <
                     SourceRefType extends Ref<@NonNull SourceRefType>,
                     TargetRefType extends Ref<@NonNull TargetRefType>>
@@ -153,35 +259,48 @@ public final class CopyUtils {
                     SourceRefType sourceRef,
                     OCI<TargetRefType> target,
                     TargetRefType targetRef,
-                    String contentType) {
-        CompletableFuture.allOf(source.collectLayers(sourceRef, contentType, true).stream()
-                        .map(layer -> {
+                    String contentType,
+                    CopyOptions options) {
+        // Same registry host, try to mount every layer before streaming it
+        boolean mountable = canMount(source, sourceRef, target, targetRef);
+        OCI.runConcurrently(
+                source.getExecutorService(),
+                options.concurrency(),
+                source.collectLayers(sourceRef, contentType, true).stream()
+                        .filter(layer -> {
+                            if (layer.isNonDistributable() && !options.copyNonDistributable()) {
+                                LOG.debug("Skipping non-distributable layer {}", layer.getDigest());
+                                return false;
+                            }
+                            return true;
+                        })
+                        .<Supplier<Layer>>map(layer -> {
                             Objects.requireNonNull(layer.getDigest(), "Layer digest is required for streaming copy");
                             Objects.requireNonNull(layer.getSize(), "Layer size is required for streaming copy");
-                            return CompletableFuture.runAsync(
-                                    () -> {
-                                        if (canMount(source, sourceRef, target, targetRef)) {
-                                            boolean result = target.mountBlob(
-                                                    (TargetRefType) sourceRef.withDigest(layer.getDigest()),
-                                                    targetRef.withDigest(layer.getDigest()));
-                                            if (result) {
-                                                LOG.debug(
-                                                        "Copied layer (mounted from {}) {}",
-                                                        sourceRef.getRepository(),
-                                                        layer.getDigest());
-                                                return;
-                                            }
-                                        }
-                                        target.pushBlob(
-                                                targetRef.withDigest(layer.getDigest()),
-                                                layer.getSize(),
-                                                () -> source.fetchBlob(sourceRef.withDigest(layer.getDigest())),
-                                                layer.getAnnotations());
-                                    },
-                                    source.getExecutorService());
+                            String key = checkpointKey(target, targetRef, null, layer.getDigest());
+                            return () -> {
+                                if (options.checkpoint().isCompleted(key)) {
+                                    LOG.debug("Skipping layer {} already copied", layer.getDigest());
+                                    return layer;
+                                }
+                                if (mountable && tryMount(sourceRef, target, targetRef, layer.getDigest())) {
+                                    LOG.debug(
+                                            "Copied layer (mounted from {}) {}",
+                                            sourceRef.getRepository(),
+                                            layer.getDigest());
+                                    options.checkpoint().markCompleted(key);
+                                    return layer;
+                                }
+                                Layer pushed = target.pushBlob(
+                                        targetRef.withDigest(layer.getDigest()),
+                                        layer.getSize(),
+                                        () -> source.fetchLayer(sourceRef, layer),
+                                        layer.getAnnotations());
+                                options.checkpoint().markCompleted(key);
+                                return pushed;
+                            };
                         })
-                        .toArray(CompletableFuture[]::new))
-                .join();
+                        .toList());
     }
 
     /**
@@ -201,7 +320,65 @@ public final class CopyUtils {
                     OCI<TargetRefType> target,
                     TargetRefType targetRef,
                     CopyOptions options) {
-        copy(source, sourceRef, target, targetRef, options, new HashSet<>(), 0);
+        notifyListeners(source, target, listener -> listener.onCopyStarted(sourceRef, targetRef));
+        try (OperationTracer.TracedOperation sourceSpan =
+                        source.getOperationTracer().start(OperationTracer.COPY, sourceRef);
+                OperationTracer.TracedOperation targetSpan = startTargetSpan(source, target, targetRef)) {
+            try {
+                copy(source, sourceRef, target, targetRef, options, new HashSet<>(), 0);
+            } catch (RuntimeException e) {
+                sourceSpan.recordError(e);
+                targetSpan.recordError(e);
+                throw e;
+            }
+        }
+        notifyListeners(source, target, listener -> listener.onCopyCompleted(sourceRef, targetRef));
+    }
+
+    /**
+     * Start the copy span of the target, only if it doesn't share the same tracer as the source
+     * @param source The source OCI
+     * @param target The target OCI
+     * @param targetRef The target reference
+     * @return The traced operation
+     */
+    private static OperationTracer.TracedOperation startTargetSpan(OCI<?> source, OCI<?> target, Ref<?> targetRef) {
+        if (target.getOperationTracer() == source.getOperationTracer()) {
+            return new OperationTracer.TracedOperation() {};
+        }
+        return target.getOperationTracer().start(OperationTracer.COPY, targetRef);
+    }
+
+    /**
+     * Notify the operation listeners of the source and target, only once if they share the same listener
+     * @param source The source OCI
+     * @param target The target OCI
+     * @param event The event to send
+     */
+    private static void notifyListeners(OCI<?> source, OCI<?> target, Consumer<OperationListener> event) {
+        event.accept(source.getOperationListener());
+        if (target.getOperationListener() != source.getOperationListener()) {
+            event.accept(target.getOperationListener());
+        }
+    }
+
+    /**
+     * Copy a container from source to target without referrers.
+     * Blobs are streamed from source to target without being stored on disk. Each side uses its own authentication.
+     * @param source The source OCI
+     * @param sourceRef The source reference
+     * @param target The target OCI
+     * @param targetRef The target reference
+     * @param <SourceRefType> The source reference type
+     * @param <TargetRefType> The target reference type
+     */
+    public static <SourceRefType extends Ref<@NonNull SourceRefType>, TargetRefType extends Ref<@NonNull TargetRefType>>
+            void copy(
+                    OCI<SourceRefType> source,
+                    SourceRefType sourceRef,
+                    OCI<TargetRefType> target,
+                    TargetRefType targetRef) {
+        copy(source, sourceRef, target, targetRef, CopyOptions.shallow());
     }
 
     /**
@@ -261,37 +438,52 @@ public final class CopyUtils {
         // Single manifest
         if (source.isManifestMediaType(contentType)) {
 
-            // Write all layers
-            copyLayers(source, effectiveSourceRef, target, effectiveTargetRef, contentType);
-
-            // Write manifest as any blob
-            Manifest manifest = source.getManifest(effectiveSourceRef);
-            String targetTag = effectiveTargetRef.getTag();
-
-            Objects.requireNonNull(manifest.getDigest(), "Manifest digest is required for streaming copy");
-
-            // Push config
-            copyConfig(manifest, source, effectiveSourceRef, target, effectiveTargetRef);
-
-            // Push the manifest
-            LOG.debug("Copying manifest {}", manifestDigest);
-            target.pushManifest(effectiveTargetRef.withDigest(targetTag), manifest);
-            LOG.debug("Copied manifest {} with tag {}", manifestDigest, targetTag);
+            String key = manifestDigest == null
+                    ? null
+                    : checkpointKey(target, effectiveTargetRef, effectiveTargetRef.getTag(), manifestDigest);
+            if (key != null && options.checkpoint().isCompleted(key)) {
+                LOG.debug("Skipping manifest {} already copied", manifestDigest);
+            } else {
+                // Write all layers
+                copyLayers(source, effectiveSourceRef, target, effectiveTargetRef, contentType, options);
+
+                // Write manifest as any blob
+                Manifest manifest = source.getManifest(effectiveSourceRef);
+                String targetTag = effectiveTargetRef.getTag();
+
+                Objects.requireNonNull(manifest.getDigest(), "Manifest digest is required for streaming copy");
+
+                // Push config
+                copyConfig(manifest, source, effectiveSourceRef, target, effectiveTargetRef, options);
+
+                // Push the manifest
+                LOG.debug("Copying manifest {}", manifestDigest);
+                if (options.convertToOci() && Const.DOCKER_MANIFEST_MEDIA_TYPE.equals(contentType)) {
+                    manifest = manifest.toOci();
+                    LOG.debug("Converted Docker manifest {} to OCI", manifestDigest);
+                    if (targetTag == null) {
+                        targetTag = effectiveTargetRef
+                                .getAlgorithm()
+                                .digest(manifest.toJson().getBytes(StandardCharsets.UTF_8));
+                    }
+                }
+                target.pushManifest(effectiveTargetRef.withDigest(targetTag), manifest);
+                LOG.debug("Copied manifest {} with tag {}", manifestDigest, targetTag);
+                if (key != null) {
+                    options.checkpoint().markCompleted(key);
+                }
+            }
 
             if (includeReferrers) {
-                LOG.debug("Including referrers on copy of manifest {}", manifestDigest);
-                Referrers referrers = source.getReferrers(effectiveSourceRef.withDigest(manifestDigest), null);
-                for (ManifestDescriptor referer : referrers.getManifests()) {
-                    LOG.debug("Copy reference from referrers {}", referer.getDigest());
-                    copy(
-                            source,
-                            effectiveSourceRef.withDigest(referer.getDigest()),
-                            target,
-                            effectiveTargetRef.withDigest(referer.getDigest()),
-                            options,
-                            visited,
-                            depth + 1);
-                }
+                copyReferrers(
+                        source,
+                        effectiveSourceRef,
+                        target,
+                        effectiveTargetRef,
+                        manifestDigest,
+                        options,
+                        visited,
+                        depth);
             } else {
                 LOG.debug("Not including referrers on copy of manifest {}", manifestDigest);
             }
@@ -327,6 +519,9 @@ public final class CopyUtils {
                 indexToPush = index;
             }
 
+            // Descriptors of manifests converted to OCI, keyed by their source digest
+            Map<String, ManifestDescriptor> convertedManifests = new LinkedHashMap<>();
+
             // Write all manifests and their config
             for (ManifestDescriptor manifestDescriptor : manifestsToCopy) {
 
@@ -334,49 +529,164 @@ public final class CopyUtils {
                 if (source.isManifestMediaType(manifestDescriptor.getMediaType())) {
                     Manifest manifest =
                             source.getManifest(effectiveSourceRef.withDigest(manifestDescriptor.getDigest()));
+                    String key = checkpointKey(target, effectiveTargetRef, null, manifestDescriptor.getDigest());
+                    boolean completed = options.checkpoint().isCompleted(key);
 
                     // Copy all layers for this manifest
-                    copyLayers(
-                            source,
-                            effectiveSourceRef.withDigest(manifestDescriptor.getDigest()),
-                            target,
-                            effectiveTargetRef,
-                            manifestDescriptor.getMediaType());
-
-                    // Push config
-                    copyConfig(manifest, source, effectiveSourceRef, target, effectiveTargetRef);
+                    if (!completed) {
+                        copyLayers(
+                                source,
+                                effectiveSourceRef.withDigest(manifestDescriptor.getDigest()),
+                                target,
+                                effectiveTargetRef,
+                                manifestDescriptor.getMediaType(),
+                                options);
+
+                        // Push config
+                        copyConfig(manifest, source, effectiveSourceRef, target, effectiveTargetRef, options);
+                    }
 
                     // Push the manifest
                     LOG.debug("Copying nested manifest {}", manifestDescriptor.getDigest());
-                    Manifest pushedManifest = target.pushManifest(
-                            effectiveTargetRef.withDigest(manifest.getDigest()),
-                            manifest.withDescriptor(manifestDescriptor));
+                    if (options.convertToOci()
+                            && Const.DOCKER_MANIFEST_MEDIA_TYPE.equals(manifestDescriptor.getMediaType())) {
+                        Manifest converted = manifest.toOci();
+                        byte[] json = converted.toJson().getBytes(StandardCharsets.UTF_8);
+                        String convertedDigest = effectiveTargetRef.getAlgorithm().digest(json);
+                        if (!completed) {
+                            target.pushManifest(effectiveTargetRef.withDigest(convertedDigest), converted);
+                        }
+                        convertedManifests.put(
+                                manifestDescriptor.getDigest(),
+                                manifestDescriptor.withContent(
+                                        Const.DEFAULT_MANIFEST_MEDIA_TYPE, convertedDigest, json.length));
+                        LOG.debug(
+                                "Converted nested Docker manifest {} to OCI manifest {}",
+                                manifestDescriptor.getDigest(),
+                                convertedDigest);
+                    } else {
+                        if (!completed) {
+                            target.pushManifest(
+                                    effectiveTargetRef.withDigest(manifest.getDigest()),
+                                    manifest.withDescriptor(manifestDescriptor));
+                        }
+                        // Referrers of converted manifests are not copied since their subject no longer exists
+                        if (includeReferrers) {
+                            copyReferrers(
+                                    source,
+                                    effectiveSourceRef,
+                                    target,
+                                    effectiveTargetRef,
+                                    manifestDescriptor.getDigest(),
+                                    options,
+                                    visited,
+                                    depth);
+                        }
+                    }
+                    options.checkpoint().markCompleted(key);
                     LOG.debug("Copied nested manifest {}", manifestDescriptor.getDigest());
 
                 } else if (source.isIndexMediaType(manifestDescriptor.getMediaType())) {
-                    // Copy index of index
+                    // Copy index of index. Nested indexes are kept as-is since their digest is referenced
                     LOG.debug("Copying nested index {}", manifestDescriptor.getDigest());
                     copy(
                             source,
                             effectiveSourceRef.withDigest(manifestDescriptor.getDigest()),
                             target,
                             effectiveTargetRef.withDigest(manifestDescriptor.getDigest()),
-                            options,
+                            options.withConvertToOci(false),
                             visited,
                             depth + 1);
                     LOG.debug("Copied nested index {}", manifestDescriptor.getDigest());
                 }
             }
 
-            LOG.debug("Copying index {}", manifestDigest);
-            Index pushedIndex = target.pushIndex(effectiveTargetRef.withDigest(targetTag), indexToPush);
-            LOG.debug("Copied index {} with tag {}", pushedIndex, targetTag);
+            // Convert the Docker manifest list and reference the converted manifests
+            if (options.convertToOci() && Const.DOCKER_INDEX_MEDIA_TYPE.equals(index.getMediaType())) {
+                List<ManifestDescriptor> manifests = indexToPush.getManifests().stream()
+                        .map(d -> convertedManifests.getOrDefault(d.getDigest(), d))
+                        .toList();
+                indexToPush = indexToPush.withManifests(manifests).toOci();
+                LOG.debug("Converted Docker manifest list {} to OCI index", manifestDigest);
+                if (targetTag == null) {
+                    targetTag = effectiveTargetRef
+                            .getAlgorithm()
+                            .digest(indexToPush.toJson().getBytes(StandardCharsets.UTF_8));
+                }
+            }
+
+            String key = manifestDigest == null
+                    ? null
+                    : checkpointKey(target, effectiveTargetRef, effectiveTargetRef.getTag(), manifestDigest);
+            if (key != null && options.checkpoint().isCompleted(key)) {
+                LOG.debug("Skipping index {} already copied", manifestDigest);
+            } else {
+                LOG.debug("Copying index {}", manifestDigest);
+                Index pushedIndex = target.pushIndex(effectiveTargetRef.withDigest(targetTag), indexToPush);
+                LOG.debug("Copied index {} with tag {}", pushedIndex, targetTag);
+                if (key != null) {
+                    options.checkpoint().markCompleted(key);
+                }
+            }
+
+            if (includeReferrers) {
+                copyReferrers(
+                        source,
+                        effectiveSourceRef,
+                        target,
+                        effectiveTargetRef,
+                        manifestDigest,
+                        options,
+                        visited,
+                        depth);
+            } else {
+                LOG.debug("Not including referrers on copy of index {}", manifestDigest);
+            }
 
         } else {
             throw new OrasException("Unsupported content type: %s".formatted(contentType));
         }
     }
 
+    /**
+     * Copy the referrers of a manifest or index, transitively since each referrer is copied with the same options.
+     * Referrers keep their digest so the subject relationship is preserved on the target.
+     * @param source The source OCI
+     * @param sourceRef The source reference
+     * @param target The target OCI
+     * @param targetRef The target reference
+     * @param subjectDigest The digest of the subject
+     * @param options The copy options
+     * @param visited The digests already copied in this operation
+     * @param depth The current recursion depth
+     */
+    private static <
+                    SourceRefType extends Ref<@NonNull SourceRefType>,
+                    TargetRefType extends Ref<@NonNull TargetRefType>>
+            void copyReferrers(
+                    OCI<SourceRefType> source,
+                    SourceRefType sourceRef,
+                    OCI<TargetRefType> target,
+                    TargetRefType targetRef,
+                    String subjectDigest,
+                    CopyOptions options,
+                    Set<String> visited,
+                    int depth) {
+        LOG.debug("Including referrers on copy of {}", subjectDigest);
+        Referrers referrers = source.getReferrers(sourceRef.withDigest(subjectDigest), null);
+        for (ManifestDescriptor referer : referrers.getManifests()) {
+            LOG.debug("Copy reference from referrers {}", referer.getDigest());
+            copy(
+                    source,
+                    sourceRef.withDigest(referer.getDigest()),
+                    target,
+                    targetRef.withDigest(referer.getDigest()),
+                    options,
+                    visited,
+                    depth + 1);
+        }
+    }
+
     @SuppressWarnings("unchecked")
     private static <
                     SourceRefType extends Ref<@NonNull SourceRefType>,
@@ -393,7 +703,32 @@ public final class CopyUtils {
         return source.canMount(target, sourceRef, (SourceRefType) targetRef);
     }
 
+    /**
+     * Try to mount a blob from the source repository. A failed or rejected mount is not an error,
+     * the blob is copied instead.
+     * @param sourceRef The source reference
+     * @param target The target OCI
+     * @param targetRef The target reference
+     * @param digest The digest of the blob
+     * @return True if the blob was mounted
+     */
     @SuppressWarnings("unchecked")
+    private static <
+                    SourceRefType extends Ref<@NonNull SourceRefType>,
+                    TargetRefType extends Ref<@NonNull TargetRefType>>
+            boolean tryMount(
+                    SourceRefType sourceRef,
+                    OCI<TargetRefType> target,
+                    TargetRefType targetRef,
+                    String digest) {
+        try {
+            return target.mountBlob((TargetRefType) sourceRef.withDigest(digest), targetRef.withDigest(digest));
+        } catch (OrasException e) {
+            LOG.debug("Unable to mount blob {} from {}: {}", digest, sourceRef.getRepository(), e.getMessage());
+            return false;
+        }
+    }
+
     private static <
                     SourceRefType extends Ref<@NonNull SourceRefType>,
                     TargetRefType extends Ref<@NonNull TargetRefType>>
@@ -402,20 +737,27 @@ public final class CopyUtils {
                     OCI<SourceRefType> source,
                     SourceRefType sourceRef,
                     OCI<TargetRefType> target,
-                    TargetRefType targetRef) {
+                    TargetRefType targetRef,
+                    CopyOptions options) {
         // Write config as any blob
         LOG.debug("Copying config {}", manifest.getConfig().getDigest());
         Config config = manifest.getConfig();
         Objects.requireNonNull(config.getDigest(), "Config digest is required for streaming copy");
         Objects.requireNonNull(config.getSize(), "Config size is required for streaming copy");
+        String key = checkpointKey(target, targetRef, null, config.getDigest());
+        if (options.checkpoint().isCompleted(key)) {
+            LOG.debug("Skipping config {} already copied", config.getDigest());
+            return;
+        }
         TargetRefType configTargetRef =
                 targetRef.forTarget(target).withDigest(manifest.getConfig().getDigest());
-        if (canMount(source, sourceRef, target, targetRef)) {
-            target.mountBlob((TargetRefType) sourceRef.withDigest(config.getDigest()), configTargetRef);
+        if (canMount(source, sourceRef, target, targetRef)
+                && tryMount(sourceRef, target, targetRef, config.getDigest())) {
             LOG.debug(
                     "Copied config (mounted from {}) {}",
                     sourceRef.getRepository(),
                     manifest.getConfig().getDigest());
+            options.checkpoint().markCompleted(key);
             return;
         }
         target.pushBlob(
@@ -423,6 +765,27 @@ public final class CopyUtils {
                 config.getSize(),
                 () -> source.pullConfig(sourceRef, manifest.getConfig()),
                 config.getAnnotations());
+        options.checkpoint().markCompleted(key);
         LOG.debug("Copied config {}", manifest.getConfig().getDigest());
     }
+
+    /**
+     * The key of content copied to a target repository, recorded in the checkpoint
+     * @param target The target OCI
+     * @param targetRef The target reference
+     * @param tag The tag the content is copied to, if any
+     * @param digest The digest of the content
+     * @return The key
+     */
+    private static <TargetRefType extends Ref<@NonNull TargetRefType>> String checkpointKey(
+            OCI<TargetRefType> target, TargetRefType targetRef, @Nullable String tag, String digest) {
+        // The target of an OCI layout is its folder
+        String location = targetRef.getTarget(target);
+        if (targetRef instanceof ContainerRef containerRef) {
+            location = "%s/%s".formatted(location, containerRef.getFullRepository());
+        }
+        return tag == null || tag.equals(digest)
+                ? "%s@%s".formatted(location, digest)
+                : "%s:%s@%s".formatted(location, tag, digest);
+    }
 }
@@ -0,0 +1,136 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.Arrays;
+import java.util.Locale;
+import java.util.Map;
+import land.oras.utils.Const;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Default media type resolver using well known file extensions, then the magic bytes of the content
+ */
+@NullMarked
+final class DefaultMediaTypeResolver implements MediaTypeResolver {
+
+    /**
+     * The singleton instance
+     */
+    static final DefaultMediaTypeResolver INSTANCE = new DefaultMediaTypeResolver();
+
+    /**
+     * Media types by file extension, the longest matching extension wins
+     */
+    private static final Map<String, String> EXTENSIONS = Map.ofEntries(
+            Map.entry(".tar.gz", Const.DEFAULT_BLOB_DIR_MEDIA_TYPE),
+            Map.entry(".tgz", Const.DEFAULT_BLOB_DIR_MEDIA_TYPE),
+            Map.entry(".tar.zst", Const.BLOB_DIR_ZSTD_MEDIA_TYPE),
+            Map.entry(".tar", Const.DEFAULT_BLOB_MEDIA_TYPE),
+            Map.entry(".gz", "application/gzip"),
+            Map.entry(".zst", "application/zstd"),
+            Map.entry(".zip", Const.ZIP_MEDIA_TYPE),
+            Map.entry(".jar", "application/java-archive"),
+            Map.entry(".json", Const.DEFAULT_JSON_MEDIA_TYPE),
+            Map.entry(".yaml", "application/yaml"),
+            Map.entry(".yml", "application/yaml"),
+            Map.entry(".xml", "application/xml"),
+            Map.entry(".toml", "application/toml"),
+            Map.entry(".txt", "text/plain"),
+            Map.entry(".md", "text/markdown"),
+            Map.entry(".wasm", "application/wasm"));
+
+    private static final byte[] GZIP_MAGIC = {(byte) 0x1f, (byte) 0x8b};
+    private static final byte[] ZSTD_MAGIC = {(byte) 0x28, (byte) 0xb5, (byte) 0x2f, (byte) 0xfd};
+    private static final byte[] ZIP_MAGIC = {'P', 'K', 3, 4};
+    private static final byte[] WASM_MAGIC = {0, 'a', 's', 'm'};
+    private static final byte[] TAR_MAGIC = "ustar".getBytes(StandardCharsets.US_ASCII);
+
+    /**
+     * Offset of the magic in a tar header
+     */
+    private static final int TAR_MAGIC_OFFSET = 257;
+
+    private DefaultMediaTypeResolver() {}
+
+    @Override
+    public @Nullable String resolve(Path path) {
+        String mediaType = fromExtension(path);
+        return mediaType != null ? mediaType : fromContent(path);
+    }
+
+    private static @Nullable String fromExtension(Path path) {
+        Path fileName = path.getFileName();
+        if (fileName == null) {
+            return null;
+        }
+        String name = fileName.toString().toLowerCase(Locale.ROOT);
+        String match = null;
+        for (String extension : EXTENSIONS.keySet()) {
+            // Keep the longest matching extension
+            if (name.endsWith(extension) && (match == null || extension.length() > match.length())) {
+                match = extension;
+            }
+        }
+        return match != null ? EXTENSIONS.get(match) : null;
+    }
+
+    private static @Nullable String fromContent(Path path) {
+        if (!Files.isRegularFile(path)) {
+            return null;
+        }
+        byte[] header;
+        try (InputStream is = Files.newInputStream(path)) {
+            header = is.readNBytes(TAR_MAGIC_OFFSET + TAR_MAGIC.length);
+        } catch (IOException e) {
+            return null;
+        }
+        if (startsWith(header, 0, GZIP_MAGIC)) {
+            return "application/gzip";
+        }
+        if (startsWith(header, 0, ZSTD_MAGIC)) {
+            return "application/zstd";
+        }
+        if (startsWith(header, 0, ZIP_MAGIC)) {
+            return Const.ZIP_MEDIA_TYPE;
+        }
+        if (startsWith(header, 0, WASM_MAGIC)) {
+            return "application/wasm";
+        }
+        if (startsWith(header, TAR_MAGIC_OFFSET, TAR_MAGIC)) {
+            return Const.DEFAULT_BLOB_MEDIA_TYPE;
+        }
+        return null;
+    }
+
+    private static boolean startsWith(byte[] data, int offset, byte[] magic) {
+        if (data.length < offset + magic.length) {
+            return false;
+        }
+        return Arrays.equals(data, offset, offset + magic.length, magic, 0, magic.length);
+    }
+}
@@ -0,0 +1,125 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.FilterInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.security.MessageDigest;
+import land.oras.exception.DigestMismatchException;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.DigestAlgorithms;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * An input stream computing the digest of the bytes read and verifying it against the expected digest and size
+ * once the end of the stream is reached. A {@link DigestMismatchException} is thrown from the read reaching the end.
+ */
+@NullMarked
+final class DigestVerifyingInputStream extends FilterInputStream {
+
+    private final String expectedDigest;
+    private final long expectedSize;
+    private final DigestAlgorithm algorithm;
+    private final MessageDigest messageDigest;
+    private long bytesRead;
+    private boolean verified;
+
+    /**
+     * Constructor
+     * @param in The input stream to wrap
+     * @param expectedDigest The expected digest
+     * @param expectedSize The expected size or -1 if unknown
+     */
+    DigestVerifyingInputStream(InputStream in, String expectedDigest, long expectedSize) {
+        super(in);
+        this.expectedDigest = expectedDigest;
+        this.expectedSize = expectedSize;
+        this.algorithm = DigestAlgorithms.fromDigest(expectedDigest);
+        this.messageDigest = algorithm.newMessageDigest();
+    }
+
+    @Override
+    public int read() throws IOException {
+        int b = super.read();
+        if (b == -1) {
+            verify();
+        } else {
+            messageDigest.update((byte) b);
+            bytesRead++;
+        }
+        return b;
+    }
+
+    @Override
+    public int read(byte[] b, int off, int len) throws IOException {
+        int read = super.read(b, off, len);
+        if (read == -1) {
+            verify();
+        } else if (read > 0) {
+            messageDigest.update(b, off, read);
+            bytesRead += read;
+        }
+        return read;
+    }
+
+    @Override
+    public long skip(long n) throws IOException {
+        // Skipped bytes must be hashed too
+        byte[] buffer = new byte[(int) Math.min(n, 8192)];
+        long skipped = 0;
+        while (skipped < n) {
+            int read = read(buffer, 0, (int) Math.min(buffer.length, n - skipped));
+            if (read == -1) {
+                break;
+            }
+            skipped += read;
+        }
+        return skipped;
+    }
+
+    @Override
+    public boolean markSupported() {
+        return false;
+    }
+
+    @Override
+    public synchronized void mark(int readlimit) {}
+
+    @Override
+    public synchronized void reset() throws IOException {
+        throw new IOException("mark/reset not supported");
+    }
+
+    private void verify() {
+        if (verified) {
+            return;
+        }
+        verified = true;
+        if (expectedSize >= 0 && bytesRead != expectedSize) {
+            throw new DigestMismatchException(expectedDigest, expectedSize, bytesRead);
+        }
+        String actualDigest = algorithm.formatDigest(messageDigest.digest());
+        if (!expectedDigest.equals(actualDigest)) {
+            throw new DigestMismatchException(expectedDigest, actualDigest);
+        }
+    }
+}
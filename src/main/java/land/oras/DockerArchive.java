@@ -0,0 +1,279 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import com.fasterxml.jackson.annotation.JsonInclude;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.io.BufferedOutputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.OutputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.ArrayList;
+import java.util.Comparator;
+import java.util.List;
+import java.util.Map;
+import java.util.stream.Stream;
+import land.oras.exception.OrasException;
+import land.oras.utils.ArchiveUtils;
+import land.oras.utils.Const;
+import land.oras.utils.DockerMediaTypes;
+import land.oras.utils.JsonUtils;
+import land.oras.utils.SupportedAlgorithm;
+import org.apache.commons.compress.archivers.tar.TarArchiveEntry;
+import org.apache.commons.compress.archivers.tar.TarArchiveOutputStream;
+import org.jspecify.annotations.NonNull;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * Export and import of images as {@code docker save} tarballs (docker-archive).
+ * The tarball contains a {@code manifest.json} file listing the config and the uncompressed layers of each image,
+ * which can be loaded with {@code docker load}.
+ */
+public final class DockerArchive {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(DockerArchive.class);
+
+    /**
+     * The file listing the images of the archive
+     */
+    private static final String MANIFEST_FILE = "manifest.json";
+
+    /**
+     * Private constructor
+     */
+    private DockerArchive() {
+        // Utils class
+    }
+
+    /**
+     * An image entry of the manifest.json file
+     * @param config The path of the config in the archive
+     * @param repoTags The repository tags of the image
+     * @param layers The paths of the layers in the archive
+     */
+    @OrasModel
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    private record ImageEntry(
+            @JsonProperty("Config") String config,
+            @JsonProperty("RepoTags") @Nullable List<String> repoTags,
+            @JsonProperty("Layers") List<String> layers) {}
+
+    /**
+     * Export an image to a docker-archive tarball
+     * @param source The source to read the image from
+     * @param ref The reference of the image manifest
+     * @param repoTag The repository tag recorded in the archive (e.g. my-app:1.0.0)
+     * @param tarball The tarball to write
+     * @param <T> The reference type
+     */
+    public static <T extends Ref<@NonNull T>> void save(OCI<T> source, T ref, String repoTag, Path tarball) {
+        save(source, ref, repoTag, null, tarball);
+    }
+
+    /**
+     * Export an image to a docker-archive tarball, selecting the manifest matching the platform for an index
+     * @param source The source to read the image from
+     * @param ref The reference of the image manifest or index
+     * @param repoTag The repository tag recorded in the archive (e.g. my-app:1.0.0)
+     * @param platform The platform to select when the reference is an index. Can be null for a manifest
+     * @param tarball The tarball to write
+     * @param <T> The reference type
+     */
+    public static <T extends Ref<@NonNull T>> void save(
+            OCI<T> source, T ref, String repoTag, @Nullable Platform platform, Path tarball) {
+        Manifest manifest = resolveManifest(source, ref, platform);
+        Config config = manifest.getConfig();
+        String configMediaType = DockerMediaTypes.toOci(config.getMediaType());
+        if (!Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE.equals(configMediaType)) {
+            throw new OrasException("Cannot export %s to a docker archive, config media type %s is not an image config"
+                    .formatted(ref, config.getMediaType()));
+        }
+        try (OutputStream os = Files.newOutputStream(tarball);
+                BufferedOutputStream bos = new BufferedOutputStream(os);
+                TarArchiveOutputStream tar = new TarArchiveOutputStream(bos)) {
+            tar.setLongFileMode(TarArchiveOutputStream.LONGFILE_POSIX);
+
+            // Config
+            byte[] configData;
+            try (InputStream is = source.pullConfig(ref, config)) {
+                configData = is.readAllBytes();
+            }
+            String configFile = "%s.json".formatted(SupportedAlgorithm.getDigest(config.getDigest()));
+            putEntry(tar, configFile, configData);
+
+            // Layers are stored uncompressed so their digests match the diff IDs of the config
+            List<String> layerFiles = new ArrayList<>();
+            for (Layer layer : manifest.getLayers()) {
+                LocalPath uncompressed;
+                try (InputStream is = source.fetchLayer(ref, layer)) {
+                    uncompressed = ArchiveUtils.uncompress(is, layer.getMediaType());
+                }
+                try {
+                    String diffId = SupportedAlgorithm.SHA256.digest(uncompressed.getPath());
+                    String layerFile = "%s/layer.tar".formatted(SupportedAlgorithm.getDigest(diffId));
+                    putEntry(tar, layerFile, uncompressed.getPath());
+                    layerFiles.add(layerFile);
+                } finally {
+                    Files.deleteIfExists(uncompressed.getPath());
+                }
+            }
+
+            ImageEntry entry = new ImageEntry(configFile, List.of(repoTag), layerFiles);
+            putEntry(tar, MANIFEST_FILE, JsonUtils.toJson(List.of(entry)).getBytes(StandardCharsets.UTF_8));
+            tar.finish();
+        } catch (IOException e) {
+            throw new OrasException("Failed to write docker archive %s".formatted(tarball), e);
+        }
+        LOG.debug("Exported {} to docker archive {}", ref, tarball);
+    }
+
+    /**
+     * Import the first image of a docker-archive tarball and push it to the target
+     * @param target The target to push the image to
+     * @param ref The reference of the pushed manifest
+     * @param tarball The tarball to read
+     * @param <T> The reference type
+     * @return The pushed manifest
+     */
+    public static <T extends Ref<@NonNull T>> Manifest load(OCI<T> target, T ref, Path tarball) {
+        Path dir = ArchiveUtils.untar(tarball);
+        try {
+            Path manifestFile = dir.resolve(MANIFEST_FILE);
+            if (!Files.exists(manifestFile)) {
+                throw new OrasException("Not a docker archive, %s is missing in %s".formatted(MANIFEST_FILE, tarball));
+            }
+            ImageEntry[] entries = JsonUtils.fromJson(manifestFile, ImageEntry[].class);
+            if (entries.length == 0) {
+                throw new OrasException("No image found in docker archive %s".formatted(tarball));
+            }
+            ImageEntry entry = entries[0];
+            Layer configBlob = target.pushBlob(ref, resolveEntry(dir, entry.config()), Map.of());
+            Config config = Config.fromBlob(Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE, configBlob);
+            List<Layer> layers = new ArrayList<>();
+            for (String layerFile : entry.layers()) {
+                Path layerPath = resolveEntry(dir, layerFile);
+                Layer layer = target.pushBlob(ref, layerPath, Map.of());
+                layers.add(layer.withMediaType(layerMediaType(layerPath)));
+            }
+            Manifest manifest = Manifest.empty().withConfig(config).withLayers(layers);
+            return target.pushManifest(ref, manifest);
+        } catch (IOException e) {
+            throw new OrasException("Failed to read docker archive %s".formatted(tarball), e);
+        } finally {
+            deleteDirectory(dir);
+        }
+    }
+
+    /**
+     * Resolve the manifest to export, selecting the manifest matching the platform for an index
+     * @param source The source
+     * @param ref The reference
+     * @param platform The platform
+     * @param <T> The reference type
+     * @return The manifest
+     */
+    private static <T extends Ref<@NonNull T>> Manifest resolveManifest(
+            OCI<T> source, T ref, @Nullable Platform platform) {
+        Descriptor descriptor = source.getDescriptor(ref);
+        if (!Const.DEFAULT_INDEX_MEDIA_TYPE.equals(DockerMediaTypes.toOci(descriptor.getMediaType()))) {
+            return source.getManifest(ref);
+        }
+        if (platform == null) {
+            throw new OrasException("A platform is required to export the index %s".formatted(ref));
+        }
+        ManifestDescriptor selected = source.getIndex(ref).findUnique(platform);
+        if (selected == null) {
+            throw new OrasException("No manifest found in index %s matching platform: %s".formatted(ref, platform));
+        }
+        return source.getManifest(ref.withDigest(selected.getDigest()));
+    }
+
+    /**
+     * Resolve a file of the extracted archive, rejecting paths outside the archive
+     * @param dir The extracted archive directory
+     * @param name The file name in the archive
+     * @return The file path
+     */
+    private static Path resolveEntry(Path dir, String name) {
+        Path path = dir.resolve(name).normalize();
+        if (!path.startsWith(dir) || !Files.isRegularFile(path)) {
+            throw new OrasException("Invalid docker archive entry: %s".formatted(name));
+        }
+        return path;
+    }
+
+    /**
+     * Detect the media type of a layer from its magic bytes
+     * @param layer The layer file
+     * @return The media type
+     * @throws IOException if the layer cannot be read
+     */
+    private static String layerMediaType(Path layer) throws IOException {
+        byte[] header = new byte[4];
+        int read;
+        try (InputStream is = Files.newInputStream(layer)) {
+            read = is.readNBytes(header, 0, header.length);
+        }
+        if (read >= 2 && (header[0] & 0xff) == 0x1f && (header[1] & 0xff) == 0x8b) {
+            return Const.DEFAULT_BLOB_DIR_MEDIA_TYPE;
+        }
+        if (read == 4
+                && (header[0] & 0xff) == 0x28
+                && (header[1] & 0xff) == 0xb5
+                && (header[2] & 0xff) == 0x2f
+                && (header[3] & 0xff) == 0xfd) {
+            return Const.BLOB_DIR_ZSTD_MEDIA_TYPE;
+        }
+        return Const.DEFAULT_BLOB_MEDIA_TYPE;
+    }
+
+    private static void putEntry(TarArchiveOutputStream tar, String name, byte[] data) throws IOException {
+        TarArchiveEntry entry = new TarArchiveEntry(name);
+        entry.setSize(data.length);
+        tar.putArchiveEntry(entry);
+        tar.write(data);
+        tar.closeArchiveEntry();
+    }
+
+    private static void putEntry(TarArchiveOutputStream tar, String name, Path file) throws IOException {
+        TarArchiveEntry entry = new TarArchiveEntry(name);
+        entry.setSize(Files.size(file));
+        tar.putArchiveEntry(entry);
+        Files.copy(file, tar);
+        tar.closeArchiveEntry();
+    }
+
+    private static void deleteDirectory(Path dir) {
+        try (Stream<Path> paths = Files.walk(dir)) {
+            paths.sorted(Comparator.reverseOrder()).forEach(path -> path.toFile().delete());
+        } catch (IOException e) {
+            LOG.warn("Failed to delete temporary directory {}", dir, e);
+        }
+    }
+}
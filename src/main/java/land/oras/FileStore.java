@@ -0,0 +1,258 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+import java.util.concurrent.locks.ReentrantLock;
+import land.oras.exception.OrasException;
+import land.oras.utils.ArchiveUtils;
+import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.SupportedAlgorithm;
+import land.oras.utils.SupportedCompression;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * A content store mapping local files and directories to layers.
+ * Media types are detected with a {@link MediaTypeResolver} unless given explicitly and identical blobs are only
+ * stored once. Directories are packed into tar+gzip archives annotated to be unpacked on pull.
+ * Close the store to delete the temporary archives.
+ */
+@NullMarked
+public final class FileStore implements ContentStore, AutoCloseable {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(FileStore.class);
+
+    private final MediaTypeResolver mediaTypeResolver;
+    private final DigestAlgorithm algorithm;
+
+    /**
+     * Layers by title
+     */
+    private final Map<String, Layer> layers = new LinkedHashMap<>();
+
+    /**
+     * Blob paths by digest
+     */
+    private final Map<String, Path> blobs = new HashMap<>();
+
+    /**
+     * Temporary archives created when packing directories
+     */
+    private final List<Path> temporaryFiles = new ArrayList<>();
+
+    /**
+     * Guard the store
+     */
+    private final ReentrantLock lock = new ReentrantLock();
+
+    private FileStore(MediaTypeResolver mediaTypeResolver, DigestAlgorithm algorithm) {
+        this.mediaTypeResolver = mediaTypeResolver;
+        this.algorithm = algorithm;
+    }
+
+    /**
+     * Create a file store using the default media type resolver and digest algorithm
+     * @return The file store
+     */
+    public static FileStore create() {
+        return create(MediaTypeResolver.defaults());
+    }
+
+    /**
+     * Create a file store using the given media type resolver and the default digest algorithm
+     * @param mediaTypeResolver The media type resolver
+     * @return The file store
+     */
+    public static FileStore create(MediaTypeResolver mediaTypeResolver) {
+        return create(mediaTypeResolver, SupportedAlgorithm.getDefault());
+    }
+
+    /**
+     * Create a file store
+     * @param mediaTypeResolver The media type resolver
+     * @param algorithm The digest algorithm
+     * @return The file store
+     */
+    public static FileStore create(MediaTypeResolver mediaTypeResolver, DigestAlgorithm algorithm) {
+        return new FileStore(mediaTypeResolver, algorithm);
+    }
+
+    /**
+     * Add a file or directory, detecting its media type
+     * @param path The path
+     * @return The layer
+     */
+    public Layer add(Path path) {
+        return add(path, null);
+    }
+
+    /**
+     * Add a file or directory with the given media type
+     * @param path The path
+     * @param mediaType The media type or null to detect it
+     * @return The layer
+     */
+    public Layer add(Path path, @Nullable String mediaType) {
+        if (!Files.exists(path)) {
+            throw new OrasException("File not found: %s".formatted(path));
+        }
+        Path fileName = path.toAbsolutePath().normalize().getFileName();
+        if (fileName == null) {
+            throw new OrasException("Cannot add root directory: %s".formatted(path));
+        }
+        lock.lock();
+        try {
+            Layer layer = Files.isDirectory(path)
+                    ? packDirectory(path.toAbsolutePath().normalize(), fileName.toString(), mediaType)
+                    : addFile(path, fileName.toString(), mediaType);
+            String title = layer.getAnnotations().get(Const.ANNOTATION_TITLE);
+            Layer existing = layers.get(title);
+            if (existing != null) {
+                if (Objects.equals(existing.getDigest(), layer.getDigest())) {
+                    LOG.debug("File {} already added with digest {}", title, layer.getDigest());
+                    return existing;
+                }
+                throw new OrasException("Duplicate file name '%s' with different content".formatted(title));
+            }
+            layers.put(title, layer);
+            return layer;
+        } finally {
+            lock.unlock();
+        }
+    }
+
+    private Layer addFile(Path path, String title, @Nullable String mediaType) {
+        String digest = algorithm.digest(path);
+        blobs.putIfAbsent(digest, path);
+        String resolved = mediaType != null ? mediaType : mediaTypeResolver.resolve(path);
+        return Layer.fromDigest(digest, size(path))
+                .withMediaType(resolved != null ? resolved : Const.DEFAULT_BLOB_MEDIA_TYPE)
+                .withAnnotations(Map.of(Const.ANNOTATION_TITLE, title));
+    }
+
+    private Layer packDirectory(Path path, String title, @Nullable String mediaType) {
+        String archiveMediaType = mediaType != null ? mediaType : Const.DEFAULT_BLOB_DIR_MEDIA_TYPE;
+        SupportedCompression compression = SupportedCompression.fromMediaType(archiveMediaType);
+        LocalPath source = LocalPath.of(path, archiveMediaType);
+
+        // Same layout than directories pushed with pushArtifact
+        Map<String, String> annotations = new LinkedHashMap<>();
+        LocalPath archive;
+        if (compression.isAutoUnpack()) {
+            LocalPath tar = ArchiveUtils.tar(source);
+            temporaryFiles.add(tar.getPath());
+            archive = ArchiveUtils.compress(tar, archiveMediaType);
+            annotations.put(Const.ANNOTATION_TITLE, title);
+            annotations.put(Const.ANNOTATION_ORAS_CONTENT_DIGEST, algorithm.digest(tar.getPath()));
+            annotations.put(Const.ANNOTATION_ORAS_UNPACK, "true");
+        } else {
+            archive = ArchiveUtils.compress(source, archiveMediaType);
+            annotations.put(Const.ANNOTATION_TITLE, "%s.%s".formatted(title, compression.getFileExtension()));
+            annotations.put(Const.ANNOTATION_ORAS_UNPACK, "false");
+        }
+        temporaryFiles.add(archive.getPath());
+        String digest = algorithm.digest(archive.getPath());
+        blobs.putIfAbsent(digest, archive.getPath());
+        return Layer.fromDigest(digest, size(archive.getPath()))
+                .withMediaType(archiveMediaType)
+                .withAnnotations(annotations);
+    }
+
+    private static long size(Path path) {
+        try {
+            return Files.size(path);
+        } catch (IOException e) {
+            throw new OrasException("Failed to get size of %s".formatted(path), e);
+        }
+    }
+
+    @Override
+    public List<Layer> getLayers() {
+        lock.lock();
+        try {
+            return List.copyOf(layers.values());
+        } finally {
+            lock.unlock();
+        }
+    }
+
+    @Override
+    public boolean exists(String digest) {
+        lock.lock();
+        try {
+            return blobs.containsKey(digest);
+        } finally {
+            lock.unlock();
+        }
+    }
+
+    @Override
+    public InputStream fetch(String digest) {
+        Path path;
+        lock.lock();
+        try {
+            path = blobs.get(digest);
+        } finally {
+            lock.unlock();
+        }
+        if (path == null) {
+            throw new OrasException("Blob not found in store: %s".formatted(digest));
+        }
+        try {
+            return Files.newInputStream(path);
+        } catch (IOException e) {
+            throw new OrasException("Failed to read blob %s".formatted(digest), e);
+        }
+    }
+
+    @Override
+    public void close() {
+        lock.lock();
+        try {
+            for (Path temporaryFile : temporaryFiles) {
+                try {
+                    Files.deleteIfExists(temporaryFile);
+                } catch (IOException e) {
+                    LOG.warn("Failed to delete temporary file {}", temporaryFile, e);
+                }
+            }
+            temporaryFiles.clear();
+        } finally {
+            lock.unlock();
+        }
+    }
+}
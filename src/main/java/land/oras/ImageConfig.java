@@ -0,0 +1,390 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import com.fasterxml.jackson.annotation.JsonIgnore;
+import com.fasterxml.jackson.annotation.JsonInclude;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.utils.Const;
+import land.oras.utils.JsonUtils;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Typed content of an OCI image config blob ({@value Const#CONFIG_RUNNING_CONTAINER_MEDIA_TYPE})
+ * @param created The creation date of the image in RFC 3339 format
+ * @param author The author of the image
+ * @param architecture The CPU architecture
+ * @param os The operating system
+ * @param osVersion The operating system version
+ * @param osFeatures The operating system features
+ * @param variant The variant of the CPU
+ * @param config The execution parameters used when running a container
+ * @param rootfs The layer content addresses
+ * @param history The history of each layer
+ */
+@NullMarked
+@OrasModel
+@JsonInclude(JsonInclude.Include.NON_NULL)
+public record ImageConfig(
+        @Nullable @JsonProperty("created") String created,
+        @Nullable @JsonProperty("author") String author,
+        @Nullable @JsonProperty(Const.PLATFORM_ARCHITECTURE) String architecture,
+        @Nullable @JsonProperty(Const.PLATFORM_OS) String os,
+        @Nullable @JsonProperty(Const.PLATFORM_OS_VERSION) String osVersion,
+        @Nullable @JsonProperty(Const.PLATFORM_OS_FEATURES) List<String> osFeatures,
+        @Nullable @JsonProperty(Const.PLATFORM_VARIANT) String variant,
+        @Nullable @JsonProperty("config") ContainerConfig config,
+        @Nullable @JsonProperty("rootfs") RootFs rootfs,
+        @Nullable @JsonProperty("history") List<History> history) {
+
+    /**
+     * The execution parameters used when running a container
+     * @param user The user or UID
+     * @param exposedPorts The exposed ports, keys are in the form port/protocol
+     * @param env The environment variables in the form KEY=value
+     * @param entrypoint The entrypoint
+     * @param cmd The default arguments of the entrypoint
+     * @param volumes The volumes
+     * @param workingDir The working directory
+     * @param labels The labels
+     * @param stopSignal The stop signal
+     */
+    @OrasModel
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    public record ContainerConfig(
+            @Nullable @JsonProperty("User") String user,
+            @Nullable @JsonProperty("ExposedPorts") Map<String, Map<String, Object>> exposedPorts,
+            @Nullable @JsonProperty("Env") List<String> env,
+            @Nullable @JsonProperty("Entrypoint") List<String> entrypoint,
+            @Nullable @JsonProperty("Cmd") List<String> cmd,
+            @Nullable @JsonProperty("Volumes") Map<String, Map<String, Object>> volumes,
+            @Nullable @JsonProperty("WorkingDir") String workingDir,
+            @Nullable @JsonProperty("Labels") Map<String, String> labels,
+            @Nullable @JsonProperty("StopSignal") String stopSignal) {}
+
+    /**
+     * The layer content addresses
+     * @param type The type, always layers
+     * @param diffIds The digests of the uncompressed layers
+     */
+    @OrasModel
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    public record RootFs(
+            @Nullable @JsonProperty("type") String type,
+            @Nullable @JsonProperty("diff_ids") List<String> diffIds) {}
+
+    /**
+     * The history of a layer
+     * @param created The creation date in RFC 3339 format
+     * @param createdBy The command which created the layer
+     * @param author The author
+     * @param comment The comment
+     * @param emptyLayer True if the history entry didn't create a layer
+     */
+    @OrasModel
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    public record History(
+            @Nullable @JsonProperty("created") String created,
+            @Nullable @JsonProperty("created_by") String createdBy,
+            @Nullable @JsonProperty("author") String author,
+            @Nullable @JsonProperty("comment") String comment,
+            @Nullable @JsonProperty("empty_layer") Boolean emptyLayer) {}
+
+    /**
+     * Get the platform of the image
+     * @return The platform
+     */
+    @JsonIgnore
+    public Platform getPlatform() {
+        return new Platform(os, architecture, osVersion, variant, null, osFeatures);
+    }
+
+    /**
+     * Get the labels
+     * @return The labels or an empty map
+     */
+    @JsonIgnore
+    public Map<String, String> getLabels() {
+        return config != null && config.labels() != null ? config.labels() : Map.of();
+    }
+
+    /**
+     * Get the environment variables
+     * @return The environment variables in the form KEY=value or an empty list
+     */
+    @JsonIgnore
+    public List<String> getEnv() {
+        return config != null && config.env() != null ? config.env() : List.of();
+    }
+
+    /**
+     * Get the entrypoint
+     * @return The entrypoint or an empty list
+     */
+    @JsonIgnore
+    public List<String> getEntrypoint() {
+        return config != null && config.entrypoint() != null ? config.entrypoint() : List.of();
+    }
+
+    /**
+     * Get the default arguments of the entrypoint
+     * @return The arguments or an empty list
+     */
+    @JsonIgnore
+    public List<String> getCmd() {
+        return config != null && config.cmd() != null ? config.cmd() : List.of();
+    }
+
+    /**
+     * Get the digests of the uncompressed layers
+     * @return The diff IDs or an empty list
+     */
+    @JsonIgnore
+    public List<String> getDiffIds() {
+        return rootfs != null && rootfs.diffIds() != null ? rootfs.diffIds() : List.of();
+    }
+
+    /**
+     * Return the JSON representation of the image config
+     * @return The JSON string
+     */
+    public String toJson() {
+        return JsonUtils.toJson(this);
+    }
+
+    /**
+     * Create an image config from a JSON string
+     * @param json The JSON string
+     * @return The image config
+     */
+    public static ImageConfig fromJson(String json) {
+        return JsonUtils.fromJson(json, ImageConfig.class);
+    }
+
+    /**
+     * Create a builder for an image config
+     * @return The builder
+     */
+    public static Builder builder() {
+        return new Builder();
+    }
+
+    /**
+     * Builder of image configs for packing runnable images
+     */
+    public static final class Builder {
+
+        private @Nullable String created;
+        private @Nullable String author;
+        private Platform platform = Platform.linuxAmd64();
+        private @Nullable String user;
+        private final Map<String, Map<String, Object>> exposedPorts = new LinkedHashMap<>();
+        private final List<String> env = new ArrayList<>();
+        private final List<String> entrypoint = new ArrayList<>();
+        private final List<String> cmd = new ArrayList<>();
+        private final Map<String, Map<String, Object>> volumes = new LinkedHashMap<>();
+        private @Nullable String workingDir;
+        private final Map<String, String> labels = new LinkedHashMap<>();
+        private @Nullable String stopSignal;
+        private final List<String> diffIds = new ArrayList<>();
+        private final List<History> history = new ArrayList<>();
+
+        private Builder() {}
+
+        /**
+         * Set the creation date
+         * @param created The creation date in RFC 3339 format
+         * @return The builder
+         */
+        public Builder withCreated(String created) {
+            this.created = created;
+            return this;
+        }
+
+        /**
+         * Set the author
+         * @param author The author
+         * @return The builder
+         */
+        public Builder withAuthor(String author) {
+            this.author = author;
+            return this;
+        }
+
+        /**
+         * Set the platform. Defaults to linux/amd64
+         * @param platform The platform
+         * @return The builder
+         */
+        public Builder withPlatform(Platform platform) {
+            this.platform = platform;
+            return this;
+        }
+
+        /**
+         * Set the user
+         * @param user The user or UID
+         * @return The builder
+         */
+        public Builder withUser(String user) {
+            this.user = user;
+            return this;
+        }
+
+        /**
+         * Expose a port
+         * @param port The port in the form port/protocol, for example 8080/tcp
+         * @return The builder
+         */
+        public Builder withExposedPort(String port) {
+            this.exposedPorts.put(port, Map.of());
+            return this;
+        }
+
+        /**
+         * Add an environment variable
+         * @param name The name
+         * @param value The value
+         * @return The builder
+         */
+        public Builder withEnv(String name, String value) {
+            this.env.add("%s=%s".formatted(name, value));
+            return this;
+        }
+
+        /**
+         * Set the entrypoint
+         * @param entrypoint The entrypoint
+         * @return The builder
+         */
+        public Builder withEntrypoint(List<String> entrypoint) {
+            this.entrypoint.clear();
+            this.entrypoint.addAll(entrypoint);
+            return this;
+        }
+
+        /**
+         * Set the default arguments of the entrypoint
+         * @param cmd The arguments
+         * @return The builder
+         */
+        public Builder withCmd(List<String> cmd) {
+            this.cmd.clear();
+            this.cmd.addAll(cmd);
+            return this;
+        }
+
+        /**
+         * Add a volume
+         * @param volume The path of the volume
+         * @return The builder
+         */
+        public Builder withVolume(String volume) {
+            this.volumes.put(volume, Map.of());
+            return this;
+        }
+
+        /**
+         * Set the working directory
+         * @param workingDir The working directory
+         * @return The builder
+         */
+        public Builder withWorkingDir(String workingDir) {
+            this.workingDir = workingDir;
+            return this;
+        }
+
+        /**
+         * Add a label
+         * @param name The name
+         * @param value The value
+         * @return The builder
+         */
+        public Builder withLabel(String name, String value) {
+            this.labels.put(name, value);
+            return this;
+        }
+
+        /**
+         * Set the stop signal
+         * @param stopSignal The stop signal, for example SIGTERM
+         * @return The builder
+         */
+        public Builder withStopSignal(String stopSignal) {
+            this.stopSignal = stopSignal;
+            return this;
+        }
+
+        /**
+         * Add a layer by the digest of its uncompressed content
+         * @param diffId The diff ID
+         * @return The builder
+         */
+        public Builder withDiffId(String diffId) {
+            this.diffIds.add(diffId);
+            return this;
+        }
+
+        /**
+         * Add a history entry
+         * @param history The history entry
+         * @return The builder
+         */
+        public Builder withHistory(History history) {
+            this.history.add(history);
+            return this;
+        }
+
+        /**
+         * Build the image config
+         * @return The image config
+         */
+        public ImageConfig build() {
+            ContainerConfig containerConfig = new ContainerConfig(
+                    user,
+                    exposedPorts.isEmpty() ? null : Collections.unmodifiableMap(new LinkedHashMap<>(exposedPorts)),
+                    env.isEmpty() ? null : List.copyOf(env),
+                    entrypoint.isEmpty() ? null : List.copyOf(entrypoint),
+                    cmd.isEmpty() ? null : List.copyOf(cmd),
+                    volumes.isEmpty() ? null : Collections.unmodifiableMap(new LinkedHashMap<>(volumes)),
+                    workingDir,
+                    labels.isEmpty() ? null : Collections.unmodifiableMap(new LinkedHashMap<>(labels)),
+                    stopSignal);
+            return new ImageConfig(
+                    created,
+                    author,
+                    platform.architecture(),
+                    platform.os(),
+                    platform.osVersion(),
+                    platform.osFeatures(),
+                    platform.variant(),
+                    containerConfig,
+                    new RootFs("layers", List.copyOf(diffIds)),
+                    history.isEmpty() ? null : List.copyOf(history));
+        }
+    }
+}
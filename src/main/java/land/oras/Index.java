@@ -34,6 +34,7 @@ import java.util.Objects;
 import java.util.function.BiPredicate;
 import land.oras.exception.OrasException;
 import land.oras.utils.Const;
+import land.oras.utils.DockerMediaTypes;
 import land.oras.utils.JsonUtils;
 import org.jspecify.annotations.NonNull;
 import org.jspecify.annotations.Nullable;
@@ -46,12 +47,12 @@ import org.jspecify.annotations.Nullable;
     Const.JSON_PROPERTY_SCHEMA_VERSION,
     Const.JSON_PROPERTY_MEDIA_TYPE,
     Const.JSON_PROPERTY_ARTIFACT_TYPE,
+    Const.JSON_PROPERTY_MANIFESTS,
+    Const.JSON_PROPERTY_SUBJECT,
+    Const.JSON_PROPERTY_ANNOTATIONS,
     Const.JSON_PROPERTY_DIGEST,
     Const.JSON_PROPERTY_SIZE,
     Const.JSON_PROPERTY_CONFIG,
-    Const.JSON_PROPERTY_SUBJECT,
-    Const.JSON_PROPERTY_ANNOTATIONS,
-    Const.JSON_PROPERTY_MANIFESTS,
 })
 @JsonInclude(JsonInclude.Include.NON_NULL) // We need to serialize empty list of manifests
 public final class Index extends Descriptor implements Describable {
@@ -273,6 +274,30 @@ public final class Index extends Descriptor implements Describable {
                 schemaVersion, mediaType, artifactType, newManifests, annotations, subject, descriptor, registry, json);
     }
 
+    /**
+     * Return a new index with the given manifest added for the given platform.
+     * Any existing manifest matching the same platform is replaced.
+     * @param manifest The manifest descriptor, typically from a pushed manifest to keep the exact digest
+     * @param platform The platform of the manifest
+     * @return The index
+     */
+    public Index withPlatformManifest(ManifestDescriptor manifest, Platform platform) {
+        return withRemovedPlatform(platform).withNewManifests(manifest.withPlatform(platform));
+    }
+
+    /**
+     * Return a new index without the manifests matching the given platform
+     * @param platform The platform to remove
+     * @return The index
+     */
+    public Index withRemovedPlatform(Platform platform) {
+        List<ManifestDescriptor> newManifests = manifests.stream()
+                .filter(d -> !Platform.matches(d.getPlatform(), platform))
+                .toList();
+        return new Index(
+                schemaVersion, mediaType, artifactType, newManifests, annotations, subject, descriptor, registry, null);
+    }
+
     @Override
     @JsonIgnore
     public ManifestDescriptor getDescriptor() {
@@ -321,6 +346,33 @@ public final class Index extends Descriptor implements Describable {
                 schemaVersion, mediaType, artifactType, manifests, annotations, subject, descriptor, registry, json);
     }
 
+    /**
+     * Convert a Docker manifest list to an OCI image index.
+     * The media types of the index and of its manifests are converted and the original JSON is dropped.
+     * Digests are kept, so manifests converted by {@link Manifest#toOci()} must be replaced first
+     * using {@link #withManifests(List)}
+     * @return The OCI index or the same index if it's not a Docker manifest list
+     */
+    public Index toOci() {
+        if (!Const.DOCKER_INDEX_MEDIA_TYPE.equals(mediaType)) {
+            return this;
+        }
+        List<ManifestDescriptor> ociManifests = getManifests().stream()
+                .map(manifest -> manifest.withContent(
+                        DockerMediaTypes.toOci(manifest.getMediaType()), manifest.getDigest(), manifest.getSize()))
+                .toList();
+        return new Index(
+                schemaVersion,
+                Const.DEFAULT_INDEX_MEDIA_TYPE,
+                artifactType,
+                ociManifests,
+                annotations,
+                subject,
+                null,
+                registry,
+                null);
+    }
+
     /**
      * Return same instance but with original JSON
      * @param json The original JSON
@@ -29,10 +29,12 @@ import java.io.IOException;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.util.Base64;
+import java.util.List;
 import java.util.Map;
 import java.util.Objects;
 import land.oras.exception.OrasException;
 import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
 import land.oras.utils.JsonUtils;
 import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullMarked;
@@ -47,6 +49,7 @@ import org.jspecify.annotations.Nullable;
     Const.JSON_PROPERTY_MEDIA_TYPE,
     Const.JSON_PROPERTY_DIGEST,
     Const.JSON_PROPERTY_SIZE,
+    Const.JSON_PROPERTY_URLS,
     Const.JSON_PROPERTY_ANNOTATIONS,
     Const.JSON_PROPERTY_DATA
 })
@@ -63,16 +66,40 @@ public final class Layer extends Descriptor {
      */
     private final @Nullable Path blobPath;
 
+    /**
+     * The URLs from which the blob can be downloaded, typically for non-distributable layers
+     */
+    private final @Nullable List<String> urls;
+
     @JsonCreator
     private Layer(
             @JsonProperty(Const.JSON_PROPERTY_MEDIA_TYPE) String mediaType,
             @JsonProperty(Const.JSON_PROPERTY_DIGEST) String digest,
             @JsonProperty(Const.JSON_PROPERTY_SIZE) @Nullable Long size,
             @JsonProperty(Const.JSON_PROPERTY_DATA) @Nullable String data,
+            @JsonProperty(Const.JSON_PROPERTY_URLS) @Nullable List<String> urls,
             @JsonProperty(Const.JSON_PROPERTY_ANNOTATIONS) @Nullable Map<String, String> annotations) {
         super(digest, size, mediaType, annotations, null, null, null);
         this.data = data;
         this.blobPath = null;
+        this.urls = urls != null && !urls.isEmpty() ? List.copyOf(urls) : null;
+    }
+
+    /**
+     * Constructor without URLs
+     * @param mediaType The media type
+     * @param digest The digest
+     * @param size The size
+     * @param data The base 64 encoded data
+     * @param annotations The annotations
+     */
+    private Layer(
+            String mediaType,
+            String digest,
+            @Nullable Long size,
+            @Nullable String data,
+            @Nullable Map<String, String> annotations) {
+        this(mediaType, digest, size, data, (List<String>) null, annotations);
     }
 
     /**
@@ -106,6 +133,7 @@ public final class Layer extends Descriptor {
         super(digest, size, mediaType, annotations, null, null, null);
         this.data = null;
         this.blobPath = blobPath;
+        this.urls = null;
     }
 
     /**
@@ -131,7 +159,7 @@ public final class Layer extends Descriptor {
      * @return The new layer
      */
     public Layer withAnnotations(Map<String, String> annotations) {
-        return new Layer(mediaType, digest, size, data, annotations);
+        return new Layer(mediaType, digest, size, data, urls, annotations);
     }
 
     /**
@@ -140,7 +168,37 @@ public final class Layer extends Descriptor {
      * @return The new layer
      */
     public Layer withMediaType(String mediaType) {
-        return new Layer(mediaType, digest, size, data, annotations);
+        return new Layer(mediaType, digest, size, data, urls, annotations);
+    }
+
+    /**
+     * Get the URLs from which the blob can be downloaded
+     * @return The URLs or null if not set
+     */
+    public @Nullable List<String> getUrls() {
+        return urls;
+    }
+
+    /**
+     * Create a new layer with the URLs from which the blob can be downloaded
+     * @param urls The URLs
+     * @return The new layer
+     */
+    public Layer withUrls(List<String> urls) {
+        return new Layer(mediaType, digest, size, data, urls, annotations);
+    }
+
+    /**
+     * Return if the layer is non-distributable (Docker foreign layer), meaning registries are not expected to
+     * store its blob
+     * @return True if the media type is a non-distributable layer media type
+     */
+    @JsonIgnore
+    public boolean isNonDistributable() {
+        return Const.NON_DISTRIBUTABLE_LAYER_MEDIA_TYPE.equals(mediaType)
+                || Const.NON_DISTRIBUTABLE_UNCOMPRESSED_LAYER_MEDIA_TYPE.equals(mediaType)
+                || Const.NON_DISTRIBUTABLE_ZSTD_LAYER_MEDIA_TYPE.equals(mediaType)
+                || Const.DOCKER_FOREIGN_LAYER_MEDIA_TYPE.equals(mediaType);
     }
 
     /**
@@ -185,7 +243,7 @@ public final class Layer extends Descriptor {
      * @param algorithm The algorithm
      * @return The layer
      */
-    public static Layer fromFile(Path file, SupportedAlgorithm algorithm) {
+    public static Layer fromFile(Path file, DigestAlgorithm algorithm) {
         Map<String, String> annotations =
                 Map.of(Const.ANNOTATION_TITLE, file.getFileName().toString());
         return new Layer(
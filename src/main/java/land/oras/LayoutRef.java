@@ -23,6 +23,8 @@ package land.oras;
 import java.nio.file.Path;
 import java.util.Objects;
 import land.oras.exception.OrasException;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.DigestAlgorithms;
 import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullMarked;
 import org.jspecify.annotations.Nullable;
@@ -138,14 +140,14 @@ public final class LayoutRef extends Ref<LayoutRef> {
     }
 
     @Override
-    public SupportedAlgorithm getAlgorithm() {
+    public DigestAlgorithm getAlgorithm() {
         // Default if not set
         if (tag == null) {
             return SupportedAlgorithm.getDefault();
         }
         // See https://github.com/opencontainers/image-spec/blob/main/descriptor.md#digests
-        else if (SupportedAlgorithm.isSupported(tag)) {
-            return SupportedAlgorithm.fromDigest(tag);
+        else if (DigestAlgorithms.isSupported(tag)) {
+            return DigestAlgorithms.fromDigest(tag);
         }
 
         return SupportedAlgorithm.getDefault();
@@ -159,7 +161,7 @@ public final class LayoutRef extends Ref<LayoutRef> {
         if (tag == null) {
             return false;
         }
-        return SupportedAlgorithm.isSupported(tag);
+        return DigestAlgorithms.isSupported(tag);
     }
 
     @Override
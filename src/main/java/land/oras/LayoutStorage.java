@@ -0,0 +1,282 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.file.Files;
+import java.nio.file.NoSuchFileException;
+import java.nio.file.Path;
+import java.nio.file.StandardCopyOption;
+import java.util.List;
+import java.util.Map;
+import java.util.concurrent.ConcurrentSkipListMap;
+import java.util.stream.Stream;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * The storage of the blobs and index of an {@link OCILayout}. Entries are named by their path relative to the
+ * root of the layout with {@code /} as separator, like {@code index.json} or {@code blobs/sha256/<hex>}, so a
+ * layout can be served out of any content-addressed storage, for example an object store, while the layout keeps
+ * handling manifests, tags and graphs. Implementations must be thread-safe.
+ */
+@NullMarked
+public interface LayoutStorage {
+
+    /**
+     * Check if an entry exists
+     * @param name The name of the entry
+     * @return True if the entry exists
+     */
+    boolean exists(String name);
+
+    /**
+     * Get the size of an entry
+     * @param name The name of the entry
+     * @return The size in bytes
+     */
+    long size(String name);
+
+    /**
+     * Open an entry
+     * @param name The name of the entry
+     * @return The input stream of the entry, closed by the caller
+     */
+    InputStream read(String name);
+
+    /**
+     * Write an entry, replacing any existing one. The entry must only become visible once fully written, and must
+     * not be created when reading the data fails
+     * @param name The name of the entry
+     * @param data The data. Not closed by this method
+     */
+    void write(String name, InputStream data);
+
+    /**
+     * Delete an entry
+     * @param name The name of the entry
+     * @return True if the entry existed
+     */
+    boolean delete(String name);
+
+    /**
+     * List the entries whose name starts with the given prefix
+     * @param prefix The prefix, like {@code blobs/}
+     * @return The names of the entries, sorted
+     */
+    List<String> list(String prefix);
+
+    /**
+     * Read an entry. Not suitable for large blobs
+     * @param name The name of the entry
+     * @return The content of the entry
+     */
+    default byte[] readAllBytes(String name) {
+        try (InputStream is = read(name)) {
+            return is.readAllBytes();
+        } catch (IOException e) {
+            throw new OrasException("Failed to read %s".formatted(name), e);
+        }
+    }
+
+    /**
+     * Write an entry, replacing any existing one
+     * @param name The name of the entry
+     * @param data The data
+     */
+    default void write(String name, byte[] data) {
+        write(name, new ByteArrayInputStream(data));
+    }
+
+    /**
+     * A storage on the file system, the default of layouts
+     * @param directory The root directory of the layout
+     * @return The storage
+     */
+    static LayoutStorage directory(Path directory) {
+        return new DirectoryStorage(directory);
+    }
+
+    /**
+     * A storage kept in memory, for example for tests or short-lived layouts
+     * @return The storage
+     */
+    static LayoutStorage inMemory() {
+        return new InMemoryStorage();
+    }
+
+    /**
+     * A storage in a directory of the file system. Entries are written to a temporary file and moved in place
+     */
+    final class DirectoryStorage implements LayoutStorage {
+
+        private final Path directory;
+
+        private DirectoryStorage(Path directory) {
+            this.directory = directory.toAbsolutePath().normalize();
+        }
+
+        /**
+         * Get the root directory
+         * @return The directory
+         */
+        public Path getDirectory() {
+            return directory;
+        }
+
+        @Override
+        public boolean exists(String name) {
+            return Files.isRegularFile(resolve(name));
+        }
+
+        @Override
+        public long size(String name) {
+            try {
+                return Files.size(resolve(name));
+            } catch (IOException e) {
+                throw new OrasException("Failed to get size of %s".formatted(name), e);
+            }
+        }
+
+        @Override
+        public InputStream read(String name) {
+            try {
+                return Files.newInputStream(resolve(name));
+            } catch (IOException e) {
+                throw new OrasException("Failed to read %s".formatted(name), e);
+            }
+        }
+
+        @Override
+        public void write(String name, InputStream data) {
+            Path target = resolve(name);
+            Path temp = null;
+            try {
+                Files.createDirectories(target.getParent());
+                // Created in the root directory so it's never listed as a blob
+                temp = Files.createTempFile(directory, ".oras-", ".tmp");
+                Files.copy(data, temp, StandardCopyOption.REPLACE_EXISTING);
+                Files.move(temp, target, StandardCopyOption.REPLACE_EXISTING, StandardCopyOption.ATOMIC_MOVE);
+            } catch (IOException e) {
+                throw new OrasException("Failed to write %s".formatted(name), e);
+            } finally {
+                if (temp != null) {
+                    try {
+                        Files.deleteIfExists(temp);
+                    } catch (IOException e) {
+                        // Best effort, a stale temporary file is never read
+                    }
+                }
+            }
+        }
+
+        @Override
+        public boolean delete(String name) {
+            try {
+                return Files.deleteIfExists(resolve(name));
+            } catch (IOException e) {
+                throw new OrasException("Failed to delete %s".formatted(name), e);
+            }
+        }
+
+        @Override
+        public List<String> list(String prefix) {
+            // Only walk the directory of the prefix
+            int slash = prefix.lastIndexOf('/');
+            Path start = slash > 0 ? resolve(prefix.substring(0, slash)) : directory;
+            try (Stream<Path> paths = Files.walk(start)) {
+                return paths.filter(Files::isRegularFile)
+                        .map(path -> directory.relativize(path).toString().replace('\\', '/'))
+                        .filter(name -> name.startsWith(prefix) && !name.startsWith(".oras-"))
+                        .sorted()
+                        .toList();
+            } catch (NoSuchFileException e) {
+                return List.of();
+            } catch (IOException e) {
+                throw new OrasException("Failed to list %s".formatted(prefix), e);
+            }
+        }
+
+        private Path resolve(String name) {
+            Path path = directory.resolve(name).normalize();
+            if (!path.startsWith(directory) || path.equals(directory)) {
+                throw new OrasException("Invalid layout entry outside of %s: %s".formatted(directory, name));
+            }
+            return path;
+        }
+    }
+
+    /**
+     * A storage kept in memory
+     */
+    final class InMemoryStorage implements LayoutStorage {
+
+        private final Map<String, byte[]> entries = new ConcurrentSkipListMap<>();
+
+        private InMemoryStorage() {}
+
+        @Override
+        public boolean exists(String name) {
+            return entries.containsKey(name);
+        }
+
+        @Override
+        public long size(String name) {
+            return get(name).length;
+        }
+
+        @Override
+        public InputStream read(String name) {
+            return new ByteArrayInputStream(get(name));
+        }
+
+        @Override
+        public void write(String name, InputStream data) {
+            try {
+                entries.put(name, data.readAllBytes());
+            } catch (IOException e) {
+                throw new OrasException("Failed to write %s".formatted(name), e);
+            }
+        }
+
+        @Override
+        public boolean delete(String name) {
+            return entries.remove(name) != null;
+        }
+
+        @Override
+        public List<String> list(String prefix) {
+            return entries.keySet().stream()
+                    .filter(name -> name.startsWith(prefix))
+                    .toList();
+        }
+
+        private byte[] get(String name) {
+            byte[] data = entries.get(name);
+            if (data == null) {
+                throw new OrasException("Entry not found: %s".formatted(name));
+            }
+            return data;
+        }
+    }
+}
@@ -30,8 +30,11 @@ import java.util.Collections;
 import java.util.List;
 import java.util.Map;
 import java.util.Objects;
+import land.oras.exception.OrasException;
 import land.oras.utils.Const;
+import land.oras.utils.DockerMediaTypes;
 import land.oras.utils.JsonUtils;
+import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NonNull;
 import org.jspecify.annotations.NullUnmarked;
 import org.jspecify.annotations.Nullable;
@@ -45,13 +48,13 @@ import org.jspecify.annotations.Nullable;
     Const.JSON_PROPERTY_SCHEMA_VERSION,
     Const.JSON_PROPERTY_MEDIA_TYPE,
     Const.JSON_PROPERTY_ARTIFACT_TYPE,
-    Const.JSON_PROPERTY_DIGEST,
-    Const.JSON_PROPERTY_SIZE,
     Const.JSON_PROPERTY_CONFIG,
+    Const.JSON_PROPERTY_LAYERS,
     Const.JSON_PROPERTY_SUBJECT,
     Const.JSON_PROPERTY_ANNOTATIONS,
-    Const.JSON_PROPERTY_DATA,
-    Const.JSON_PROPERTY_LAYERS
+    Const.JSON_PROPERTY_DIGEST,
+    Const.JSON_PROPERTY_SIZE,
+    Const.JSON_PROPERTY_DATA
 })
 @JsonInclude(JsonInclude.Include.NON_NULL) // We need to serialize empty list of layers
 public final class Manifest extends Descriptor implements Describable {
@@ -277,6 +280,28 @@ public final class Manifest extends Descriptor implements Describable {
                 json);
     }
 
+    /**
+     * Return a new manifest with the given descriptor as subject.
+     * The descriptor must have a media type, a supported digest and a size
+     * @param subject The subject descriptor
+     * @return The manifest
+     * @throws OrasException if the descriptor is not a valid subject
+     */
+    public Manifest withSubject(Descriptor subject) {
+        Objects.requireNonNull(subject, "Subject descriptor cannot be null");
+        String subjectDigest = subject.getDigest();
+        if (subject.getMediaType() == null || subject.getMediaType().isBlank()) {
+            throw new OrasException("Subject descriptor must have a media type");
+        }
+        if (subjectDigest == null || !SupportedAlgorithm.isSupported(subjectDigest)) {
+            throw new OrasException("Subject descriptor must have a valid digest, got: %s".formatted(subjectDigest));
+        }
+        if (subject.getSize() == null || subject.getSize() < 0) {
+            throw new OrasException("Subject descriptor must have a size");
+        }
+        return withSubject(subject.toSubject());
+    }
+
     /**
      * Return a new manifest with the given annotations
      * @param annotations The annotations
@@ -315,6 +340,31 @@ public final class Manifest extends Descriptor implements Describable {
                 json);
     }
 
+    /**
+     * Convert a Docker image manifest V2 schema 2 to an OCI image manifest.
+     * The media types of the manifest, config and layers are converted and the original JSON is dropped,
+     * so the converted manifest has a different digest than the Docker manifest.
+     * @return The OCI manifest or the same manifest if it's not a Docker manifest
+     */
+    public Manifest toOci() {
+        if (!Const.DOCKER_MANIFEST_MEDIA_TYPE.equals(mediaType)) {
+            return this;
+        }
+        return new Manifest(
+                schemaVersion,
+                Const.DEFAULT_MANIFEST_MEDIA_TYPE,
+                getTopLevelArtifactType(),
+                null,
+                config != null ? config.withMediaType(DockerMediaTypes.toOci(config.getMediaType())) : null,
+                subject,
+                getLayers().stream()
+                        .map(layer -> layer.withMediaType(DockerMediaTypes.toOci(layer.getMediaType())))
+                        .toList(),
+                Annotations.ofManifest(annotations),
+                registry,
+                null);
+    }
+
     /**
      * Return same instance but with original JSON
      * @param json The original JSON
@@ -28,6 +28,7 @@ import com.fasterxml.jackson.annotation.JsonPropertyOrder;
 import java.util.Map;
 import java.util.Objects;
 import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
 import land.oras.utils.JsonUtils;
 import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullMarked;
@@ -40,11 +41,11 @@ import org.jspecify.annotations.Nullable;
 @OrasModel
 @JsonPropertyOrder({
     Const.JSON_PROPERTY_MEDIA_TYPE,
-    Const.JSON_PROPERTY_ARTIFACT_TYPE,
     Const.JSON_PROPERTY_DIGEST,
     Const.JSON_PROPERTY_SIZE,
-    Const.JSON_PROPERTY_PLATFORM,
     Const.JSON_PROPERTY_ANNOTATIONS,
+    Const.JSON_PROPERTY_PLATFORM,
+    Const.JSON_PROPERTY_ARTIFACT_TYPE,
 })
 @JsonInclude(JsonInclude.Include.NON_NULL)
 public final class ManifestDescriptor {
@@ -195,6 +196,17 @@ public final class ManifestDescriptor {
         return new ManifestDescriptor(artifactType, mediaType, digest, size, platform, annotations);
     }
 
+    /**
+     * Create a manifest descriptor pointing to other content, keeping the platform, annotations and artifact type
+     * @param mediaType The media type
+     * @param digest The digest
+     * @param size The size
+     * @return The manifest descriptor
+     */
+    public ManifestDescriptor withContent(String mediaType, String digest, long size) {
+        return new ManifestDescriptor(artifactType, mediaType, digest, size, platform, annotations);
+    }
+
     /**
      * Create a manifest descriptor
      * @param mediaType The media type
@@ -233,7 +245,7 @@ public final class ManifestDescriptor {
      * @return The manifest descriptor
      */
     public static ManifestDescriptor of(
-            Manifest manifest, Platform platform, Annotations annotations, SupportedAlgorithm supportedAlgorithm) {
+            Manifest manifest, Platform platform, Annotations annotations, DigestAlgorithm supportedAlgorithm) {
         String json = manifest.toJson();
         String digest = supportedAlgorithm.digest(json.getBytes());
         long size = json.length();
@@ -0,0 +1,84 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.file.Path;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Typed handling of the content of a custom artifact or layer media type, for example WASM modules, Helm charts
+ * or ML models. Handlers are registered with {@link MediaTypeHandlers}.
+ * @param <V> The type of the value
+ */
+@NullMarked
+public interface MediaTypeHandler<V> {
+
+    /**
+     * Get the handled media type
+     * @return The media type
+     */
+    String getMediaType();
+
+    /**
+     * Get the type of the value
+     * @return The type
+     */
+    Class<V> getType();
+
+    /**
+     * Read a value from the content of a blob
+     * @param input The content
+     * @return The value
+     * @throws IOException If the content cannot be read
+     */
+    V unmarshal(InputStream input) throws IOException;
+
+    /**
+     * Write a value as the content of a blob
+     * @param value The value
+     * @return The content
+     */
+    default byte[] marshal(V value) {
+        throw new OrasException("Marshalling is not supported for media type %s".formatted(getMediaType()));
+    }
+
+    /**
+     * Return if layers of this media type are unpacked on pull instead of being written as a file
+     * @return True to unpack with {@link #unpack(InputStream, Layer, Path)}
+     */
+    default boolean isUnpack() {
+        return false;
+    }
+
+    /**
+     * Unpack the content of a layer on pull. The digest of the layer is verified once the content is fully read
+     * @param input The content
+     * @param layer The layer
+     * @param directory The pull directory
+     * @throws IOException If the content cannot be unpacked
+     */
+    default void unpack(InputStream input, Layer layer, Path directory) throws IOException {
+        throw new OrasException("Unpacking is not supported for media type %s".formatted(getMediaType()));
+    }
+}
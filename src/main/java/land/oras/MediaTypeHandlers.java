@@ -0,0 +1,125 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Registry of the handlers of custom artifact and layer media types.
+ * No handler is registered by default.
+ */
+@NullMarked
+public final class MediaTypeHandlers {
+
+    /**
+     * Handlers by media type
+     */
+    private static final Map<String, MediaTypeHandler<?>> HANDLERS = new LinkedHashMap<>();
+
+    /**
+     * Utils class
+     */
+    private MediaTypeHandlers() {}
+
+    /**
+     * Register a handler. A handler for the same media type is replaced.
+     * @param handler The handler
+     */
+    public static synchronized void register(MediaTypeHandler<?> handler) {
+        HANDLERS.put(handler.getMediaType(), handler);
+    }
+
+    /**
+     * Unregister the handler of a media type
+     * @param mediaType The media type
+     */
+    public static synchronized void unregister(String mediaType) {
+        HANDLERS.remove(mediaType);
+    }
+
+    /**
+     * Get all registered handlers
+     * @return The handlers
+     */
+    public static synchronized List<MediaTypeHandler<?>> values() {
+        return Collections.unmodifiableList(new ArrayList<>(HANDLERS.values()));
+    }
+
+    /**
+     * Get the handler of a media type
+     * @param mediaType The media type
+     * @return The handler or null if not registered
+     */
+    public static synchronized @Nullable MediaTypeHandler<?> get(@Nullable String mediaType) {
+        return mediaType != null ? HANDLERS.get(mediaType) : null;
+    }
+
+    /**
+     * Get the handler of a media type producing values of the given type
+     * @param mediaType The media type
+     * @param type The type of the value
+     * @param <V> The type of the value
+     * @return The handler
+     * @throws OrasException If no handler is registered or the handler produces another type
+     */
+    @SuppressWarnings("unchecked")
+    public static <V> MediaTypeHandler<V> get(@Nullable String mediaType, Class<V> type) {
+        MediaTypeHandler<?> handler = get(mediaType);
+        if (handler == null) {
+            throw new OrasException("No handler registered for media type %s".formatted(mediaType));
+        }
+        if (!type.isAssignableFrom(handler.getType())) {
+            throw new OrasException("Handler of media type %s produces %s, not %s"
+                    .formatted(mediaType, handler.getType().getName(), type.getName()));
+        }
+        return (MediaTypeHandler<V>) handler;
+    }
+
+    /**
+     * Marshal a value with the handler of a media type
+     * @param mediaType The media type
+     * @param value The value
+     * @return The content
+     * @throws OrasException If no handler is registered or the handler doesn't accept the value
+     */
+    public static byte[] marshal(String mediaType, Object value) {
+        MediaTypeHandler<?> handler = get(mediaType);
+        if (handler == null) {
+            throw new OrasException("No handler registered for media type %s".formatted(mediaType));
+        }
+        return marshal(handler, value);
+    }
+
+    private static <V> byte[] marshal(MediaTypeHandler<V> handler, Object value) {
+        if (!handler.getType().isInstance(value)) {
+            throw new OrasException("Handler of media type %s expects %s, not %s"
+                    .formatted(handler.getMediaType(), handler.getType().getName(), value.getClass().getName()));
+        }
+        return handler.marshal(handler.getType().cast(value));
+    }
+}
@@ -0,0 +1,61 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.nio.file.Path;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Resolve the media type of local files added to a {@link FileStore}
+ */
+@NullMarked
+@FunctionalInterface
+public interface MediaTypeResolver {
+
+    /**
+     * Resolve the media type of a file
+     * @param path The path of the file
+     * @return The media type or null if unknown
+     */
+    @Nullable
+    String resolve(Path path);
+
+    /**
+     * Return a resolver falling back to another resolver when this one doesn't know the media type
+     * @param fallback The fallback resolver
+     * @return The resolver
+     */
+    default MediaTypeResolver orElse(MediaTypeResolver fallback) {
+        return path -> {
+            String mediaType = resolve(path);
+            return mediaType != null ? mediaType : fallback.resolve(path);
+        };
+    }
+
+    /**
+     * The default resolver, detecting media types from the file extension or content
+     * @return The default resolver
+     */
+    static MediaTypeResolver defaults() {
+        return DefaultMediaTypeResolver.INSTANCE;
+    }
+}
@@ -0,0 +1,196 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.ByteArrayInputStream;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.SupportedAlgorithm;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * An in-memory content store to compose artifacts from byte arrays or strings without touching the disk.
+ * Identical blobs are only stored once.
+ */
+@NullMarked
+public final class MemoryStore implements ContentStore {
+
+    private final DigestAlgorithm algorithm;
+
+    /**
+     * The layers in the order they were added
+     */
+    private final List<Layer> layers = new ArrayList<>();
+
+    /**
+     * Blobs by digest
+     */
+    private final Map<String, byte[]> blobs = new HashMap<>();
+
+    /**
+     * The config
+     */
+    private @Nullable Config config;
+
+    private MemoryStore(DigestAlgorithm algorithm) {
+        this.algorithm = algorithm;
+    }
+
+    /**
+     * Create a memory store using the default digest algorithm
+     * @return The memory store
+     */
+    public static MemoryStore create() {
+        return create(SupportedAlgorithm.getDefault());
+    }
+
+    /**
+     * Create a memory store
+     * @param algorithm The digest algorithm
+     * @return The memory store
+     */
+    public static MemoryStore create(DigestAlgorithm algorithm) {
+        return new MemoryStore(algorithm);
+    }
+
+    /**
+     * Add a layer without title
+     * @param data The data
+     * @param mediaType The media type
+     * @return The layer
+     */
+    public synchronized Layer add(byte[] data, String mediaType) {
+        Layer layer = Layer.fromDigest(store(data), data.length).withMediaType(mediaType);
+        layers.add(layer);
+        return layer;
+    }
+
+    /**
+     * Add a layer with a title to be pulled as a file
+     * @param title The title
+     * @param data The data
+     * @param mediaType The media type
+     * @return The layer
+     */
+    public synchronized Layer add(String title, byte[] data, String mediaType) {
+        String digest = store(data);
+        for (Layer existing : layers) {
+            if (title.equals(existing.getAnnotations().get(Const.ANNOTATION_TITLE))) {
+                if (Objects.equals(existing.getDigest(), digest)) {
+                    return existing;
+                }
+                throw new OrasException("Duplicate file name '%s' with different content".formatted(title));
+            }
+        }
+        Layer layer = Layer.fromDigest(digest, data.length)
+                .withMediaType(mediaType)
+                .withAnnotations(Map.of(Const.ANNOTATION_TITLE, title));
+        layers.add(layer);
+        return layer;
+    }
+
+    /**
+     * Add a layer with a title from a string encoded in UTF-8
+     * @param title The title
+     * @param content The content
+     * @param mediaType The media type
+     * @return The layer
+     */
+    public Layer add(String title, String content, String mediaType) {
+        return add(title, content.getBytes(StandardCharsets.UTF_8), mediaType);
+    }
+
+    /**
+     * Set the config of the artifact
+     * @param data The config data
+     * @param mediaType The config media type
+     * @return The config
+     */
+    public synchronized Config setConfig(byte[] data, String mediaType) {
+        String digest = store(data);
+        Config newConfig = Config.fromBlob(mediaType, Layer.fromDigest(digest, data.length));
+        this.config = newConfig;
+        return newConfig;
+    }
+
+    /**
+     * Set the config of the artifact from a string encoded in UTF-8
+     * @param content The config content
+     * @param mediaType The config media type
+     * @return The config
+     */
+    public Config setConfig(String content, String mediaType) {
+        return setConfig(content.getBytes(StandardCharsets.UTF_8), mediaType);
+    }
+
+    /**
+     * Add a blob that is not a layer of the artifact, for example a manifest
+     * @param data The data
+     * @return The digest of the blob
+     */
+    public synchronized String addBlob(byte[] data) {
+        return store(data);
+    }
+
+    private String store(byte[] data) {
+        String digest = algorithm.digest(data);
+        blobs.putIfAbsent(digest, data.clone());
+        return digest;
+    }
+
+    @Override
+    public synchronized List<Layer> getLayers() {
+        return List.copyOf(layers);
+    }
+
+    @Override
+    public synchronized @Nullable Config getConfig() {
+        return config;
+    }
+
+    @Override
+    public synchronized boolean exists(String digest) {
+        return blobs.containsKey(digest);
+    }
+
+    @Override
+    public InputStream fetch(String digest) {
+        return new ByteArrayInputStream(getBlob(digest));
+    }
+
+    @Override
+    public synchronized byte[] getBlob(String digest) {
+        byte[] data = blobs.get(digest);
+        if (data == null) {
+            throw new OrasException("Blob not found in store: %s".formatted(digest));
+        }
+        return data.clone();
+    }
+}
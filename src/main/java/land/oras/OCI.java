@@ -23,23 +23,34 @@ package land.oras;
 import java.io.ByteArrayInputStream;
 import java.io.IOException;
 import java.io.InputStream;
+import java.io.OutputStream;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.nio.file.StandardCopyOption;
+import java.time.OffsetDateTime;
+import java.time.format.DateTimeFormatter;
+import java.time.format.DateTimeParseException;
+import java.util.ArrayList;
 import java.util.Arrays;
+import java.util.HashSet;
 import java.util.LinkedHashMap;
 import java.util.LinkedList;
 import java.util.List;
 import java.util.Map;
+import java.util.Objects;
+import java.util.Set;
 import java.util.concurrent.CompletableFuture;
 import java.util.concurrent.CompletionException;
 import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Semaphore;
 import java.util.function.Supplier;
+import land.oras.encryption.LayerEncryption;
+import land.oras.exception.OperationCancelledException;
 import land.oras.exception.OrasException;
 import land.oras.utils.ArchiveUtils;
 import land.oras.utils.Const;
-import land.oras.utils.SupportedAlgorithm;
+import land.oras.utils.DigestAlgorithm;
 import land.oras.utils.SupportedCompression;
 import org.jspecify.annotations.NonNull;
 import org.jspecify.annotations.Nullable;
@@ -49,6 +60,7 @@ import org.slf4j.LoggerFactory;
 /**
  * Abstract class for OCI operation on remote registry or layout
  * Commons methods for OCI operations
+ * This is the common target used by {@link CopyUtils} to copy artifacts between registries and layouts
  * @param <T> The reference type
  */
 public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OCILayout {
@@ -69,10 +81,24 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
 
         private final boolean chunkedEnabled;
         private final long chunkSize;
-
-        private PushOptions(boolean chunkedEnabled, long chunkSize) {
+        private final int concurrency;
+        private final ArchiveUtils.TarOptions tarOptions;
+        private final @Nullable LayerEncryption encryption;
+        private final @Nullable CancellationToken cancellation;
+
+        private PushOptions(
+                boolean chunkedEnabled,
+                long chunkSize,
+                int concurrency,
+                ArchiveUtils.TarOptions tarOptions,
+                @Nullable LayerEncryption encryption,
+                @Nullable CancellationToken cancellation) {
             this.chunkedEnabled = chunkedEnabled;
             this.chunkSize = chunkSize;
+            this.concurrency = concurrency;
+            this.tarOptions = tarOptions;
+            this.encryption = encryption;
+            this.cancellation = cancellation;
         }
 
         /**
@@ -80,7 +106,7 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
          * @return The default push options
          */
         public static PushOptions defaults() {
-            return new PushOptions(false, DEFAULT_CHUNK_SIZE);
+            return new PushOptions(false, DEFAULT_CHUNK_SIZE, 0, ArchiveUtils.TarOptions.defaults(), null, null);
         }
 
         /**
@@ -88,7 +114,7 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
          * @return Push options with chunked upload enabled
          */
         public static PushOptions chunked() {
-            return new PushOptions(true, DEFAULT_CHUNK_SIZE);
+            return new PushOptions(true, DEFAULT_CHUNK_SIZE, 0, ArchiveUtils.TarOptions.defaults(), null, null);
         }
 
         /**
@@ -97,7 +123,46 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
          * @return Push options with chunked upload enabled
          */
         public static PushOptions chunked(long chunkSize) {
-            return new PushOptions(true, chunkSize);
+            return new PushOptions(true, chunkSize, 0, ArchiveUtils.TarOptions.defaults(), null, null);
+        }
+
+        /**
+         * Return new options limiting the number of layers uploaded in parallel.
+         * The limit is bounded by the executor service of the target. The manifest is always pushed last.
+         * @param concurrency Maximum concurrent layer uploads. Zero or negative for the executor limit only
+         * @return New push options with the concurrency set
+         */
+        public PushOptions withConcurrency(int concurrency) {
+            return new PushOptions(chunkedEnabled, chunkSize, concurrency, tarOptions, encryption, cancellation);
+        }
+
+        /**
+         * Return new options controlling the file metadata stored when packing directories.
+         * @param tarOptions The tar options
+         * @return New push options with the tar options set
+         */
+        public PushOptions withTarOptions(ArchiveUtils.TarOptions tarOptions) {
+            return new PushOptions(chunkedEnabled, chunkSize, concurrency, tarOptions, encryption, cancellation);
+        }
+
+        /**
+         * Return new options encrypting the pushed file layers. The media type of encrypted layers ends with
+         * {@code +encrypted} and the wrapped keys are stored in the layer annotations.
+         * @param encryption The layer encryption
+         * @return New push options with the encryption set
+         */
+        public PushOptions withEncryption(LayerEncryption encryption) {
+            return new PushOptions(chunkedEnabled, chunkSize, concurrency, tarOptions, encryption, cancellation);
+        }
+
+        /**
+         * Return new options cancelling the push when the token is cancelled.
+         * Upload sessions in progress are aborted on the registry.
+         * @param cancellation The cancellation token
+         * @return New push options with the cancellation token set
+         */
+        public PushOptions withCancellation(CancellationToken cancellation) {
+            return new PushOptions(chunkedEnabled, chunkSize, concurrency, tarOptions, encryption, cancellation);
         }
 
         /**
@@ -115,6 +180,38 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         public long chunkSize() {
             return chunkSize;
         }
+
+        /**
+         * Return the maximum number of concurrent layer uploads.
+         * @return The concurrency, or {@code 0} if only limited by the executor service
+         */
+        public int concurrency() {
+            return concurrency;
+        }
+
+        /**
+         * Return the options controlling the file metadata stored when packing directories.
+         * @return The tar options
+         */
+        public ArchiveUtils.TarOptions tarOptions() {
+            return tarOptions;
+        }
+
+        /**
+         * Return the encryption of the pushed file layers.
+         * @return The layer encryption, or {@code null} if layers are not encrypted
+         */
+        public @Nullable LayerEncryption encryption() {
+            return encryption;
+        }
+
+        /**
+         * Return the token cancelling the push.
+         * @return The cancellation token, or {@code null} if the push is only cancelled by thread interruption
+         */
+        public @Nullable CancellationToken cancellation() {
+            return cancellation;
+        }
     }
 
     /**
@@ -124,9 +221,31 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
     public static final class PullOptions {
 
         private final boolean overwriteEnabled;
-
-        private PullOptions(boolean overwriteEnabled) {
+        private final @Nullable Platform platform;
+        private final boolean platformRequired;
+        private final int concurrency;
+        private final ArchiveUtils.ExtractOptions extractOptions;
+        private final @Nullable String expectedDigest;
+        private final @Nullable LayerEncryption decryption;
+        private final @Nullable CancellationToken cancellation;
+
+        private PullOptions(
+                boolean overwriteEnabled,
+                @Nullable Platform platform,
+                boolean platformRequired,
+                int concurrency,
+                ArchiveUtils.ExtractOptions extractOptions,
+                @Nullable String expectedDigest,
+                @Nullable LayerEncryption decryption,
+                @Nullable CancellationToken cancellation) {
             this.overwriteEnabled = overwriteEnabled;
+            this.platform = platform;
+            this.platformRequired = platformRequired;
+            this.concurrency = concurrency;
+            this.extractOptions = extractOptions;
+            this.expectedDigest = expectedDigest;
+            this.decryption = decryption;
+            this.cancellation = cancellation;
         }
 
         /**
@@ -134,7 +253,7 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
          * @return The default pull options
          */
         public static PullOptions defaults() {
-            return new PullOptions(false);
+            return new PullOptions(false, null, false, 0, ArchiveUtils.ExtractOptions.defaults(), null, null, null);
         }
 
         /**
@@ -142,7 +261,131 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
          * @return Pull options with overwrite enabled
          */
         public static PullOptions overwrite() {
-            return new PullOptions(true);
+            return new PullOptions(true, null, false, 0, ArchiveUtils.ExtractOptions.defaults(), null, null, null);
+        }
+
+        /**
+         * Return new options selecting the manifest matching the given platform when pulling an index.
+         * All manifests of the index are pulled if none match the platform.
+         * @param platform The platform
+         * @return New pull options with the platform set
+         */
+        public PullOptions withPlatform(Platform platform) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    false,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
+        }
+
+        /**
+         * Return new options selecting the manifest matching the given platform when pulling an index.
+         * The pull fails if no manifest match the platform.
+         * @param platform The platform
+         * @return New pull options with the required platform set
+         */
+        public PullOptions withRequiredPlatform(Platform platform) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    true,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
+        }
+
+        /**
+         * Return new options limiting the number of layers downloaded in parallel.
+         * The limit is bounded by the executor service of the source.
+         * @param concurrency Maximum concurrent layer downloads. Zero or negative for the executor limit only
+         * @return New pull options with the concurrency set
+         */
+        public PullOptions withConcurrency(int concurrency) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    platformRequired,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
+        }
+
+        /**
+         * Return new options with the safeguards applied when extracting unpacked layers.
+         * @param extractOptions The extract options
+         * @return New pull options with the extract options set
+         */
+        public PullOptions withExtractOptions(ArchiveUtils.ExtractOptions extractOptions) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    platformRequired,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
+        }
+
+        /**
+         * Return new options failing the pull if the reference doesn't resolve to the given digest.
+         * The digest is checked before any file is written.
+         * @param expectedDigest The expected digest of the manifest or index
+         * @return New pull options with the expected digest set
+         */
+        public PullOptions withExpectedDigest(String expectedDigest) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    platformRequired,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
+        }
+
+        /**
+         * Return new options decrypting the pulled encrypted layers. Encrypted layers are pulled as is when not set.
+         * @param decryption The layer encryption holding the private keys
+         * @return New pull options with the decryption set
+         */
+        public PullOptions withDecryption(LayerEncryption decryption) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    platformRequired,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
+        }
+
+        /**
+         * Return new options cancelling the pull when the token is cancelled.
+         * Partially downloaded files are removed.
+         * @param cancellation The cancellation token
+         * @return New pull options with the cancellation token set
+         */
+        public PullOptions withCancellation(CancellationToken cancellation) {
+            return new PullOptions(
+                    overwriteEnabled,
+                    platform,
+                    platformRequired,
+                    concurrency,
+                    extractOptions,
+                    expectedDigest,
+                    decryption,
+                    cancellation);
         }
 
         /**
@@ -152,6 +395,196 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         public boolean isOverwrite() {
             return overwriteEnabled;
         }
+
+        /**
+         * Return the optional platform to select when pulling an index.
+         * @return The platform, or {@code null} if not set
+         */
+        public @Nullable Platform platform() {
+            return platform;
+        }
+
+        /**
+         * Return whether the pull must fail if no manifest match the platform.
+         * @return {@code true} if a manifest matching the platform is required
+         */
+        public boolean isPlatformRequired() {
+            return platformRequired;
+        }
+
+        /**
+         * Return the maximum number of concurrent layer downloads.
+         * @return The concurrency, or {@code 0} if only limited by the executor service
+         */
+        public int concurrency() {
+            return concurrency;
+        }
+
+        /**
+         * Return the safeguards applied when extracting unpacked layers.
+         * @return The extract options
+         */
+        public ArchiveUtils.ExtractOptions extractOptions() {
+            return extractOptions;
+        }
+
+        /**
+         * Return the digest the pulled reference must resolve to.
+         * @return The expected digest, or {@code null} if not set
+         */
+        public @Nullable String expectedDigest() {
+            return expectedDigest;
+        }
+
+        /**
+         * Return the decryption of the pulled encrypted layers.
+         * @return The layer encryption, or {@code null} if encrypted layers are pulled as is
+         */
+        public @Nullable LayerEncryption decryption() {
+            return decryption;
+        }
+
+        /**
+         * Return the token cancelling the pull.
+         * @return The cancellation token, or {@code null} if the pull is only cancelled by thread interruption
+         */
+        public @Nullable CancellationToken cancellation() {
+            return cancellation;
+        }
+
+        /**
+         * Check the resolved digest of a pull against the expected digest, if any.
+         * @param ref The pulled reference
+         * @param digest The resolved digest
+         * @throws OrasException if the digest doesn't match the expected one
+         */
+        void checkExpectedDigest(Ref<?> ref, @Nullable String digest) {
+            if (expectedDigest != null && !expectedDigest.equals(digest)) {
+                throw new OrasException("Reference %s resolved to digest %s but %s was expected"
+                        .formatted(ref, digest, expectedDigest));
+            }
+        }
+    }
+
+    /**
+     * Options controlling the behavior of {@link OCI#packManifest} operations.
+     * Layers and config referenced only by digest must already exist in the target.
+     */
+    @OrasModel
+    public static final class PackOptions {
+
+        private final @Nullable Config config;
+        private final List<Layer> layers;
+        private final @Nullable Subject subject;
+        private final Map<String, String> annotations;
+        private final @Nullable Provenance provenance;
+
+        private PackOptions(
+                @Nullable Config config,
+                List<Layer> layers,
+                @Nullable Subject subject,
+                Map<String, String> annotations,
+                @Nullable Provenance provenance) {
+            this.config = config;
+            this.layers = List.copyOf(layers);
+            this.subject = subject;
+            this.annotations = Map.copyOf(annotations);
+            this.provenance = provenance;
+        }
+
+        /**
+         * Default options: empty config, no layers, no subject and no annotations.
+         * @return The default pack options
+         */
+        public static PackOptions defaults() {
+            return new PackOptions(null, List.of(), null, Map.of(), null);
+        }
+
+        /**
+         * Return new options with the given config. Configs with inline data are pushed when packing.
+         * @param config The config
+         * @return New pack options with the config set
+         */
+        public PackOptions withConfig(Config config) {
+            return new PackOptions(config, layers, subject, annotations, provenance);
+        }
+
+        /**
+         * Return new options with the given layers. The layers must already be pushed.
+         * @param layers The layers
+         * @return New pack options with the layers set
+         */
+        public PackOptions withLayers(List<Layer> layers) {
+            return new PackOptions(config, layers, subject, annotations, provenance);
+        }
+
+        /**
+         * Return new options with the given subject.
+         * @param subject The subject
+         * @return New pack options with the subject set
+         */
+        public PackOptions withSubject(Subject subject) {
+            return new PackOptions(config, layers, subject, annotations, provenance);
+        }
+
+        /**
+         * Return new options with the given manifest annotations.
+         * @param annotations The manifest annotations
+         * @return New pack options with the annotations set
+         */
+        public PackOptions withAnnotations(Map<String, String> annotations) {
+            return new PackOptions(config, layers, subject, annotations, provenance);
+        }
+
+        /**
+         * Return new options stamping the provenance annotations on the packed manifest.
+         * Explicit manifest annotations take precedence over the provenance ones.
+         * @param provenance The provenance
+         * @return New pack options with the provenance set
+         */
+        public PackOptions withProvenance(Provenance provenance) {
+            return new PackOptions(config, layers, subject, annotations, provenance);
+        }
+
+        /**
+         * Return the config.
+         * @return The config, or {@code null} to use the empty config
+         */
+        public @Nullable Config config() {
+            return config;
+        }
+
+        /**
+         * Return the layers.
+         * @return The layers
+         */
+        public List<Layer> layers() {
+            return layers;
+        }
+
+        /**
+         * Return the subject.
+         * @return The subject, or {@code null} if not set
+         */
+        public @Nullable Subject subject() {
+            return subject;
+        }
+
+        /**
+         * Return the manifest annotations.
+         * @return The manifest annotations
+         */
+        public Map<String, String> annotations() {
+            return annotations;
+        }
+
+        /**
+         * Return the provenance stamped on the packed manifest.
+         * @return The provenance, or {@code null} if not set
+         */
+        public @Nullable Provenance provenance() {
+            return provenance;
+        }
     }
 
     /**
@@ -229,6 +662,41 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         return pushArtifact(ref, artifactType, annotations, Config.empty(), options, paths);
     }
 
+    /**
+     * Push an artifact with the content of a store. Blobs shared by several layers are pushed once.
+     * @param ref The ref
+     * @param artifactType The artifact type
+     * @param annotations The annotations
+     * @param store The content store
+     * @return The manifest
+     */
+    public Manifest pushArtifact(T ref, ArtifactType artifactType, Annotations annotations, ContentStore store) {
+        List<Layer> layers = store.getLayers();
+        Set<String> pushed = new HashSet<>();
+        for (Layer layer : layers) {
+            pushStoreBlob(ref, store, layer, pushed);
+        }
+        PackOptions options =
+                PackOptions.defaults().withLayers(layers).withAnnotations(annotations.manifestAnnotations());
+        Config config = store.getConfig();
+        if (config != null) {
+            pushStoreBlob(ref, store, config, pushed);
+            options = options.withConfig(config);
+        }
+        return packManifest(ref, artifactType, options);
+    }
+
+    private void pushStoreBlob(T ref, ContentStore store, Descriptor descriptor, Set<String> pushed) {
+        String digest = Objects.requireNonNull(descriptor.getDigest());
+        if (pushed.add(digest)) {
+            pushBlob(
+                    ref.withDigest(digest),
+                    Objects.requireNonNull(descriptor.getSize()),
+                    () -> store.fetch(digest),
+                    descriptor.getAnnotations());
+        }
+    }
+
     /**
      * Push a blob from file
      * @param ref The ref
@@ -263,12 +731,21 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
      * @return The layer
      */
     public Layer pushBlob(T ref, InputStream input) {
+        Path tempFile = null;
         try {
-            Path tempFile = Files.createTempFile("oras", "layer");
+            tempFile = Files.createTempFile("oras", "layer");
             Files.copy(input, tempFile, StandardCopyOption.REPLACE_EXISTING);
             return pushBlob(ref, tempFile);
         } catch (IOException e) {
             throw new OrasException("Failed to push blob", e);
+        } finally {
+            if (tempFile != null) {
+                try {
+                    Files.deleteIfExists(tempFile);
+                } catch (IOException e) {
+                    LOG.warn("Failed to delete temporary file {}", tempFile, e);
+                }
+            }
         }
     }
 
@@ -295,6 +772,12 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
                         manifestDescriptor.getDigest());
                 continue;
             }
+            // Nested index (OCI index or Docker manifest list)
+            if (isIndexMediaType(manifestContentType)) {
+                layers.addAll(collectLayers(
+                        ref.withDigest(manifestDescriptor.getDigest()), manifestContentType, includeAll));
+                continue;
+            }
             // Collect layer for each manifest
             List<Layer> manifestLayers =
                     getManifest(ref.withDigest(manifestDescriptor.getDigest())).getLayers();
@@ -314,6 +797,29 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         return layers;
     }
 
+    /**
+     * Select the manifest of the index matching the platform of the pull options
+     * @param index The index
+     * @param options The pull options
+     * @return The matching manifest descriptor, or null if no platform is set or no manifest match
+     */
+    protected @Nullable ManifestDescriptor selectPlatform(Index index, PullOptions options) {
+        Platform platform = options.platform();
+        if (platform == null) {
+            return null;
+        }
+        ManifestDescriptor descriptor = index.findUnique(platform);
+        if (descriptor == null) {
+            if (options.isPlatformRequired()) {
+                throw new OrasException("No manifest found in index matching platform: %s".formatted(platform));
+            }
+            LOG.info("No manifest found in index matching platform {}, pulling all manifests", platform);
+            return null;
+        }
+        LOG.debug("Selected manifest {} for platform {}", descriptor.getDigest(), platform);
+        return descriptor;
+    }
+
     /**
      * Push layers to the target using default push options
      * @param ref The ref
@@ -338,16 +844,49 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
     protected final List<Layer> pushLayers(
             T ref, Annotations annotations, boolean withDigest, PushOptions options, LocalPath... paths) {
         try {
-            return Arrays.stream(paths)
-                    .map(p -> CompletableFuture.supplyAsync(
-                            () -> pushLayer(ref, annotations, withDigest, p, options), getExecutorService()))
-                    .map(CompletableFuture::join)
-                    .toList();
+            return runConcurrently(
+                    getExecutorService(),
+                    options.concurrency(),
+                    Arrays.stream(paths)
+                            .<Supplier<Layer>>map(p -> () -> pushLayer(ref, annotations, withDigest, p, options))
+                            .toList());
         } catch (CompletionException e) {
+            if (e.getCause() instanceof OperationCancelledException cancelled) {
+                throw cancelled;
+            }
             throw new OrasException("Failed to push layers", e.getCause());
         }
     }
 
+    /**
+     * Run the tasks on the executor service with at most the given number of tasks in flight.
+     * All tasks are started before waiting, so the caller can push dependent content (like the manifest) afterward.
+     * @param executorService The executor service
+     * @param concurrency Maximum number of tasks running at the same time. Zero or negative for no additional limit
+     * @param tasks The tasks
+     * @param <R> The result type
+     * @return The results in the order of the tasks
+     * @throws CompletionException If any task failed
+     */
+    static <R> List<R> runConcurrently(ExecutorService executorService, int concurrency, List<Supplier<R>> tasks) {
+        Semaphore permits = new Semaphore(concurrency > 0 ? concurrency : Integer.MAX_VALUE);
+        List<CompletableFuture<R>> futures = new ArrayList<>(tasks.size());
+        try {
+            for (Supplier<R> task : tasks) {
+                permits.acquire();
+                CompletableFuture<R> future = CompletableFuture.supplyAsync(task, executorService);
+                future.whenComplete((result, error) -> permits.release());
+                futures.add(future);
+            }
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+            futures.forEach(future -> future.cancel(true));
+            throw new OperationCancelledException("Interrupted while transferring layers", e);
+        }
+        CompletableFuture.allOf(futures.toArray(CompletableFuture[]::new)).join();
+        return futures.stream().map(CompletableFuture::join).toList();
+    }
+
     /**
      * Return if a media type is an index media type
      * @param mediaType The media type
@@ -394,6 +933,80 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         return fetchBlob(ref.withDigest(digest));
     }
 
+    /**
+     * Get the typed image config of an image manifest
+     * @param ref The ref
+     * @param config The config of the manifest
+     * @return The image config
+     */
+    public final ImageConfig getImageConfig(T ref, Config config) {
+        try (InputStream is = pullConfig(ref, config)) {
+            return ImageConfig.fromJson(new String(is.readAllBytes(), StandardCharsets.UTF_8));
+        } catch (IOException e) {
+            throw new OrasException("Failed to read image config %s".formatted(config.getDigest()), e);
+        }
+    }
+
+    /**
+     * Pack and push an image manifest with the same semantics as oras-go {@code PackManifest} (OCI image-spec v1.1).
+     * <ul>
+     *     <li>The artifact type is required when the config is empty</li>
+     *     <li>The empty descriptor ({@value Const#DEFAULT_EMPTY_MEDIA_TYPE}) is used for a missing config</li>
+     *     <li>The empty descriptor is used as single layer when no layer is given</li>
+     *     <li>The {@value Const#ANNOTATION_CREATED} annotation is added if missing, and must be RFC 3339 otherwise</li>
+     *     <li>The provenance annotations are added if a {@link Provenance} is set</li>
+     * </ul>
+     * @param ref The ref
+     * @param artifactType The artifact type. Can be null if a non empty config is given
+     * @param options The pack options
+     * @return The pushed manifest
+     */
+    public Manifest packManifest(T ref, @Nullable ArtifactType artifactType, PackOptions options) {
+        Manifest manifest = buildPackedManifest(artifactType, options);
+        if (options.config() == null || options.config().getData() != null) {
+            pushConfig(ref, manifest.getConfig());
+        }
+        if (options.layers().isEmpty()) {
+            pushBlob(ref, Layer.empty().getDataBytes());
+        }
+        return pushManifest(ref, manifest);
+    }
+
+    /**
+     * Assemble and validate the manifest to pack
+     * @param artifactType The artifact type
+     * @param options The pack options
+     * @return The manifest
+     */
+    static Manifest buildPackedManifest(@Nullable ArtifactType artifactType, PackOptions options) {
+        Config config = options.config() != null ? options.config() : Config.empty();
+        if (artifactType == null && Const.DEFAULT_EMPTY_MEDIA_TYPE.equals(config.getMediaType())) {
+            throw new OrasException("Artifact type is required when the config is empty");
+        }
+        List<Layer> layers = options.layers().isEmpty() ? List.of(Layer.empty()) : options.layers();
+        Manifest manifest = Manifest.empty().withArtifactType(artifactType).withConfig(config).withLayers(layers);
+        if (options.subject() != null) {
+            manifest = manifest.withSubject(options.subject());
+        }
+        Map<String, String> annotations = options.provenance() != null
+                ? options.provenance().annotate(manifest, options.annotations())
+                : new LinkedHashMap<>(options.annotations());
+        String created = annotations.get(Const.ANNOTATION_CREATED);
+        if (created == null) {
+            annotations.put(Const.ANNOTATION_CREATED, Const.currentTimestamp());
+        } else {
+            try {
+                OffsetDateTime.parse(created, DateTimeFormatter.ISO_OFFSET_DATE_TIME);
+            } catch (DateTimeParseException e) {
+                throw new OrasException(
+                        "Invalid date and time format for annotation %s: %s"
+                                .formatted(Const.ANNOTATION_CREATED, created),
+                        e);
+            }
+        }
+        return manifest.withAnnotations(annotations);
+    }
+
     /**
      * Attach an artifact
      * @param ref The ref
@@ -418,6 +1031,20 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
      */
     public abstract ExecutorService getExecutorService();
 
+    /**
+     * Get the listener notified of high-level operations
+     * @return The operation listener
+     */
+    public abstract OperationListener getOperationListener();
+
+    /**
+     * Get the tracer creating spans for high-level operations
+     * @return The operation tracer
+     */
+    public OperationTracer getOperationTracer() {
+        return OperationTracer.noop();
+    }
+
     /**
      * Get the tags for a ref
      * @param ref The ref
@@ -548,6 +1175,18 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
      */
     public abstract InputStream fetchBlob(T ref);
 
+    /**
+     * Fetch the blob of a layer and return it as input stream.
+     * Implementations might fall back to the layer URLs for non-distributable layers
+     * @param ref The ref
+     * @param layer The layer
+     * @return The input stream
+     */
+    public InputStream fetchLayer(T ref, Layer layer) {
+        Objects.requireNonNull(layer.getDigest(), "Layer digest is required");
+        return fetchBlob(ref.withDigest(layer.getDigest()));
+    }
+
     /**
      * Fetch blob and return it's descriptor
      * @param ref The ref
@@ -582,6 +1221,35 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
      */
     public abstract Layer pushBlob(T ref, byte[] data);
 
+    /**
+     * Push a value as a layer of a custom media type using the handler registered in {@link MediaTypeHandlers}
+     * @param ref The ref
+     * @param mediaType The media type
+     * @param value The value
+     * @return The layer
+     */
+    public Layer pushContent(T ref, String mediaType, Object value) {
+        byte[] data = MediaTypeHandlers.marshal(mediaType, value);
+        return pushBlob(ref.withDigest(getDigestAlgorithm(ref).digest(data)), data).withMediaType(mediaType);
+    }
+
+    /**
+     * Fetch the content of a layer of a custom media type using the handler registered in {@link MediaTypeHandlers}
+     * @param ref The ref of the repository
+     * @param layer The layer
+     * @param type The type of the value
+     * @param <V> The type of the value
+     * @return The value
+     */
+    public <V> V fetchContent(T ref, Layer layer, Class<V> type) {
+        MediaTypeHandler<V> handler = MediaTypeHandlers.get(layer.getMediaType(), type);
+        try (InputStream is = fetchBlob(ref.withDigest(Objects.requireNonNull(layer.getDigest())))) {
+            return handler.unmarshal(is);
+        } catch (IOException e) {
+            throw new OrasException("Failed to read content of media type %s".formatted(layer.getMediaType()), e);
+        }
+    }
+
     /**
      * Get the referrers of a container
      * @param ref The ref
@@ -590,6 +1258,55 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
      */
     public abstract Referrers getReferrers(T ref, @Nullable ArtifactType artifactType);
 
+    /**
+     * Get the successors of a node in the content graph.
+     * <ul>
+     *     <li>Image manifest: the config, the layers and the subject</li>
+     *     <li>Index: the manifests and the subject</li>
+     *     <li>Any other content (blobs) has no successors</li>
+     * </ul>
+     * @param ref The ref of the repository
+     * @param descriptor The descriptor of the node
+     * @return The direct successors
+     */
+    public List<ManifestDescriptor> getSuccessors(T ref, ManifestDescriptor descriptor) {
+        String mediaType = descriptor.getMediaType();
+        T nodeRef = ref.withDigest(descriptor.getDigest());
+        List<ManifestDescriptor> successors = new ArrayList<>();
+        if (isManifestMediaType(mediaType)) {
+            Manifest manifest = getManifest(nodeRef);
+            successors.add(ManifestDescriptor.of(manifest.getConfig()));
+            for (Layer layer : manifest.getLayers()) {
+                successors.add(ManifestDescriptor.of(layer));
+            }
+            if (manifest.getSubject() != null) {
+                successors.add(toManifestDescriptor(manifest.getSubject()));
+            }
+        } else if (isIndexMediaType(mediaType)) {
+            Index index = getIndex(nodeRef);
+            successors.addAll(index.getManifests());
+            if (index.getSubject() != null) {
+                successors.add(toManifestDescriptor(index.getSubject()));
+            }
+        }
+        return successors;
+    }
+
+    /**
+     * Get the predecessors of a node in the content graph, which are the manifests referring to it as subject.
+     * Indexes including the node are not predecessors since they cannot be discovered from a repository
+     * @param ref The ref of the repository
+     * @param descriptor The descriptor of the node
+     * @return The direct predecessors
+     */
+    public List<ManifestDescriptor> getPredecessors(T ref, ManifestDescriptor descriptor) {
+        return getReferrers(ref.withDigest(descriptor.getDigest()), null).getManifests();
+    }
+
+    private static ManifestDescriptor toManifestDescriptor(Subject subject) {
+        return ManifestDescriptor.of(subject.getMediaType(), subject.getDigest(), subject.getSize());
+    }
+
     /**
      * Attach file to an existing manifest
      * @param ref The ref
@@ -607,29 +1324,57 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         Descriptor descriptor = getDescriptor(ref);
         Subject subject = descriptor.toSubject();
 
-        // Add created annotation if not present since we push with digest
-        Map<String, String> manifestAnnotations = annotations.manifestAnnotations();
-        if (!manifestAnnotations.containsKey(Const.ANNOTATION_CREATED)) {
-            manifestAnnotations.put(Const.ANNOTATION_CREATED, Const.currentTimestamp());
-        }
+        // Assemble the manifest like packManifest, the created annotation is added since we push with digest
+        Manifest manifest = buildPackedManifest(
+                artifactType,
+                PackOptions.defaults()
+                        .withLayers(layers)
+                        .withSubject(subject)
+                        .withAnnotations(annotations.manifestAnnotations()));
 
-        // assemble manifest
-        Manifest manifest = Manifest.empty()
-                .withArtifactType(artifactType)
-                .withAnnotations(manifestAnnotations)
-                .withLayers(layers)
-                .withSubject(subject);
+        // Strict registries require the empty config (and empty layer) blob to exist
+        pushConfig(ref, manifest.getConfig());
         return pushManifest(
                 ref.withDigest(
-                        SupportedAlgorithm.getDefault().digest(manifest.toJson().getBytes(StandardCharsets.UTF_8))),
+                        getDigestAlgorithm(ref).digest(manifest.toJson().getBytes(StandardCharsets.UTF_8))),
                 manifest);
     }
 
+    /**
+     * Get the digest algorithm used to compute the digest of content pushed to the given ref
+     * @param ref The ref
+     * @return The digest algorithm
+     */
+    protected DigestAlgorithm getDigestAlgorithm(T ref) {
+        return ref.getAlgorithm();
+    }
+
+    /**
+     * Find the annotations of a file, looked up by title first then by the path as given.
+     * This match the keys of ORAS CLI annotation files
+     * @param annotations The annotations
+     * @param title The title of the layer
+     * @param path The local path
+     * @return The file annotations or null if the file has no annotations
+     */
+    private static @Nullable Map<String, String> findFileAnnotations(
+            Annotations annotations, String title, LocalPath path) {
+        if (annotations.hasFileAnnotations(title)) {
+            return annotations.getFileAnnotations(title);
+        }
+        String key = path.getPath().toString();
+        if (annotations.hasFileAnnotations(key)) {
+            return annotations.getFileAnnotations(key);
+        }
+        return null;
+    }
+
     protected Layer pushLayer(T ref, Annotations annotations, boolean withDigest, LocalPath path) {
         return pushLayer(ref, annotations, withDigest, path, PushOptions.defaults());
     }
 
     protected Layer pushLayer(T ref, Annotations annotations, boolean withDigest, LocalPath path, PushOptions options) {
+        CancellationToken.check(options.cancellation());
         try {
             // Create tar.gz archive for directory
             if (Files.isDirectory(path.getPath())) {
@@ -637,13 +1382,9 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
 
                 // If source need to be packed first
                 boolean autoUnpack = compression.isAutoUnpack();
-                LocalPath tempSource = autoUnpack ? ArchiveUtils.tar(path) : path;
+                LocalPath tempSource = autoUnpack ? ArchiveUtils.tar(path, true, options.tarOptions()) : path;
                 LocalPath tempArchive = ArchiveUtils.compress(tempSource, path.getMediaType());
 
-                if (withDigest) {
-                    ref = ref.withDigest(ref.getAlgorithm().digest(tempArchive.getPath()));
-                }
-
                 String title = path.getPath().isAbsolute()
                         ? path.getPath().getFileName().toString()
                         : path.getPath().toString();
@@ -654,8 +1395,9 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
                 }
                 LOG.debug("Uploading directory as archive with title: {}", title);
 
-                Map<String, String> layerAnnotations = annotations.hasFileAnnotations(title)
-                        ? annotations.getFileAnnotations(title)
+                Map<String, String> fileAnnotations = findFileAnnotations(annotations, title, path);
+                Map<String, String> layerAnnotations = fileAnnotations != null
+                        ? new LinkedHashMap<>(fileAnnotations)
                         : new LinkedHashMap<>(Map.of(Const.ANNOTATION_TITLE, title));
 
                 // Add oras digest/unpack
@@ -664,30 +1406,25 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
                 if (compression.isAutoUnpack()) {
                     layerAnnotations.put(
                             Const.ANNOTATION_ORAS_CONTENT_DIGEST,
-                            ref.getAlgorithm().digest(tempSource.getPath()));
+                            getDigestAlgorithm(ref).digest(tempSource.getPath()));
                     layerAnnotations.put(Const.ANNOTATION_ORAS_UNPACK, "true");
                 } else {
                     layerAnnotations.put(Const.ANNOTATION_ORAS_UNPACK, "false");
                 }
 
-                Layer layer = doPushBlob(ref, tempArchive.getPath(), options)
-                        .withMediaType(path.getMediaType())
-                        .withAnnotations(layerAnnotations);
+                Layer layer = pushLayerBlob(
+                        ref, withDigest, tempArchive.getPath(), path.getMediaType(), layerAnnotations, options);
                 LOG.info("Uploaded directory: {}", layer.getDigest());
                 Files.delete(tempArchive.getPath());
                 return layer;
             } else {
-                if (withDigest) {
-                    ref = ref.withDigest(ref.getAlgorithm().digest(path.getPath()));
-                }
                 String title = path.getPath().getFileName().toString();
-                Map<String, String> layerAnnotations = annotations.hasFileAnnotations(title)
-                        ? annotations.getFileAnnotations(title)
-                        : Map.of(Const.ANNOTATION_TITLE, title);
+                Map<String, String> fileAnnotations = findFileAnnotations(annotations, title, path);
+                Map<String, String> layerAnnotations =
+                        fileAnnotations != null ? fileAnnotations : Map.of(Const.ANNOTATION_TITLE, title);
 
-                Layer layer = doPushBlob(ref, path.getPath(), options)
-                        .withMediaType(path.getMediaType())
-                        .withAnnotations(layerAnnotations);
+                Layer layer = pushLayerBlob(
+                        ref, withDigest, path.getPath(), path.getMediaType(), layerAnnotations, options);
                 LOG.info("Uploaded: {}", layer.getDigest());
                 return layer;
             }
@@ -696,6 +1433,49 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
         }
     }
 
+    /**
+     * Push the blob of a layer, encrypting it first if the push options have an encryption
+     * @param ref The ref
+     * @param withDigest Whether to push with the digest of the blob
+     * @param blob The blob file
+     * @param mediaType The media type of the layer
+     * @param annotations The annotations of the layer
+     * @param options The push options
+     * @return The layer
+     */
+    private Layer pushLayerBlob(
+            T ref,
+            boolean withDigest,
+            Path blob,
+            String mediaType,
+            Map<String, String> annotations,
+            PushOptions options)
+            throws IOException {
+        LayerEncryption encryption = options.encryption();
+        if (encryption == null) {
+            if (withDigest) {
+                ref = ref.withDigest(getDigestAlgorithm(ref).digest(blob));
+            }
+            return doPushBlob(ref, blob, options).withMediaType(mediaType).withAnnotations(annotations);
+        }
+        Path encrypted = Files.createTempFile("oras-encrypted", ".tmp");
+        try {
+            Map<String, String> layerAnnotations = new LinkedHashMap<>(annotations);
+            try (InputStream is = Files.newInputStream(blob);
+                    OutputStream os = Files.newOutputStream(encrypted)) {
+                layerAnnotations.putAll(encryption.encrypt(is, os));
+            }
+            if (withDigest) {
+                ref = ref.withDigest(getDigestAlgorithm(ref).digest(encrypted));
+            }
+            return doPushBlob(ref, encrypted, options)
+                    .withMediaType(mediaType + Const.ENCRYPTED_MEDIA_TYPE_SUFFIX)
+                    .withAnnotations(layerAnnotations);
+        } finally {
+            Files.deleteIfExists(encrypted);
+        }
+    }
+
     /**
      * Push a blob from a file path, respecting the given push options.
      * Subclasses may override to support chunked upload.
@@ -705,7 +1485,7 @@ public abstract sealed class OCI<T extends Ref<@NonNull T>> permits Registry, OC
      * @return The layer
      */
     protected Layer doPushBlob(T ref, Path blob, PushOptions options) {
-        try (InputStream is = Files.newInputStream(blob)) {
+        try (InputStream is = new CancellableInputStream(Files.newInputStream(blob), options.cancellation())) {
             return pushBlob(ref, is);
         } catch (IOException e) {
             throw new OrasException("Failed to push blob", e);
@@ -21,28 +21,38 @@
 package land.oras;
 
 import com.fasterxml.jackson.annotation.JsonIgnore;
+import java.io.BufferedOutputStream;
 import java.io.IOException;
 import java.io.InputStream;
+import java.io.OutputStream;
+import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.nio.file.StandardCopyOption;
+import java.nio.file.attribute.FileTime;
 import java.util.ArrayList;
 import java.util.HashMap;
 import java.util.HashSet;
 import java.util.LinkedList;
 import java.util.List;
 import java.util.Map;
+import java.util.Objects;
 import java.util.Set;
 import java.util.concurrent.ExecutorService;
 import java.util.concurrent.Executors;
 import java.util.function.Supplier;
 import land.oras.OCI.PullOptions;
 import land.oras.OCI.PushOptions;
+import land.oras.encryption.LayerEncryption;
 import land.oras.exception.OrasException;
 import land.oras.utils.ArchiveUtils;
 import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.DigestAlgorithms;
 import land.oras.utils.JsonUtils;
-import land.oras.utils.SupportedAlgorithm;
+import org.apache.commons.compress.archivers.tar.TarArchiveEntry;
+import org.apache.commons.compress.archivers.tar.TarArchiveInputStream;
+import org.apache.commons.compress.archivers.tar.TarArchiveOutputStream;
 import org.jspecify.annotations.Nullable;
 
 /**
@@ -69,6 +79,21 @@ public final class OCILayout extends OCI<LayoutRef> {
     @Nullable
     private Path tarPath;
 
+    /**
+     * The storage of the blobs and index, the {@code path} directory by default
+     */
+    private LayoutStorage storage;
+
+    /**
+     * The listener notified of blob transfers
+     */
+    private TransferListener transferListener = TransferListener.noop();
+
+    /**
+     * The listener notified of high-level operations
+     */
+    private OperationListener operationListener = OperationListener.noop();
+
     /**
      * Private constructor
      */
@@ -86,28 +111,28 @@ public final class OCILayout extends OCI<LayoutRef> {
     @Override
     public boolean mountBlob(LayoutRef sourceRef, LayoutRef targetRef) {
         String digest = sourceRef.getTag();
-        if (digest == null || !SupportedAlgorithm.isSupported(digest)) {
+        if (digest == null || !DigestAlgorithms.isSupported(digest)) {
             throw new OrasException("Digest is required to mount blob");
         }
-        ensureAlgorithmPath(digest);
-        Path targetBlobPath = getBlobPath(targetRef);
-        if (Files.exists(targetBlobPath)) {
+        String targetBlob = getBlobName(targetRef);
+        if (storage.exists(targetBlob)) {
             LOG.info("Blob already exists: {}", digest);
+            operationListener.onBlobSkipped(targetRef, digest);
             return true;
         }
-        // Compute source blob path from the source layout folder
-        SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(digest);
-        Path sourceBlobPath = sourceRef
-                .getFolder()
-                .resolve(Const.OCI_LAYOUT_BLOBS)
-                .resolve(algorithm.getPrefix())
-                .resolve(SupportedAlgorithm.getDigest(digest));
-        if (!Files.exists(sourceBlobPath)) {
-            throw new OrasException("Source blob not found at: %s".formatted(sourceBlobPath));
+        // Blobs of another layout folder are read from the file system
+        LayoutStorage source = sourceRef.getFolder().equals(targetRef.getFolder())
+                ? storage
+                : LayoutStorage.directory(sourceRef.getFolder());
+        String sourceBlob = getBlobName(digest);
+        if (!source.exists(sourceBlob)) {
+            throw new OrasException("Source blob not found at: %s"
+                    .formatted(sourceRef.getFolder().resolve(sourceBlob)));
         }
-        try {
-            Files.copy(sourceBlobPath, targetBlobPath);
+        try (InputStream is = source.read(sourceBlob)) {
+            storage.write(targetBlob, is);
             LOG.info("Blob mounted from {}: {}", sourceRef.getFolder(), digest);
+            operationListener.onBlobMounted(sourceRef, targetRef);
         } catch (IOException e) {
             throw new OrasException("Failed to mount blob", e);
         }
@@ -128,6 +153,11 @@ public final class OCILayout extends OCI<LayoutRef> {
         return executors;
     }
 
+    @Override
+    public OperationListener getOperationListener() {
+        return operationListener;
+    }
+
     @Override
     public Manifest pushArtifact(
             LayoutRef ref,
@@ -146,6 +176,8 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (config != null) {
             config = config.withAnnotations(annotations);
             manifest = manifest.withConfig(config);
+        } else if (!annotations.configAnnotations().isEmpty()) {
+            config = Config.empty().withAnnotations(annotations);
         }
 
         // Push layers
@@ -170,35 +202,93 @@ public final class OCILayout extends OCI<LayoutRef> {
             throw new OrasException("Tag is required to pull artifact from layout");
         }
 
-        // Find manifest
-        Manifest manifest = getManifest(ref);
+        // Find manifest, selecting the one matching the platform when pulling an index
+        Manifest manifest;
+        ManifestDescriptor descriptor = findManifestDescriptor(ref);
+        options.checkExpectedDigest(ref, descriptor.getDigest());
+        ManifestDescriptor selected = options.platform() != null && isIndexMediaType(descriptor.getMediaType())
+                ? selectPlatform(readIndex(getBlobName(descriptor.getDigest())), options)
+                : null;
+        if (selected != null) {
+            manifest = readManifest(getBlobName(selected.getDigest())).withDescriptor(selected);
+        } else {
+            manifest = getManifest(ref);
+        }
 
-        // Find the layer with title annotation
-        Layer layer = manifest.getLayers().stream()
+        // Only collect layer that are files
+        List<Layer> layers = manifest.getLayers().stream()
                 .filter(l -> l.getAnnotations().containsKey(Const.ANNOTATION_TITLE))
-                .findFirst()
-                .orElseThrow(() -> new OrasException("Layer not found with title annotation"));
-
-        Path blobPath = getBlobPath(layer);
+                .toList();
+        if (layers.isEmpty()) {
+            throw new OrasException("Layer not found with title annotation");
+        }
+        for (Layer layer : layers) {
+            pullLayer(layer, path, options);
+        }
+    }
 
-        // Copy the blob to the target path
+    /**
+     * Pull a single layer into the given folder. Either unpack or copy the blob
+     * @param layer The layer
+     * @param path The target folder
+     * @param options The pull options
+     */
+    private void pullLayer(Layer layer, Path path, PullOptions options) {
+        String blob = getBlobName(Objects.requireNonNull(layer.getDigest()));
+        // Encrypted layers are decrypted while reading when a decryption is set, otherwise pulled as is
+        LayerEncryption decryption = LayerEncryption.isEncrypted(layer) ? options.decryption() : null;
+        Layer content = decryption != null ? LayerEncryption.toDecrypted(layer) : layer;
         try {
-            Path targetPath = path.resolve(layer.getAnnotations().get(Const.ANNOTATION_TITLE))
+            MediaTypeHandler<?> handler = MediaTypeHandlers.get(content.getMediaType());
+            if (handler != null && handler.isUnpack()) {
+                LOG.debug("Unpacking blob of media type {} to: {}", content.getMediaType(), path);
+                try (InputStream stored = new CancellableInputStream(storage.read(blob), options.cancellation());
+                        InputStream is = decrypt(layer, stored, decryption)) {
+                    handler.unpack(is, content, path);
+                }
+                return;
+            }
+            if (Boolean.parseBoolean(content.getAnnotations().getOrDefault(Const.ANNOTATION_ORAS_UNPACK, "false"))) {
+                LOG.debug("Extracting blob to: {}", path);
+                // Verify the stored blob while extracting, nothing is moved to the folder on mismatch
+                String digest = Objects.requireNonNull(layer.getDigest());
+                long size = layer.getSize() != null ? layer.getSize() : -1;
+                try (InputStream stored = new CancellableInputStream(
+                                new DigestVerifyingInputStream(storage.read(blob), digest, size),
+                                options.cancellation());
+                        InputStream is = decrypt(layer, stored, decryption)) {
+                    ArchiveUtils.uncompressuntar(
+                            is,
+                            path,
+                            content.getMediaType(),
+                            content.getAnnotations().get(Const.ANNOTATION_ORAS_CONTENT_DIGEST),
+                            options.extractOptions());
+                }
+                return;
+            }
+            Path targetPath = path.resolve(content.getAnnotations().get(Const.ANNOTATION_TITLE))
                     .normalize();
             if (!targetPath.startsWith(path.normalize())) {
                 throw new OrasException("Refusing to pull layer: title annotation is not withing folder '%s'"
-                        .formatted(layer.getAnnotations().get(Const.ANNOTATION_TITLE)));
-            }
-            if (options.isOverwrite()) {
-                Files.copy(blobPath, targetPath, java.nio.file.StandardCopyOption.REPLACE_EXISTING);
-            } else {
-                Files.copy(blobPath, targetPath);
+                        .formatted(content.getAnnotations().get(Const.ANNOTATION_TITLE)));
             }
+            copyBlob(blob, targetPath, layer, options);
         } catch (IOException e) {
             throw new OrasException("Failed to copy blob", e);
         }
     }
 
+    /**
+     * Decrypt the content of a layer if a decryption is given
+     * @param layer The layer
+     * @param is The stored content
+     * @param decryption The decryption or null to read the content as is
+     * @return The content
+     */
+    private static InputStream decrypt(Layer layer, InputStream is, @Nullable LayerEncryption decryption) {
+        return decryption != null ? decryption.decrypt(layer, is) : is;
+    }
+
     @Override
     public Manifest pushManifest(LayoutRef layoutRef, Manifest manifest) {
 
@@ -226,16 +316,13 @@ public final class OCILayout extends OCI<LayoutRef> {
         }
         manifest = manifest.withDescriptor(manifestDescriptor);
 
-        Index index = Index.fromPath(getIndexPath()).withNewManifests(manifestDescriptor);
+        Index index = readIndex().withNewManifests(manifestDescriptor);
 
         // Write blobs
-        try {
-            writeManifest(manifest);
-            writeOCIIndex(index);
-        } catch (IOException e) {
-            throw new OrasException("Failed to write manifest", e);
-        }
+        writeManifest(manifest);
+        writeOCIIndex(index);
         packToTar();
+        operationListener.onManifestPushed(layoutRef, manifest);
         return manifest;
     }
 
@@ -262,23 +349,39 @@ public final class OCILayout extends OCI<LayoutRef> {
         }
         index = index.withDescriptor(indexDescriptor);
 
-        Index ociIndex = Index.fromPath(getIndexPath()).withNewManifests(indexDescriptor);
+        Index ociIndex = readIndex().withNewManifests(indexDescriptor);
 
         // Write blobs
-        try {
-            writeIndex(index);
-            writeOCIIndex(ociIndex);
-        } catch (IOException e) {
-            throw new OrasException("Failed to write manifest", e);
-        }
+        writeIndex(index);
+        writeOCIIndex(ociIndex);
         packToTar();
+        operationListener.onIndexPushed(layoutRef, index);
         return index;
     }
 
     @Override
     public Index getIndex(LayoutRef ref) {
-        Path path = getIndexPath();
-        return Index.fromPath(path);
+        // Index referenced by tag or digest, including nested index not listed on the layout index
+        String tag = ref.getTag();
+        if (tag != null) {
+            ManifestDescriptor descriptor = readIndex().getManifests().stream()
+                    .filter(m -> (m.getAnnotations() != null
+                                    && tag.equals(m.getAnnotations().get(Const.ANNOTATION_REF))
+                            || tag.equals(m.getDigest())))
+                    .findFirst()
+                    .orElse(null);
+            if (descriptor != null && isIndexMediaType(descriptor.getMediaType())) {
+                return readIndex(getBlobName(descriptor.getDigest())).withDescriptor(descriptor);
+            }
+            if (descriptor == null && ref.isValidDigest()) {
+                String blob = getBlobName(tag);
+                if (storage.exists(blob)) {
+                    return readIndex(blob);
+                }
+            }
+        }
+        // The index of the layout
+        return readIndex();
     }
 
     @Override
@@ -288,12 +391,12 @@ public final class OCILayout extends OCI<LayoutRef> {
             throw new OrasException("Tag or digest is required to find manifest");
         }
         ManifestDescriptor descriptor = findManifestDescriptor(ref);
-        Path manifestPath = getBlobPath(descriptor);
-        if (!Files.exists(manifestPath)) {
-            throw new OrasException("Blob not found: %s".formatted(manifestPath));
+        String manifestBlob = getBlobName(descriptor.getDigest());
+        if (!storage.exists(manifestBlob)) {
+            throw new OrasException("Blob not found: %s".formatted(manifestBlob));
         }
 
-        return Manifest.fromPath(manifestPath).withDescriptor(descriptor);
+        return readManifest(manifestBlob).withDescriptor(descriptor);
     }
 
     @Override
@@ -307,8 +410,7 @@ public final class OCILayout extends OCI<LayoutRef> {
 
     @Override
     public void fetchBlob(LayoutRef ref, Path path) {
-        InputStream is = fetchBlob(ref);
-        try {
+        try (InputStream is = fetchBlob(ref)) {
             Files.copy(is, path);
             LOG.info("Downloaded: {}", ref.getTag());
         } catch (IOException e) {
@@ -318,12 +420,7 @@ public final class OCILayout extends OCI<LayoutRef> {
 
     @Override
     public InputStream fetchBlob(LayoutRef ref) {
-        Path blobPath = getBlobPath(ref);
-        try {
-            return Files.newInputStream(blobPath);
-        } catch (IOException e) {
-            throw new OrasException("Failed to fetch blob", e);
-        }
+        return storage.read(getBlobName(ref));
     }
 
     @Override
@@ -331,8 +428,8 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (ref.getTag() == null) {
             throw new OrasException("Tag or digest is required to get blob from layout");
         }
-        if (SupportedAlgorithm.isSupported(ref.getTag())) {
-            return Descriptor.of(ref.getTag(), size(getBlobPath(ref)));
+        if (DigestAlgorithms.isSupported(ref.getTag())) {
+            return Descriptor.of(ref.getTag(), storage.size(getBlobName(ref)));
         }
         // A manifest
         else {
@@ -345,23 +442,29 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (ref.getTag() == null) {
             throw new OrasException("Missing ref");
         }
-        if (!SupportedAlgorithm.isSupported(ref.getTag())) {
+        if (!DigestAlgorithms.isSupported(ref.getTag())) {
             throw new OrasException("Unsupported digest: %s".formatted(ref.getTag()));
         }
         String digest = ref.getTag();
-        ensureAlgorithmPath(digest);
-        Path blobPath = getBlobPath(ref);
+        String blobName = getBlobName(ref);
         LOG.trace("Digest: {}", digest);
         try {
-            if (Files.exists(blobPath)) {
+            if (storage.exists(blobName)) {
                 LOG.info("Blob already exists: {}", digest);
-                return Layer.fromFile(blobPath, ref.getAlgorithm()).withAnnotations(annotations);
+                operationListener.onBlobSkipped(ref, digest);
+                return toLayer(digest, storage.size(blobName), annotations);
             }
             ensureDigest(ref, blob);
-            Files.copy(blob, blobPath);
-            Layer layer = Layer.fromFile(blobPath, ref.getAlgorithm()).withAnnotations(annotations);
+            long size = Files.size(blob);
+            transferListener.onStarted(digest, size);
+            try (InputStream is = new ProgressInputStream(Files.newInputStream(blob), transferListener, digest, size)) {
+                storage.write(blobName, is);
+            }
+            transferListener.onCompleted(digest, size);
+            Layer layer = toLayer(digest, size, annotations);
             packToTar();
             LOG.debug("Blob pushed to OCI layout: {}", digest);
+            operationListener.onBlobPushed(ref, layer);
             return layer;
         } catch (IOException e) {
             throw new OrasException("Failed to push blob", e);
@@ -374,23 +477,26 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (digest == null) {
             throw new OrasException("Digest is required to push blob to layout");
         }
-        boolean isDigest = SupportedAlgorithm.isSupported(digest);
+        boolean isDigest = DigestAlgorithms.isSupported(digest);
         if (!isDigest) {
             throw new OrasException("Unsupported digest: %s".formatted(digest));
         }
-        ensureAlgorithmPath(digest);
         try {
-            Path blobPath = getBlobPath(ref);
-            if (Files.exists(blobPath)) {
+            String blobName = getBlobName(ref);
+            if (storage.exists(blobName)) {
                 LOG.info("Blob already exists: {}", digest);
-                return Layer.fromFile(blobPath, ref.getAlgorithm()).withAnnotations(annotations);
+                operationListener.onBlobSkipped(ref, digest);
+                return toLayer(digest, storage.size(blobName), annotations);
             }
-            try (InputStream is = stream.get()) {
-                Files.copy(is, blobPath);
+            transferListener.onStarted(digest, size);
+            // The digest is verified while writing so a mismatching blob is never stored
+            try (InputStream is = new ProgressInputStream(stream.get(), transferListener, digest, size)) {
+                storage.write(blobName, new DigestVerifyingInputStream(is, digest, size));
             }
-            ensureDigest(ref, blobPath);
-            Layer layer = Layer.fromFile(blobPath, ref.getAlgorithm()).withAnnotations(annotations);
+            transferListener.onCompleted(digest, size);
+            Layer layer = toLayer(digest, storage.size(blobName), annotations);
             packToTar();
+            operationListener.onBlobPushed(ref, layer);
             return layer;
         } catch (IOException e) {
             throw new OrasException("Failed to push blob", e);
@@ -399,35 +505,33 @@ public final class OCILayout extends OCI<LayoutRef> {
 
     @Override
     public Layer pushBlob(LayoutRef ref, byte[] data) {
-        try {
-            if (ref.getTag() == null) {
-                throw new OrasException("Missing ref");
-            }
-            if (!SupportedAlgorithm.isSupported(ref.getTag())) {
-                throw new OrasException("Unsupported digest: %s".formatted(ref.getTag()));
-            }
-            String digest = ref.getAlgorithm().digest(data);
-            if (!ref.getTag().equals(digest)) {
-                throw new OrasException("Digest mismatch: %s != %s".formatted(ref.getTag(), digest));
-            }
-            ensureAlgorithmPath(digest);
-            Path blobPath = getBlobAlgorithmPath(digest).resolve(SupportedAlgorithm.getDigest(digest));
-            if (Files.exists(blobPath)) {
-                LOG.info("Blob already exists: {}", digest);
-                return Layer.fromFile(blobPath, ref.getAlgorithm()).withAnnotations(Map.of());
-            }
-            Files.write(blobPath, data);
-            packToTar();
-            LOG.debug("Blob pushed to OCI layout: {}", digest);
-            return Layer.fromFile(blobPath, ref.getAlgorithm()).withAnnotations(Map.of());
-        } catch (IOException e) {
-            throw new OrasException("Failed to push blob to OCI layout", e);
+        if (ref.getTag() == null) {
+            throw new OrasException("Missing ref");
+        }
+        if (!DigestAlgorithms.isSupported(ref.getTag())) {
+            throw new OrasException("Unsupported digest: %s".formatted(ref.getTag()));
+        }
+        String digest = ref.getAlgorithm().digest(data);
+        if (!ref.getTag().equals(digest)) {
+            throw new OrasException("Digest mismatch: %s != %s".formatted(ref.getTag(), digest));
         }
+        String blobName = getBlobName(digest);
+        if (storage.exists(blobName)) {
+            LOG.info("Blob already exists: {}", digest);
+            operationListener.onBlobSkipped(ref, digest);
+            return toLayer(digest, data.length, Map.of());
+        }
+        storage.write(blobName, data);
+        packToTar();
+        LOG.debug("Blob pushed to OCI layout: {}", digest);
+        Layer layer = toLayer(digest, data.length, Map.of());
+        operationListener.onBlobPushed(ref, layer);
+        return layer;
     }
 
     @Override
     public Tags getTags(LayoutRef ref) {
-        Index index = Index.fromPath(getIndexPath());
+        Index index = readIndex();
         String name = ref.getFolder().getFileName().toString();
         List<String> tags = index.getManifests().stream()
                 .filter(m -> m.getAnnotations() != null && m.getAnnotations().containsKey(Const.ANNOTATION_REF))
@@ -448,10 +552,69 @@ public final class OCILayout extends OCI<LayoutRef> {
             if (lastIndex == -1) {
                 throw new OrasException("Last tag not found: %s".formatted(last));
             }
+            startIndex = lastIndex + 1;
         }
         return new Tags(name, tags.stream().skip(startIndex).limit(n).toList());
     }
 
+    /**
+     * Tag an existing manifest or index of the layout with a new tag. The tag is moved if already used
+     * @param ref The existing tag or digest
+     * @param tag The new tag
+     * @return The descriptor of the tagged manifest
+     */
+    public ManifestDescriptor tag(LayoutRef ref, String tag) {
+        if (DigestAlgorithms.isSupported(tag)) {
+            throw new OrasException("Invalid tag: %s".formatted(tag));
+        }
+        ManifestDescriptor existing = findManifestDescriptor(ref);
+        Map<String, String> annotations = new HashMap<>();
+        if (existing.getAnnotations() != null) {
+            annotations.putAll(existing.getAnnotations());
+        }
+        annotations.put(Const.ANNOTATION_REF, tag);
+        ManifestDescriptor tagged = existing.withAnnotations(annotations);
+
+        // Multiple descriptors can point to the same digest with different tags
+        List<ManifestDescriptor> manifests = new ArrayList<>();
+        for (ManifestDescriptor descriptor : readIndex().getManifests()) {
+            if (descriptor.getAnnotations() != null
+                    && tag.equals(descriptor.getAnnotations().get(Const.ANNOTATION_REF))) {
+                continue;
+            }
+            manifests.add(descriptor);
+        }
+        manifests.add(tagged);
+        updateOCIIndex(manifests);
+        return tagged;
+    }
+
+    /**
+     * Remove a tag from the layout. The manifest and its blobs are kept until garbage collected
+     * @param ref The tag to remove
+     */
+    public void deleteTag(LayoutRef ref) {
+        String tag = ref.getTag();
+        if (tag == null || DigestAlgorithms.isSupported(tag)) {
+            throw new OrasException("Tag is required to delete tag from layout");
+        }
+        ManifestDescriptor existing = findManifestDescriptor(ref);
+        List<ManifestDescriptor> manifests = new ArrayList<>();
+        for (ManifestDescriptor descriptor : readIndex().getManifests()) {
+            if (descriptor.getAnnotations() == null
+                    || !tag.equals(descriptor.getAnnotations().get(Const.ANNOTATION_REF))) {
+                manifests.add(descriptor);
+            }
+        }
+        // Keep the manifest reachable from the index if it was the only descriptor pointing to it
+        if (manifests.stream().noneMatch(m -> m.getDigest().equals(existing.getDigest()))) {
+            Map<String, String> annotations = new HashMap<>(Objects.requireNonNull(existing.getAnnotations()));
+            annotations.remove(Const.ANNOTATION_REF);
+            manifests.add(existing.withAnnotations(annotations.isEmpty() ? null : annotations));
+        }
+        updateOCIIndex(manifests);
+    }
+
     @Override
     public Repositories getRepositories() {
         // When tar-backed, report the tar file name rather than the temp-dir name
@@ -461,7 +624,7 @@ public final class OCILayout extends OCI<LayoutRef> {
 
     @Override
     public Referrers getReferrers(LayoutRef ref, @Nullable ArtifactType artifactType) {
-        Index index = Index.fromPath(getIndexPath());
+        Index index = readIndex();
         ManifestDescriptor currentDescriptor = findManifestDescriptor(ref);
         String currentDescriptorDigest = currentDescriptor.getDigest();
         LOG.info("Looking for referrers of manifest: {}", currentDescriptorDigest);
@@ -484,67 +647,142 @@ public final class OCILayout extends OCI<LayoutRef> {
         return Referrers.from(manifestDescriptors);
     }
 
+    /**
+     * Result of a garbage collection
+     * @param removed The digests (in {@code <algorithm>:<hex>} format) of the removed blobs
+     * @param freedBytes The number of bytes freed on disk
+     */
+    public record GarbageCollectionResult(List<String> removed, long freedBytes) {}
+
     /**
      * Remove all blobs that are not referenced by any manifest reachable from the root {@code index.json}.
      * @return the list of digests (in {@code <algorithm>:<hex>} format) that were removed
      */
     public List<String> garbageCollect() {
+        return gc(false).removed();
+    }
+
+    /**
+     * Remove all blobs that are not referenced by any manifest reachable from the root {@code index.json}.
+     * @return The result of the garbage collection
+     */
+    public GarbageCollectionResult gc() {
+        return gc(false);
+    }
+
+    /**
+     * Remove all blobs that are not referenced by any manifest reachable from the root {@code index.json}.
+     * @param removeUntagged Whether to first remove manifests without {@code org.opencontainers.image.ref.name}
+     *                       annotation from the root index. Referrers of kept manifests are kept.
+     * @return The result of the garbage collection
+     */
+    public GarbageCollectionResult gc(boolean removeUntagged) {
+        List<ManifestDescriptor> entries = readIndex().getManifests();
+        if (removeUntagged) {
+            List<ManifestDescriptor> kept = keepTaggedAndReferrers(entries);
+            if (kept.size() != entries.size()) {
+                LOG.info("Removing {} untagged manifests from index", entries.size() - kept.size());
+                updateOCIIndex(kept);
+                entries = kept;
+            }
+        }
         Set<String> referencedDigests = new HashSet<>();
-        Index rootIndex = Index.fromPath(getIndexPath());
-        collectReferencedDigests(rootIndex, referencedDigests);
+        collectReferencedDigests(entries, referencedDigests);
 
         List<String> removed = new ArrayList<>();
-        Path blobsRoot = getBlobPath();
-        try {
-            if (!Files.exists(blobsRoot)) {
-                return removed;
-            }
-            // Iterate over algorithm directories (e.g. blobs/sha256/)
-            try (var algoDirs = Files.newDirectoryStream(blobsRoot)) {
-                for (Path algoDir : algoDirs) {
-                    String algoPrefix = algoDir.getFileName().toString();
-                    try (var blobFiles = Files.newDirectoryStream(algoDir)) {
-                        for (Path blobFile : blobFiles) {
-                            String hex = blobFile.getFileName().toString();
-                            String digest = algoPrefix + ":" + hex;
-                            if (!referencedDigests.contains(digest)) {
-                                LOG.info("Removing unreferenced blob: {}", digest);
-                                Files.delete(blobFile);
-                                removed.add(digest);
-                            }
-                        }
-                    }
-                }
+        long freedBytes = 0;
+        // Blobs are named blobs/<algorithm>/<hex>
+        for (String blob : storage.list(Const.OCI_LAYOUT_BLOBS + "/")) {
+            String[] parts = blob.split("/");
+            if (parts.length != 3) {
+                continue;
+            }
+            String digest = parts[1] + ":" + parts[2];
+            if (!referencedDigests.contains(digest)) {
+                LOG.info("Removing unreferenced blob: {}", digest);
+                long size = storage.size(blob);
+                storage.delete(blob);
+                freedBytes += size;
+                removed.add(digest);
             }
-        } catch (IOException e) {
-            throw new OrasException("Failed to garbage collect OCI layout", e);
         }
         if (!removed.isEmpty()) {
             packToTar();
         }
-        return removed;
+        LOG.debug("Garbage collection freed {} bytes", freedBytes);
+        return new GarbageCollectionResult(List.copyOf(removed), freedBytes);
     }
 
     /**
-     * Recursively collect all blob digests that are reachable from the given index.
+     * Keep the tagged entries of the index and, transitively, the entries referring to a kept entry
+     * @param entries The entries of the root index
+     * @return The kept entries in their original order
+     */
+    private List<ManifestDescriptor> keepTaggedAndReferrers(List<ManifestDescriptor> entries) {
+        boolean[] kept = new boolean[entries.size()];
+        Set<String> keptDigests = new HashSet<>();
+        for (int i = 0; i < entries.size(); i++) {
+            Map<String, String> annotations = entries.get(i).getAnnotations();
+            if (annotations != null && annotations.containsKey(Const.ANNOTATION_REF)) {
+                kept[i] = true;
+                keptDigests.add(entries.get(i).getDigest());
+            }
+        }
+        boolean changed = true;
+        while (changed) {
+            changed = false;
+            for (int i = 0; i < entries.size(); i++) {
+                if (kept[i]) {
+                    continue;
+                }
+                String subject = getSubjectDigest(entries.get(i));
+                if (subject != null && keptDigests.contains(subject)) {
+                    kept[i] = true;
+                    keptDigests.add(entries.get(i).getDigest());
+                    changed = true;
+                }
+            }
+        }
+        List<ManifestDescriptor> result = new ArrayList<>();
+        for (int i = 0; i < entries.size(); i++) {
+            if (kept[i]) {
+                result.add(entries.get(i));
+            }
+        }
+        return result;
+    }
+
+    private @Nullable String getSubjectDigest(ManifestDescriptor descriptor) {
+        String blob = getBlobName(descriptor.getDigest());
+        if (!storage.exists(blob)) {
+            return null;
+        }
+        Subject subject = isIndexMediaType(descriptor.getMediaType())
+                ? readIndex(blob).getSubject()
+                : readManifest(blob).getSubject();
+        return subject != null ? subject.getDigest() : null;
+    }
+
+    /**
+     * Recursively collect all blob digests that are reachable from the given index entries.
      *
-     * @param index the index to traverse
+     * @param entries the index entries to traverse
      * @param referencedDigests the set to populate with reachable digests
      */
-    private void collectReferencedDigests(Index index, Set<String> referencedDigests) {
-        for (ManifestDescriptor entry : index.getManifests()) {
+    private void collectReferencedDigests(List<ManifestDescriptor> entries, Set<String> referencedDigests) {
+        for (ManifestDescriptor entry : entries) {
             String entryDigest = entry.getDigest();
             referencedDigests.add(entryDigest);
-            Path blobPath = getBlobPath(entry);
+            String blob = getBlobName(entryDigest);
 
             // Nested index
             if (isIndexMediaType(entry.getMediaType())) {
-                Index nestedIndex = Index.fromPath(blobPath);
-                collectReferencedDigests(nestedIndex, referencedDigests);
+                Index nestedIndex = readIndex(blob);
+                collectReferencedDigests(nestedIndex.getManifests(), referencedDigests);
             }
             // Manifest
             else {
-                Manifest manifest = Manifest.fromPath(blobPath);
+                Manifest manifest = readManifest(blob);
                 Config config = manifest.getConfig();
                 if (config != null && config.getDigest() != null) {
                     referencedDigests.add(config.getDigest());
@@ -566,6 +804,48 @@ public final class OCILayout extends OCI<LayoutRef> {
         this.tarPath = tarPath;
     }
 
+    private void setStorage(LayoutStorage storage) {
+        this.storage = storage;
+    }
+
+    private void setTransferListener(TransferListener transferListener) {
+        this.transferListener = transferListener;
+    }
+
+    private void setOperationListener(OperationListener operationListener) {
+        this.operationListener = operationListener;
+    }
+
+    /**
+     * Copy a blob to a file while reporting progress to the transfer listener
+     * @param source The name of the blob in the storage
+     * @param target The target file
+     * @param layer The layer of the blob
+     * @param options The pull options, to decrypt the blob and overwrite an existing target
+     * @throws IOException If the copy fails
+     */
+    private void copyBlob(String source, Path target, Layer layer, PullOptions options) throws IOException {
+        String digest = Objects.requireNonNull(layer.getDigest());
+        LayerEncryption decryption = LayerEncryption.isEncrypted(layer) ? options.decryption() : null;
+        long size = storage.size(source);
+        transferListener.onStarted(digest, size);
+        try (InputStream stored = new CancellableInputStream(
+                        new ProgressInputStream(storage.read(source), transferListener, digest, size),
+                        options.cancellation());
+                InputStream is = decrypt(layer, stored, decryption)) {
+            if (options.isOverwrite()) {
+                Files.copy(is, target, StandardCopyOption.REPLACE_EXISTING);
+            } else {
+                Files.copy(is, target);
+            }
+        } catch (OrasException e) {
+            // Don't leave content failing the decryption checks or cancelled
+            Files.deleteIfExists(target);
+            throw e;
+        }
+        transferListener.onCompleted(digest, size);
+    }
+
     /**
      * Re-pack the working directory back into the backing tar file.
      * Called after every mutating operation when {@link #tarPath} is non-null.
@@ -612,26 +892,19 @@ public final class OCILayout extends OCI<LayoutRef> {
 
     private void ensureMinimalLayout() {
         try {
-            Files.createDirectories(getBlobPath());
-            if (!Files.exists(getOciLayoutPath())) {
-                Files.writeString(getOciLayoutPath(), toJson());
+            if (storage instanceof LayoutStorage.DirectoryStorage) {
+                Files.createDirectories(path.resolve(Const.OCI_LAYOUT_BLOBS));
             }
-            if (!Files.exists(getIndexPath())) {
-                Files.writeString(getIndexPath(), Index.fromManifests(List.of()).toJson());
+            if (!storage.exists(Const.OCI_LAYOUT_FILE)) {
+                storage.write(Const.OCI_LAYOUT_FILE, toJson().getBytes(StandardCharsets.UTF_8));
             }
-        } catch (IOException e) {
-            throw new OrasException("Failed to create layout", e);
-        }
-    }
-
-    private void ensureAlgorithmPath(String digest) {
-        Path prefixDirectory = getBlobAlgorithmPath(digest);
-        try {
-            if (!Files.exists(prefixDirectory)) {
-                Files.createDirectory(prefixDirectory);
+            if (!storage.exists(Const.OCI_LAYOUT_INDEX)) {
+                storage.write(
+                        Const.OCI_LAYOUT_INDEX,
+                        Index.fromManifests(List.of()).toJson().getBytes(StandardCharsets.UTF_8));
             }
         } catch (IOException e) {
-            throw new OrasException("Failed to create algorithm path", e);
+            throw new OrasException("Failed to create layout", e);
         }
     }
 
@@ -641,8 +914,10 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (tag == null) {
             throw new OrasException("Tag or digest is required to find manifest");
         }
-        ManifestDescriptor manifestDescriptor = findManifestDescriptor(ref);
-        return manifestDescriptor.toDescriptor();
+        operationListener.onResolveStarted(ref);
+        Descriptor descriptor = findManifestDescriptor(ref).toDescriptor();
+        operationListener.onResolved(ref, descriptor);
+        return descriptor;
     }
 
     @Override
@@ -667,35 +942,45 @@ public final class OCILayout extends OCI<LayoutRef> {
                                 : descriptor.toJson().getBytes());
     }
 
-    private Path getOciLayoutPath() {
-        return path.resolve(Const.OCI_LAYOUT_FILE);
-    }
-
-    private Path getBlobPath() {
-        return path.resolve(Const.OCI_LAYOUT_BLOBS);
-    }
-
-    private Path getBlobPath(LayoutRef ref) {
+    private String getBlobName(LayoutRef ref) {
         if (ref.getTag() == null) {
             throw new OrasException("Tag is required to get blob from layout");
         }
-        boolean isDigest = SupportedAlgorithm.isSupported(ref.getTag());
+        boolean isDigest = DigestAlgorithms.isSupported(ref.getTag());
         if (isDigest) {
-            SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(ref.getTag());
-            return getBlobPath().resolve(algorithm.getPrefix()).resolve(SupportedAlgorithm.getDigest(ref.getTag()));
+            return getBlobName(ref.getTag());
         }
 
         Manifest manifest = getManifest(ref);
 
-        return getBlobPath(manifest.getDescriptor());
+        return getBlobName(manifest.getDescriptor().getDigest());
     }
 
-    private long size(Path path) {
-        try {
-            return Files.size(path);
-        } catch (IOException e) {
-            throw new OrasException("Failed to get size", e);
-        }
+    private String getBlobName(String digest) {
+        DigestAlgorithm algorithm = DigestAlgorithms.fromDigest(digest);
+        return "%s/%s/%s".formatted(Const.OCI_LAYOUT_BLOBS, algorithm.getPrefix(), DigestAlgorithms.getDigest(digest));
+    }
+
+    private Layer toLayer(String digest, long size, Map<String, String> annotations) {
+        return Layer.fromDigest(digest, size)
+                .withMediaType(Const.DEFAULT_BLOB_MEDIA_TYPE)
+                .withAnnotations(annotations);
+    }
+
+    /**
+     * Read the index of the layout. The original JSON is not kept
+     * @return The index
+     */
+    private Index readIndex() {
+        return readIndex(Const.OCI_LAYOUT_INDEX);
+    }
+
+    private Index readIndex(String name) {
+        return JsonUtils.fromJson(new String(storage.readAllBytes(name), StandardCharsets.UTF_8), Index.class);
+    }
+
+    private Manifest readManifest(String name) {
+        return Manifest.fromJson(new String(storage.readAllBytes(name), StandardCharsets.UTF_8));
     }
 
     private ManifestDescriptor findManifestDescriptor(LayoutRef ref) {
@@ -703,7 +988,7 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (tag == null) {
             throw new OrasException("Tag or digest is required to find manifest");
         }
-        Index index = Index.fromPath(getIndexPath());
+        Index index = readIndex();
         return index.getManifests().stream()
                 .filter(m -> (m.getAnnotations() != null
                                 && tag.equals(m.getAnnotations().get(Const.ANNOTATION_REF))
@@ -712,86 +997,55 @@ public final class OCILayout extends OCI<LayoutRef> {
                 .orElseThrow(() -> new OrasException("Tag or digest not found: %s".formatted(tag)));
     }
 
-    private Path getBlobPath(ManifestDescriptor manifestDescriptor) {
-        String digest = manifestDescriptor.getDigest();
-        SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(digest);
-        return getBlobPath().resolve(algorithm.getPrefix()).resolve(SupportedAlgorithm.getDigest(digest));
-    }
-
-    private Path getBlobPath(Layer layer) {
-        String digest = layer.getDigest();
-        SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(digest);
-        return getBlobPath().resolve(algorithm.getPrefix()).resolve(SupportedAlgorithm.getDigest(digest));
-    }
-
-    private Path getIndexPath() {
-        return path.resolve(Const.OCI_LAYOUT_INDEX);
-    }
-
-    private Path getIndexBlobPath(Index index) {
+    private String getIndexBlobName(Index index) {
         ManifestDescriptor descriptor = index.getDescriptor();
         if (descriptor == null)
             throw new OrasException("Index descriptor is required when writing index blob with existing JSON");
-        String digest = descriptor.getDigest();
-        return getBlobAlgorithmPath(digest).resolve(SupportedAlgorithm.getDigest(digest));
-    }
-
-    private Path getBlobAlgorithmPath(String digest) {
-        SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(digest);
-        return getBlobPath().resolve(algorithm.getPrefix());
+        return getBlobName(descriptor.getDigest());
     }
 
-    private void writeOCIIndex(Index index) throws IOException {
-        Path indexFile = getIndexPath();
-        Files.writeString(indexFile, index.getJson() != null ? index.getJson() : index.toJson());
+    private void writeOCIIndex(Index index) {
+        String json = index.getJson() != null ? index.getJson() : index.toJson();
+        storage.write(Const.OCI_LAYOUT_INDEX, json.getBytes(StandardCharsets.UTF_8));
         if (index.getJson() != null) {
-            Files.writeString(getIndexBlobPath(index), index.getJson());
+            storage.write(getIndexBlobName(index), index.getJson().getBytes(StandardCharsets.UTF_8));
         }
     }
 
-    private void writeManifest(Manifest manifest) throws IOException {
-        ManifestDescriptor descriptor = manifest.getDescriptor();
-        Path manifestFile = getBlobPath(descriptor);
-        Path manifestPrefixDirectory =
-                getBlobAlgorithmPath(manifest.getDescriptor().getDigest());
+    private void updateOCIIndex(List<ManifestDescriptor> manifests) {
+        writeOCIIndex(readIndex().withManifests(manifests));
+        packToTar();
+    }
 
-        if (!Files.exists(manifestPrefixDirectory)) {
-            Files.createDirectory(manifestPrefixDirectory);
-        }
+    private void writeManifest(Manifest manifest) {
+        String manifestBlob = getBlobName(manifest.getDescriptor().getDigest());
         // Skip if already exists
-        if (Files.exists(manifestFile)) {
-            LOG.debug("Manifest already exists: {}", manifestFile);
+        if (storage.exists(manifestBlob)) {
+            LOG.debug("Manifest already exists: {}", manifestBlob);
             return;
         }
         if (manifest.getJson() == null) {
-            LOG.debug("Writing new manifest: {}", manifestFile);
-            Files.writeString(manifestFile, manifest.toJson());
+            LOG.debug("Writing new manifest: {}", manifestBlob);
+            storage.write(manifestBlob, manifest.toJson().getBytes(StandardCharsets.UTF_8));
         } else {
-            LOG.debug("Writing existing manifest: {}", manifestFile);
-            Files.writeString(manifestFile, manifest.getJson());
+            LOG.debug("Writing existing manifest: {}", manifestBlob);
+            storage.write(manifestBlob, manifest.getJson().getBytes(StandardCharsets.UTF_8));
         }
     }
 
-    private void writeIndex(Index index) throws IOException {
-        ManifestDescriptor descriptor = index.getDescriptor();
-        Path manifestFile = getBlobPath(descriptor);
-        Path manifestPrefixDirectory =
-                getBlobAlgorithmPath(index.getDescriptor().getDigest());
-
-        if (!Files.exists(manifestPrefixDirectory)) {
-            Files.createDirectory(manifestPrefixDirectory);
-        }
+    private void writeIndex(Index index) {
+        String manifestBlob = getBlobName(index.getDescriptor().getDigest());
         // Skip if already exists
-        if (Files.exists(manifestFile)) {
-            LOG.debug("Manifest already exists: {}", manifestFile);
+        if (storage.exists(manifestBlob)) {
+            LOG.debug("Manifest already exists: {}", manifestBlob);
             return;
         }
         if (index.getJson() == null) {
-            LOG.debug("Writing new manifest: {}", manifestFile);
-            Files.writeString(manifestFile, index.toJson());
+            LOG.debug("Writing new manifest: {}", manifestBlob);
+            storage.write(manifestBlob, index.toJson().getBytes(StandardCharsets.UTF_8));
         } else {
-            LOG.debug("Writing existing manifest: {}", manifestFile);
-            Files.writeString(manifestFile, index.getJson());
+            LOG.debug("Writing existing manifest: {}", manifestBlob);
+            storage.write(manifestBlob, index.getJson().getBytes(StandardCharsets.UTF_8));
         }
     }
 
@@ -799,10 +1053,10 @@ public final class OCILayout extends OCI<LayoutRef> {
         if (ref.getTag() == null) {
             throw new OrasException("Missing ref");
         }
-        if (!SupportedAlgorithm.isSupported(ref.getTag())) {
+        if (!DigestAlgorithms.isSupported(ref.getTag())) {
             throw new OrasException("Unsupported digest: %s".formatted(ref.getTag()));
         }
-        SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(ref.getTag());
+        DigestAlgorithm algorithm = DigestAlgorithms.fromDigest(ref.getTag());
         String pathDigest = algorithm.digest(path);
         if (!ref.getTag().equals(pathDigest)) {
             throw new OrasException("Digest mismatch: %s != %s".formatted(ref.getTag(), pathDigest));
@@ -817,6 +1071,7 @@ public final class OCILayout extends OCI<LayoutRef> {
     public static OCILayout fromLayoutIndex(Path layoutPath) {
         OCILayout layout = JsonUtils.fromJson(layoutPath.resolve(Const.OCI_LAYOUT_INDEX), OCILayout.class);
         layout.path = layoutPath;
+        layout.storage = LayoutStorage.directory(layoutPath);
         return layout;
     }
 
@@ -831,6 +1086,15 @@ public final class OCILayout extends OCI<LayoutRef> {
         return path;
     }
 
+    /**
+     * Return the storage of the blobs and index
+     * @return The storage
+     */
+    @JsonIgnore
+    public LayoutStorage getStorage() {
+        return storage;
+    }
+
     /**
      * Return the path to the backing tar file, or {@code null} if the layout is directory-backed.
      * @return The tar file path, or {@code null}
@@ -841,6 +1105,118 @@ public final class OCILayout extends OCI<LayoutRef> {
         return tarPath;
     }
 
+    /**
+     * Write this layout to an OCI archive (oci-archive), a tar of the layout directory.
+     * The {@code oci-layout} and {@code index.json} files are written first so the archive can be consumed as a
+     * stream, followed by the blobs. Entries are streamed to the tar file without intermediate copy.
+     * @param tarFile The tar file to write
+     */
+    public void toTar(Path tarFile) {
+        try (OutputStream os = Files.newOutputStream(tarFile);
+                BufferedOutputStream bos = new BufferedOutputStream(os);
+                TarArchiveOutputStream tar = new TarArchiveOutputStream(bos)) {
+            tar.setLongFileMode(TarArchiveOutputStream.LONGFILE_POSIX);
+            putTarEntry(tar, Const.OCI_LAYOUT_FILE, false);
+            putTarEntry(tar, Const.OCI_LAYOUT_INDEX, false);
+            putTarEntry(tar, Const.OCI_LAYOUT_BLOBS, true);
+            Set<String> directories = new HashSet<>();
+            for (String blob : storage.list(Const.OCI_LAYOUT_BLOBS + "/")) {
+                // Algorithm directories come before their blobs
+                String directory = blob.substring(0, blob.lastIndexOf('/'));
+                if (!directory.equals(Const.OCI_LAYOUT_BLOBS) && directories.add(directory)) {
+                    putTarEntry(tar, directory, true);
+                }
+                putTarEntry(tar, blob, false);
+            }
+            tar.finish();
+        } catch (IOException e) {
+            throw new OrasException("Failed to write OCI archive: " + tarFile, e);
+        }
+    }
+
+    /**
+     * Read an OCI archive (oci-archive) from a stream into a temporary directory
+     * @param input The tar stream. Not closed by this method
+     * @return The OCI layout
+     */
+    public static OCILayout fromTar(InputStream input) {
+        return fromTar(input, ArchiveUtils.createTempDir());
+    }
+
+    /**
+     * Read an OCI archive (oci-archive) from a stream into the given layout directory.
+     * Entries are written through the layout storage as they are read, and the digest of each blob is verified on the
+     * fly, so a blob only appears in the layout once verified. Entries that are not part of the OCI image layout are
+     * ignored.
+     * @param input The tar stream. Not closed by this method
+     * @param path The directory of the layout
+     * @return The OCI layout
+     */
+    public static OCILayout fromTar(InputStream input, Path path) {
+        Path root = path.toAbsolutePath().normalize();
+        LayoutStorage storage = LayoutStorage.directory(root);
+        try {
+            Files.createDirectories(root);
+            TarArchiveInputStream tar = new TarArchiveInputStream(input);
+            TarArchiveEntry entry;
+            while ((entry = tar.getNextEntry()) != null) {
+                String name = entry.getName().startsWith("./")
+                        ? entry.getName().substring(2)
+                        : entry.getName();
+                if (entry.isDirectory() || name.isEmpty()) {
+                    continue;
+                }
+                Path target = root.resolve(name).normalize();
+                if (!target.startsWith(root)) {
+                    throw new OrasException("Invalid OCI archive entry outside of the layout: " + name);
+                }
+                String[] parts = name.split("/");
+                if (name.equals(Const.OCI_LAYOUT_FILE) || name.equals(Const.OCI_LAYOUT_INDEX)) {
+                    storage.write(name, tar);
+                } else if (parts.length == 3 && parts[0].equals(Const.OCI_LAYOUT_BLOBS)) {
+                    String digest = "%s:%s".formatted(parts[1], parts[2]);
+                    if (!entry.isFile() || !DigestAlgorithms.isSupported(digest)) {
+                        throw new OrasException("Invalid OCI archive blob entry: " + name);
+                    }
+                    // Written to a temporary file, only moved in place once the digest is verified
+                    storage.write(name, new DigestVerifyingInputStream(tar, digest, entry.getSize()));
+                } else {
+                    LOG.debug("Ignoring OCI archive entry: {}", name);
+                }
+            }
+        } catch (IOException e) {
+            throw new OrasException("Failed to read OCI archive", e);
+        }
+        if (!storage.exists(Const.OCI_LAYOUT_FILE) || !storage.exists(Const.OCI_LAYOUT_INDEX)) {
+            throw new OrasException("Not an OCI archive, %s or %s is missing"
+                    .formatted(Const.OCI_LAYOUT_FILE, Const.OCI_LAYOUT_INDEX));
+        }
+        return Builder.builder().defaults(root).build();
+    }
+
+    /**
+     * Add an entry or directory of the layout to the tar with normalized metadata
+     * @param tar The tar output stream
+     * @param name The name of the entry in the storage, or of the directory
+     * @param directory Whether the entry is a directory
+     * @throws IOException if the entry cannot be read
+     */
+    private void putTarEntry(TarArchiveOutputStream tar, String name, boolean directory) throws IOException {
+        TarArchiveEntry entry = new TarArchiveEntry(directory ? name + "/" : name);
+        entry.setModTime(FileTime.fromMillis(0));
+        entry.setMode(directory ? 0755 : 0644);
+        if (!directory) {
+            entry.setSize(storage.size(name));
+        }
+        tar.putArchiveEntry(entry);
+        if (!directory) {
+            try (InputStream is = storage.read(name)) {
+                is.transferTo(tar);
+            }
+        }
+        tar.closeArchiveEntry();
+    }
+
     /**
      * Builder for the registry
      */
@@ -891,17 +1267,57 @@ public final class OCILayout extends OCI<LayoutRef> {
             return new OCILayout.Builder();
         }
 
+        /**
+         * Set the listener notified of blob transfers during push and pull
+         * @param transferListener The transfer listener
+         * @return The builder
+         */
+        public OCILayout.Builder withTransferListener(TransferListener transferListener) {
+            layout.setTransferListener(transferListener);
+            return this;
+        }
+
+        /**
+         * Set the listener notified of high-level operations like resolves, pushes and copies
+         * @param operationListener The operation listener
+         * @return The builder
+         */
+        public OCILayout.Builder withOperationListener(OperationListener operationListener) {
+            layout.setOperationListener(operationListener);
+            return this;
+        }
+
+        /**
+         * Set the storage of the blobs and index, for example an object store, instead of the directory of the
+         * layout. The path given to {@link #defaults(Path)} still names the layout in {@link LayoutRef}s, but
+         * nothing is written to it. Not supported for tar-backed layouts
+         * @param storage The storage
+         * @return The builder
+         */
+        public OCILayout.Builder withStorage(LayoutStorage storage) {
+            layout.setStorage(Objects.requireNonNull(storage, "storage"));
+            return this;
+        }
+
         /**
          * Build the registry
          * @return The registry
          */
         public OCILayout build() {
-            if (!Files.isDirectory(layout.path)) {
-                try {
-                    Files.createDirectory(layout.path);
-                } catch (IOException e) {
-                    throw new OrasException("Failed to create OCI layout directory", e);
+            if (layout.path == null) {
+                throw new OrasException("Path of the OCI layout is required");
+            }
+            if (layout.storage == null) {
+                if (!Files.isDirectory(layout.path)) {
+                    try {
+                        Files.createDirectory(layout.path);
+                    } catch (IOException e) {
+                        throw new OrasException("Failed to create OCI layout directory", e);
+                    }
                 }
+                layout.setStorage(LayoutStorage.directory(layout.path));
+            } else if (layout.tarPath != null) {
+                throw new OrasException("A custom storage is not supported for tar-backed OCI layouts");
             }
             layout.ensureMinimalLayout();
             layout.packToTar();
@@ -0,0 +1,165 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import io.opentelemetry.api.GlobalOpenTelemetry;
+import io.opentelemetry.api.OpenTelemetry;
+import io.opentelemetry.api.common.AttributeKey;
+import io.opentelemetry.api.trace.Span;
+import io.opentelemetry.api.trace.SpanKind;
+import io.opentelemetry.api.trace.StatusCode;
+import io.opentelemetry.api.trace.Tracer;
+import io.opentelemetry.context.Context;
+import io.opentelemetry.context.Scope;
+import io.opentelemetry.context.propagation.TextMapSetter;
+import java.util.function.BiConsumer;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Operation tracer exporting spans with OpenTelemetry.
+ * The OpenTelemetry API is an optional dependency and must be added to the classpath to use this tracer.
+ * The context of the current span is propagated to the registry with the configured propagators.
+ */
+@NullMarked
+public final class OpenTelemetryTracer implements OperationTracer {
+
+    /**
+     * Instrumentation scope name
+     */
+    public static final String INSTRUMENTATION_NAME = "land.oras";
+
+    /**
+     * Operation attribute
+     */
+    public static final AttributeKey<String> OPERATION = AttributeKey.stringKey("oras.operation");
+
+    /**
+     * Registry attribute
+     */
+    public static final AttributeKey<String> REGISTRY = AttributeKey.stringKey("oras.registry");
+
+    /**
+     * Repository attribute
+     */
+    public static final AttributeKey<String> REPOSITORY = AttributeKey.stringKey("oras.repository");
+
+    /**
+     * Digest attribute
+     */
+    public static final AttributeKey<String> DIGEST = AttributeKey.stringKey("oras.digest");
+
+    /**
+     * Bytes attribute
+     */
+    public static final AttributeKey<Long> BYTES = AttributeKey.longKey("oras.bytes");
+
+    /**
+     * Setter for header propagation
+     */
+    private static final TextMapSetter<BiConsumer<String, String>> SETTER = (carrier, key, value) -> {
+        if (carrier != null) {
+            carrier.accept(key, value);
+        }
+    };
+
+    /**
+     * The OpenTelemetry instance
+     */
+    private final OpenTelemetry openTelemetry;
+
+    /**
+     * The tracer
+     */
+    private final Tracer tracer;
+
+    private OpenTelemetryTracer(OpenTelemetry openTelemetry) {
+        this.openTelemetry = openTelemetry;
+        this.tracer = openTelemetry.getTracer(INSTRUMENTATION_NAME);
+    }
+
+    /**
+     * Create a tracer for the given OpenTelemetry instance
+     * @param openTelemetry The OpenTelemetry instance
+     * @return The tracer
+     */
+    public static OpenTelemetryTracer of(OpenTelemetry openTelemetry) {
+        return new OpenTelemetryTracer(openTelemetry);
+    }
+
+    /**
+     * Create a tracer for the global OpenTelemetry instance
+     * @return The tracer
+     */
+    public static OpenTelemetryTracer global() {
+        return new OpenTelemetryTracer(GlobalOpenTelemetry.get());
+    }
+
+    @Override
+    public TracedOperation start(String operation, Ref<?> ref) {
+        Span span = tracer.spanBuilder("oras %s".formatted(operation))
+                .setSpanKind(SpanKind.INTERNAL)
+                .setAttribute(OPERATION, operation)
+                .setAttribute(REPOSITORY, ref.getRepository())
+                .startSpan();
+        if (ref instanceof ContainerRef containerRef) {
+            span.setAttribute(REGISTRY, containerRef.getRegistry());
+            if (containerRef.getDigest() != null) {
+                span.setAttribute(DIGEST, containerRef.getDigest());
+            }
+        }
+        return new OpenTelemetryOperation(span, span.makeCurrent());
+    }
+
+    @Override
+    public void inject(BiConsumer<String, String> headers) {
+        openTelemetry.getPropagators().getTextMapPropagator().inject(Context.current(), headers, SETTER);
+    }
+
+    /**
+     * Operation backed by an OpenTelemetry span
+     * @param span The span
+     * @param scope The scope making the span current
+     */
+    private record OpenTelemetryOperation(Span span, Scope scope) implements TracedOperation {
+
+        @Override
+        public void setDigest(String digest) {
+            span.setAttribute(DIGEST, digest);
+        }
+
+        @Override
+        public void setBytes(long bytes) {
+            span.setAttribute(BYTES, bytes);
+        }
+
+        @Override
+        public void recordError(Throwable error) {
+            span.recordException(error);
+            span.setStatus(StatusCode.ERROR, error.getMessage() != null ? error.getMessage() : "");
+        }
+
+        @Override
+        public void close() {
+            scope.close();
+            span.end();
+        }
+    }
+}
@@ -0,0 +1,103 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Listener notified of high-level operations on a registry or OCI layout.
+ * Useful to build auditing or caching layers on top of push, pull and copy operations.
+ * All methods have a no-op default implementation so consumers only need to override the callbacks they need.
+ * Callbacks might be invoked concurrently from different threads when layers are transferred in parallel.
+ */
+@NullMarked
+public interface OperationListener {
+
+    /**
+     * Called before a reference is resolved to a descriptor
+     * @param ref The reference
+     */
+    default void onResolveStarted(Ref<?> ref) {}
+
+    /**
+     * Called when a reference was resolved to a descriptor
+     * @param ref The reference
+     * @param descriptor The resolved descriptor
+     */
+    default void onResolved(Ref<?> ref, Descriptor descriptor) {}
+
+    /**
+     * Called when a blob was pushed
+     * @param ref The target reference
+     * @param layer The pushed blob
+     */
+    default void onBlobPushed(Ref<?> ref, Layer layer) {}
+
+    /**
+     * Called when a blob was not pushed because it already exists in the target
+     * @param ref The target reference
+     * @param digest The digest of the blob
+     */
+    default void onBlobSkipped(Ref<?> ref, String digest) {}
+
+    /**
+     * Called when a blob was mounted from another repository instead of being pushed
+     * @param sourceRef The source reference with digest
+     * @param targetRef The target reference
+     */
+    default void onBlobMounted(Ref<?> sourceRef, Ref<?> targetRef) {}
+
+    /**
+     * Called when a manifest was pushed
+     * @param ref The target reference
+     * @param manifest The pushed manifest
+     */
+    default void onManifestPushed(Ref<?> ref, Manifest manifest) {}
+
+    /**
+     * Called when an index was pushed
+     * @param ref The target reference
+     * @param index The pushed index
+     */
+    default void onIndexPushed(Ref<?> ref, Index index) {}
+
+    /**
+     * Called before a copy starts
+     * @param sourceRef The source reference
+     * @param targetRef The target reference
+     */
+    default void onCopyStarted(Ref<?> sourceRef, Ref<?> targetRef) {}
+
+    /**
+     * Called when a copy completed, including the referrers if requested
+     * @param sourceRef The source reference
+     * @param targetRef The target reference
+     */
+    default void onCopyCompleted(Ref<?> sourceRef, Ref<?> targetRef) {}
+
+    /**
+     * Return a listener that ignore all events
+     * @return The listener
+     */
+    static OperationListener noop() {
+        return new OperationListener() {};
+    }
+}
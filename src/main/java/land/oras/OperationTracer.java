@@ -0,0 +1,105 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.function.BiConsumer;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Tracer creating a span for each high-level operation like resolve, push, pull or copy.
+ * Use {@link OpenTelemetryTracer} to export spans with OpenTelemetry when the API is on the classpath.
+ */
+@NullMarked
+@FunctionalInterface
+public interface OperationTracer {
+
+    /**
+     * Resolve operation
+     */
+    String RESOLVE = "resolve";
+
+    /**
+     * Push operation
+     */
+    String PUSH = "push";
+
+    /**
+     * Pull operation
+     */
+    String PULL = "pull";
+
+    /**
+     * Copy operation
+     */
+    String COPY = "copy";
+
+    /**
+     * Start a new operation. The returned operation must be closed once the operation completes
+     * @param operation The operation name
+     * @param ref The reference
+     * @return The traced operation
+     */
+    TracedOperation start(String operation, Ref<?> ref);
+
+    /**
+     * Inject the context of the current operation into the headers of an outgoing HTTP request
+     * @param headers The header setter
+     */
+    default void inject(BiConsumer<String, String> headers) {}
+
+    /**
+     * Return a tracer that doesn't record anything
+     * @return The tracer
+     */
+    static OperationTracer noop() {
+        return (operation, ref) -> new TracedOperation() {};
+    }
+
+    /**
+     * A traced operation
+     */
+    interface TracedOperation extends AutoCloseable {
+
+        /**
+         * Set the digest of the content resolved or transferred by the operation
+         * @param digest The digest
+         */
+        default void setDigest(String digest) {}
+
+        /**
+         * Set the number of bytes transferred by the operation
+         * @param bytes The number of bytes
+         */
+        default void setBytes(long bytes) {}
+
+        /**
+         * Record the error that made the operation fail
+         * @param error The error
+         */
+        default void recordError(Throwable error) {}
+
+        /**
+         * End the operation
+         */
+        @Override
+        default void close() {}
+    }
+}
@@ -41,11 +41,12 @@ import org.jspecify.annotations.Nullable;
 @NullMarked
 @OrasModel
 @JsonPropertyOrder({
-    Const.PLATFORM_OS,
     Const.PLATFORM_ARCHITECTURE,
-    Const.PLATFORM_VARIANT,
+    Const.PLATFORM_OS,
     Const.PLATFORM_OS_VERSION,
-    Const.PLATFORM_OS_FEATURES
+    Const.PLATFORM_OS_FEATURES,
+    Const.PLATFORM_VARIANT,
+    Const.PLATFORM_FEATURES
 })
 @JsonInclude(JsonInclude.Include.NON_NULL)
 public record Platform(
@@ -0,0 +1,92 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.FilterInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * An input stream reporting the number of bytes read to a {@link TransferListener}
+ */
+@NullMarked
+final class ProgressInputStream extends FilterInputStream {
+
+    private final TransferListener listener;
+    private final String digest;
+    private final long totalSize;
+    private long bytesTransferred;
+
+    /**
+     * Constructor
+     * @param in The input stream to wrap
+     * @param listener The listener
+     * @param digest The digest of the blob
+     * @param totalSize The total size of the blob or -1 if unknown
+     */
+    ProgressInputStream(InputStream in, TransferListener listener, String digest, long totalSize) {
+        super(in);
+        this.listener = listener;
+        this.digest = digest;
+        this.totalSize = totalSize;
+    }
+
+    /**
+     * Get the number of bytes transferred
+     * @return The number of bytes
+     */
+    long getBytesTransferred() {
+        return bytesTransferred;
+    }
+
+    @Override
+    public int read() throws IOException {
+        int b = super.read();
+        if (b != -1) {
+            report(1);
+        }
+        return b;
+    }
+
+    @Override
+    public int read(byte[] b, int off, int len) throws IOException {
+        int read = super.read(b, off, len);
+        if (read > 0) {
+            report(read);
+        }
+        return read;
+    }
+
+    @Override
+    public long skip(long n) throws IOException {
+        long skipped = super.skip(n);
+        if (skipped > 0) {
+            report(skipped);
+        }
+        return skipped;
+    }
+
+    private void report(long count) {
+        bytesTransferred += count;
+        listener.onProgress(digest, bytesTransferred, totalSize);
+    }
+}
@@ -0,0 +1,223 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.time.Instant;
+import java.util.ArrayList;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Standard provenance annotations stamped on packed manifests. Annotations given explicitly when packing always take
+ * precedence, then the ones of the annotation providers, then the standard ones.
+ */
+@NullMarked
+public final class Provenance {
+
+    /**
+     * Environment variable of reproducible builds, holding the creation time in seconds since the epoch
+     */
+    public static final String SOURCE_DATE_EPOCH = "SOURCE_DATE_EPOCH";
+
+    private final @Nullable Instant created;
+    private final @Nullable String authors;
+    private final @Nullable String source;
+    private final @Nullable String revision;
+    private final List<AnnotationProvider> annotationProviders;
+
+    /**
+     * Callback invoked at pack time to add annotations to the packed manifest
+     */
+    @FunctionalInterface
+    public interface AnnotationProvider {
+
+        /**
+         * Return the annotations to add to the manifest
+         * @param manifest The packed manifest, without its annotations
+         * @return The annotations
+         */
+        Map<String, String> annotations(Manifest manifest);
+    }
+
+    private Provenance(
+            @Nullable Instant created,
+            @Nullable String authors,
+            @Nullable String source,
+            @Nullable String revision,
+            List<AnnotationProvider> annotationProviders) {
+        this.created = created;
+        this.authors = authors;
+        this.source = source;
+        this.revision = revision;
+        this.annotationProviders = List.copyOf(annotationProviders);
+    }
+
+    /**
+     * Provenance without any annotation. The created annotation uses the current time
+     * @return The provenance
+     */
+    public static Provenance empty() {
+        return new Provenance(null, null, null, null, List.of());
+    }
+
+    /**
+     * Provenance using the {@value #SOURCE_DATE_EPOCH} environment variable, if set, as creation time
+     * @return The provenance
+     */
+    public static Provenance fromEnvironment() {
+        return fromEnvironment(System.getenv());
+    }
+
+    /**
+     * Provenance using the {@value #SOURCE_DATE_EPOCH} of the given environment, if set, as creation time
+     * @param env The environment variables
+     * @return The provenance
+     */
+    static Provenance fromEnvironment(Map<String, String> env) {
+        String epoch = env.get(SOURCE_DATE_EPOCH);
+        if (epoch == null || epoch.isBlank()) {
+            return empty();
+        }
+        try {
+            return empty().withCreated(Instant.ofEpochSecond(Long.parseLong(epoch.trim())));
+        } catch (NumberFormatException e) {
+            throw new OrasException("Invalid %s: %s".formatted(SOURCE_DATE_EPOCH, epoch), e);
+        }
+    }
+
+    /**
+     * Return a new provenance with a fixed creation time, for reproducible manifests
+     * @param created The creation time
+     * @return The provenance
+     */
+    public Provenance withCreated(Instant created) {
+        return new Provenance(created, authors, source, revision, annotationProviders);
+    }
+
+    /**
+     * Return a new provenance with the authors
+     * @param authors The contact details of the people or organization responsible for the artifact
+     * @return The provenance
+     */
+    public Provenance withAuthors(String authors) {
+        return new Provenance(created, authors, source, revision, annotationProviders);
+    }
+
+    /**
+     * Return a new provenance with the source
+     * @param source The URL to get the source code of the artifact
+     * @return The provenance
+     */
+    public Provenance withSource(String source) {
+        return new Provenance(created, authors, source, revision, annotationProviders);
+    }
+
+    /**
+     * Return a new provenance with the revision
+     * @param revision The source control revision of the artifact
+     * @return The provenance
+     */
+    public Provenance withRevision(String revision) {
+        return new Provenance(created, authors, source, revision, annotationProviders);
+    }
+
+    /**
+     * Return a new provenance with an additional annotation provider. Providers are invoked in order
+     * @param annotationProvider The annotation provider
+     * @return The provenance
+     */
+    public Provenance withAnnotationProvider(AnnotationProvider annotationProvider) {
+        List<AnnotationProvider> providers = new ArrayList<>(annotationProviders);
+        providers.add(annotationProvider);
+        return new Provenance(created, authors, source, revision, providers);
+    }
+
+    /**
+     * Get the creation time
+     * @return The creation time or null to use the current time
+     */
+    public @Nullable Instant getCreated() {
+        return created;
+    }
+
+    /**
+     * Get the authors
+     * @return The authors or null
+     */
+    public @Nullable String getAuthors() {
+        return authors;
+    }
+
+    /**
+     * Get the source
+     * @return The source or null
+     */
+    public @Nullable String getSource() {
+        return source;
+    }
+
+    /**
+     * Get the revision
+     * @return The revision or null
+     */
+    public @Nullable String getRevision() {
+        return revision;
+    }
+
+    /**
+     * Get the annotation providers
+     * @return The annotation providers
+     */
+    public List<AnnotationProvider> getAnnotationProviders() {
+        return annotationProviders;
+    }
+
+    /**
+     * Compute the annotations of the packed manifest
+     * @param manifest The packed manifest, without its annotations
+     * @param annotations The annotations given explicitly, taking precedence
+     * @return The annotations
+     */
+    Map<String, String> annotate(Manifest manifest, Map<String, String> annotations) {
+        Map<String, String> result = new LinkedHashMap<>();
+        result.put(
+                Const.ANNOTATION_CREATED, created != null ? Const.formatTimestamp(created) : Const.currentTimestamp());
+        if (authors != null) {
+            result.put(Const.ANNOTATION_AUTHORS, authors);
+        }
+        if (source != null) {
+            result.put(Const.ANNOTATION_SOURCE, source);
+        }
+        if (revision != null) {
+            result.put(Const.ANNOTATION_REVISION, revision);
+        }
+        for (AnnotationProvider annotationProvider : annotationProviders) {
+            result.putAll(annotationProvider.annotations(manifest));
+        }
+        result.putAll(annotations);
+        return result;
+    }
+}
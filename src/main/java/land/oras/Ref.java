@@ -20,7 +20,7 @@
 
 package land.oras;
 
-import land.oras.utils.SupportedAlgorithm;
+import land.oras.utils.DigestAlgorithm;
 import org.jspecify.annotations.NullMarked;
 import org.jspecify.annotations.Nullable;
 
@@ -63,7 +63,7 @@ public abstract sealed class Ref<T extends Ref<T>> permits ContainerRef, LayoutR
      * Get the algorithm
      * @return The algorithm
      */
-    public abstract SupportedAlgorithm getAlgorithm();
+    public abstract DigestAlgorithm getAlgorithm();
 
     /**
      * Get the repository where to find the ref
@@ -21,42 +21,83 @@
 package land.oras;
 
 import io.micrometer.core.instrument.MeterRegistry;
+import io.micrometer.core.instrument.Metrics;
 import io.micrometer.core.instrument.simple.SimpleMeterRegistry;
 import java.io.IOException;
 import java.io.InputStream;
+import java.io.OutputStream;
 import java.net.URI;
 import java.net.URISyntaxException;
+import java.net.URLDecoder;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.nio.file.StandardCopyOption;
+import java.nio.file.StandardOpenOption;
 import java.security.MessageDigest;
+import java.time.Duration;
 import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Collections;
 import java.util.HashMap;
+import java.util.HashSet;
+import java.util.Iterator;
+import java.util.LinkedHashMap;
+import java.util.LinkedHashSet;
 import java.util.List;
+import java.util.Locale;
 import java.util.Map;
+import java.util.NoSuchElementException;
 import java.util.Objects;
 import java.util.Optional;
+import java.util.Set;
+import java.util.Spliterator;
+import java.util.Spliterators;
 import java.util.concurrent.CompletableFuture;
 import java.util.concurrent.ExecutorService;
 import java.util.concurrent.Executors;
+import java.util.concurrent.LinkedBlockingQueue;
+import java.util.concurrent.RejectedExecutionException;
+import java.util.concurrent.ThreadFactory;
+import java.util.concurrent.ThreadPoolExecutor;
+import java.util.concurrent.TimeUnit;
 import java.util.function.BiFunction;
+import java.util.function.Function;
 import java.util.function.Supplier;
+import java.util.stream.Stream;
+import java.util.stream.StreamSupport;
+import javax.net.ssl.X509KeyManager;
+import javax.net.ssl.X509TrustManager;
 import land.oras.auth.AuthProvider;
 import land.oras.auth.AuthStoreAuthenticationProvider;
 import land.oras.auth.BearerTokenProvider;
+import land.oras.auth.HostConfig;
 import land.oras.auth.HttpClient;
+import land.oras.auth.HttpInterceptor;
+import land.oras.auth.HttpTransport;
 import land.oras.auth.NoAuthProvider;
+import land.oras.auth.ProxyConfig;
 import land.oras.auth.RegistriesConf;
+import land.oras.auth.RetryPolicy;
+import land.oras.auth.Scope;
 import land.oras.auth.Scopes;
 import land.oras.auth.UsernamePasswordProvider;
+import land.oras.encryption.LayerEncryption;
+import land.oras.exception.ContentTooLargeException;
+import land.oras.exception.DigestMismatchException;
+import land.oras.exception.OperationCancelledException;
+import land.oras.exception.OperationNotSupportedException;
 import land.oras.exception.OrasException;
+import land.oras.exception.RateLimitException;
 import land.oras.policy.ContainersPolicy;
 import land.oras.policy.PolicyContext;
 import land.oras.policy.Transport;
 import land.oras.utils.ArchiveUtils;
 import land.oras.utils.Const;
+import land.oras.utils.DigestAlgorithm;
+import land.oras.utils.DigestAlgorithms;
 import land.oras.utils.JsonUtils;
+import land.oras.utils.LogUtils;
 import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullMarked;
 import org.jspecify.annotations.Nullable;
@@ -67,16 +108,43 @@ import org.jspecify.annotations.Nullable;
 @NullMarked
 public final class Registry extends OCI<ContainerRef> {
 
+    /**
+     * Default size in bytes of the buffer used to stream blobs
+     */
+    public static final int DEFAULT_BUFFER_SIZE = 8192;
+
+    /**
+     * Max number of platform threads of the default executor for asynchronous operations, when virtual threads
+     * are not available
+     */
+    public static final int DEFAULT_ASYNC_THREADS = Math.max(4, Runtime.getRuntime().availableProcessors());
+
+    /**
+     * Max number of asynchronous operations waiting for a platform thread of the default executor. Further
+     * operations fail right away
+     */
+    public static final int DEFAULT_ASYNC_QUEUE_SIZE = 256;
+
     /**
      * Max concurrent downloads and upload for blobs
      */
     private int maxConcurrentDownloads = 1;
 
+    /**
+     * Whether the default executors run tasks on virtual threads
+     */
+    private boolean virtualThreads;
+
     /**
      * The executor service for parallel operations
      */
     private ExecutorService executorService;
 
+    /**
+     * The executor service for asynchronous operations
+     */
+    private ExecutorService asyncExecutorService;
+
     /**
      * The registries configuration loaded from the environment
      */
@@ -125,31 +193,216 @@ public final class Registry extends OCI<ContainerRef> {
      */
     private @Nullable String caContent;
 
+    /**
+     * Custom trust manager for TLS verification
+     */
+    private @Nullable X509TrustManager trustManager;
+
+    /**
+     * Custom key manager providing the client certificate for mTLS
+     */
+    private @Nullable X509KeyManager keyManager;
+
+    /**
+     * Path to a PEM-encoded client certificate chain for mTLS
+     */
+    private @Nullable Path clientCertificatePath;
+
+    /**
+     * Path to a PEM-encoded PKCS#8 private key for mTLS
+     */
+    private @Nullable Path clientKeyPath;
+
+    /**
+     * Whether the server hostname is verified against its certificate
+     */
+    private boolean hostnameVerification = true;
+
+    /**
+     * Per-host connection settings keyed by lowercase host[:port]
+     */
+    private final Map<String, HostConfig> hostConfigs = new HashMap<>();
+
+    /**
+     * Mirrors keyed by lowercase upstream host[:port], tried in order before the upstream registry
+     */
+    private final Map<String, List<RegistriesConf.MirrorConfig>> mirrors = new HashMap<>();
+
     /**
      * The meter registry for metrics
      */
     private @Nullable MeterRegistry meterRegistry;
 
     /**
-     * Maximum number of attempts for retryable requests (1 = no retry)
+     * Operation metrics recorded on the meter registry
+     */
+    private RegistryMetrics metrics = new RegistryMetrics(Metrics.globalRegistry);
+
+    /**
+     * The retry policy for transient failures
+     */
+    private RetryPolicy retryPolicy = RetryPolicy.defaults();
+
+    /**
+     * Custom transport for HTTP requests. If null a JDK HTTP client is created
+     */
+    private @Nullable HttpTransport transport;
+
+    /**
+     * The proxy configuration. If null the proxy environment variables are used
+     */
+    private @Nullable ProxyConfig proxyConfig;
+
+    /**
+     * Whether pull-only token requests are retried anonymously when the credentials are rejected
+     */
+    private boolean anonymousFallback = true;
+
+    /**
+     * Whether read operations reject references without digest
+     */
+    private boolean digestPinning;
+
+    /**
+     * Tag references allowed on read operations when digest pinning is enabled
+     */
+    private final Set<String> allowedTagReferences = new HashSet<>();
+
+    /**
+     * Chunk size in bytes used for blob uploads. Zero or negative means monolithic uploads
+     */
+    private long chunkSize;
+
+    /**
+     * Strategy used to push blobs from streams of unknown length
+     */
+    private UnknownSizeStrategy unknownSizeStrategy = UnknownSizeStrategy.CHUNKED;
+
+    /**
+     * Size in bytes of the buffer used to stream blobs to disk
+     */
+    private int bufferSize = DEFAULT_BUFFER_SIZE;
+
+    /**
+     * Timeout to establish a connection. If null the HTTP client default applies
+     */
+    private @Nullable Duration connectTimeout;
+
+    /**
+     * Timeout waiting for the response headers of each request. If null requests never time out
+     */
+    private @Nullable Duration readTimeout;
+
+    /**
+     * Whether HTTP/2 is negotiated with the registry. If false, HTTP/1.1 is always used
      */
-    private int maxRetries = 3;
+    private boolean http2 = true;
 
     /**
-     * Initial delay between retries in milliseconds
+     * Maximum number of HTTP requests in flight to a single host. Zero or negative for no limit
      */
-    private long retryDelayMs = 500L;
+    private int maxRequestsPerHost;
 
     /**
-     * Upper bound on retry delay in milliseconds
+     * The digest algorithm used for pushed content when the reference doesn't have a digest
      */
-    private long maxRetryDelayMs = 30_000L;
+    private DigestAlgorithm digestAlgorithm = SupportedAlgorithm.getDefault();
 
     /**
      * The containers policy for trust verification
      */
     private ContainersPolicy containersPolicy;
 
+    /**
+     * The listener notified of blob transfers
+     */
+    private TransferListener transferListener = TransferListener.noop();
+
+    /**
+     * The listener notified of high-level operations
+     */
+    private OperationListener operationListener = OperationListener.noop();
+
+    /**
+     * The tracer creating spans for high-level operations
+     */
+    private OperationTracer operationTracer = OperationTracer.noop();
+
+    /**
+     * The listener notified of registry warnings. If null warnings are logged
+     */
+    private @Nullable WarningListener warningListener;
+
+    /**
+     * The cache of tag resolutions, shared with the registries created for other transports
+     */
+    private ResolveCache resolveCache = ResolveCache.disabled();
+
+    /**
+     * The verifiers invoked on pull
+     */
+    private final List<ArtifactVerifier> verifiers = new ArrayList<>();
+
+    /**
+     * The signers invoked after a manifest or index is pushed
+     */
+    private final List<ArtifactSigner> signers = new ArrayList<>();
+
+    /**
+     * The interceptors of every HTTP request
+     */
+    private final List<HttpInterceptor> interceptors = new ArrayList<>();
+
+    /**
+     * The suffix appended to the User-Agent header
+     */
+    private @Nullable String userAgentSuffix;
+
+    /**
+     * The headers added to every HTTP request
+     */
+    private final Map<String, String> defaultHeaders = new LinkedHashMap<>();
+
+    /**
+     * The default headers redacted from the logs
+     */
+    private final Set<String> sensitiveHeaders = new HashSet<>();
+
+    /**
+     * Repositories on the same registry to mount blobs from before uploading them
+     */
+    private final List<String> mountSources = new ArrayList<>();
+
+    /**
+     * The limiter shared by all blob transfers
+     */
+    private @Nullable BandwidthLimiter bandwidthLimiter;
+
+    /**
+     * The bandwidth limit in bytes per second applied to each blob transfer. Zero or negative means unlimited
+     */
+    private long transferBandwidthLimit;
+
+    /**
+     * The number of times an interrupted blob download is resumed with a range request
+     */
+    private int downloadResumeAttempts = 3;
+
+    /**
+     * The local cache of verified blobs consulted before fetching blobs by digest
+     */
+    private @Nullable BlobCache blobCache;
+
+    /**
+     * The maximum size of manifests and indexes in bytes. Zero or negative means unlimited
+     */
+    private long maxManifestSize = Const.DEFAULT_MAX_MANIFEST_SIZE;
+
+    /**
+     * The maximum size of blobs in bytes. Zero or negative means unlimited
+     */
+    private long maxBlobSize;
+
     /**
      * Constructor
      */
@@ -206,15 +459,18 @@ public final class Registry extends OCI<ContainerRef> {
             return copyForNewTransport(ref.getRegistry(), false).mountBlob(sourceRef, ref);
         }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getBlobsMountPath(this, sourceRef)));
+        // Registries enforcing scopes on the mount source silently fall back to an upload without pull on it
+        Scopes scopes = Scopes.of(ref, Scope.PULL, Scope.PUSH).withAddedRepositoryScopes(sourceRef, Scope.PULL);
         HttpClient.ResponseWrapper<String> response = client.post(
                 uri,
                 new byte[0],
                 Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
-                Scopes.of(ref),
+                scopes,
                 authProvider);
         logResponse(response);
         if (response.statusCode() == 201) {
             LOG.info("Blob mounted successfully from {}: {}", sourceRef.getFullRepository(), digest);
+            operationListener.onBlobMounted(sourceRef, ref);
             return true;
         }
         if (response.statusCode() == 202) {
@@ -233,6 +489,15 @@ public final class Registry extends OCI<ContainerRef> {
         return registriesConf;
     }
 
+    /**
+     * Get the connection settings configured for a host
+     * @param host The host[:port]
+     * @return The host configuration or null if none configured
+     */
+    public @Nullable HostConfig getHostConfig(String host) {
+        return hostConfigs.get(host.toLowerCase(Locale.ROOT));
+    }
+
     /**
      * Return a new builder for this registry
      * @return The builder
@@ -257,6 +522,10 @@ public final class Registry extends OCI<ContainerRef> {
         this.maxConcurrentDownloads = maxConcurrentDownloads;
     }
 
+    private void setVirtualThreads(boolean virtualThreads) {
+        this.virtualThreads = virtualThreads;
+    }
+
     /**
      * Allow consumer to set custom executor service for parallel operations. If not set, a default one will be created with the given parallelism
      * @param executorService The executor service
@@ -265,6 +534,14 @@ public final class Registry extends OCI<ContainerRef> {
         this.executorService = executorService;
     }
 
+    /**
+     * Allow consumer to set custom executor service for asynchronous operations. If not set, a default cached thread pool will be created
+     * @param asyncExecutorService The executor service
+     */
+    private void setAsyncExecutorService(ExecutorService asyncExecutorService) {
+        this.asyncExecutorService = asyncExecutorService;
+    }
+
     /**
      * Return if this registry is insecure
      * @return True if insecure
@@ -345,34 +622,183 @@ public final class Registry extends OCI<ContainerRef> {
      */
     private void setMeterRegistry(MeterRegistry meterRegistry) {
         this.meterRegistry = meterRegistry;
+        this.metrics = new RegistryMetrics(meterRegistry);
+    }
+
+    private void setAnonymousFallback(boolean anonymousFallback) {
+        this.anonymousFallback = anonymousFallback;
+    }
+
+    private void setDigestPinning(boolean digestPinning) {
+        this.digestPinning = digestPinning;
+    }
+
+    private void addAllowedTagReference(String reference) {
+        this.allowedTagReferences.add(reference);
+    }
+
+    private void setRetryPolicy(RetryPolicy retryPolicy) {
+        this.retryPolicy = retryPolicy;
+    }
+
+    private void setTrustManager(X509TrustManager trustManager) {
+        this.trustManager = trustManager;
+    }
+
+    private void setKeyManager(X509KeyManager keyManager) {
+        this.keyManager = keyManager;
+    }
+
+    private void setClientCertificate(Path certificatePath, Path keyPath) {
+        this.clientCertificatePath = certificatePath;
+        this.clientKeyPath = keyPath;
+    }
+
+    private void setHostnameVerification(boolean hostnameVerification) {
+        this.hostnameVerification = hostnameVerification;
+    }
+
+    private void setHostConfig(String host, HostConfig hostConfig) {
+        this.hostConfigs.put(host.toLowerCase(Locale.ROOT), hostConfig);
+    }
+
+    private void addMirror(String host, RegistriesConf.MirrorConfig mirror) {
+        this.mirrors
+                .computeIfAbsent(host.toLowerCase(Locale.ROOT), key -> new ArrayList<>())
+                .add(mirror);
+    }
+
+    private void setTransport(HttpTransport transport) {
+        this.transport = transport;
+    }
+
+    private void setProxyConfig(ProxyConfig proxyConfig) {
+        this.proxyConfig = proxyConfig;
+    }
+
+    private void setChunkSize(long chunkSize) {
+        this.chunkSize = chunkSize;
+    }
+
+    private void setBufferSize(int bufferSize) {
+        this.bufferSize = bufferSize;
+    }
+
+    private void setConnectTimeout(@Nullable Duration connectTimeout) {
+        this.connectTimeout = connectTimeout;
+    }
+
+    private void setReadTimeout(@Nullable Duration readTimeout) {
+        this.readTimeout = readTimeout;
+    }
+
+    private void setHttp2(boolean http2) {
+        this.http2 = http2;
     }
 
-    private void setMaxRetries(int maxRetries) {
-        this.maxRetries = maxRetries;
+    private void setMaxRequestsPerHost(int maxRequestsPerHost) {
+        this.maxRequestsPerHost = maxRequestsPerHost;
     }
 
-    private void setRetryDelayMs(long retryDelayMs) {
-        this.retryDelayMs = retryDelayMs;
+    private void setUnknownSizeStrategy(UnknownSizeStrategy unknownSizeStrategy) {
+        this.unknownSizeStrategy = unknownSizeStrategy;
     }
 
-    private void setMaxRetryDelayMs(long maxRetryDelayMs) {
-        this.maxRetryDelayMs = maxRetryDelayMs;
+    private void setDigestAlgorithm(DigestAlgorithm digestAlgorithm) {
+        this.digestAlgorithm = digestAlgorithm;
     }
 
     private void setContainersPolicy(ContainersPolicy containersPolicy) {
         this.containersPolicy = containersPolicy;
     }
 
+    private void setTransferListener(TransferListener transferListener) {
+        this.transferListener = transferListener;
+    }
+
+    private void setOperationListener(OperationListener operationListener) {
+        this.operationListener = operationListener;
+    }
+
+    private void setOperationTracer(OperationTracer operationTracer) {
+        this.operationTracer = operationTracer;
+    }
+
+    private void setWarningListener(WarningListener warningListener) {
+        this.warningListener = warningListener;
+    }
+
+    private void setResolveCache(ResolveCache resolveCache) {
+        this.resolveCache = resolveCache;
+    }
+
+    private void addVerifier(ArtifactVerifier verifier) {
+        this.verifiers.add(verifier);
+    }
+
+    private void addSigner(ArtifactSigner signer) {
+        this.signers.add(signer);
+    }
+
+    private void addInterceptor(HttpInterceptor interceptor) {
+        this.interceptors.add(interceptor);
+    }
+
+    private void setUserAgentSuffix(String userAgentSuffix) {
+        this.userAgentSuffix = userAgentSuffix;
+    }
+
+    private void addDefaultHeader(String name, String value, boolean sensitive) {
+        this.defaultHeaders.put(name, value);
+        if (sensitive) {
+            this.sensitiveHeaders.add(name);
+        }
+    }
+
+    private void addMountSource(String repository) {
+        this.mountSources.add(repository);
+    }
+
+    private void setBandwidthLimiter(BandwidthLimiter bandwidthLimiter) {
+        this.bandwidthLimiter = bandwidthLimiter;
+    }
+
+    private void setTransferBandwidthLimit(long transferBandwidthLimit) {
+        this.transferBandwidthLimit = transferBandwidthLimit;
+    }
+
+    private void setDownloadResumeAttempts(int downloadResumeAttempts) {
+        this.downloadResumeAttempts = downloadResumeAttempts;
+    }
+
+    private void setBlobCache(BlobCache blobCache) {
+        this.blobCache = blobCache;
+    }
+
+    private void setMaxManifestSize(long maxManifestSize) {
+        this.maxManifestSize = maxManifestSize;
+    }
+
+    private void setMaxBlobSize(long maxBlobSize) {
+        this.maxBlobSize = maxBlobSize;
+    }
+
     /**
      * Build the provider
      * @return The provider
      */
     private Registry build() {
         HttpClient.Builder clientBuilder = HttpClient.Builder.builder()
+                .withOperationTracer(operationTracer)
                 .withSkipTlsVerify(skipTlsVerify)
-                .withMaxRetries(maxRetries)
-                .withRetryDelay(retryDelayMs)
-                .withMaxRetryDelay(maxRetryDelayMs);
+                .withRetryPolicy(retryPolicy)
+                .withAnonymousFallback(anonymousFallback)
+                .withRequestTimeout(readTimeout)
+                .withHttp2(http2)
+                .withMaxRequestsPerHost(maxRequestsPerHost);
+        if (connectTimeout != null) {
+            clientBuilder = clientBuilder.withConnectTimeout(connectTimeout);
+        }
         if (caFilePath != null) {
             clientBuilder = clientBuilder.withCaFile(caFilePath);
         }
@@ -382,17 +808,110 @@ public final class Registry extends OCI<ContainerRef> {
         if (meterRegistry != null) {
             clientBuilder = clientBuilder.withMeterRegistry(meterRegistry);
         }
+        if (warningListener != null) {
+            clientBuilder = clientBuilder.withWarningListener(warningListener);
+        }
+        for (HttpInterceptor interceptor : interceptors) {
+            clientBuilder = clientBuilder.withInterceptor(interceptor);
+        }
+        if (userAgentSuffix != null) {
+            clientBuilder = clientBuilder.withUserAgentSuffix(userAgentSuffix);
+        }
+        for (Map.Entry<String, String> header : defaultHeaders.entrySet()) {
+            clientBuilder = sensitiveHeaders.contains(header.getKey())
+                    ? clientBuilder.withSensitiveDefaultHeader(header.getKey(), header.getValue())
+                    : clientBuilder.withDefaultHeader(header.getKey(), header.getValue());
+        }
+        if (trustManager != null) {
+            clientBuilder = clientBuilder.withTrustManager(trustManager);
+        }
+        if (keyManager != null) {
+            clientBuilder = clientBuilder.withKeyManager(keyManager);
+        }
+        if (clientCertificatePath != null && clientKeyPath != null) {
+            clientBuilder = clientBuilder.withClientCertificate(clientCertificatePath, clientKeyPath);
+        }
+        if (!hostnameVerification) {
+            clientBuilder = clientBuilder.withHostnameVerification(false);
+        }
+        List<String> skipTlsVerifyHosts = hostConfigs.entrySet().stream()
+                .filter(entry -> entry.getValue().isSkipTlsVerify())
+                .map(Map.Entry::getKey)
+                .toList();
+        if (!skipTlsVerifyHosts.isEmpty()) {
+            clientBuilder = clientBuilder.withSkipTlsVerifyHosts(skipTlsVerifyHosts);
+        }
+        List<String> http1Hosts = hostConfigs.entrySet().stream()
+                .filter(entry -> entry.getValue().isHttp1())
+                .map(Map.Entry::getKey)
+                .toList();
+        if (!http1Hosts.isEmpty()) {
+            clientBuilder = clientBuilder.withHttp1Hosts(http1Hosts);
+        }
+        if (transport != null) {
+            clientBuilder = clientBuilder.withTransport(transport);
+        }
+        if (proxyConfig != null) {
+            clientBuilder = clientBuilder.withProxy(proxyConfig);
+        }
         client = clientBuilder.build();
         if (executorService == null) {
-            executorService = Executors.newFixedThreadPool(maxConcurrentDownloads, r -> {
-                Thread t = new Thread(r);
-                t.setName("layer-transfer-worker-%d".formatted(t.getId()));
-                return t;
-            });
+            executorService = Executors.newFixedThreadPool(
+                    maxConcurrentDownloads,
+                    virtualThreads ? virtualThreadFactory("layer-transfer-worker-") : r -> {
+                        Thread t = new Thread(r);
+                        t.setName("layer-transfer-worker-%d".formatted(t.getId()));
+                        return t;
+                    });
+        }
+        if (asyncExecutorService == null) {
+            asyncExecutorService = defaultAsyncExecutorService();
         }
         return this;
     }
 
+    /**
+     * Create the default executor for asynchronous operations. Asynchronous operations block a thread for the
+     * whole transfer, so they run on virtual threads when available, otherwise on a bounded pool of daemon threads
+     * with a bounded queue
+     * @return The executor service
+     */
+    private ExecutorService defaultAsyncExecutorService() {
+        if (virtualThreads || Runtime.version().feature() >= 21) {
+            return Executors.newCachedThreadPool(virtualThreadFactory("async-worker-"));
+        }
+        ThreadPoolExecutor executor = new ThreadPoolExecutor(
+                DEFAULT_ASYNC_THREADS,
+                DEFAULT_ASYNC_THREADS,
+                60,
+                TimeUnit.SECONDS,
+                new LinkedBlockingQueue<>(DEFAULT_ASYNC_QUEUE_SIZE),
+                r -> {
+                    Thread t = new Thread(r);
+                    t.setName("async-worker-%d".formatted(t.getId()));
+                    t.setDaemon(true);
+                    return t;
+                });
+        executor.allowCoreThreadTimeOut(true);
+        return executor;
+    }
+
+    /**
+     * Create a factory of named virtual threads. Resolved reflectively since the SDK targets Java 17
+     * @param prefix The prefix of the thread names
+     * @return The thread factory
+     */
+    private static ThreadFactory virtualThreadFactory(String prefix) {
+        try {
+            Class<?> builderClass = Class.forName("java.lang.Thread$Builder");
+            Object builder = Thread.class.getMethod("ofVirtual").invoke(null);
+            builder = builderClass.getMethod("name", String.class, long.class).invoke(builder, prefix, 0L);
+            return (ThreadFactory) builderClass.getMethod("factory").invoke(builder);
+        } catch (ReflectiveOperationException e) {
+            throw new OrasException("Virtual threads require Java 21 or later", e);
+        }
+    }
+
     /**
      * Get the HTTP scheme depending on the insecure flag
      * @return The scheme
@@ -484,6 +1003,8 @@ public final class Registry extends OCI<ContainerRef> {
                 .withSkipTlsVerify(insecureMirror && skipTlsVerify)
                 .withTransportLocked(true)
                 .withAuthProvider(mirrorAuthProvider)
+                // Already enforced on the reference given to this registry
+                .withDigestPinning(false)
                 .build();
     }
 
@@ -501,62 +1022,296 @@ public final class Registry extends OCI<ContainerRef> {
     }
 
     @Override
-    public Tags getTags(ContainerRef containerRef) {
-        ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
-        if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).getTags(ref);
-        }
-        if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).getTags(ref);
-        }
-        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getTagsPath(this)));
-        HttpClient.ResponseWrapper<String> response = client.get(
-                uri, Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_JSON_MEDIA_TYPE), Scopes.of(ref), authProvider);
-        logResponse(response);
-        handleError(response);
-        return JsonUtils.fromJson(response.response(), Tags.class)
-                .withLast(getLastFromLink(response).orElse(null));
+    public OperationListener getOperationListener() {
+        return operationListener;
     }
 
     @Override
-    public Tags getTags(ContainerRef containerRef, int n, @Nullable String last) {
-        ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
-        if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).getTags(ref);
-        }
-        if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).getTags(ref);
-        }
-        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getTagsPath(this, n, last)));
-        HttpClient.ResponseWrapper<String> response = client.get(
-                uri, Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_JSON_MEDIA_TYPE), Scopes.of(ref), authProvider);
-        logResponse(response);
-        handleError(response);
-        return JsonUtils.fromJson(response.response(), Tags.class)
-                .withLast(getLastFromLink(response).orElse(null));
+    public OperationTracer getOperationTracer() {
+        return operationTracer;
     }
 
-    @Override
+    /**
+     * Get the executor service used for asynchronous operations
+     * @return The executor service
+     */
+    public ExecutorService getAsyncExecutorService() {
+        return asyncExecutorService;
+    }
+
+    /**
+     * Push an artifact asynchronously. Cancelling the future cancels the push. The push blocks a thread of the
+     * {@link #getAsyncExecutorService() async executor service} until it completes
+     * @param containerRef The container
+     * @param paths The paths
+     * @return A future completed with the manifest or exceptionally with an {@link OrasException}
+     */
+    public CompletableFuture<Manifest> pushArtifactAsync(ContainerRef containerRef, LocalPath... paths) {
+        return pushArtifactAsync(
+                containerRef,
+                ArtifactType.unknown(),
+                Annotations.empty(),
+                Config.empty(),
+                PushOptions.defaults(),
+                paths);
+    }
+
+    /**
+     * Push an artifact asynchronously. Cancelling the future cancels the push, like the cancellation token
+     * of the options. The push blocks a thread of the {@link #getAsyncExecutorService() async executor service}
+     * until it completes
+     * @param containerRef The container
+     * @param artifactType The artifact type
+     * @param annotations The annotations
+     * @param config The config
+     * @param options The push options
+     * @param paths The paths
+     * @return A future completed with the manifest or exceptionally with an {@link OrasException}
+     */
+    public CompletableFuture<Manifest> pushArtifactAsync(
+            ContainerRef containerRef,
+            ArtifactType artifactType,
+            Annotations annotations,
+            @Nullable Config config,
+            PushOptions options,
+            LocalPath... paths) {
+        CancellationToken cancellation =
+                options.cancellation() != null ? options.cancellation() : CancellationToken.create();
+        PushOptions cancellable = options.withCancellation(cancellation);
+        return cancellation.linkTo(
+                submitAsync(() -> pushArtifact(containerRef, artifactType, annotations, config, cancellable, paths)));
+    }
+
+    /**
+     * Pull an artifact asynchronously. Cancelling the future cancels the pull, like the cancellation token
+     * of the options. The pull blocks a thread of the {@link #getAsyncExecutorService() async executor service}
+     * until it completes
+     * @param containerRef The container
+     * @param path The path
+     * @param options The pull options
+     * @return A future completed when all layers are pulled or exceptionally with an {@link OrasException}
+     */
+    public CompletableFuture<Void> pullArtifactAsync(ContainerRef containerRef, Path path, PullOptions options) {
+        CancellationToken cancellation =
+                options.cancellation() != null ? options.cancellation() : CancellationToken.create();
+        PullOptions cancellable = options.withCancellation(cancellation);
+        return cancellation.linkTo(submitAsync(() -> {
+            pullArtifact(containerRef, path, cancellable);
+            return null;
+        }));
+    }
+
+    /**
+     * Push a blob from file asynchronously, blocking a thread of the async executor service until it completes
+     * @param containerRef The container
+     * @param blob The blob
+     * @return A future completed with the layer or exceptionally with an {@link OrasException}
+     */
+    public CompletableFuture<Layer> pushBlobAsync(ContainerRef containerRef, Path blob) {
+        return submitAsync(() -> pushBlob(containerRef, blob));
+    }
+
+    /**
+     * Push a blob from data asynchronously, blocking a thread of the async executor service until it completes
+     * @param containerRef The container
+     * @param data The data
+     * @return A future completed with the layer or exceptionally with an {@link OrasException}
+     */
+    public CompletableFuture<Layer> pushBlobAsync(ContainerRef containerRef, byte[] data) {
+        return submitAsync(() -> pushBlob(containerRef, data));
+    }
+
+    /**
+     * Get a manifest asynchronously, blocking a thread of the async executor service until it completes
+     * @param containerRef The container
+     * @return A future completed with the manifest or exceptionally with an {@link OrasException}
+     */
+    public CompletableFuture<Manifest> getManifestAsync(ContainerRef containerRef) {
+        return submitAsync(() -> getManifest(containerRef));
+    }
+
+    /**
+     * Run a blocking operation on the async executor service
+     * @param operation The operation
+     * @param <R> The result type
+     * @return A future completed with the result, or exceptionally with an {@link OrasException} if the executor
+     * rejects the operation
+     */
+    private <R> CompletableFuture<R> submitAsync(Supplier<R> operation) {
+        try {
+            return CompletableFuture.supplyAsync(operation, asyncExecutorService);
+        } catch (RejectedExecutionException e) {
+            return CompletableFuture.failedFuture(new OrasException("Too many asynchronous operations pending", e));
+        }
+    }
+
+    @Override
+    public Tags getTags(ContainerRef containerRef) {
+        return getTagsPage(containerRef, null, null);
+    }
+
+    @Override
+    public Tags getTags(ContainerRef containerRef, int n, @Nullable String last) {
+        return getTagsPage(containerRef, n, last);
+    }
+
+    /**
+     * List all the tags of a repository, lazily following the pagination {@code Link} headers
+     * @param containerRef The container
+     * @return A lazy stream of tags. Pages are only fetched when consumed
+     */
+    public Stream<String> listTags(ContainerRef containerRef) {
+        return listTags(containerRef, null, null);
+    }
+
+    /**
+     * List the tags of a repository, lazily following the pagination {@code Link} headers
+     * @param containerRef The container
+     * @param n The optional page size hint sent to the registry
+     * @param last The optional tag to start after
+     * @return A lazy stream of tags. Pages are only fetched when consumed
+     */
+    public Stream<String> listTags(ContainerRef containerRef, @Nullable Integer n, @Nullable String last) {
+        return paginate(last, cursor -> {
+            Tags tags = getTagsPage(containerRef, n, cursor);
+            return new Page(tags.tags(), tags.last());
+        });
+    }
+
+    /**
+     * List all the repositories of the registry catalog, lazily following the pagination {@code Link} headers.
+     * Not all registries expose the catalog endpoint
+     * @return A lazy stream of repositories. Pages are only fetched when consumed
+     */
+    public Stream<String> listRepositories() {
+        return listRepositories(null, null, null);
+    }
+
+    /**
+     * List the repositories of the registry catalog, lazily following the pagination {@code Link} headers.
+     * Not all registries expose the catalog endpoint
+     * @param n The optional page size hint sent to the registry
+     * @param last The optional repository to start after
+     * @param namespace The optional namespace to filter on. For example {@code team} keeps {@code team/app}
+     *                  and {@code team/sub/app}
+     * @return A lazy stream of repositories. Pages are only fetched when consumed
+     */
+    public Stream<String> listRepositories(@Nullable Integer n, @Nullable String last, @Nullable String namespace) {
+        Stream<String> repositories = paginate(last, cursor -> {
+            Repositories page = getRepositoriesPage(n, cursor);
+            return new Page(page.repositories(), page.last());
+        });
+        if (namespace == null) {
+            return repositories;
+        }
+        String prefix = namespace.endsWith("/") ? namespace : namespace + "/";
+        return repositories.filter(repository -> repository.startsWith(prefix));
+    }
+
+    /**
+     * A page of a paginated listing
+     * @param items The items of the page
+     * @param last The cursor of the next page or null if this is the last page
+     */
+    private record Page(List<String> items, @Nullable String last) {}
+
+    /**
+     * Lazily iterate over paginated results
+     * @param last The optional cursor to start after
+     * @param fetch Function fetching the page after the given cursor
+     * @return A lazy stream of items
+     */
+    private Stream<String> paginate(@Nullable String last, Function<@Nullable String, Page> fetch) {
+        Iterator<String> iterator = new Iterator<>() {
+            private Iterator<String> page = Collections.emptyIterator();
+            private @Nullable String next = last;
+            private boolean started;
+
+            @Override
+            public boolean hasNext() {
+                while (!page.hasNext() && (!started || next != null)) {
+                    String previous = next;
+                    Page result = fetch.apply(next);
+                    started = true;
+                    page = result.items().iterator();
+                    next = result.last();
+                    // Stop on misbehaving registries returning the same cursor
+                    if (next != null && next.equals(previous)) {
+                        LOG.warn("Registry returned the same pagination cursor '{}', stopping", next);
+                        next = null;
+                    }
+                }
+                return page.hasNext();
+            }
+
+            @Override
+            public String next() {
+                if (!hasNext()) {
+                    throw new NoSuchElementException();
+                }
+                return page.next();
+            }
+        };
+        return StreamSupport.stream(
+                Spliterators.spliteratorUnknownSize(iterator, Spliterator.ORDERED | Spliterator.NONNULL), false);
+    }
+
+    /**
+     * Get a single page of tags
+     * @param containerRef The container
+     * @param n The optional page size
+     * @param last The optional tag to start after
+     * @return The tags, with the cursor of the next page if any
+     */
+    private Tags getTagsPage(ContainerRef containerRef, @Nullable Integer n, @Nullable String last) {
+        ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
+        if (ref.isInsecure(this) && !this.isInsecure()) {
+            return copyForNewTransport(ref.getRegistry(), true).getTagsPage(ref, n, last);
+        }
+        if (!ref.isInsecure(this) && this.isInsecure()) {
+            return copyForNewTransport(ref.getRegistry(), false).getTagsPage(ref, n, last);
+        }
+        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getTagsPath(this, n, last)));
+        HttpClient.ResponseWrapper<String> response = client.get(
+                uri, Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_JSON_MEDIA_TYPE), Scopes.of(ref), authProvider);
+        logResponse(response);
+        handleError(response);
+        return JsonUtils.fromJson(response.response(), Tags.class)
+                .withLast(getLastFromLink(response).orElse(null));
+    }
+
+    @Override
     public Repositories getRepositories() {
+        return getRepositoriesPage(null, null);
+    }
+
+    /**
+     * Get a single page of the registry catalog
+     * @param n The optional page size
+     * @param last The optional repository to start after
+     * @return The repositories, with the cursor of the next page if any
+     */
+    private Repositories getRepositoriesPage(@Nullable Integer n, @Nullable String last) {
         if (registry != null
                 && getRegistriesConf()
                         .isInsecure(this, ContainerRef.parse(registry).forRegistry(registry))
                 && !this.isInsecure()) {
-            return asInsecure().getRepositories();
+            return asInsecure().getRepositoriesPage(n, last);
         }
         if (registry != null
                 && !getRegistriesConf()
                         .isInsecure(this, ContainerRef.parse(registry).forRegistry(registry))
                 && this.isInsecure()) {
-            return asSecure().getRepositories();
+            return asSecure().getRepositoriesPage(n, last);
         }
         ContainerRef ref = ContainerRef.parse("default").forRegistry(this);
-        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getRepositoriesPath(this)));
+        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getRepositoriesPath(this, n, last)));
         HttpClient.ResponseWrapper<String> response = client.get(
                 uri, Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_JSON_MEDIA_TYPE), Scopes.of(ref), authProvider);
         logResponse(response);
         handleError(response);
-        return JsonUtils.fromJson(response.response(), Repositories.class);
+        return JsonUtils.fromJson(response.response(), Repositories.class)
+                .withLast(getLastFromLink(response).orElse(null));
     }
 
     @Override
@@ -572,16 +1327,130 @@ public final class Registry extends OCI<ContainerRef> {
             return copyForNewTransport(ref.getRegistry(), false).getReferrers(ref, artifactType);
         }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getReferrersPath(this, artifactType)));
+        List<ManifestDescriptor> manifests = new ArrayList<>();
+        Set<URI> visited = new HashSet<>();
+        while (uri != null && visited.add(uri)) {
+            HttpClient.ResponseWrapper<String> response = client.get(
+                    uri,
+                    Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE),
+                    Scopes.of(ref),
+                    authProvider,
+                    maxManifestSize);
+            logResponse(response);
+            // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#unavailable-referrers-api
+            if (response.statusCode() == 404 && visited.size() == 1) {
+                LOG.debug("Referrers API not supported, falling back to referrers tag schema");
+                return getReferrersFromTagSchema(ref, artifactType);
+            }
+            handleError(response);
+            Referrers page = JsonUtils.fromJson(response.response(), Referrers.class);
+            if (page.getManifests() != null) {
+                // Filter client side if the registry ignored the artifact type filter
+                if (artifactType != null && !isArtifactTypeFilterApplied(response)) {
+                    LOG.debug("Registry didn't apply artifact type filter, filtering referrers client side");
+                    manifests.addAll(filterReferrers(page.getManifests(), artifactType));
+                } else {
+                    manifests.addAll(page.getManifests());
+                }
+            }
+            uri = getNextFromLink(uri, response).orElse(null);
+        }
+        return Referrers.from(manifests);
+    }
+
+    /**
+     * Get referrers from the fallback index stored under the referrers tag schema
+     * @param ref The container ref with digest
+     * @param artifactType The optional artifact type to filter on
+     * @return The referrers
+     */
+    private Referrers getReferrersFromTagSchema(ContainerRef ref, @Nullable ArtifactType artifactType) {
+        Index index = getReferrersIndex(ref.withReferrersTag());
+        if (index == null) {
+            return Referrers.from(List.of());
+        }
+        return Referrers.from(filterReferrers(index.getManifests(), artifactType));
+    }
+
+    /**
+     * Check if the registry applied the artifact type filter from the {@value Const#OCI_FILTERS_APPLIED_HEADER} header
+     * @param response The referrers response
+     * @return True if the artifact type filter was applied
+     */
+    private boolean isArtifactTypeFilterApplied(HttpClient.ResponseWrapper<String> response) {
+        String filters = response.headers().get(Const.OCI_FILTERS_APPLIED_HEADER.toLowerCase());
+        if (filters == null) {
+            return false;
+        }
+        return Arrays.stream(filters.split(","))
+                .map(String::trim)
+                .anyMatch(Const.REFERRERS_FILTER_ARTIFACT_TYPE::equals);
+    }
+
+    /**
+     * Filter the referrers by artifact type
+     * @param manifests The referrers
+     * @param artifactType The optional artifact type to filter on
+     * @return The filtered referrers
+     */
+    private static List<ManifestDescriptor> filterReferrers(
+            List<ManifestDescriptor> manifests, @Nullable ArtifactType artifactType) {
+        return manifests.stream()
+                .filter(descriptor -> artifactType == null
+                        || artifactType.getMediaType().equals(descriptor.getArtifactType()))
+                .toList();
+    }
+
+    /**
+     * Get the fallback referrers index for the given referrers tag
+     * @param tagRef The container ref with the referrers tag
+     * @return The index or null if the tag doesn't exist
+     */
+    private @Nullable Index getReferrersIndex(ContainerRef tagRef) {
+        URI uri = URI.create("%s://%s".formatted(getScheme(), tagRef.getManifestsPath(this)));
         HttpClient.ResponseWrapper<String> response = client.get(
-                uri, Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE), Scopes.of(ref), authProvider);
+                uri,
+                Map.of(Const.ACCEPT_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE),
+                Scopes.of(tagRef),
+                authProvider,
+                maxManifestSize);
         logResponse(response);
+        if (response.statusCode() == 404) {
+            return null;
+        }
         handleError(response);
-        return JsonUtils.fromJson(response.response(), Referrers.class);
+        return Index.fromJson(response.response());
+    }
+
+    /**
+     * Add the given manifest to the fallback referrers index of its subject
+     * @param ref The container ref of the pushed manifest
+     * @param manifest The pushed manifest
+     */
+    private void updateReferrersIndex(ContainerRef ref, Manifest manifest) {
+        Subject subject = Objects.requireNonNull(manifest.getSubject());
+        ContainerRef tagRef = ref.withDigest(subject.getDigest()).withReferrersTag();
+        ManifestDescriptor descriptor = manifest.getDescriptor()
+                .withArtifactType(manifest.getArtifactTypeAsString())
+                .withAnnotations(manifest.getAnnotations().isEmpty() ? null : manifest.getAnnotations());
+        Index index = getReferrersIndex(tagRef);
+        if (index == null) {
+            index = Index.fromManifests(List.of());
+        }
+        if (index.getManifests().stream().anyMatch(m -> m.getDigest().equals(descriptor.getDigest()))) {
+            LOG.debug("Manifest {} already present in referrers index {}", descriptor.getDigest(), tagRef);
+            return;
+        }
+        LOG.debug("Updating referrers index {} with manifest {}", tagRef, descriptor.getDigest());
+        // The fallback index is internal, so it is never signed
+        pushIndex(tagRef, index.withNewManifests(descriptor), false);
     }
 
     /**
-     * Delete a manifest
+     * Delete a manifest. A tag reference is first resolved to its digest, so every tag pointing to the
+     * manifest is removed. Use {@link #deleteTag(ContainerRef)} to only remove the tag.
      * @param containerRef The artifact
+     * @throws OperationNotSupportedException If the registry doesn't allow deletion
      */
     public void deleteManifest(ContainerRef containerRef) {
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
@@ -593,14 +1462,97 @@ public final class Registry extends OCI<ContainerRef> {
             asSecure().deleteManifest(containerRef);
             return;
         }
+        if (ref.getDigest() == null) {
+            ref = ref.withDigest(resolveManifestDigest(ref));
+        }
+        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
+        HttpClient.ResponseWrapper<String> response = client.delete(uri, Map.of(), Scopes.of(ref), authProvider);
+        logResponse(response);
+        handleDeleteError("delete manifest", response);
+        resolveCache.invalidateDigest(ref.getDigest());
+    }
+
+    /**
+     * Delete a tag without deleting the manifest it points to.
+     * Tag deletion is optional in the distribution spec and not supported by all registries.
+     * @param containerRef The tag
+     * @throws OperationNotSupportedException If the registry doesn't support tag deletion
+     */
+    public void deleteTag(ContainerRef containerRef) {
+        if (containerRef.getDigest() != null || containerRef.getTag() == null) {
+            throw new OrasException("Tag without digest is required to delete tag");
+        }
+        ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
+        if (ref.isInsecure(this) && !this.isInsecure()) {
+            asInsecure().deleteTag(containerRef);
+            return;
+        }
+        if (!ref.isInsecure(this) && this.isInsecure()) {
+            asSecure().deleteTag(containerRef);
+            return;
+        }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
         HttpClient.ResponseWrapper<String> response = client.delete(uri, Map.of(), Scopes.of(ref), authProvider);
         logResponse(response);
+        handleDeleteError("delete tag", response);
+        invalidateResolveCache(ref);
+    }
+
+    /**
+     * Resolve the digest of a manifest or index using a HEAD request
+     * @param ref The container ref
+     * @return The digest
+     */
+    private String resolveManifestDigest(ContainerRef ref) {
+        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
+        HttpClient.ResponseWrapper<String> response =
+                client.head(uri, Map.of(Const.ACCEPT_HEADER, Const.MANIFEST_ACCEPT_TYPE), Scopes.of(ref), authProvider);
+        logResponse(response);
         handleError(response);
+        String digest = validateDockerContentDigest(response.headers());
+        if (digest == null) {
+            throw new OrasException("Unable to resolve digest of %s".formatted(ref));
+        }
+        return digest;
     }
 
     @Override
     public Manifest pushManifest(ContainerRef containerRef, Manifest manifest) {
+        return pushManifestWithResult(containerRef, manifest).manifest();
+    }
+
+    /**
+     * Result of a manifest push
+     * @param manifest The pushed manifest
+     * @param subjectProcessed True if the manifest has a subject and the registry returned the {@code OCI-Subject}
+     *                         header. False if the subject was not processed by the registry and the referrers tag
+     *                         schema was updated by the client instead
+     */
+    public record PushManifestResult(Manifest manifest, boolean subjectProcessed) {}
+
+    /**
+     * Strategy used to push a blob from a stream of unknown length
+     */
+    public enum UnknownSizeStrategy {
+
+        /**
+         * Stream the blob through a chunked upload session, computing the digest on the fly
+         */
+        CHUNKED,
+
+        /**
+         * Spill the stream to a temporary file, then push the file
+         */
+        TEMP_FILE,
+    }
+
+    /**
+     * Push a manifest and return whether the registry processed its subject
+     * @param containerRef The container
+     * @param manifest The manifest
+     * @return The push result
+     */
+    public PushManifestResult pushManifestWithResult(ContainerRef containerRef, Manifest manifest) {
 
         Map<String, String> annotations = manifest.getAnnotations();
 
@@ -614,36 +1566,128 @@ public final class Registry extends OCI<ContainerRef> {
         }
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).pushManifest(ref, manifest);
+            return copyForNewTransport(ref.getRegistry(), true).pushManifestWithResult(ref, manifest);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).pushManifest(ref, manifest);
+            return copyForNewTransport(ref.getRegistry(), false).pushManifestWithResult(ref, manifest);
         }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
         byte[] manifestData = manifest.getJson() != null
                 ? manifest.getJson().getBytes()
                 : manifest.toJson().getBytes();
         LOG.debug("Manifest data to push: {}", new String(manifestData, StandardCharsets.UTF_8));
+        String mediaType =
+                manifest.getMediaType() != null ? manifest.getMediaType() : Const.DEFAULT_MANIFEST_MEDIA_TYPE;
         HttpClient.ResponseWrapper<String> response = client.put(
                 uri,
                 manifestData,
-                Map.of(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE),
+                Map.of(Const.CONTENT_TYPE_HEADER, mediaType),
                 Scopes.of(ref),
                 authProvider);
         logResponse(response);
         handleError(response);
+        invalidateResolveCache(ref);
+        Manifest pushed = readManifest(ref);
+        boolean subjectProcessed = false;
         if (manifest.getSubject() != null) {
             // https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pushing-manifests-with-subject
-            if (!response.headers().containsKey(Const.OCI_SUBJECT_HEADER.toLowerCase())) {
-                throw new OrasException(
-                        "Subject was set on manifest but not OCI subject header was returned. Legacy flow not implemented");
+            subjectProcessed = response.headers().containsKey(Const.OCI_SUBJECT_HEADER.toLowerCase());
+            if (!subjectProcessed) {
+                LOG.debug("No OCI subject header returned, updating referrers tag schema");
+                updateReferrersIndex(ref, pushed);
             }
         }
-        return getManifest(ref);
+        operationListener.onManifestPushed(ref, pushed);
+        signArtifact(ref, pushed.getDescriptor(), pushed.getArtifactTypeAsString());
+        return new PushManifestResult(pushed, subjectProcessed);
+    }
+
+    /**
+     * Push a manifest once and tag it with all the given tags.
+     * The manifest is uploaded under the first tag, then the same bytes are put under each additional tag
+     * so that all tags resolve to the same digest
+     * @param containerRef The container. Must not contain a digest
+     * @param manifest The manifest
+     * @param tags The tags. Must not be empty
+     * @return The created references, one per tag
+     */
+    public List<ContainerRef> pushManifest(ContainerRef containerRef, Manifest manifest, List<String> tags) {
+        if (tags.isEmpty()) {
+            throw new OrasException("At least one tag is required to push %s".formatted(containerRef));
+        }
+        if (containerRef.getDigest() != null) {
+            throw new OrasException("Cannot tag a digest reference %s".formatted(containerRef));
+        }
+        List<String> uniqueTags = List.copyOf(new LinkedHashSet<>(tags));
+        ContainerRef firstRef = containerRef.withTag(uniqueTags.get(0));
+        Manifest pushed = pushManifest(firstRef, manifest);
+        byte[] manifestData = pushed.getJson().getBytes(StandardCharsets.UTF_8);
+        String mediaType = pushed.getMediaType() != null ? pushed.getMediaType() : Const.DEFAULT_MANIFEST_MEDIA_TYPE;
+        List<ContainerRef> refs = new ArrayList<>();
+        refs.add(firstRef);
+        for (String tag : uniqueTags.subList(1, uniqueTags.size())) {
+            ContainerRef tagRef = containerRef.withTag(tag);
+            putManifestData(tagRef, manifestData, mediaType);
+            operationListener.onManifestPushed(tagRef, pushed);
+            refs.add(tagRef);
+        }
+        return refs;
+    }
+
+    /**
+     * Tag an existing manifest or index with a new tag, without transferring any blob.
+     * The manifest bytes are resolved and put unchanged under the new tag in the same repository
+     * @param existing The reference of the existing manifest, by tag or digest
+     * @param newTag The new tag
+     * @return The new tag reference
+     */
+    public ContainerRef tag(ContainerRef existing, String newTag) {
+        Descriptor descriptor = getDescriptor(existing);
+        ContainerRef tagRef = existing.withoutDigest().withTag(newTag);
+        String mediaType =
+                descriptor.getMediaType() != null ? descriptor.getMediaType() : Const.DEFAULT_MANIFEST_MEDIA_TYPE;
+        LOG.debug("Tagging {} with digest {} as {}", existing, descriptor.getDigest(), tagRef);
+        putManifestData(tagRef, descriptor.getJson().getBytes(StandardCharsets.UTF_8), mediaType);
+        return tagRef;
+    }
+
+    /**
+     * Put raw manifest bytes under the given reference without any modification
+     * @param containerRef The container
+     * @param manifestData The manifest bytes
+     * @param mediaType The media type of the manifest
+     */
+    private void putManifestData(ContainerRef containerRef, byte[] manifestData, String mediaType) {
+        ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
+        if (ref.isInsecure(this) && !this.isInsecure()) {
+            copyForNewTransport(ref.getRegistry(), true).putManifestData(ref, manifestData, mediaType);
+            return;
+        }
+        if (!ref.isInsecure(this) && this.isInsecure()) {
+            copyForNewTransport(ref.getRegistry(), false).putManifestData(ref, manifestData, mediaType);
+            return;
+        }
+        URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
+        HttpClient.ResponseWrapper<String> response = client.put(
+                uri, manifestData, Map.of(Const.CONTENT_TYPE_HEADER, mediaType), Scopes.of(ref), authProvider);
+        logResponse(response);
+        handleError(response);
+        invalidateResolveCache(ref);
     }
 
     @Override
     public Index pushIndex(ContainerRef containerRef, Index index) {
+        return pushIndex(containerRef, index, true);
+    }
+
+    /**
+     * Push an index
+     * @param containerRef The container ref
+     * @param index The index
+     * @param sign Whether the index is signed with the configured signers
+     * @return The pushed index
+     */
+    private Index pushIndex(ContainerRef containerRef, Index index, boolean sign) {
 
         Map<String, String> annotations = index.getAnnotations();
 
@@ -658,28 +1702,36 @@ public final class Registry extends OCI<ContainerRef> {
 
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).pushIndex(ref, index);
+            return copyForNewTransport(ref.getRegistry(), true).pushIndex(ref, index, sign);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).pushIndex(ref, index);
+            return copyForNewTransport(ref.getRegistry(), false).pushIndex(ref, index, sign);
         }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
         byte[] indexData = JsonUtils.toJson(index).getBytes();
         LOG.debug("Index data to push: {}", new String(indexData, StandardCharsets.UTF_8));
+        String mediaType = index.getMediaType() != null ? index.getMediaType() : Const.DEFAULT_INDEX_MEDIA_TYPE;
         HttpClient.ResponseWrapper<String> response = client.put(
                 uri,
                 indexData,
-                Map.of(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE),
+                Map.of(Const.CONTENT_TYPE_HEADER, mediaType),
                 Scopes.of(ref),
                 authProvider);
         logResponse(response);
         handleError(response);
-        return getIndex(ref);
+        invalidateResolveCache(ref);
+        Index pushed = readIndex(ref);
+        operationListener.onIndexPushed(ref, pushed);
+        if (sign) {
+            signArtifact(ref, pushed.getDescriptor(), pushed.getArtifactTypeAsString());
+        }
+        return pushed;
     }
 
     /**
      * Delete a blob
      * @param containerRef The container
+     * @throws OperationNotSupportedException If the registry doesn't allow deletion
      */
     public void deleteBlob(ContainerRef containerRef) {
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
@@ -694,46 +1746,79 @@ public final class Registry extends OCI<ContainerRef> {
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getBlobsPath(this)));
         HttpClient.ResponseWrapper<String> response = client.delete(uri, Map.of(), Scopes.of(ref), authProvider);
         logResponse(response);
-        handleError(response);
+        handleDeleteError("delete blob", response);
     }
 
     @Override
     public void pullArtifact(ContainerRef containerRef, Path path, PullOptions options) {
-        withMirrorFallback(containerRef, (reg, ref) -> {
-            reg.pullArtifactDirect(ref, path, options);
-            return null;
-        });
+        pullArtifactWithDigest(containerRef, path, options);
+    }
+
+    /**
+     * Pull an artifact and return the digest the reference resolved to, so it can be recorded or compared
+     * against an expected value. Use {@link PullOptions#withExpectedDigest(String)} to fail the pull on mismatch.
+     * @param containerRef The container
+     * @param path The path to save the artifact
+     * @param options The pull options
+     * @return The resolved digest of the manifest or index
+     */
+    public String pullArtifactWithDigest(ContainerRef containerRef, Path path, PullOptions options) {
+        checkDigestPinned(containerRef);
+        return instrument(
+                OperationTracer.PULL,
+                containerRef,
+                () -> withMirrorFallback(containerRef, (reg, ref) -> reg.pullArtifactDirect(ref, path, options)));
     }
 
-    private void pullArtifactDirect(ContainerRef containerRef, Path path, PullOptions options) {
+    private String pullArtifactDirect(ContainerRef containerRef, Path path, PullOptions options) {
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            asInsecure().pullArtifactDirect(containerRef, path, options);
-            return;
+            return asInsecure().pullArtifactDirect(containerRef, path, options);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            asSecure().pullArtifactDirect(containerRef, path, options);
-            return;
+            return asSecure().pullArtifactDirect(containerRef, path, options);
         }
+        // Resolve the digest before writing anything to the destination
+        Map<String, String> headers = getResolvedHeaders(ref).headers();
+        String contentType = headers.get(Const.CONTENT_TYPE_HEADER.toLowerCase());
+        String digest = ref.getDigest() != null ? ref.getDigest() : validateDockerContentDigest(headers);
+        if (digest == null) {
+            digest = isIndexMediaType(contentType)
+                    ? getIndex(ref).getDescriptor().getDigest()
+                    : getManifest(ref).getDescriptor().getDigest();
+        }
+        options.checkExpectedDigest(ref, digest);
+        // Select the manifest matching the platform
+        ContainerRef pullRef = ref;
+        if (options.platform() != null && isIndexMediaType(contentType)) {
+            ManifestDescriptor selected = selectPlatform(getIndex(ref), options);
+            if (selected != null) {
+                pullRef = ref.withDigest(selected.getDigest());
+                contentType = selected.getMediaType();
+            }
+        }
+        // Verify before writing anything to the destination
+        verifyArtifact(pullRef, contentType);
         // Only collect layer that are files
-        String contentType = getContentType(ref);
-        List<Layer> layers = collectLayers(ref, contentType, false);
+        List<Layer> layers = collectLayers(pullRef, contentType, false);
         if (layers.isEmpty()
                 || layers.stream().noneMatch(layer -> layer.getAnnotations().containsKey(Const.ANNOTATION_TITLE))) {
             LOG.info("Skipped pulling layers without file name in '{}'", Const.ANNOTATION_TITLE);
-            return;
-        }
-        if (layers.stream().noneMatch(layer -> layer.getAnnotations().containsKey(Const.ANNOTATION_TITLE))) {
-            LOG.info("Skipped pulling artifact without file name in '{}'", Const.ANNOTATION_TITLE);
-            return;
+            return digest;
         }
         // Pull layers in parallel
-        CompletableFuture.allOf(layers.stream()
+        ContainerRef layerRef = pullRef;
+        runConcurrently(
+                getExecutorService(),
+                options.concurrency(),
+                layers.stream()
                         .filter(layer -> layer.getAnnotations().containsKey(Const.ANNOTATION_TITLE))
-                        .map(layer -> CompletableFuture.runAsync(
-                                () -> pullLayer(ref, layer, path, options.isOverwrite()), getExecutorService()))
-                        .toArray(CompletableFuture[]::new))
-                .join();
+                        .<Supplier<Layer>>map(layer -> () -> {
+                            pullLayer(layerRef, layer, path, options);
+                            return layer;
+                        })
+                        .toList());
+        return digest;
     }
 
     @Override
@@ -744,6 +1829,19 @@ public final class Registry extends OCI<ContainerRef> {
             @Nullable Config config,
             PushOptions options,
             LocalPath... paths) {
+        return instrument(
+                OperationTracer.PUSH,
+                containerRef,
+                () -> pushArtifactDirect(containerRef, artifactType, annotations, config, options, paths));
+    }
+
+    private Manifest pushArtifactDirect(
+            ContainerRef containerRef,
+            ArtifactType artifactType,
+            Annotations annotations,
+            @Nullable Config config,
+            PushOptions options,
+            LocalPath... paths) {
         Manifest manifest = Manifest.empty().withArtifactType(artifactType);
         Map<String, String> manifestAnnotations = new HashMap<>(annotations.manifestAnnotations());
         if (!manifestAnnotations.containsKey(Const.ANNOTATION_CREATED) && containerRef.getDigest() == null) {
@@ -753,6 +1851,8 @@ public final class Registry extends OCI<ContainerRef> {
         if (config != null) {
             config = config.withAnnotations(annotations);
             manifest = manifest.withConfig(config);
+        } else if (!annotations.configAnnotations().isEmpty()) {
+            config = Config.empty().withAnnotations(annotations);
         }
 
         // Push the config like any other blob
@@ -780,41 +1880,60 @@ public final class Registry extends OCI<ContainerRef> {
     @Override
     protected Layer doPushBlob(ContainerRef ref, Path blob, PushOptions options) {
         if (options.isChunked()) {
-            return pushBlobChunked(ref, blob, options.chunkSize());
+            return pushFileChunked(ref, blob, options.chunkSize(), options.cancellation());
         }
-        return super.doPushBlob(ref, blob, options);
+        return pushFile(ref, blob, Map.of(), options.cancellation());
+    }
+
+    /**
+     * Whether a blob of the given size is uploaded in chunks. Blobs fitting in a single chunk are uploaded
+     * monolithically, whether they are pushed from a file or a stream
+     * @param size The size of the blob
+     * @return True to upload the blob in chunks
+     */
+    private boolean isChunkedUpload(long size) {
+        return chunkSize > 0 && size > chunkSize;
     }
 
     @Override
     public Layer pushBlob(ContainerRef containerRef, Path blob, Map<String, String> annotations) {
-        String digest = containerRef.getAlgorithm().digest(blob);
+        return pushFile(containerRef, blob, annotations, null);
+    }
+
+    private Layer pushFile(
+            ContainerRef containerRef,
+            Path blob,
+            Map<String, String> annotations,
+            @Nullable CancellationToken cancellation) {
+        long size = blob.toFile().length();
+        if (isChunkedUpload(size)) {
+            return pushFileChunked(containerRef, blob, chunkSize, cancellation).withAnnotations(annotations);
+        }
+        String digest = getDigestAlgorithm(containerRef).digest(blob);
         LOG.debug("Digest: {}", digest);
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).pushBlob(ref, blob, annotations);
+            return copyForNewTransport(ref.getRegistry(), true).pushFile(ref, blob, annotations, cancellation);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).pushBlob(ref, blob, annotations);
+            return copyForNewTransport(ref.getRegistry(), false).pushFile(ref, blob, annotations, cancellation);
         }
         // This might not works with registries performing HEAD request
-        if (hasBlob(ref.withDigest(digest))) {
+        if (isBlobPresent(ref.withDigest(digest))) {
             LOG.info("Blob already exists: {}", digest);
-            return Layer.fromFile(blob, ref.getAlgorithm()).withAnnotations(annotations);
+            transferListener.onCompleted(digest, size);
+            return Layer.fromFile(blob, getDigestAlgorithm(ref)).withAnnotations(annotations);
         }
+        transferListener.onStarted(digest, size);
         URI uri = URI.create(
                 "%s://%s".formatted(getScheme(), ref.withDigest(digest).getBlobsUploadDigestPath(this)));
-        HttpClient.ResponseWrapper<String> response = client.upload(
-                "POST",
-                uri,
-                Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
-                blob,
-                Scopes.of(ref),
-                authProvider);
+        HttpClient.ResponseWrapper<String> response = uploadFile("POST", uri, ref, blob, digest, size, cancellation);
         logResponse(response);
 
         // Accepted single POST push
         if (response.statusCode() == 201) {
-            return Layer.fromFile(blob, ref.getAlgorithm()).withAnnotations(annotations);
+            transferListener.onCompleted(digest, size);
+            return blobPushed(ref, Layer.fromFile(blob, getDigestAlgorithm(ref)).withAnnotations(annotations));
         }
 
         // We need to push via PUT
@@ -825,17 +1944,15 @@ public final class Registry extends OCI<ContainerRef> {
                 location =
                         "%s://%s/%s".formatted(getScheme(), ref.getApiRegistry(this), location.replaceFirst("^/", ""));
             }
-            LOG.debug("Location header: {}", location);
+            LOG.debug("Location header: {}", LogUtils.redactUri(location));
 
             URI uploadURI = createLocationWithDigest(location, digest);
 
-            response = client.upload(
-                    "PUT",
-                    uploadURI,
-                    Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
-                    blob,
-                    Scopes.of(ref),
-                    authProvider);
+            try {
+                response = uploadFile("PUT", uploadURI, ref, blob, digest, size, cancellation);
+            } catch (OrasException e) {
+                throw abortUpload(ref, location, cancellation, e);
+            }
             if (response.statusCode() == 201) {
                 LOG.debug("Successful push: {}", response.response());
             } else {
@@ -844,7 +1961,48 @@ public final class Registry extends OCI<ContainerRef> {
         }
 
         handleError(response);
-        return Layer.fromFile(blob, containerRef.getAlgorithm()).withAnnotations(annotations);
+        transferListener.onCompleted(digest, size);
+        return blobPushed(
+                ref, Layer.fromFile(blob, getDigestAlgorithm(containerRef)).withAnnotations(annotations));
+    }
+
+    /**
+     * Upload a file and report the progress to the transfer listener
+     * @param method The method (POST or PUT)
+     * @param uri The URI
+     * @param ref The container ref
+     * @param blob The blob
+     * @param digest The digest of the blob
+     * @param size The size of the blob
+     * @param cancellation The cancellation token or null
+     * @return The response
+     */
+    private HttpClient.ResponseWrapper<String> uploadFile(
+            String method,
+            URI uri,
+            ContainerRef ref,
+            Path blob,
+            String digest,
+            long size,
+            @Nullable CancellationToken cancellation) {
+        return client.upload(
+                method,
+                uri,
+                size,
+                Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
+                () -> {
+                    try {
+                        return new CancellableInputStream(
+                                new ProgressInputStream(
+                                        throttle(Files.newInputStream(blob)), transferListener, digest, size),
+                                cancellation);
+                    } catch (IOException e) {
+                        throw new OrasException("Unable to upload file. File not found.", e);
+                    }
+                },
+                Scopes.of(ref),
+                authProvider,
+                true);
     }
 
     @Override
@@ -860,10 +2018,19 @@ public final class Registry extends OCI<ContainerRef> {
         if (!containerRef.isInsecure(this) && this.isInsecure()) {
             return copyForNewTransport(ref.getRegistry(), false).pushBlob(ref, size, stream, annotations);
         }
-        if (hasBlob(containerRef)) {
+        if (isChunkedUpload(size)) {
+            try (InputStream is = stream.get()) {
+                return pushBlobChunked(containerRef, is, size, chunkSize).withAnnotations(annotations);
+            } catch (IOException e) {
+                throw new OrasException("Failed to push blob", e);
+            }
+        }
+        if (isBlobPresent(containerRef)) {
             LOG.info("Blob already exists: {}", digest);
+            transferListener.onCompleted(digest, size);
             return Layer.fromDigest(digest, size).withAnnotations(annotations);
         }
+        transferListener.onStarted(digest, size);
         // Empty post without digest
         URI uri = URI.create("%s://%s".formatted(getScheme(), containerRef.getBlobsUploadPath(this)));
         HttpClient.ResponseWrapper<String> response = client.post(
@@ -881,17 +2048,22 @@ public final class Registry extends OCI<ContainerRef> {
         if (!location.startsWith("http") && !location.startsWith("https")) {
             location = "%s://%s/%s".formatted(getScheme(), ref.getApiRegistry(this), location.replaceFirst("^/", ""));
         }
-        LOG.debug("Location header: {}", location);
+        LOG.debug("Location header: {}", LogUtils.redactUri(location));
 
         URI uploadURI = createLocationWithDigest(location, digest);
 
-        response = client.upload(
-                uploadURI,
-                size,
-                Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
-                stream,
-                Scopes.of(containerRef),
-                authProvider);
+        try {
+            response = client.upload(
+                    uploadURI,
+                    size,
+                    Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
+                    () -> new CancellableInputStream(
+                            new ProgressInputStream(throttle(stream.get()), transferListener, digest, size), null),
+                    Scopes.of(containerRef),
+                    authProvider);
+        } catch (OrasException e) {
+            throw abortUpload(containerRef, location, null, e);
+        }
         logResponse(response);
         if (response.statusCode() == 201) {
             LOG.debug("Successful push: {}", response.response());
@@ -899,12 +2071,13 @@ public final class Registry extends OCI<ContainerRef> {
             throw new OrasException("Failed to push layer: %s".formatted(response.response()));
         }
         handleError(response);
-        return Layer.fromDigest(digest, size).withAnnotations(annotations);
+        transferListener.onCompleted(digest, size);
+        return blobPushed(containerRef, Layer.fromDigest(digest, size).withAnnotations(annotations));
     }
 
     @Override
     public Layer pushBlob(ContainerRef containerRef, byte[] data) {
-        String digest = containerRef.getAlgorithm().digest(data);
+        String digest = getDigestAlgorithm(containerRef).digest(data);
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
             return copyForNewTransport(ref.getRegistry(), true).pushBlob(ref, data);
@@ -915,10 +2088,12 @@ public final class Registry extends OCI<ContainerRef> {
         if (ref.getDigest() != null) {
             ensureDigest(ref, data);
         }
-        if (hasBlob(ref.withDigest(digest))) {
+        if (isBlobPresent(ref.withDigest(digest))) {
             LOG.info("Blob already exists: {}", digest);
-            return Layer.fromData(ref, data);
+            transferListener.onCompleted(digest, data.length);
+            return Layer.fromData(ref.withDigest(digest), data);
         }
+        transferListener.onStarted(digest, data.length);
         URI uri = URI.create(
                 "%s://%s".formatted(getScheme(), ref.withDigest(digest).getBlobsUploadDigestPath(this)));
         HttpClient.ResponseWrapper<String> response = client.post(
@@ -931,7 +2106,9 @@ public final class Registry extends OCI<ContainerRef> {
 
         // Accepted single POST push
         if (response.statusCode() == 201) {
-            return Layer.fromData(ref, data);
+            transferListener.onProgress(digest, data.length, data.length);
+            transferListener.onCompleted(digest, data.length);
+            return blobPushed(ref, Layer.fromData(ref.withDigest(digest), data));
         }
 
         // We need to push via PUT
@@ -945,7 +2122,7 @@ public final class Registry extends OCI<ContainerRef> {
 
             URI uploadURI = createLocationWithDigest(location, digest);
 
-            LOG.debug("Location header: {}", location);
+            LOG.debug("Location header: {}", LogUtils.redactUri(location));
             response = client.put(
                     uploadURI,
                     data,
@@ -960,7 +2137,65 @@ public final class Registry extends OCI<ContainerRef> {
         }
 
         handleError(response);
-        return Layer.fromData(ref, data);
+        transferListener.onProgress(digest, data.length, data.length);
+        transferListener.onCompleted(digest, data.length);
+        return blobPushed(ref, Layer.fromData(ref.withDigest(digest), data));
+    }
+
+    /**
+     * Push a blob from a stream of unknown length.
+     * With {@link UnknownSizeStrategy#CHUNKED} the stream is sent in chunks of the configured chunk size
+     * (or {@link PushOptions#DEFAULT_CHUNK_SIZE}) and the digest is computed while reading. The upload is only
+     * committed once the digest is known. If the ref has a digest, it must match the content, otherwise the upload
+     * session is deleted. The transfer is started with the digest of the ref, or an empty digest if not known yet.
+     * With {@link UnknownSizeStrategy#TEMP_FILE} the stream is first copied to a temporary file.
+     * @param containerRef The container reference
+     * @param input The input stream. Not closed by this method
+     * @return The layer
+     */
+    @Override
+    public Layer pushBlob(ContainerRef containerRef, InputStream input) {
+        if (unknownSizeStrategy == UnknownSizeStrategy.TEMP_FILE) {
+            return super.pushBlob(containerRef, input);
+        }
+        ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
+        if (ref.isInsecure(this) && !this.isInsecure()) {
+            return copyForNewTransport(ref.getRegistry(), true).pushBlob(ref, input);
+        }
+        if (!ref.isInsecure(this) && this.isInsecure()) {
+            return copyForNewTransport(ref.getRegistry(), false).pushBlob(ref, input);
+        }
+        DigestAlgorithm algorithm = getDigestAlgorithm(ref);
+        MessageDigest messageDigest = algorithm.newMessageDigest();
+        long maxChunkSize = chunkSize > 0 ? chunkSize : PushOptions.DEFAULT_CHUNK_SIZE;
+        byte[] buffer = new byte[(int) Math.min(maxChunkSize, Integer.MAX_VALUE)];
+        String location = initiateChunkedUpload(ref);
+        transferListener.onStarted(ref.getDigest() != null ? ref.getDigest() : "", -1);
+        long offset = 0;
+        try {
+            InputStream is = new CancellableInputStream(throttle(input), null);
+            int read;
+            while ((read = is.readNBytes(buffer, 0, buffer.length)) > 0) {
+                messageDigest.update(buffer, 0, read);
+                location = uploadChunk(ref, Arrays.copyOf(buffer, read), offset, location);
+                offset += read;
+                LOG.debug("Uploaded chunk {}-{} ({} bytes)", offset - read, offset - 1, read);
+            }
+        } catch (IOException e) {
+            throw abortUpload(
+                    ref, location, null, new OrasException("Failed to read blob stream for chunked upload", e));
+        } catch (OrasException e) {
+            throw abortUpload(ref, location, null, e);
+        }
+        String digest = algorithm.formatDigest(messageDigest.digest());
+        if (ref.getDigest() != null && !ref.getDigest().equals(digest)) {
+            DigestMismatchException failure = new DigestMismatchException(ref.getDigest(), digest);
+            deleteUploadSession(ref, location, failure);
+            throw failure;
+        }
+        finalizeChunkedUpload(ref, location, digest);
+        transferListener.onCompleted(digest, offset);
+        return blobPushed(ref, Layer.fromDigest(digest, offset));
     }
 
     /**
@@ -973,30 +2208,42 @@ public final class Registry extends OCI<ContainerRef> {
      * @throws OrasException if the upload fails at any stage.
      */
     public Layer pushBlobChunked(ContainerRef containerRef, Path blob, long chunkSize) {
+        return pushFileChunked(containerRef, blob, chunkSize, null);
+    }
+
+    private Layer pushFileChunked(
+            ContainerRef containerRef, Path blob, long chunkSize, @Nullable CancellationToken cancellation) {
         if (chunkSize <= 0) {
             throw new OrasException("chunkSize must be greater than 0");
         }
-        String digest = containerRef.getAlgorithm().digest(blob);
+        String digest = getDigestAlgorithm(containerRef).digest(blob);
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).pushBlobChunked(ref, blob, chunkSize);
+            return copyForNewTransport(ref.getRegistry(), true).pushFileChunked(ref, blob, chunkSize, cancellation);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).pushBlobChunked(ref, blob, chunkSize);
+            return copyForNewTransport(ref.getRegistry(), false).pushFileChunked(ref, blob, chunkSize, cancellation);
         }
-        if (hasBlob(ref.withDigest(digest))) {
+        long totalSize = blob.toFile().length();
+        if (isBlobPresent(ref.withDigest(digest))) {
             LOG.info("Blob already exists: {}", digest);
-            return Layer.fromFile(blob, ref.getAlgorithm());
+            transferListener.onCompleted(digest, totalSize);
+            return Layer.fromFile(blob, getDigestAlgorithm(ref));
         }
+        transferListener.onStarted(digest, totalSize);
         String location = initiateChunkedUpload(ref);
-        try (InputStream is = Files.newInputStream(blob)) {
-            long totalSize = Files.size(blob);
+        try (InputStream is = new CancellableInputStream(
+                new ProgressInputStream(throttle(Files.newInputStream(blob)), transferListener, digest, totalSize),
+                cancellation)) {
             location = uploadChunks(ref, is, totalSize, chunkSize, location);
         } catch (IOException e) {
             throw new OrasException("Failed to read blob for chunked upload: %s".formatted(blob), e);
+        } catch (OrasException e) {
+            throw abortUpload(ref, location, cancellation, e);
         }
         finalizeChunkedUpload(ref, location, digest);
-        return Layer.fromFile(blob, ref.getAlgorithm());
+        transferListener.onCompleted(digest, totalSize);
+        return blobPushed(ref, Layer.fromFile(blob, getDigestAlgorithm(ref)));
     }
 
     /**
@@ -1024,14 +2271,70 @@ public final class Registry extends OCI<ContainerRef> {
         if (!ref.isInsecure(this) && this.isInsecure()) {
             return copyForNewTransport(ref.getRegistry(), false).pushBlobChunked(ref, stream, totalSize, chunkSize);
         }
-        if (hasBlob(ref)) {
+        if (isBlobPresent(ref)) {
             LOG.info("Blob already exists: {}", digest);
+            transferListener.onCompleted(digest, totalSize);
             return Layer.fromDigest(digest, totalSize);
         }
+        transferListener.onStarted(digest, totalSize);
         String location = initiateChunkedUpload(ref);
-        location = uploadChunks(ref, stream, totalSize, chunkSize, location);
+        try {
+            location = uploadChunks(
+                    ref,
+                    new CancellableInputStream(
+                            new ProgressInputStream(throttle(stream), transferListener, digest, totalSize), null),
+                    totalSize,
+                    chunkSize,
+                    location);
+        } catch (OrasException e) {
+            throw abortUpload(ref, location, null, e);
+        }
         finalizeChunkedUpload(ref, location, digest);
-        return Layer.fromDigest(digest, totalSize);
+        transferListener.onCompleted(digest, totalSize);
+        return blobPushed(ref, Layer.fromDigest(digest, totalSize));
+    }
+
+    /**
+     * Abort the upload session on the registry if the upload failed because it was cancelled or interrupted
+     * @param ref The container ref
+     * @param location The upload session location
+     * @param cancellation The cancellation token or null
+     * @param failure The failure of the upload
+     * @return The exception to throw
+     */
+    private OrasException abortUpload(
+            ContainerRef ref, String location, @Nullable CancellationToken cancellation, OrasException failure) {
+        if (!CancellationToken.cancelled(cancellation) && !(failure instanceof OperationCancelledException)) {
+            return failure;
+        }
+        deleteUploadSession(ref, location, failure);
+        return failure instanceof OperationCancelledException
+                ? failure
+                : new OperationCancelledException("Upload cancelled", failure);
+    }
+
+    /**
+     * Delete an upload session so the registry doesn't keep the uploaded chunks
+     * @param ref The container reference
+     * @param location The location of the upload session
+     * @param failure The failure of the upload, errors deleting the session are added as suppressed
+     */
+    private void deleteUploadSession(ContainerRef ref, String location, OrasException failure) {
+        // Requests fail right away on an interrupted thread, restore the flag once the session is deleted
+        boolean interrupted = Thread.interrupted();
+        try {
+            HttpClient.ResponseWrapper<String> response =
+                    client.delete(URI.create(location), Map.of(), Scopes.of(ref), authProvider);
+            logResponse(response);
+            LOG.debug("Aborted upload session {}: status {}", LogUtils.redactUri(location), response.statusCode());
+        } catch (OrasException e) {
+            LOG.debug("Failed to abort upload session {}", LogUtils.redactUri(location), e);
+            failure.addSuppressed(e);
+        } finally {
+            if (interrupted) {
+                Thread.currentThread().interrupt();
+            }
+        }
     }
 
     private String initiateChunkedUpload(ContainerRef ref) {
@@ -1051,7 +2354,7 @@ public final class Registry extends OCI<ContainerRef> {
         if (!location.startsWith("http://") && !location.startsWith("https://")) {
             location = "%s://%s/%s".formatted(getScheme(), ref.getApiRegistry(this), location.replaceFirst("^/", ""));
         }
-        LOG.debug("Chunked upload session location: {}", location);
+        LOG.debug("Chunked upload session location: {}", LogUtils.redactUri(location));
         return location;
     }
 
@@ -1066,43 +2369,137 @@ public final class Registry extends OCI<ContainerRef> {
                 if (read == 0) {
                     break;
                 }
-                long rangeEnd = offset + read - 1;
-                String contentRange = "%d-%d".formatted(offset, rangeEnd);
                 final byte[] chunk = java.util.Arrays.copyOf(buffer, read);
-                URI patchUri = URI.create(location);
+                location = uploadChunk(ref, chunk, offset, location);
+                offset += read;
+                LOG.debug("Uploaded chunk {}-{} ({} bytes)", offset - read, offset - 1, read);
+            }
+        } catch (IOException e) {
+            throw new OrasException("Failed during chunked blob upload", e);
+        }
+        return location;
+    }
+
+    /**
+     * Upload a single chunk. If the PATCH fails, the upload session is queried for the last acknowledged
+     * offset and only the remaining bytes of the chunk are sent again, up to the configured max retries
+     * @param ref The container ref
+     * @param chunk The chunk data
+     * @param offset The offset of the chunk in the blob
+     * @param location The upload session location
+     * @return The upload session location to use for the next chunk
+     */
+    private String uploadChunk(ContainerRef ref, byte[] chunk, long offset, String location) {
+        int start = 0;
+        for (int attempt = 1; ; attempt++) {
+            final int from = start;
+            String contentRange = "%d-%d".formatted(offset + from, offset + chunk.length - 1);
+            OrasException failure;
+            try {
                 HttpClient.ResponseWrapper<String> patchResponse = client.patch(
-                        patchUri,
-                        read,
+                        URI.create(location),
+                        chunk.length - from,
                         Map.of(
                                 Const.CONTENT_TYPE_HEADER,
                                 Const.APPLICATION_OCTET_STREAM_HEADER_VALUE,
                                 Const.CONTENT_RANGE_HEADER,
                                 contentRange),
-                        () -> new java.io.ByteArrayInputStream(chunk),
+                        () -> new java.io.ByteArrayInputStream(chunk, from, chunk.length - from),
                         Scopes.of(ref),
                         authProvider);
                 logResponse(patchResponse);
-                if (patchResponse.statusCode() != 202) {
-                    throw new OrasException("Chunked upload PATCH failed for range %s: status %d"
-                            .formatted(contentRange, patchResponse.statusCode()));
-                }
-                // The registry MAY return a new location after each PATCH
-                String newLocation = patchResponse.headers().get(Const.LOCATION_HEADER.toLowerCase());
-                if (newLocation != null && !newLocation.isBlank()) {
-                    if (!newLocation.startsWith("http://") && !newLocation.startsWith("https://")) {
-                        newLocation = "%s://%s/%s"
-                                .formatted(getScheme(), ref.getApiRegistry(this), newLocation.replaceFirst("^/", ""));
-                    }
-                    location = newLocation;
-                    LOG.debug("Chunked upload location updated: {}", location);
+                if (patchResponse.statusCode() == 202) {
+                    // The registry MAY return a new location after each PATCH
+                    return getUploadLocation(ref, patchResponse, location);
                 }
-                offset += read;
-                LOG.debug("Uploaded chunk {}-{} ({} bytes)", offset - read, rangeEnd, read);
+                failure = new OrasException(
+                        patchResponse.statusCode(),
+                        "Chunked upload PATCH failed for range %s: status %d"
+                                .formatted(contentRange, patchResponse.statusCode()));
+            } catch (OrasException e) {
+                failure = e;
             }
-        } catch (IOException e) {
-            throw new OrasException("Failed during chunked blob upload", e);
+            if (attempt >= retryPolicy.getMaxAttempts()) {
+                throw failure;
+            }
+
+            // Find out how much of the chunk the registry already received
+            HttpClient.ResponseWrapper<String> status;
+            try {
+                status = getUploadStatus(ref, location);
+            } catch (OrasException e) {
+                failure.addSuppressed(e);
+                throw failure;
+            }
+            long acknowledged = getUploadOffset(status);
+            if (status.statusCode() != 204 || acknowledged < offset || acknowledged > offset + chunk.length) {
+                LOG.debug(
+                        "Cannot resume chunked upload: status {}, acknowledged offset {}",
+                        status.statusCode(),
+                        acknowledged);
+                throw failure;
+            }
+            location = getUploadLocation(ref, status, location);
+            if (acknowledged == offset + chunk.length) {
+                LOG.debug("Chunk {} already fully acknowledged by registry", contentRange);
+                return location;
+            }
+            LOG.info("Resuming chunked upload from offset {}", acknowledged);
+            start = (int) (acknowledged - offset);
         }
-        return location;
+    }
+
+    /**
+     * Query the status of an upload session
+     * @param ref The container ref
+     * @param location The upload session location
+     * @return The response
+     */
+    private HttpClient.ResponseWrapper<String> getUploadStatus(ContainerRef ref, String location) {
+        HttpClient.ResponseWrapper<String> response =
+                client.get(URI.create(location), Map.of(), Scopes.of(ref), authProvider);
+        logResponse(response);
+        return response;
+    }
+
+    /**
+     * Get the next offset to upload from the Range header of an upload status response (for example 0-1023)
+     * @param response The upload status response
+     * @return The offset of the next byte to upload
+     */
+    static long getUploadOffset(HttpClient.ResponseWrapper<String> response) {
+        String range = response.headers().get(Const.RANGE_HEADER.toLowerCase());
+        if (range == null || range.isBlank()) {
+            return 0;
+        }
+        int index = range.lastIndexOf('-');
+        try {
+            long end = Long.parseLong(range.substring(index + 1).trim());
+            // Some registries return 0-0 for an empty session, resend from start in that case
+            return end == 0 ? 0 : end + 1;
+        } catch (NumberFormatException e) {
+            throw new OrasException("Invalid Range header on upload status: %s".formatted(range));
+        }
+    }
+
+    /**
+     * Get the upload location from the response or keep the current one
+     * @param ref The container ref
+     * @param response The response
+     * @param location The current location
+     * @return The absolute upload location
+     */
+    private String getUploadLocation(ContainerRef ref, HttpClient.ResponseWrapper<String> response, String location) {
+        String newLocation = response.headers().get(Const.LOCATION_HEADER.toLowerCase());
+        if (newLocation == null || newLocation.isBlank()) {
+            return location;
+        }
+        if (!newLocation.startsWith("http://") && !newLocation.startsWith("https://")) {
+            newLocation =
+                    "%s://%s/%s".formatted(getScheme(), ref.getApiRegistry(this), newLocation.replaceFirst("^/", ""));
+        }
+        LOG.debug("Chunked upload location updated: {}", newLocation);
+        return newLocation;
     }
 
     private void finalizeChunkedUpload(ContainerRef ref, String location, String digest) {
@@ -1121,6 +2518,99 @@ public final class Registry extends OCI<ContainerRef> {
         LOG.debug("Chunked upload finalized successfully for digest: {}", digest);
     }
 
+    /**
+     * Return if the blob is already present in the target, either existing or mounted from a mount source
+     * @param ref The target container with digest
+     * @return True if the blob doesn't need to be uploaded
+     */
+    private boolean isBlobPresent(ContainerRef ref) {
+        if (hasBlob(ref)) {
+            operationListener.onBlobSkipped(ref, Objects.requireNonNull(ref.getDigest()));
+            metrics.recordBlobReused(ref, RegistryMetrics.REUSE_EXISTS);
+            return true;
+        }
+        if (mountFromSources(ref)) {
+            metrics.recordBlobReused(ref, RegistryMetrics.REUSE_MOUNTED);
+            return true;
+        }
+        return false;
+    }
+
+    /**
+     * Notify the operation listener of a pushed blob
+     * @param ref The target container
+     * @param layer The pushed blob
+     * @return The layer
+     */
+    private Layer blobPushed(ContainerRef ref, Layer layer) {
+        operationListener.onBlobPushed(ref, layer);
+        Long size = layer.getSize();
+        metrics.recordBytes(OperationTracer.PUSH, ref, size != null ? size : 0);
+        return layer;
+    }
+
+    /**
+     * Record metrics and a span for a high-level operation
+     * @param operation The operation
+     * @param ref The container ref
+     * @param supplier The operation to execute
+     * @param <T> The result type
+     * @return The result of the operation
+     */
+    private <T> T instrument(String operation, ContainerRef ref, Supplier<T> supplier) {
+        try (OperationTracer.TracedOperation traced = operationTracer.start(operation, ref)) {
+            try {
+                T result = metrics.record(operation, ref, supplier);
+                if (result instanceof Descriptor descriptor) {
+                    traceDescriptor(traced, descriptor);
+                } else if (result instanceof Manifest manifest && manifest.getDescriptor() != null) {
+                    traced.setDigest(manifest.getDescriptor().getDigest());
+                    traced.setBytes(manifest.getDescriptor().getSize());
+                }
+                return result;
+            } catch (RuntimeException e) {
+                traced.recordError(e);
+                throw e;
+            }
+        }
+    }
+
+    private static void traceDescriptor(OperationTracer.TracedOperation traced, Descriptor descriptor) {
+        if (descriptor.getDigest() != null) {
+            traced.setDigest(descriptor.getDigest());
+        }
+        if (descriptor.getSize() != null) {
+            traced.setBytes(descriptor.getSize());
+        }
+    }
+
+    /**
+     * Try to mount the blob from the configured mount sources
+     * @param targetRef The target container with digest
+     * @return True if the blob was mounted
+     */
+    private boolean mountFromSources(ContainerRef targetRef) {
+        String digest = targetRef.getDigest();
+        if (digest == null || mountSources.isEmpty()) {
+            return false;
+        }
+        for (String repository : mountSources) {
+            ContainerRef sourceRef = ContainerRef.parse("%s/%s".formatted(targetRef.getRegistry(), repository))
+                    .withDigest(digest);
+            if (sourceRef.getFullRepository(this).equals(targetRef.getFullRepository(this))) {
+                continue;
+            }
+            try {
+                if (mountBlob(sourceRef, targetRef)) {
+                    return true;
+                }
+            } catch (OrasException e) {
+                LOG.debug("Unable to mount blob {} from {}: {}", digest, repository, e.getMessage());
+            }
+        }
+        return false;
+    }
+
     /**
      * Return if the registry contains already the blob
      * @param containerRef The container
@@ -1167,16 +2657,32 @@ public final class Registry extends OCI<ContainerRef> {
         if (!ref.isInsecure(this) && this.isInsecure()) {
             return copyForNewTransport(ref.getRegistry(), false).getBlobDirect(ref);
         }
+        Path cached = getCachedBlob(ref);
+        if (cached != null) {
+            try {
+                return Files.readAllBytes(cached);
+            } catch (IOException e) {
+                LOG.debug("Failed to read cached blob {}", cached, e);
+            }
+        }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getBlobsPath(this)));
-        HttpClient.ResponseWrapper<String> response = client.get(
+        HttpClient.ResponseWrapper<byte[]> response = client.getBytes(
                 uri,
                 Map.of(Const.ACCEPT_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
                 Scopes.of(ref),
-                authProvider);
+                authProvider,
+                maxBlobSize);
         logResponse(response);
         handleError(response);
-        byte[] data = response.response().getBytes(StandardCharsets.UTF_8);
+        byte[] data = response.response();
         validateDockerContentDigest(response, data);
+        if (ref.getDigest() != null) {
+            ensureDigest(ref, data);
+            if (blobCache != null) {
+                blobCache.put(ref.getDigest(), data);
+            }
+        }
+        metrics.recordBytes(OperationTracer.PULL, ref, data.length);
         return data;
     }
 
@@ -1199,15 +2705,234 @@ public final class Registry extends OCI<ContainerRef> {
             return;
         }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getBlobsPath(this)));
-        HttpClient.ResponseWrapper<Path> response = client.download(
-                uri,
-                Map.of(Const.ACCEPT_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
-                path,
-                Scopes.of(ref),
-                authProvider);
-        logResponse(response);
-        handleError(response);
-        validateDockerContentDigest(response, path);
+        // Download next to the destination and only move it once verified
+        Path tempFile = createTempFile(path);
+        try {
+            HttpClient.ResponseWrapper<?> response;
+            Path cached = getCachedBlob(ref);
+            if (cached != null) {
+                Files.copy(cached, tempFile, StandardCopyOption.REPLACE_EXISTING);
+                Files.move(tempFile, path, StandardCopyOption.REPLACE_EXISTING);
+                return;
+            }
+            if (ref.getDigest() != null) {
+                // The content is verified against the requested digest while downloading
+                downloadWithResume(uri, ref, ref.getDigest(), tempFile);
+                if (blobCache != null) {
+                    blobCache.put(ref.getDigest(), tempFile);
+                }
+                Files.move(tempFile, path, StandardCopyOption.REPLACE_EXISTING);
+                metrics.recordBytes(OperationTracer.PULL, ref, Files.size(path));
+                return;
+            }
+            if (isThrottled()) {
+                HttpClient.ResponseWrapper<InputStream> streamResponse = client.download(
+                        uri,
+                        Map.of(Const.ACCEPT_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
+                        Scopes.of(ref),
+                        authProvider);
+                logResponse(streamResponse);
+                handleError(streamResponse);
+                ensureSize(streamResponse, maxBlobSize);
+                try (InputStream is = throttle(streamResponse.response())) {
+                    Files.copy(is, tempFile, StandardCopyOption.REPLACE_EXISTING);
+                }
+                response = streamResponse;
+            } else {
+                response = client.download(
+                        uri,
+                        Map.of(Const.ACCEPT_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE),
+                        tempFile,
+                        Scopes.of(ref),
+                        authProvider,
+                        maxBlobSize);
+                logResponse(response);
+                handleError(response);
+            }
+            validateDockerContentDigest(response, tempFile);
+            Files.move(tempFile, path, StandardCopyOption.REPLACE_EXISTING);
+            metrics.recordBytes(OperationTracer.PULL, ref, Files.size(path));
+        } catch (IOException e) {
+            throw new OrasException("Failed to download blob to %s".formatted(path), e);
+        } finally {
+            deleteQuietly(tempFile);
+        }
+    }
+
+    /**
+     * Download a blob to a file while computing its digest. If the transfer is interrupted, the download is resumed
+     * from the last written byte with a range request and the digest state is kept. When the registry answers a range
+     * request with the full content, the download restarts from zero.
+     * @param uri The blob URI
+     * @param ref The container ref
+     * @param digest The expected digest
+     * @param file The file to write
+     * @throws IOException If the download still fails after all resume attempts
+     */
+    private void downloadWithResume(URI uri, ContainerRef ref, String digest, Path file) throws IOException {
+        DigestAlgorithm algorithm = DigestAlgorithms.fromDigest(digest);
+        MessageDigest messageDigest = algorithm.newMessageDigest();
+        long written = 0;
+        int attempts = 0;
+        boolean rangeSupported = true;
+        while (true) {
+            Map<String, String> headers = new HashMap<>();
+            headers.put(Const.ACCEPT_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE);
+            if (written > 0 && rangeSupported) {
+                headers.put(Const.RANGE_HEADER, "bytes=%d-".formatted(written));
+            }
+            HttpClient.ResponseWrapper<InputStream> response =
+                    client.download(uri, headers, Scopes.of(ref), authProvider);
+            logResponse(response);
+            handleError(response);
+            if (written == 0) {
+                ensureSize(response, maxBlobSize);
+            }
+            if (written > 0 && !isResumed(response, written)) {
+                LOG.debug("Registry did not resume download of {}, restarting from zero", digest);
+                rangeSupported = false;
+                written = 0;
+                messageDigest.reset();
+            }
+            try (InputStream is = throttle(response.response());
+                    OutputStream os = written > 0
+                            ? Files.newOutputStream(file, StandardOpenOption.APPEND)
+                            : Files.newOutputStream(file)) {
+                byte[] buffer = new byte[bufferSize];
+                int read;
+                while ((read = is.read(buffer)) != -1) {
+                    if (maxBlobSize > 0 && written + read > maxBlobSize) {
+                        throw new ContentTooLargeException(maxBlobSize, written + read);
+                    }
+                    os.write(buffer, 0, read);
+                    messageDigest.update(buffer, 0, read);
+                    written += read;
+                }
+            } catch (IOException e) {
+                if (attempts++ >= downloadResumeAttempts) {
+                    throw e;
+                }
+                LOG.debug("Download of {} interrupted after {} bytes, resuming", digest, written, e);
+                continue;
+            }
+            String computedDigest = algorithm.formatDigest(messageDigest.digest());
+            String headerDigest = validateDockerContentDigest(response);
+            if (headerDigest != null
+                    && !DigestAlgorithms.fromDigest(headerDigest).getPrefix().equals(algorithm.getPrefix())) {
+                validateDockerContentDigest(response, file);
+            } else if (headerDigest != null) {
+                ensureDigest(headerDigest, computedDigest);
+            }
+            ensureDigest(digest, computedDigest);
+            return;
+        }
+    }
+
+    /**
+     * Reject a response announcing a body larger than the maximum size
+     * @param response The response
+     * @param maxSize The maximum size in bytes. Zero or negative for unlimited
+     */
+    private static void ensureSize(HttpClient.ResponseWrapper<?> response, long maxSize) {
+        String contentLength = response.headers().get(Const.CONTENT_LENGTH_HEADER.toLowerCase());
+        if (maxSize <= 0 || contentLength == null) {
+            return;
+        }
+        try {
+            long size = Long.parseLong(contentLength.trim());
+            if (size > maxSize) {
+                if (response.response() instanceof InputStream is) {
+                    closeQuietly(is);
+                }
+                throw new ContentTooLargeException(maxSize, size);
+            }
+        } catch (NumberFormatException e) {
+            LOG.debug("Invalid Content-Length header: {}", contentLength);
+        }
+    }
+
+    /**
+     * Return if the response continues a download at the given offset
+     * @param response The response of the range request
+     * @param offset The number of bytes already downloaded
+     * @return True if the registry returned partial content starting at the offset
+     */
+    private static boolean isResumed(HttpClient.ResponseWrapper<?> response, long offset) {
+        if (response.statusCode() != 206) {
+            return false;
+        }
+        String contentRange = response.headers().get(Const.CONTENT_RANGE_HEADER.toLowerCase());
+        return contentRange != null && contentRange.trim().startsWith("bytes %d-".formatted(offset));
+    }
+
+    /**
+     * Get the cached blob of a reference by digest
+     * @param ref The container ref
+     * @return The path of the cached blob or null if not cached or no cache is configured
+     */
+    private @Nullable Path getCachedBlob(ContainerRef ref) {
+        if (blobCache == null || ref.getDigest() == null) {
+            return null;
+        }
+        Path cached = blobCache.get(ref.getDigest()).orElse(null);
+        if (cached != null) {
+            LOG.debug("Using cached blob {}", ref.getDigest());
+        }
+        return cached;
+    }
+
+    /**
+     * Create a temporary file in the directory of the destination so it can be moved once verified
+     * @param destination The destination
+     * @return The temporary file
+     */
+    private static Path createTempFile(Path destination) {
+        Path directory = destination.toAbsolutePath().getParent();
+        try {
+            return Files.createTempFile(directory, ".oras-", ".tmp");
+        } catch (IOException e) {
+            throw new OrasException("Failed to create temporary file in %s".formatted(directory), e);
+        }
+    }
+
+    /**
+     * Return if blob transfers are throttled
+     * @return True if a bandwidth limit is configured
+     */
+    private boolean isThrottled() {
+        return bandwidthLimiter != null || transferBandwidthLimit > 0;
+    }
+
+    /**
+     * Wrap the stream of a blob transfer with the configured bandwidth limits
+     * @param in The input stream
+     * @return The throttled input stream or the same stream if no limit is configured
+     */
+    private InputStream throttle(InputStream in) {
+        InputStream throttled = in;
+        if (bandwidthLimiter != null) {
+            throttled = new ThrottledInputStream(throttled, bandwidthLimiter);
+        }
+        if (transferBandwidthLimit > 0) {
+            throttled = new ThrottledInputStream(throttled, BandwidthLimiter.of(transferBandwidthLimit));
+        }
+        return throttled;
+    }
+
+    private static void closeQuietly(InputStream is) {
+        try {
+            is.close();
+        } catch (IOException e) {
+            LOG.debug("Failed to close response stream", e);
+        }
+    }
+
+    private static void deleteQuietly(Path path) {
+        try {
+            Files.deleteIfExists(path);
+        } catch (IOException e) {
+            LOG.debug("Failed to delete temporary file {}", path, e);
+        }
     }
 
     @Override
@@ -1223,6 +2948,14 @@ public final class Registry extends OCI<ContainerRef> {
         if (!ref.isInsecure(this) && this.isInsecure()) {
             return copyForNewTransport(ref.getRegistry(), false).fetchBlobDirect(ref);
         }
+        Path cached = getCachedBlob(ref);
+        if (cached != null) {
+            try {
+                return Files.newInputStream(cached);
+            } catch (IOException e) {
+                LOG.debug("Failed to open cached blob {}", cached, e);
+            }
+        }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getBlobsPath(this)));
         HttpClient.ResponseWrapper<InputStream> response = client.download(
                 uri,
@@ -1231,14 +2964,75 @@ public final class Registry extends OCI<ContainerRef> {
                 authProvider);
         logResponse(response);
         handleError(response);
+        ensureSize(response, maxBlobSize);
         validateDockerContentDigest(response);
-        return response.response();
+        InputStream stream = metrics.countBytes(OperationTracer.PULL, ref, throttle(response.response()));
+        if (ref.getDigest() != null) {
+            return new DigestVerifyingInputStream(stream, ref.getDigest(), -1);
+        }
+        return stream;
     }
 
     @Override
-    public Descriptor fetchBlobDescriptor(ContainerRef containerRef) {
-        HttpClient.ResponseWrapper<String> response = headBlob(containerRef);
-        handleError(response);
+    public InputStream fetchLayer(ContainerRef containerRef, Layer layer) {
+        Objects.requireNonNull(layer.getDigest(), "Layer digest is required");
+        ContainerRef ref = containerRef.withDigest(layer.getDigest());
+        List<String> urls = layer.getUrls();
+        if (urls == null || urls.isEmpty()) {
+            return fetchBlob(ref);
+        }
+        try {
+            return fetchBlob(ref);
+        } catch (OrasException e) {
+            if (e.getStatusCode() != 404) {
+                throw e;
+            }
+            LOG.debug("Blob {} not found on registry, trying layer URLs", layer.getDigest());
+            return fetchFromUrls(ref, layer, urls, e);
+        }
+    }
+
+    /**
+     * Fetch a layer blob from its external URLs. No registry credentials are sent to these URLs
+     * @param ref The container ref with the layer digest
+     * @param layer The layer
+     * @param urls The URLs
+     * @param notFound The exception of the registry lookup, rethrown if no URL could be used
+     * @return The input stream
+     */
+    private InputStream fetchFromUrls(ContainerRef ref, Layer layer, List<String> urls, OrasException notFound) {
+        long size = layer.getSize() != null ? layer.getSize() : -1;
+        for (String url : urls) {
+            URI uri;
+            try {
+                uri = URI.create(url);
+            } catch (IllegalArgumentException e) {
+                LOG.debug("Ignoring invalid layer URL {}", LogUtils.redactUri(url));
+                continue;
+            }
+            if (!"https".equalsIgnoreCase(uri.getScheme()) && !"http".equalsIgnoreCase(uri.getScheme())) {
+                LOG.debug("Ignoring unsupported layer URL {}", LogUtils.redactUri(url));
+                continue;
+            }
+            try {
+                HttpClient.ResponseWrapper<InputStream> response = client.downloadUnauthenticated(
+                        uri, Map.of(Const.ACCEPT_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE), Scopes.of(ref));
+                logResponse(response);
+                handleError(response);
+                ensureSize(response, maxBlobSize);
+                InputStream stream = metrics.countBytes(OperationTracer.PULL, ref, throttle(response.response()));
+                return new DigestVerifyingInputStream(stream, layer.getDigest(), size);
+            } catch (OrasException e) {
+                LOG.debug("Failed to fetch layer {} from {}", layer.getDigest(), LogUtils.redactUri(url), e);
+            }
+        }
+        throw notFound;
+    }
+
+    @Override
+    public Descriptor fetchBlobDescriptor(ContainerRef containerRef) {
+        HttpClient.ResponseWrapper<String> response = headBlob(containerRef);
+        handleError(response);
         String size = response.headers().get(Const.CONTENT_LENGTH_HEADER.toLowerCase());
         return Descriptor.of(
                 validateDockerContentDigest(response), Long.parseLong(size), Const.DEFAULT_DESCRIPTOR_MEDIA_TYPE);
@@ -1246,7 +3040,35 @@ public final class Registry extends OCI<ContainerRef> {
 
     @Override
     public Manifest getManifest(ContainerRef containerRef) {
-        Descriptor descriptor = getDescriptor(containerRef);
+        checkDigestPinned(containerRef);
+        return readManifest(containerRef);
+    }
+
+    /**
+     * Ensure the reference is pinned by digest when digest pinning is enabled
+     * @param containerRef The container
+     * @throws OrasException if the reference has no digest and is not an allowed tag reference
+     */
+    private void checkDigestPinned(ContainerRef containerRef) {
+        if (!digestPinning || containerRef.getDigest() != null) {
+            return;
+        }
+        ContainerRef ref = containerRef.forRegistry(this);
+        boolean allowed = allowedTagReferences.stream()
+                .anyMatch(reference -> ContainerRef.parse(reference).forRegistry(this).equals(ref));
+        if (!allowed) {
+            throw new OrasException(
+                    "Reference %s is not pinned by digest and digest pinning is enabled".formatted(containerRef));
+        }
+    }
+
+    /**
+     * Read a manifest without enforcing digest pinning, for example to read back a manifest pushed by tag
+     * @param containerRef The container
+     * @return The manifest
+     */
+    private Manifest readManifest(ContainerRef containerRef) {
+        Descriptor descriptor = resolveDescriptor(containerRef);
         String contentType = descriptor.getMediaType();
         if (!isManifestMediaType(contentType)) {
             throw new OrasException(
@@ -1264,7 +3086,7 @@ public final class Registry extends OCI<ContainerRef> {
             digest = containerRef.getDigest();
             if (digest == null) {
                 LOG.debug("Digest missing from reference, computing from content");
-                digest = containerRef.getAlgorithm().digest(json.getBytes(StandardCharsets.UTF_8));
+                digest = getDigestAlgorithm(containerRef).digest(json.getBytes(StandardCharsets.UTF_8));
                 LOG.debug("Computed index digest: {}", digest);
             }
         }
@@ -1278,7 +3100,17 @@ public final class Registry extends OCI<ContainerRef> {
 
     @Override
     public Index getIndex(ContainerRef containerRef) {
-        Descriptor descriptor = getDescriptor(containerRef);
+        checkDigestPinned(containerRef);
+        return readIndex(containerRef);
+    }
+
+    /**
+     * Read an index without enforcing digest pinning, for example to read back an index pushed by tag
+     * @param containerRef The container
+     * @return The index
+     */
+    private Index readIndex(ContainerRef containerRef) {
+        Descriptor descriptor = resolveDescriptor(containerRef);
         String contentType = descriptor.getMediaType();
         if (!isIndexMediaType(contentType)) {
             throw new OrasException("Expected index but got %s".formatted(contentType));
@@ -1290,7 +3122,7 @@ public final class Registry extends OCI<ContainerRef> {
             digest = containerRef.getDigest();
             if (digest == null) {
                 LOG.debug("Digest missing from reference, computing from content");
-                digest = containerRef.getAlgorithm().digest(json.getBytes(StandardCharsets.UTF_8));
+                digest = getDigestAlgorithm(containerRef).digest(json.getBytes(StandardCharsets.UTF_8));
                 LOG.debug("Computed index digest: {}", digest);
             }
         }
@@ -1301,12 +3133,25 @@ public final class Registry extends OCI<ContainerRef> {
 
     @Override
     public Descriptor getDescriptor(ContainerRef containerRef) {
+        checkDigestPinned(containerRef);
+        return resolveDescriptor(containerRef);
+    }
+
+    private Descriptor resolveDescriptor(ContainerRef containerRef) {
+        return instrument(OperationTracer.RESOLVE, containerRef, () -> getDescriptorDirect(containerRef));
+    }
+
+    private Descriptor getDescriptorDirect(ContainerRef containerRef) {
+        operationListener.onResolveStarted(containerRef);
         HttpClient.ResponseWrapper<String> response = getManifestResponse(containerRef);
         logResponse(response);
         handleError(response);
+        if (containerRef.getDigest() != null) {
+            ensureDigest(containerRef, response.response().getBytes(StandardCharsets.UTF_8));
+        }
         String size = response.headers().get(Const.CONTENT_LENGTH_HEADER.toLowerCase());
         String contentType = response.headers().get(Const.CONTENT_TYPE_HEADER.toLowerCase());
-        return Descriptor.of(
+        Descriptor descriptor = Descriptor.of(
                         validateDockerContentDigest(response),
                         Long.parseLong(
                                 size == null
@@ -1314,39 +3159,166 @@ public final class Registry extends OCI<ContainerRef> {
                                         : size),
                         contentType)
                 .withJson(response.response());
+        operationListener.onResolved(containerRef, descriptor);
+        return descriptor;
     }
 
     @Override
     public Descriptor probeDescriptor(ContainerRef ref) {
+        checkDigestPinned(ref);
+        return instrument(OperationTracer.RESOLVE, ref, () -> probeDescriptorDirect(ref));
+    }
+
+    private Descriptor probeDescriptorDirect(ContainerRef ref) {
+        operationListener.onResolveStarted(ref);
+        String cacheKey = resolveCacheKey(ref);
+        ResolveCache.Entry cached = cacheKey != null ? resolveCache.get(cacheKey) : null;
+        if (cached != null) {
+            LOG.debug("Resolved {} to {} from cache", ref, cached.digest());
+            Descriptor descriptor =
+                    Descriptor.of(cached.digest(), 0L, cached.mediaType()).withRegistry(cached.registry());
+            operationListener.onResolved(ref, descriptor);
+            return descriptor;
+        }
         ResolvedRegistry resolvedRegistry = getResolvedHeaders(ref);
         Map<String, String> headers = resolvedRegistry.headers();
         String registry = resolvedRegistry.registry();
         String digest = validateDockerContentDigest(headers);
         if (digest != null) {
-            SupportedAlgorithm.fromDigest(digest);
+            DigestAlgorithms.fromDigest(digest);
         }
         String contentType = headers.get(Const.CONTENT_TYPE_HEADER.toLowerCase());
-        return Descriptor.of(digest, 0L, contentType).withRegistry(registry);
+        if (cacheKey != null && digest != null) {
+            resolveCache.put(cacheKey, digest, contentType, registry);
+        }
+        Descriptor descriptor = Descriptor.of(digest, 0L, contentType).withRegistry(registry);
+        operationListener.onResolved(ref, descriptor);
+        return descriptor;
+    }
+
+    /**
+     * Invalidate the cached resolution of a tag
+     * @param containerRef The container ref with the tag
+     */
+    public void invalidateResolveCache(ContainerRef containerRef) {
+        String cacheKey = resolveCacheKey(containerRef);
+        if (cacheKey != null) {
+            resolveCache.invalidate(cacheKey);
+        }
+    }
+
+    /**
+     * Invalidate all cached resolutions
+     */
+    public void invalidateResolveCache() {
+        resolveCache.invalidateAll();
+    }
+
+    /**
+     * Get the key of a tag resolution in the cache
+     * @param containerRef The container ref
+     * @return The key or null if the ref doesn't need to be resolved or the cache is disabled
+     */
+    private @Nullable String resolveCacheKey(ContainerRef containerRef) {
+        if (!resolveCache.isEnabled()) {
+            return null;
+        }
+        ContainerRef ref = containerRef.forRegistry(this);
+        if (ref.getDigest() != null || ref.getTag() == null) {
+            return null;
+        }
+        return "%s/%s:%s".formatted(ref.getRegistry(), ref.getFullRepository(this), ref.getTag());
     }
 
     /**
-     * Return if the container ref manifests or index exists
+     * Return if the container ref manifests or index exists using a HEAD request
      * @param containerRef The container
      * @return True if exists
      */
-    boolean exists(ContainerRef containerRef) {
+    public boolean exists(ContainerRef containerRef) {
+        return headManifest(containerRef).statusCode() == 200;
+    }
+
+    /**
+     * Get the descriptor of a manifest or index using a HEAD request, without fetching its content
+     * @param containerRef The container
+     * @return The descriptor with digest, size and media type, or empty if the manifest or index doesn't exist
+     */
+    public Optional<Descriptor> findDescriptor(ContainerRef containerRef) {
+        HttpClient.ResponseWrapper<String> response = headManifest(containerRef);
+        if (response.statusCode() == 404) {
+            return Optional.empty();
+        }
+        handleError(response);
+        return Optional.of(toHeadDescriptor(containerRef, response, Const.DEFAULT_MANIFEST_MEDIA_TYPE));
+    }
+
+    /**
+     * Return if the blob exists using a HEAD request
+     * @param containerRef The container
+     * @param digest The digest of the blob
+     * @return True if the blob exists
+     */
+    public boolean blobExists(ContainerRef containerRef, String digest) {
+        return hasBlob(containerRef.withDigest(digest));
+    }
+
+    /**
+     * Get the descriptor of a blob using a HEAD request, without fetching its content
+     * @param containerRef The container
+     * @param digest The digest of the blob
+     * @return The descriptor with digest, size and media type, or empty if the blob doesn't exist
+     */
+    public Optional<Descriptor> findBlobDescriptor(ContainerRef containerRef, String digest) {
+        ContainerRef ref = containerRef.withDigest(digest);
+        HttpClient.ResponseWrapper<String> response = headBlob(ref);
+        if (response.statusCode() == 404) {
+            return Optional.empty();
+        }
+        handleError(response);
+        return Optional.of(toHeadDescriptor(ref, response, Const.DEFAULT_DESCRIPTOR_MEDIA_TYPE));
+    }
+
+    /**
+     * Build a descriptor from the headers of a HEAD response
+     * @param containerRef The container
+     * @param response The HEAD response
+     * @param defaultMediaType The media type if the response has no content type
+     * @return The descriptor
+     */
+    private Descriptor toHeadDescriptor(
+            ContainerRef containerRef, HttpClient.ResponseWrapper<String> response, String defaultMediaType) {
+        String digest = validateDockerContentDigest(response);
+        if (digest == null) {
+            digest = containerRef.getDigest();
+        }
+        String size = response.headers().get(Const.CONTENT_LENGTH_HEADER.toLowerCase());
+        String contentType = response.headers().get(Const.CONTENT_TYPE_HEADER.toLowerCase());
+        return Descriptor.of(
+                        digest,
+                        size != null ? Long.parseLong(size) : 0L,
+                        contentType != null ? contentType : defaultMediaType)
+                .withRegistry(containerRef.forRegistry(this).getRegistry());
+    }
+
+    /**
+     * Execute a HEAD request on the manifest or index
+     * @param containerRef The container
+     * @return The response
+     */
+    private HttpClient.ResponseWrapper<String> headManifest(ContainerRef containerRef) {
         ContainerRef ref = containerRef.forRegistry(this).checkBlocked(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).exists(ref);
+            return copyForNewTransport(ref.getRegistry(), true).headManifest(ref);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).exists(ref);
+            return copyForNewTransport(ref.getRegistry(), false).headManifest(ref);
         }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
         HttpClient.ResponseWrapper<String> response =
                 client.head(uri, Map.of(Const.ACCEPT_HEADER, Const.MANIFEST_ACCEPT_TYPE), Scopes.of(ref), authProvider);
         logResponse(response);
-        return response.statusCode() == 200;
+        return response;
     }
 
     /**
@@ -1357,8 +3329,13 @@ public final class Registry extends OCI<ContainerRef> {
      * @return The result from the first successful invocation
      */
     private <T> T withMirrorFallback(ContainerRef containerRef, BiFunction<Registry, ContainerRef, T> operation) {
-        List<RegistriesConf.MirrorConfig> mirrors = registriesConf.getApplicableMirrors(containerRef);
-        for (RegistriesConf.MirrorConfig mirror : mirrors) {
+        ContainerRef upstreamRef = containerRef.forRegistry(this);
+        List<RegistriesConf.MirrorConfig> candidates = new ArrayList<>();
+        mirrors.getOrDefault(upstreamRef.getRegistry().toLowerCase(Locale.ROOT), List.of()).stream()
+                .filter(mirror -> mirror.appliesTo(upstreamRef))
+                .forEach(candidates::add);
+        candidates.addAll(registriesConf.getApplicableMirrors(containerRef));
+        for (RegistriesConf.MirrorConfig mirror : candidates) {
             String mirrorLocation = mirror.location();
             if (mirrorLocation == null || mirrorLocation.isBlank()) continue;
             ContainerRef mirrorRef = registriesConf.rewriteForMirror(containerRef, mirror);
@@ -1399,15 +3376,41 @@ public final class Registry extends OCI<ContainerRef> {
         if (!ref.isInsecure(this) && this.isInsecure()) {
             return copyForNewTransport(ref.getRegistry(), false).getManifestResponseDirect(ref);
         }
+        // Skip the HEAD request if the tag resolution is cached
+        String cacheKey = resolveCacheKey(ref);
+        ResolveCache.Entry cached = cacheKey != null ? resolveCache.get(cacheKey) : null;
+        if (cached != null) {
+            LOG.debug("Resolved {} to {} from cache", ref, cached.digest());
+            URI uri = URI.create(
+                    "%s://%s".formatted(getScheme(), ref.withDigest(cached.digest()).getManifestsPath(this)));
+            HttpClient.ResponseWrapper<String> response = client.get(
+                    uri, Map.of("Accept", Const.MANIFEST_ACCEPT_TYPE), Scopes.of(ref), authProvider, maxManifestSize);
+            if (response.statusCode() != 404) {
+                return response;
+            }
+            LOG.debug("Cached digest {} of {} not found, resolving again", cached.digest(), ref);
+            resolveCache.invalidate(cacheKey);
+        }
         URI uri = URI.create("%s://%s".formatted(getScheme(), ref.getManifestsPath(this)));
         HttpClient.ResponseWrapper<String> response =
                 client.head(uri, Map.of(Const.ACCEPT_HEADER, Const.MANIFEST_ACCEPT_TYPE), Scopes.of(ref), authProvider);
         logResponse(response);
         handleError(response);
-        return client.get(uri, Map.of("Accept", Const.MANIFEST_ACCEPT_TYPE), Scopes.of(ref), authProvider);
+        // Reject oversized manifests before fetching them
+        ensureSize(response, maxManifestSize);
+        String digest = validateDockerContentDigest(response);
+        if (cacheKey != null && digest != null) {
+            resolveCache.put(
+                    cacheKey,
+                    digest,
+                    response.headers().get(Const.CONTENT_TYPE_HEADER.toLowerCase()),
+                    ref.getRegistry());
+        }
+        return client.get(
+                uri, Map.of("Accept", Const.MANIFEST_ACCEPT_TYPE), Scopes.of(ref), authProvider, maxManifestSize);
     }
 
-    private void validateDockerContentDigest(HttpClient.ResponseWrapper<String> response, byte[] data) {
+    private void validateDockerContentDigest(HttpClient.ResponseWrapper<?> response, byte[] data) {
         String digest = response.headers().get(Const.DOCKER_CONTENT_DIGEST_HEADER.toLowerCase());
         // This might happen when blob are hosted other storage.
         // We need a way to propagate the headers like scoped.
@@ -1416,11 +3419,11 @@ public final class Registry extends OCI<ContainerRef> {
             LOG.debug("Docker-Content-Digest header not found in response. Skipping validation.");
             return;
         }
-        String computedDigest = SupportedAlgorithm.fromDigest(digest).digest(data);
+        String computedDigest = DigestAlgorithms.fromDigest(digest).digest(data);
         ensureDigest(digest, computedDigest);
     }
 
-    private void validateDockerContentDigest(HttpClient.ResponseWrapper<Path> response, Path path) {
+    private void validateDockerContentDigest(HttpClient.ResponseWrapper<?> response, Path path) {
         String digest = response.headers().get(Const.DOCKER_CONTENT_DIGEST_HEADER.toLowerCase());
         // This might happen when blob are hosted other storage.
         // We need a way to propagate the headers like scoped.
@@ -1429,7 +3432,7 @@ public final class Registry extends OCI<ContainerRef> {
             LOG.debug("Docker-Content-Digest header not found in response. Skipping validation.");
             return;
         }
-        String computedDigest = SupportedAlgorithm.fromDigest(digest).digest(path);
+        String computedDigest = DigestAlgorithms.fromDigest(digest).digest(path);
         ensureDigest(digest, computedDigest);
     }
 
@@ -1446,7 +3449,7 @@ public final class Registry extends OCI<ContainerRef> {
             LOG.debug("Docker-Content-Digest header not found in response. Skipping validation.");
             return null;
         }
-        SupportedAlgorithm.fromDigest(digest);
+        DigestAlgorithms.fromDigest(digest);
         return digest;
     }
 
@@ -1454,7 +3457,7 @@ public final class Registry extends OCI<ContainerRef> {
         if (ref.getDigest() == null) {
             throw new OrasException("Missing digest");
         }
-        SupportedAlgorithm algorithm = SupportedAlgorithm.fromDigest(ref.getDigest());
+        DigestAlgorithm algorithm = DigestAlgorithms.fromDigest(ref.getDigest());
         String dataDigest = algorithm.digest(data);
         ensureDigest(ref.getDigest(), dataDigest);
     }
@@ -1464,7 +3467,7 @@ public final class Registry extends OCI<ContainerRef> {
             throw new OrasException("Received null digest");
         }
         if (!expected.equals(current)) {
-            throw new OrasException("Digest mismatch: %s != %s".formatted(expected, current));
+            throw new DigestMismatchException(expected, current);
         }
     }
 
@@ -1475,8 +3478,26 @@ public final class Registry extends OCI<ContainerRef> {
     @SuppressWarnings("unchecked")
     private void handleError(HttpClient.ResponseWrapper<?> responseWrapper) {
         if (responseWrapper.statusCode() >= 400) {
-            if (responseWrapper.response() instanceof String) {
-                LOG.debug("Response: {}", responseWrapper.response());
+            if (responseWrapper.response() instanceof InputStream is) {
+                closeQuietly(is);
+            }
+            if (responseWrapper.response() instanceof byte[] body) {
+                handleError(new HttpClient.ResponseWrapper<>(
+                        new String(body, StandardCharsets.UTF_8),
+                        responseWrapper.statusCode(),
+                        responseWrapper.headers(),
+                        responseWrapper.service()));
+                return;
+            }
+            if (responseWrapper.statusCode() == 429) {
+                throw new RateLimitException(new HttpClient.ResponseWrapper<>(
+                        responseWrapper.response() instanceof String body ? body : "",
+                        responseWrapper.statusCode(),
+                        responseWrapper.headers(),
+                        responseWrapper.service()));
+            }
+            if (responseWrapper.response() instanceof String body) {
+                LOG.debug("Response: {}", LogUtils.redactBody(body));
                 throw new OrasException((HttpClient.ResponseWrapper<String>) responseWrapper);
             }
             throw new OrasException(new HttpClient.ResponseWrapper<>(
@@ -1484,24 +3505,95 @@ public final class Registry extends OCI<ContainerRef> {
         }
     }
 
+    /**
+     * Handle the response of a delete request
+     * @param operation The operation name used in the error message
+     * @param response The response
+     */
+    private void handleDeleteError(String operation, HttpClient.ResponseWrapper<String> response) {
+        if (OperationNotSupportedException.isUnsupported(response)) {
+            throw new OperationNotSupportedException(operation, response);
+        }
+        handleError(response);
+    }
+
     /**
      * Log the response
      * @param response The response
      */
     private void logResponse(HttpClient.ResponseWrapper<?> response) {
+        if (!LOG.isDebugEnabled()) {
+            return;
+        }
         LOG.debug("Status Code: {}", response.statusCode());
-        LOG.debug("Headers: {}", response.headers());
+        LOG.debug("Headers: {}", LogUtils.redactHeaders(response.headers()));
         LOG.debug("Service: {}", response.service());
         String contentType = response.headers().get(Const.CONTENT_TYPE_HEADER.toLowerCase());
         boolean isBinaryResponse = contentType != null && contentType.contains("octet-stream");
         // Only log non-binary responses
-        if (response.response() instanceof String && !isBinaryResponse) {
-            LOG.debug("Response: {}", response.response());
+        if (response.response() instanceof String body && !isBinaryResponse) {
+            LOG.debug("Response: {}", LogUtils.redactBody(body));
         } else {
             LOG.debug("Not logging binary response of content type: {}", contentType);
         }
     }
 
+    /**
+     * Sign a pushed manifest or index with the configured signers, then push the signatures as its referrers
+     * @param ref The container ref
+     * @param descriptor The descriptor of the pushed manifest or index
+     * @param artifactType The artifact type of the pushed manifest or index
+     */
+    private void signArtifact(ContainerRef ref, ManifestDescriptor descriptor, @Nullable String artifactType) {
+        // Signatures are never signed themselves
+        if (signers.isEmpty() || signers.stream().anyMatch(signer -> signer.getArtifactType().equals(artifactType))) {
+            return;
+        }
+        ContainerRef repositoryRef = ref.withoutDigest();
+        for (ArtifactSigner signer : signers) {
+            ArtifactSigner.Signature signature = signer.sign(ref.withDigest(descriptor.getDigest()), descriptor);
+            Layer layer = pushBlob(repositoryRef, signature.content()).withMediaType(signature.mediaType());
+            Manifest manifest = buildPackedManifest(
+                    ArtifactType.from(signer.getArtifactType()),
+                    PackOptions.defaults()
+                            .withLayers(List.of(layer))
+                            .withSubject(descriptor.toSubject())
+                            .withAnnotations(signature.annotations()));
+            pushConfig(repositoryRef, manifest.getConfig());
+            Manifest signatureManifest = pushManifest(
+                    repositoryRef.withDigest(getDigestAlgorithm(repositoryRef)
+                            .digest(manifest.toJson().getBytes(StandardCharsets.UTF_8))),
+                    manifest);
+            LOG.debug(
+                    "Artifact {} signed by {}: {}",
+                    descriptor.getDigest(),
+                    signer.getClass().getName(),
+                    signatureManifest.getDescriptor().getDigest());
+        }
+    }
+
+    /**
+     * Run the configured verifiers against the resolved artifact
+     * @param ref The container ref
+     * @param contentType The content type of the artifact
+     */
+    private void verifyArtifact(ContainerRef ref, String contentType) {
+        if (verifiers.isEmpty()) {
+            return;
+        }
+        Describable describable = isIndexMediaType(contentType) ? getIndex(ref) : getManifest(ref);
+        ContainerRef digestRef = ref.withDigest(describable.getDescriptor().getDigest());
+        VerificationContext context =
+                new VerificationContext(digestRef, describable, this::getReferrers, this::getManifest, this::getBlob);
+        for (ArtifactVerifier verifier : verifiers) {
+            if (!verifier.verify(context)) {
+                throw new OrasException("Artifact '%s' rejected by verifier %s"
+                        .formatted(digestRef, verifier.getClass().getName()));
+            }
+        }
+        LOG.debug("Artifact {} accepted by {} verifier(s)", digestRef, verifiers.size());
+    }
+
     private void verifyContainersPolicy(ContainerRef containerRef, String digest) {
         String effectiveRegistry = containerRef.getEffectiveRegistry(this);
         ContainerRef effectiveRef = containerRef.forRegistry(effectiveRegistry);
@@ -1554,47 +3646,72 @@ public final class Registry extends OCI<ContainerRef> {
         return getResolvedHeaders(containerRef).headers().get(Const.CONTENT_TYPE_HEADER.toLowerCase());
     }
 
-    private void pullLayer(ContainerRef ref, Layer layer, Path path, boolean overwrite) {
+    private void pullLayer(ContainerRef ref, Layer layer, Path path, PullOptions options) {
         Objects.requireNonNull(layer.getDigest());
-        try (InputStream is = fetchBlob(ref.withDigest(layer.getDigest()))) {
-            // Unpack or just copy blob
-            if (Boolean.parseBoolean(layer.getAnnotations().getOrDefault(Const.ANNOTATION_ORAS_UNPACK, "false"))) {
+        CancellationToken.check(options.cancellation());
+        long totalSize = layer.getSize() != null ? layer.getSize() : -1;
+        transferListener.onStarted(layer.getDigest(), totalSize);
+        // Encrypted layers are decrypted while streaming when a decryption is set, otherwise pulled as is
+        LayerEncryption decryption = LayerEncryption.isEncrypted(layer) ? options.decryption() : null;
+        Layer content = decryption != null ? LayerEncryption.toDecrypted(layer) : layer;
+        try (InputStream fetched = new CancellableInputStream(
+                        new ProgressInputStream(fetchLayer(ref, layer), transferListener, layer.getDigest(), totalSize),
+                        options.cancellation());
+                InputStream is = decryption != null ? decryption.decrypt(layer, fetched) : fetched) {
+            // Custom media types are unpacked by their handler, others are unpacked or just copied
+            MediaTypeHandler<?> handler = MediaTypeHandlers.get(content.getMediaType());
+            if (handler != null && handler.isUnpack()) {
+                LOG.debug("Unpacking blob of media type {} to: {}", content.getMediaType(), path);
+                handler.unpack(is, content, path);
+            } else if (Boolean.parseBoolean(
+                    content.getAnnotations().getOrDefault(Const.ANNOTATION_ORAS_UNPACK, "false"))) {
                 LOG.debug("Extracting blob to: {}", path);
 
-                // Uncompress the tar.gz archive and verify digest if present
-                LocalPath tempArchive = ArchiveUtils.uncompress(is, layer.getMediaType());
-                String expectedDigest = layer.getAnnotations().get(Const.ANNOTATION_ORAS_CONTENT_DIGEST);
-                if (expectedDigest != null) {
-                    LOG.trace("Expected digest: {}", expectedDigest);
-                    String actualDigest = ref.getAlgorithm().digest(tempArchive.getPath());
-                    LOG.trace("Actual digest: {}", actualDigest);
-                    if (!expectedDigest.equals(actualDigest)) {
-                        throw new OrasException(
-                                "Digest mismatch: expected %s but got %s".formatted(expectedDigest, actualDigest));
-                    }
-                }
-
-                // Extract the tar
-                ArchiveUtils.untar(Files.newInputStream(tempArchive.getPath()), path);
+                // Stream extract the archive and verify the content digest if present
+                ArchiveUtils.uncompressuntar(
+                        is,
+                        path,
+                        content.getMediaType(),
+                        content.getAnnotations().get(Const.ANNOTATION_ORAS_CONTENT_DIGEST),
+                        options.extractOptions());
 
             } else {
-                Path targetPath = path.resolve(layer.getAnnotations().get(Const.ANNOTATION_TITLE))
+                Path targetPath = path.resolve(content.getAnnotations().get(Const.ANNOTATION_TITLE))
                         .normalize();
                 if (!targetPath.startsWith(path.normalize())) {
                     throw new OrasException(
                             "Refusing to pull layer: path is not withing folder in title annotation '%s'"
-                                    .formatted(layer.getAnnotations().get(Const.ANNOTATION_TITLE)));
+                                    .formatted(content.getAnnotations().get(Const.ANNOTATION_TITLE)));
                 }
-                if (Files.exists(targetPath) && !overwrite) {
+                if (Files.exists(targetPath) && !options.isOverwrite()) {
                     LOG.info("File already exists: {}", targetPath);
                     return;
                 }
                 LOG.debug("Copying blob to: {}", targetPath);
-                Files.copy(is, targetPath, StandardCopyOption.REPLACE_EXISTING);
+                // The stream verifies the digest at its end, only move the file once fully verified
+                Path tempFile = createTempFile(targetPath);
+                try {
+                    long copied = Files.copy(is, tempFile, StandardCopyOption.REPLACE_EXISTING);
+                    if (layer.getSize() != null && copied != layer.getSize()) {
+                        throw new DigestMismatchException(layer.getDigest(), layer.getSize(), copied);
+                    }
+                    Files.move(tempFile, targetPath, StandardCopyOption.REPLACE_EXISTING);
+                } finally {
+                    deleteQuietly(tempFile);
+                }
             }
         } catch (IOException e) {
             throw new OrasException("Failed to pull artifact", e);
         }
+        transferListener.onCompleted(layer.getDigest(), totalSize);
+    }
+
+    @Override
+    protected DigestAlgorithm getDigestAlgorithm(ContainerRef ref) {
+        if (ref.getDigest() != null) {
+            return ref.getAlgorithm();
+        }
+        return digestAlgorithm;
     }
 
     /**
@@ -1626,12 +3743,16 @@ public final class Registry extends OCI<ContainerRef> {
      * @return The resolved registry and headers
      */
     ResolvedRegistry getResolvedHeaders(ContainerRef containerRef) {
+        return withMirrorFallback(containerRef, (reg, ref) -> reg.getResolvedHeadersDirect(ref));
+    }
+
+    private ResolvedRegistry getResolvedHeadersDirect(ContainerRef containerRef) {
         ContainerRef ref = containerRef.forRegistry(this);
         if (ref.isInsecure(this) && !this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), true).getResolvedHeaders(ref);
+            return copyForNewTransport(ref.getRegistry(), true).getResolvedHeadersDirect(ref);
         }
         if (!ref.isInsecure(this) && this.isInsecure()) {
-            return copyForNewTransport(ref.getRegistry(), false).getResolvedHeaders(ref);
+            return copyForNewTransport(ref.getRegistry(), false).getResolvedHeadersDirect(ref);
         }
         URI uri = URI.create(
                 "%s://%s".formatted(getScheme(), ref.forRegistry(this).getManifestsPath(this)));
@@ -1642,6 +3763,39 @@ public final class Registry extends OCI<ContainerRef> {
         return new ResolvedRegistry(ref.getRegistry(), response.headers());
     }
 
+    /**
+     * Get the next page URI from the Link header (for example {@code </v2/foo/referrers/sha256:...?n=10&last=abc>; rel="next"})
+     * Links pointing to another origin are ignored to avoid leaking credentials.
+     * @param current The current URI
+     * @param response The response
+     * @return The next URI if any
+     */
+    private Optional<URI> getNextFromLink(URI current, HttpClient.ResponseWrapper<String> response) {
+        String linkHeader = response.headers().get(Const.LINK_HEADER.toLowerCase());
+        if (linkHeader == null) {
+            return Optional.empty();
+        }
+        for (String link : linkHeader.split(",")) {
+            int start = link.indexOf('<');
+            int end = link.indexOf('>', start + 1);
+            if (start == -1 || end == -1) {
+                continue;
+            }
+            String params = link.substring(end + 1).replace(" ", "").replace("\"", "");
+            if (!params.contains("rel=next")) {
+                continue;
+            }
+            URI next = current.resolve(link.substring(start + 1, end));
+            if (!Objects.equals(current.getScheme(), next.getScheme())
+                    || !Objects.equals(current.getAuthority(), next.getAuthority())) {
+                LOG.warn("Ignoring next link to another origin: {}", next);
+                return Optional.empty();
+            }
+            return Optional.of(next);
+        }
+        return Optional.empty();
+    }
+
     private Optional<String> getLastFromLink(HttpClient.ResponseWrapper<String> response) {
         String linkHeader = response.headers().get(Const.LINK_HEADER.toLowerCase());
         if (linkHeader == null) {
@@ -1664,7 +3818,7 @@ public final class Registry extends OCI<ContainerRef> {
         for (String param : query.split("&")) {
             int eq = param.indexOf('=');
             if (eq > 0 && "last".equals(param.substring(0, eq))) {
-                return Optional.of(param.substring(eq + 1));
+                return Optional.of(URLDecoder.decode(param.substring(eq + 1), StandardCharsets.UTF_8));
             }
         }
 
@@ -1721,12 +3875,49 @@ public final class Registry extends OCI<ContainerRef> {
             this.registry.setRegistry(registry.registry);
             this.registry.setSkipTlsVerify(registry.skipTlsVerify);
             this.registry.setTransportLocked(registry.transportLocked);
+            this.registry.setVirtualThreads(registry.virtualThreads);
             this.registry.setExecutorService(registry.executorService);
+            this.registry.setAsyncExecutorService(registry.asyncExecutorService);
             this.registry.setParallelism(registry.maxConcurrentDownloads);
-            this.registry.setMaxRetries(registry.maxRetries);
-            this.registry.setRetryDelayMs(registry.retryDelayMs);
-            this.registry.setMaxRetryDelayMs(registry.maxRetryDelayMs);
+            this.registry.setRetryPolicy(registry.retryPolicy);
+            this.registry.setAnonymousFallback(registry.anonymousFallback);
+            this.registry.setDigestPinning(registry.digestPinning);
+            registry.allowedTagReferences.forEach(this.registry::addAllowedTagReference);
+            this.registry.setChunkSize(registry.chunkSize);
+            this.registry.setUnknownSizeStrategy(registry.unknownSizeStrategy);
+            this.registry.setBufferSize(registry.bufferSize);
+            this.registry.setConnectTimeout(registry.connectTimeout);
+            this.registry.setReadTimeout(registry.readTimeout);
+            this.registry.setHttp2(registry.http2);
+            this.registry.setMaxRequestsPerHost(registry.maxRequestsPerHost);
+            this.registry.setDigestAlgorithm(registry.digestAlgorithm);
             this.registry.setContainersPolicy(registry.containersPolicy);
+            registry.verifiers.forEach(this.registry::addVerifier);
+            registry.signers.forEach(this.registry::addSigner);
+            registry.interceptors.forEach(this.registry::addInterceptor);
+            if (registry.userAgentSuffix != null) {
+                this.registry.setUserAgentSuffix(registry.userAgentSuffix);
+            }
+            registry.defaultHeaders.forEach((name, value) ->
+                    this.registry.addDefaultHeader(name, value, registry.sensitiveHeaders.contains(name)));
+            registry.mountSources.forEach(this.registry::addMountSource);
+            this.registry.setTransferBandwidthLimit(registry.transferBandwidthLimit);
+            this.registry.setDownloadResumeAttempts(registry.downloadResumeAttempts);
+            if (registry.blobCache != null) {
+                this.registry.setBlobCache(registry.blobCache);
+            }
+            this.registry.setMaxManifestSize(registry.maxManifestSize);
+            this.registry.setMaxBlobSize(registry.maxBlobSize);
+            if (registry.bandwidthLimiter != null) {
+                this.registry.setBandwidthLimiter(registry.bandwidthLimiter);
+            }
+            this.registry.setTransferListener(registry.transferListener);
+            this.registry.setOperationListener(registry.operationListener);
+            this.registry.setOperationTracer(registry.operationTracer);
+            if (registry.warningListener != null) {
+                this.registry.setWarningListener(registry.warningListener);
+            }
+            this.registry.setResolveCache(registry.resolveCache);
             if (registry.meterRegistry != null) {
                 this.registry.setMeterRegistry(registry.meterRegistry);
             }
@@ -1736,6 +3927,25 @@ public final class Registry extends OCI<ContainerRef> {
             if (registry.caContent != null) {
                 this.registry.setCaContent(registry.caContent);
             }
+            if (registry.trustManager != null) {
+                this.registry.setTrustManager(registry.trustManager);
+            }
+            if (registry.keyManager != null) {
+                this.registry.setKeyManager(registry.keyManager);
+            }
+            if (registry.clientCertificatePath != null && registry.clientKeyPath != null) {
+                this.registry.setClientCertificate(registry.clientCertificatePath, registry.clientKeyPath);
+            }
+            this.registry.setHostnameVerification(registry.hostnameVerification);
+            registry.hostConfigs.forEach(this.registry::setHostConfig);
+            registry.mirrors.forEach(
+                    (host, hostMirrors) -> hostMirrors.forEach(mirror -> this.registry.addMirror(host, mirror)));
+            if (registry.transport != null) {
+                this.registry.setTransport(registry.transport);
+            }
+            if (registry.proxyConfig != null) {
+                this.registry.setProxyConfig(registry.proxyConfig);
+            }
             return this;
         }
 
@@ -1845,6 +4055,32 @@ public final class Registry extends OCI<ContainerRef> {
             return this;
         }
 
+        /**
+         * Set the executor service to use for asynchronous operations (like pushArtifactAsync() or getManifestAsync()).
+         * The operations block a thread of this executor until they complete. By default it uses virtual threads
+         * on Java 21 or later, otherwise a pool of at most {@link Registry#DEFAULT_ASYNC_THREADS} daemon threads
+         * queuing up to {@link Registry#DEFAULT_ASYNC_QUEUE_SIZE} operations.
+         * Must not be the same executor service as the one used for layers upload/download to avoid starvation.
+         * @param asyncExecutorService The executor service
+         * @return The builder
+         */
+        public Builder withAsyncExecutorService(ExecutorService asyncExecutorService) {
+            registry.setAsyncExecutorService(asyncExecutorService);
+            return this;
+        }
+
+        /**
+         * Run the default executors on virtual threads (Java 21 or later). Transfers are still bounded by the
+         * parallelism. Blocking calls of the SDK don't pin the carrier thread: HTTP goes through the JDK client and
+         * no lock is held with synchronized while doing I/O. Ignored for executors set explicitly
+         * @param virtualThreads True to use virtual threads
+         * @return The builder
+         */
+        public Builder withVirtualThreads(boolean virtualThreads) {
+            registry.setVirtualThreads(virtualThreads);
+            return this;
+        }
+
         /**
          * Set the insecure flag
          * @param insecure Insecure
@@ -1916,6 +4152,126 @@ public final class Registry extends OCI<ContainerRef> {
             return this;
         }
 
+        /**
+         * Use a custom trust manager for TLS verification, for example backed by a private CA truststore
+         * @param trustManager The trust manager
+         * @return The builder
+         */
+        public Builder withTrustManager(X509TrustManager trustManager) {
+            registry.setTrustManager(trustManager);
+            return this;
+        }
+
+        /**
+         * Use a custom key manager providing the client certificate for mTLS
+         * @param keyManager The key manager
+         * @return The builder
+         */
+        public Builder withKeyManager(X509KeyManager keyManager) {
+            registry.setKeyManager(keyManager);
+            return this;
+        }
+
+        /**
+         * Use a client certificate for mTLS
+         * @param certificatePath The path to a PEM-encoded certificate chain
+         * @param keyPath The path to a PEM-encoded unencrypted PKCS#8 private key
+         * @return The builder
+         */
+        public Builder withClientCertificate(Path certificatePath, Path keyPath) {
+            registry.setClientCertificate(certificatePath, keyPath);
+            return this;
+        }
+
+        /**
+         * Whether the server hostname is verified against its certificate (default: true).
+         * Only disable for lab environments, the certificate chain is still verified.
+         * @param hostnameVerification False to skip hostname verification
+         * @return The builder
+         */
+        public Builder withHostnameVerification(boolean hostnameVerification) {
+            registry.setHostnameVerification(hostnameVerification);
+            return this;
+        }
+
+        /**
+         * Set the connection settings for a single host, for example to reach a local registry over plain HTTP
+         * while other registries use HTTPS. Host settings take precedence over registries.conf insecure entries.
+         * @param host The host, including the port if not the default one (e.g. localhost:5000)
+         * @param hostConfig The host configuration
+         * @return The builder
+         */
+        public Builder withHostConfig(String host, HostConfig hostConfig) {
+            registry.setHostConfig(host, hostConfig);
+            return this;
+        }
+
+        /**
+         * Add a mirror for an upstream registry, for example to pull docker.io content through an internal mirror.
+         * Mirrors are tried in order when resolving and fetching content, before the upstream registry which is used
+         * on miss. Mirrors of registries.conf are tried after the ones configured here.
+         * @param host The upstream registry host, including the port if not the default one (e.g. docker.io)
+         * @param location The mirror location (host[:port][/path])
+         * @return The builder
+         */
+        public Builder withMirror(String host, String location) {
+            return withMirror(host, RegistriesConf.MirrorConfig.of(location));
+        }
+
+        /**
+         * Add a mirror for an upstream registry
+         * @param host The upstream registry host, including the port if not the default one (e.g. docker.io)
+         * @param mirror The mirror configuration
+         * @return The builder
+         */
+        public Builder withMirror(String host, RegistriesConf.MirrorConfig mirror) {
+            registry.addMirror(host, mirror);
+            return this;
+        }
+
+        /**
+         * Set the HTTP(S) proxy configuration.
+         * By default, the {@code https_proxy}, {@code http_proxy} and {@code no_proxy} environment variables are used.
+         * Use {@link ProxyConfig#direct()} to ignore them.
+         * @param proxyConfig The proxy configuration
+         * @return The builder
+         */
+        public Builder withProxy(ProxyConfig proxyConfig) {
+            registry.setProxyConfig(proxyConfig);
+            return this;
+        }
+
+        /**
+         * Use a custom transport to send HTTP requests, for example to route them through another HTTP library.
+         * TLS settings of this builder cannot be combined with a custom transport.
+         * @param transport The transport
+         * @return The builder
+         */
+        public Builder withTransport(HttpTransport transport) {
+            registry.setTransport(transport);
+            return this;
+        }
+
+        /**
+         * Use a pre-configured JDK HTTP client to send HTTP requests
+         * @param httpClient The JDK HTTP client
+         * @return The builder
+         */
+        public Builder withHttpClient(java.net.http.HttpClient httpClient) {
+            return withTransport(HttpTransport.of(httpClient));
+        }
+
+        /**
+         * Set the retry policy for transient failures (default: {@link RetryPolicy#defaults()}).
+         * Streamed uploads and chunks are only retried if the policy allows retrying non-idempotent requests.
+         * @param retryPolicy The retry policy
+         * @return The builder
+         */
+        public Builder withRetryPolicy(RetryPolicy retryPolicy) {
+            registry.setRetryPolicy(retryPolicy);
+            return this;
+        }
+
         /**
          * Set the maximum number of attempts for retryable requests (default: 3).
          * A value of 1 disables retries entirely.
@@ -1925,7 +4281,7 @@ public final class Registry extends OCI<ContainerRef> {
          * @return The builder
          */
         public Builder withMaxRetries(int maxRetries) {
-            registry.setMaxRetries(maxRetries);
+            registry.setRetryPolicy(registry.retryPolicy.withMaxAttempts(maxRetries));
             return this;
         }
 
@@ -1936,7 +4292,7 @@ public final class Registry extends OCI<ContainerRef> {
          * @return The builder
          */
         public Builder withRetryDelay(long retryDelayMs) {
-            registry.setRetryDelayMs(retryDelayMs);
+            registry.setRetryPolicy(registry.retryPolicy.withInitialDelay(Duration.ofMillis(retryDelayMs)));
             return this;
         }
 
@@ -1946,7 +4302,39 @@ public final class Registry extends OCI<ContainerRef> {
          * @return The builder
          */
         public Builder withMaxRetryDelay(long maxRetryDelayMs) {
-            registry.setMaxRetryDelayMs(maxRetryDelayMs);
+            registry.setRetryPolicy(registry.retryPolicy.withMaxDelay(Duration.ofMillis(maxRetryDelayMs)));
+            return this;
+        }
+
+        /**
+         * Whether pull-only token requests are retried anonymously when the credentials are rejected (default: true).
+         * Public registries allow anonymous pulls, so stale local credentials don't break read paths.
+         * @param anonymousFallback True to retry anonymously
+         * @return The builder
+         */
+        public Builder withAnonymousFallback(boolean anonymousFallback) {
+            registry.setAnonymousFallback(anonymousFallback);
+            return this;
+        }
+
+        /**
+         * Enforce digest pinning: resolving, reading or pulling a reference without digest fails unless the
+         * reference is explicitly allowed with {@link #withAllowedTagReference(String)}. Pushes are not affected.
+         * @param digestPinning True to reject references without digest
+         * @return The builder
+         */
+        public Builder withDigestPinning(boolean digestPinning) {
+            registry.setDigestPinning(digestPinning);
+            return this;
+        }
+
+        /**
+         * Allow a tag reference on read operations when digest pinning is enabled
+         * @param reference The tag reference (e.g. docker.io/library/alpine:latest)
+         * @return The builder
+         */
+        public Builder withAllowedTagReference(String reference) {
+            registry.addAllowedTagReference(reference);
             return this;
         }
 
@@ -1985,6 +4373,338 @@ public final class Registry extends OCI<ContainerRef> {
             return this;
         }
 
+        /**
+         * Use chunked uploads (PATCH with Content-Range) for blobs larger than the chunk size, pushed from files or
+         * streams. Smaller blobs are uploaded monolithically.
+         * Useful for registries behind proxies rejecting large monolithic uploads.
+         * Default is 0 which means monolithic uploads unless requested via {@link PushOptions#chunked()}.
+         * Each chunk is held in memory while it is sent, so an upload uses up to {@code chunkSize} bytes
+         * and a push up to {@code chunkSize} times the parallelism. Larger chunks mean fewer requests.
+         * @param chunkSize Maximum number of bytes per chunk. Zero or negative to disable chunked uploads
+         * @return The builder
+         */
+        public Builder withChunkSize(long chunkSize) {
+            registry.setChunkSize(chunkSize);
+            return this;
+        }
+
+        /**
+         * Set the size of the buffer used to stream blobs to disk. Default is {@value Registry#DEFAULT_BUFFER_SIZE}
+         * bytes. One buffer is allocated per concurrent download, so memory use is up to {@code bufferSize} times
+         * the parallelism. Larger buffers reduce the number of reads on fast networks
+         * @param bufferSize The buffer size in bytes. Must be positive
+         * @return The builder
+         */
+        public Builder withBufferSize(int bufferSize) {
+            if (bufferSize <= 0) {
+                throw new OrasException("Buffer size must be positive");
+            }
+            registry.setBufferSize(bufferSize);
+            return this;
+        }
+
+        /**
+         * Set the timeout to establish a connection to the registry. Default to 60 seconds
+         * @param connectTimeout The connect timeout
+         * @return The builder
+         */
+        public Builder withConnectTimeout(Duration connectTimeout) {
+            if (connectTimeout.isNegative() || connectTimeout.isZero()) {
+                throw new OrasException("Connect timeout must be positive");
+            }
+            registry.setConnectTimeout(connectTimeout);
+            return this;
+        }
+
+        /**
+         * Set the timeout waiting for the response headers of each request. Reading the body of a blob is not
+         * subject to this timeout, so it doesn't limit the size of the blobs. Default to no timeout
+         * @param readTimeout The read timeout
+         * @return The builder
+         */
+        public Builder withReadTimeout(Duration readTimeout) {
+            if (readTimeout.isNegative() || readTimeout.isZero()) {
+                throw new OrasException("Read timeout must be positive");
+            }
+            registry.setReadTimeout(readTimeout);
+            return this;
+        }
+
+        /**
+         * Enable or disable HTTP/2. When enabled (the default), HTTP/2 is negotiated with the registries supporting
+         * it. Disable it for registries mishandling HTTP/2 uploads, or use {@link HostConfig#withHttp1(boolean)}
+         * to only downgrade some hosts
+         * @param http2 Whether to allow HTTP/2
+         * @return The builder
+         */
+        public Builder withHttp2(boolean http2) {
+            registry.setHttp2(http2);
+            return this;
+        }
+
+        /**
+         * Limit the number of HTTP requests in flight to a single host, for example to not overload a registry
+         * with parallel transfers. With HTTP/1.1 this bounds the number of connections opened to the host
+         * @param maxRequestsPerHost The maximum number of requests. Zero or negative for no limit (default)
+         * @return The builder
+         */
+        public Builder withMaxRequestsPerHost(int maxRequestsPerHost) {
+            registry.setMaxRequestsPerHost(maxRequestsPerHost);
+            return this;
+        }
+
+        /**
+         * Set the strategy used to push blobs from streams of unknown length.
+         * Default is {@link UnknownSizeStrategy#CHUNKED}
+         * @param unknownSizeStrategy The strategy
+         * @return The builder
+         */
+        public Builder withUnknownSizeStrategy(UnknownSizeStrategy unknownSizeStrategy) {
+            registry.setUnknownSizeStrategy(Objects.requireNonNull(unknownSizeStrategy, "unknownSizeStrategy"));
+            return this;
+        }
+
+        /**
+         * Set the digest algorithm used for pushed content when the reference doesn't have a digest.
+         * Default to sha256.
+         * @param digestAlgorithm The digest algorithm
+         * @return The builder
+         */
+        public Builder withDigestAlgorithm(DigestAlgorithm digestAlgorithm) {
+            registry.setDigestAlgorithm(digestAlgorithm);
+            return this;
+        }
+
+        /**
+         * Set the listener notified of blob transfers during push and pull
+         * @param transferListener The transfer listener
+         * @return The builder
+         */
+        public Builder withTransferListener(TransferListener transferListener) {
+            registry.setTransferListener(transferListener);
+            return this;
+        }
+
+        /**
+         * Set a listener notified of high-level operations like resolves, pushes and copies
+         * @param operationListener The operation listener
+         * @return The builder
+         */
+        public Builder withOperationListener(OperationListener operationListener) {
+            registry.setOperationListener(operationListener);
+            return this;
+        }
+
+        /**
+         * Set the tracer creating a span for each resolve, push and pull operation.
+         * The context of the current span is propagated to the registry in the request headers.
+         * @param operationTracer The operation tracer, for example {@link OpenTelemetryTracer}
+         * @return The builder
+         */
+        public Builder withOperationTracer(OperationTracer operationTracer) {
+            registry.setOperationTracer(operationTracer);
+            return this;
+        }
+
+        /**
+         * Set the listener notified of warnings returned by the registry in {@code Warning} headers,
+         * like deprecation notices or quota warnings. By default warnings are logged
+         * @param warningListener The warning listener
+         * @return The builder
+         */
+        public Builder withWarningListener(WarningListener warningListener) {
+            registry.setWarningListener(warningListener);
+            return this;
+        }
+
+        /**
+         * Add an interceptor of every HTTP request, including token requests, retries and redirects.
+         * Runs after the {@code Authorization} header is attached unless the interceptor declares another stage
+         * @param interceptor The interceptor
+         * @return The builder
+         */
+        public Builder withInterceptor(HttpInterceptor interceptor) {
+            registry.addInterceptor(Objects.requireNonNull(interceptor, "interceptor"));
+            return this;
+        }
+
+        /**
+         * Add an interceptor of every HTTP request running at the given stage
+         * @param interceptor The interceptor
+         * @param stage Whether the interceptor runs before or after the {@code Authorization} header is attached
+         * @return The builder
+         */
+        public Builder withInterceptor(HttpInterceptor interceptor, HttpInterceptor.Stage stage) {
+            return withInterceptor(HttpInterceptor.at(stage, interceptor));
+        }
+
+        /**
+         * Append a suffix to the User-Agent header of every HTTP request, to identify the client application
+         * @param userAgentSuffix The suffix, like {@code my-app/1.0}
+         * @return The builder
+         */
+        public Builder withUserAgentSuffix(String userAgentSuffix) {
+            registry.setUserAgentSuffix(Objects.requireNonNull(userAgentSuffix, "userAgentSuffix"));
+            return this;
+        }
+
+        /**
+         * Add a header to every HTTP request, unless the request already sets it.
+         * The {@code Authorization} and {@code User-Agent} headers cannot be set this way
+         * @param name The header name
+         * @param value The header value
+         * @return The builder
+         */
+        public Builder withDefaultHeader(String name, String value) {
+            registry.addDefaultHeader(
+                    Objects.requireNonNull(name, "name"), Objects.requireNonNull(value, "value"), false);
+            return this;
+        }
+
+        /**
+         * Add headers to every HTTP request, unless the request already sets them
+         * @param headers The headers
+         * @return The builder
+         */
+        public Builder withDefaultHeaders(Map<String, String> headers) {
+            headers.forEach(this::withDefaultHeader);
+            return this;
+        }
+
+        /**
+         * Add a header to every HTTP request like {@link #withDefaultHeader(String, String)}, but redact its value
+         * from the logs, like API keys
+         * @param name The header name
+         * @param value The header value
+         * @return The builder
+         */
+        public Builder withSensitiveDefaultHeader(String name, String value) {
+            registry.addDefaultHeader(
+                    Objects.requireNonNull(name, "name"), Objects.requireNonNull(value, "value"), true);
+            return this;
+        }
+
+        /**
+         * Cache tag to digest resolutions for the given time to live, so repeated operations on the same tag
+         * skip the HEAD request. Entries are invalidated when a tag is pushed or deleted through this registry.
+         * Use {@link Registry#invalidateResolveCache(ContainerRef)} when tags are updated by other clients.
+         * By default resolutions are not cached
+         * @param ttl The time to live of cached resolutions. Zero to disable the cache
+         * @return The builder
+         */
+        public Builder withResolveCacheTtl(Duration ttl) {
+            registry.setResolveCache(new ResolveCache(ttl));
+            return this;
+        }
+
+        /**
+         * Add a verifier invoked on pull with the resolved manifest and its referrers.
+         * The pull fails before writing any file if a verifier rejects the artifact.
+         * @param verifier The verifier
+         * @return The builder
+         */
+        public Builder withVerifier(ArtifactVerifier verifier) {
+            registry.addVerifier(verifier);
+            return this;
+        }
+
+        /**
+         * Add a signer invoked after each manifest or index is pushed, for example a
+         * {@link land.oras.signing.NotationSigner}. The signature is pushed as a referrer of the artifact, and
+         * the push fails if signing fails. The artifact itself stays pushed in this case.
+         * @param signer The signer
+         * @return The builder
+         */
+        public Builder withSigner(ArtifactSigner signer) {
+            registry.addSigner(signer);
+            return this;
+        }
+
+        /**
+         * Add a repository on the same registry to mount blobs from when pushing.
+         * Before uploading a missing blob, a cross-repository mount is attempted from each source in order
+         * and the upload proceeds as usual if the registry doesn't mount it.
+         * @param repository The source repository (for example {@code library/alpine})
+         * @return The builder
+         */
+        public Builder withMountSource(String repository) {
+            registry.addMountSource(repository);
+            return this;
+        }
+
+        /**
+         * Limit the total bandwidth of blob uploads and downloads of this registry
+         * @param bytesPerSecond The maximum number of bytes per second for all transfers
+         * @return The builder
+         */
+        public Builder withBandwidthLimit(long bytesPerSecond) {
+            registry.setBandwidthLimiter(BandwidthLimiter.of(bytesPerSecond));
+            return this;
+        }
+
+        /**
+         * Limit the total bandwidth of blob uploads and downloads with a limiter that can be shared between registries.
+         * For example to throttle both the source and target of a copy.
+         * @param bandwidthLimiter The limiter
+         * @return The builder
+         */
+        public Builder withBandwidthLimiter(BandwidthLimiter bandwidthLimiter) {
+            registry.setBandwidthLimiter(bandwidthLimiter);
+            return this;
+        }
+
+        /**
+         * Limit the bandwidth of each blob upload and download
+         * @param bytesPerSecond The maximum number of bytes per second per transfer. Zero or negative for unlimited
+         * @return The builder
+         */
+        public Builder withTransferBandwidthLimit(long bytesPerSecond) {
+            registry.setTransferBandwidthLimit(bytesPerSecond);
+            return this;
+        }
+
+        /**
+         * Set how many times an interrupted download of a blob referenced by digest is resumed with a range request.
+         * Defaults to 3
+         * @param attempts The maximum number of resume attempts. Zero to always fail on interrupted downloads
+         * @return The builder
+         */
+        public Builder withDownloadResumeAttempts(int attempts) {
+            registry.setDownloadResumeAttempts(Math.max(0, attempts));
+            return this;
+        }
+
+        /**
+         * Use a local cache of blobs. Blobs fetched by digest are served from the cache when present
+         * and added to it once verified. The cache can be shared between registries
+         * @param blobCache The blob cache
+         * @return The builder
+         */
+        public Builder withBlobCache(BlobCache blobCache) {
+            registry.setBlobCache(blobCache);
+            return this;
+        }
+
+        /**
+         * Set the maximum size of manifests and indexes accepted from the registry. Defaults to 4 MiB
+         * @param maxSize The maximum size in bytes. Zero or negative for unlimited
+         * @return The builder
+         */
+        public Builder withMaxManifestSize(long maxSize) {
+            registry.setMaxManifestSize(maxSize);
+            return this;
+        }
+
+        /**
+         * Set the maximum size of blobs accepted from the registry. Unlimited by default
+         * @param maxSize The maximum size in bytes. Zero or negative for unlimited
+         * @return The builder
+         */
+        public Builder withMaxBlobSize(long maxSize) {
+            registry.setMaxBlobSize(maxSize);
+            return this;
+        }
+
         /**
          * Return a new builder
          * @return The builder
@@ -0,0 +1,167 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import io.micrometer.core.instrument.Counter;
+import io.micrometer.core.instrument.MeterRegistry;
+import io.micrometer.core.instrument.Timer;
+import java.io.FilterInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.util.concurrent.TimeUnit;
+import java.util.function.Supplier;
+import land.oras.utils.Const;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Record registry operations, bytes transferred and reused blobs on a Micrometer registry.
+ * Metrics are tagged by registry host and operation.
+ */
+@NullMarked
+final class RegistryMetrics {
+
+    /**
+     * Blob already exists on the target
+     */
+    static final String REUSE_EXISTS = "exists";
+
+    /**
+     * Blob mounted from another repository
+     */
+    static final String REUSE_MOUNTED = "mounted";
+
+    /**
+     * The meter registry
+     */
+    private final MeterRegistry meterRegistry;
+
+    /**
+     * Constructor
+     * @param meterRegistry The meter registry
+     */
+    RegistryMetrics(MeterRegistry meterRegistry) {
+        this.meterRegistry = meterRegistry;
+    }
+
+    /**
+     * Time the given operation and record its outcome
+     * @param operation The operation
+     * @param ref The container ref
+     * @param supplier The operation to execute
+     * @param <T> The result type
+     * @return The result of the operation
+     */
+    <T> T record(String operation, ContainerRef ref, Supplier<T> supplier) {
+        long start = System.nanoTime();
+        String outcome = "error";
+        try {
+            T result = supplier.get();
+            outcome = "success";
+            return result;
+        } finally {
+            Timer.builder(Const.METRIC_OPERATIONS)
+                    .tag(Const.METRIC_TAG_HOST, host(ref))
+                    .tag(Const.METRIC_TAG_OPERATION, operation)
+                    .tag(Const.METRIC_TAG_OUTCOME, outcome)
+                    .register(meterRegistry)
+                    .record(System.nanoTime() - start, TimeUnit.NANOSECONDS);
+        }
+    }
+
+    /**
+     * Record bytes transferred by a blob upload or download
+     * @param operation The operation
+     * @param ref The container ref
+     * @param bytes The number of bytes
+     */
+    void recordBytes(String operation, ContainerRef ref, long bytes) {
+        if (bytes <= 0) {
+            return;
+        }
+        Counter.builder(Const.METRIC_TRANSFER_BYTES)
+                .baseUnit("bytes")
+                .tag(Const.METRIC_TAG_HOST, host(ref))
+                .tag(Const.METRIC_TAG_OPERATION, operation)
+                .register(meterRegistry)
+                .increment(bytes);
+    }
+
+    /**
+     * Record a blob that didn't need to be uploaded
+     * @param ref The container ref
+     * @param reason The reason, either {@link #REUSE_EXISTS} or {@link #REUSE_MOUNTED}
+     */
+    void recordBlobReused(ContainerRef ref, String reason) {
+        Counter.builder(Const.METRIC_BLOBS_REUSED)
+                .tag(Const.METRIC_TAG_HOST, host(ref))
+                .tag("reason", reason)
+                .register(meterRegistry)
+                .increment();
+    }
+
+    /**
+     * Wrap the stream of a blob download to record the bytes read once closed
+     * @param operation The operation
+     * @param ref The container ref
+     * @param in The input stream
+     * @return The counting input stream
+     */
+    InputStream countBytes(String operation, ContainerRef ref, InputStream in) {
+        return new FilterInputStream(in) {
+            private long count;
+            private boolean closed;
+
+            @Override
+            public int read() throws IOException {
+                int b = super.read();
+                if (b >= 0) {
+                    count++;
+                }
+                return b;
+            }
+
+            @Override
+            public int read(byte[] b, int off, int len) throws IOException {
+                int n = super.read(b, off, len);
+                if (n > 0) {
+                    count += n;
+                }
+                return n;
+            }
+
+            @Override
+            public void close() throws IOException {
+                try {
+                    super.close();
+                } finally {
+                    if (!closed) {
+                        closed = true;
+                        recordBytes(operation, ref, count);
+                    }
+                }
+            }
+        };
+    }
+
+    private static String host(ContainerRef ref) {
+        return ref.getRegistry();
+    }
+}
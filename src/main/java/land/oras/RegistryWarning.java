@@ -0,0 +1,67 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * A warning returned by a registry in a {@code Warning} response header, like deprecation notices or quota warnings.
+ * See <a href="https://github.com/opencontainers/distribution-spec/blob/main/spec.md#warnings">Warnings</a>
+ * @param host The registry host returning the warning
+ * @param code The warning code, {@link #MISCELLANEOUS_PERSISTENT_WARNING} for registries following the spec
+ * @param agent The warning agent, usually {@code -}
+ * @param text The warning text
+ */
+@NullMarked
+public record RegistryWarning(String host, int code, String agent, String text) {
+
+    /**
+     * Warning code used by the distribution spec
+     */
+    public static final int MISCELLANEOUS_PERSISTENT_WARNING = 299;
+
+    /**
+     * A warning value: warn-code SP warn-agent SP warn-text [SP warn-date]
+     */
+    private static final Pattern WARNING_VALUE =
+            Pattern.compile("(\\d{3})\\s+(\\S+)\\s+\"((?:[^\"\\\\]|\\\\.)*)\"(?:\\s+\"[^\"]*\")?");
+
+    /**
+     * Parse the warnings of a Warning header value. A single header might contain several comma separated warnings.
+     * Malformed values are ignored.
+     * @param host The registry host returning the header
+     * @param value The header value
+     * @return The warnings
+     */
+    public static List<RegistryWarning> parse(String host, String value) {
+        List<RegistryWarning> warnings = new ArrayList<>();
+        Matcher matcher = WARNING_VALUE.matcher(value);
+        while (matcher.find()) {
+            String text = matcher.group(3).replaceAll("\\\\(.)", "$1");
+            warnings.add(new RegistryWarning(host, Integer.parseInt(matcher.group(1)), matcher.group(2), text));
+        }
+        return warnings;
+    }
+}
@@ -20,13 +20,35 @@
 
 package land.oras;
 
+import com.fasterxml.jackson.annotation.JsonInclude;
 import java.util.List;
 import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
 
 /**
  * The repositories response object
  * @param repositories The repositories
+ * @param last The last repository index, to iterate
  */
 @NullMarked
 @OrasModel
-public record Repositories(List<String> repositories) {}
+@JsonInclude(JsonInclude.Include.NON_NULL)
+public record Repositories(List<String> repositories, @Nullable String last) {
+
+    /**
+     * Constructor without last
+     * @param repositories The repositories
+     */
+    public Repositories(List<String> repositories) {
+        this(repositories, null);
+    }
+
+    /**
+     * With last
+     * @param last The last repository index, to iterate
+     * @return A new Repositories object with the last index
+     */
+    public Repositories withLast(@Nullable String last) {
+        return new Repositories(this.repositories, last);
+    }
+}
@@ -0,0 +1,158 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.time.Clock;
+import java.time.Duration;
+import java.time.Instant;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Cache of tag to digest resolutions with a time to live.
+ * A zero or negative time to live disables the cache.
+ */
+@NullMarked
+final class ResolveCache {
+
+    /**
+     * The time to live of entries
+     */
+    private final Duration ttl;
+
+    /**
+     * The clock used to expire entries
+     */
+    private final Clock clock;
+
+    /**
+     * The entries keyed by registry, repository and tag
+     */
+    private final Map<String, Entry> entries = new ConcurrentHashMap<>();
+
+    /**
+     * A resolved tag
+     * @param digest The digest
+     * @param mediaType The media type
+     * @param registry The registry that resolved the tag
+     * @param expiresAt The expiration time
+     */
+    record Entry(String digest, @Nullable String mediaType, String registry, Instant expiresAt) {}
+
+    /**
+     * Constructor
+     * @param ttl The time to live of entries
+     */
+    ResolveCache(Duration ttl) {
+        this(ttl, Clock.systemUTC());
+    }
+
+    /**
+     * Constructor
+     * @param ttl The time to live of entries
+     * @param clock The clock used to expire entries
+     */
+    ResolveCache(Duration ttl, Clock clock) {
+        this.ttl = ttl;
+        this.clock = clock;
+    }
+
+    /**
+     * A disabled cache
+     * @return The cache
+     */
+    static ResolveCache disabled() {
+        return new ResolveCache(Duration.ZERO);
+    }
+
+    /**
+     * Return if the cache is enabled
+     * @return True if entries are cached
+     */
+    boolean isEnabled() {
+        return !ttl.isZero() && !ttl.isNegative();
+    }
+
+    /**
+     * Get the time to live of entries
+     * @return The time to live
+     */
+    Duration getTtl() {
+        return ttl;
+    }
+
+    /**
+     * Get a non expired entry
+     * @param key The key
+     * @return The entry or null if missing or expired
+     */
+    @Nullable
+    Entry get(String key) {
+        Entry entry = entries.get(key);
+        if (entry == null) {
+            return null;
+        }
+        if (!clock.instant().isBefore(entry.expiresAt())) {
+            entries.remove(key, entry);
+            return null;
+        }
+        return entry;
+    }
+
+    /**
+     * Cache a resolution
+     * @param key The key
+     * @param digest The digest
+     * @param mediaType The media type
+     * @param registry The registry that resolved the tag
+     */
+    void put(String key, String digest, @Nullable String mediaType, String registry) {
+        if (!isEnabled()) {
+            return;
+        }
+        entries.put(key, new Entry(digest, mediaType, registry, clock.instant().plus(ttl)));
+    }
+
+    /**
+     * Invalidate an entry
+     * @param key The key
+     */
+    void invalidate(String key) {
+        entries.remove(key);
+    }
+
+    /**
+     * Invalidate all entries resolved to the given digest
+     * @param digest The digest
+     */
+    void invalidateDigest(@Nullable String digest) {
+        entries.values().removeIf(entry -> entry.digest().equals(digest));
+    }
+
+    /**
+     * Invalidate all entries
+     */
+    void invalidateAll() {
+        entries.clear();
+    }
+}
@@ -0,0 +1,82 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.io.FilterInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.InterruptedIOException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * An input stream waiting on a {@link BandwidthLimiter} for every byte read
+ */
+@NullMarked
+final class ThrottledInputStream extends FilterInputStream {
+
+    private final BandwidthLimiter limiter;
+
+    /**
+     * Constructor
+     * @param in The input stream to wrap
+     * @param limiter The limiter
+     */
+    ThrottledInputStream(InputStream in, BandwidthLimiter limiter) {
+        super(in);
+        this.limiter = limiter;
+    }
+
+    @Override
+    public int read() throws IOException {
+        int b = super.read();
+        if (b != -1) {
+            throttle(1);
+        }
+        return b;
+    }
+
+    @Override
+    public int read(byte[] b, int off, int len) throws IOException {
+        int read = super.read(b, off, len);
+        if (read > 0) {
+            throttle(read);
+        }
+        return read;
+    }
+
+    @Override
+    public long skip(long n) throws IOException {
+        long skipped = super.skip(n);
+        if (skipped > 0) {
+            throttle(skipped);
+        }
+        return skipped;
+    }
+
+    private void throttle(long count) throws InterruptedIOException {
+        try {
+            limiter.acquire(count);
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+            throw new InterruptedIOException("Interrupted while waiting for bandwidth");
+        }
+    }
+}
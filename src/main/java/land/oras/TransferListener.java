@@ -0,0 +1,62 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Listener notified of blob transfers during push and pull operations.
+ * All methods have a no-op default implementation so consumers only need to override the callbacks they need.
+ * Callbacks might be invoked concurrently from different threads when layers are transferred in parallel.
+ */
+@NullMarked
+public interface TransferListener {
+
+    /**
+     * Called when the transfer of a blob starts
+     * @param digest The digest of the blob, empty when a stream is pushed without knowing its digest upfront
+     * @param totalSize The total size of the blob in bytes or -1 if unknown
+     */
+    default void onStarted(String digest, long totalSize) {}
+
+    /**
+     * Called when bytes of a blob were transferred
+     * @param digest The digest of the blob
+     * @param bytesTransferred The total number of bytes transferred so far for this blob
+     * @param totalSize The total size of the blob in bytes or -1 if unknown
+     */
+    default void onProgress(String digest, long bytesTransferred, long totalSize) {}
+
+    /**
+     * Called when the transfer of a blob is completed
+     * @param digest The digest of the blob
+     * @param totalSize The total size of the blob in bytes
+     */
+    default void onCompleted(String digest, long totalSize) {}
+
+    /**
+     * Return a listener that ignore all events
+     * @return The listener
+     */
+    static TransferListener noop() {
+        return new TransferListener() {};
+    }
+}
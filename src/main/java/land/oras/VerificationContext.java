@@ -0,0 +1,112 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import java.util.function.BiFunction;
+import java.util.function.Function;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Carries the data an {@link ArtifactVerifier} needs to evaluate a pulled artifact.
+ * Referrers and blobs are only fetched when requested by the verifier.
+ */
+@NullMarked
+public final class VerificationContext {
+
+    private final ContainerRef ref;
+    private final Describable describable;
+    private final BiFunction<ContainerRef, @Nullable ArtifactType, Referrers> referrersFetcher;
+    private final Function<ContainerRef, Manifest> manifestFetcher;
+    private final Function<ContainerRef, byte[]> blobFetcher;
+
+    /**
+     * Create a verification context
+     * @param ref The reference of the artifact, pinned to its digest
+     * @param describable The resolved manifest or index
+     * @param referrersFetcher Fetches the referrers of a reference
+     * @param manifestFetcher Fetches a manifest
+     * @param blobFetcher Fetches a blob
+     */
+    VerificationContext(
+            ContainerRef ref,
+            Describable describable,
+            BiFunction<ContainerRef, @Nullable ArtifactType, Referrers> referrersFetcher,
+            Function<ContainerRef, Manifest> manifestFetcher,
+            Function<ContainerRef, byte[]> blobFetcher) {
+        this.ref = ref;
+        this.describable = describable;
+        this.referrersFetcher = referrersFetcher;
+        this.manifestFetcher = manifestFetcher;
+        this.blobFetcher = blobFetcher;
+    }
+
+    /**
+     * Return the reference of the artifact, pinned to its digest
+     * @return The reference
+     */
+    public ContainerRef getRef() {
+        return ref;
+    }
+
+    /**
+     * Return the digest of the artifact
+     * @return The digest
+     */
+    public String getDigest() {
+        return describable.getDescriptor().getDigest();
+    }
+
+    /**
+     * Return the resolved manifest or index of the artifact
+     * @return The manifest or index
+     */
+    public Describable getDescribable() {
+        return describable;
+    }
+
+    /**
+     * Return the referrers of the artifact
+     * @param artifactType The optional artifact type to filter on (e.g. a signature artifact type)
+     * @return The referrers
+     */
+    public Referrers getReferrers(@Nullable ArtifactType artifactType) {
+        return referrersFetcher.apply(ref, artifactType);
+    }
+
+    /**
+     * Fetch a manifest from the repository of the artifact, typically a referrer
+     * @param digest The digest of the manifest
+     * @return The manifest
+     */
+    public Manifest getManifest(String digest) {
+        return manifestFetcher.apply(ref.withDigest(digest));
+    }
+
+    /**
+     * Fetch a blob from the repository of the artifact, typically a signature layer of a referrer
+     * @param digest The digest of the blob
+     * @return The blob content
+     */
+    public byte[] getBlob(String digest) {
+        return blobFetcher.apply(ref.withDigest(digest));
+    }
+}
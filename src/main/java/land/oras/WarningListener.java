@@ -0,0 +1,39 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Listener notified of warnings returned by registries in {@code Warning} response headers.
+ * Each distinct warning is only delivered once per registry client.
+ * Callbacks might be invoked concurrently from different threads when layers are transferred in parallel.
+ */
+@NullMarked
+@FunctionalInterface
+public interface WarningListener {
+
+    /**
+     * Called when a registry returned a warning
+     * @param warning The warning
+     */
+    void onWarning(RegistryWarning warning);
+}
@@ -0,0 +1,232 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import com.azure.core.credential.AccessToken;
+import com.azure.core.credential.TokenCredential;
+import com.azure.core.credential.TokenRequestContext;
+import com.azure.identity.DefaultAzureCredentialBuilder;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.net.URI;
+import java.net.URLEncoder;
+import java.nio.charset.StandardCharsets;
+import java.time.Clock;
+import java.time.Duration;
+import java.time.Instant;
+import java.util.Base64;
+import java.util.LinkedHashMap;
+import java.util.Locale;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.locks.ReentrantLock;
+import java.util.stream.Collectors;
+import land.oras.ContainerRef;
+import land.oras.OrasModel;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.JsonUtils;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * A provider exchanging Microsoft Entra ID (AAD) tokens for Azure Container Registry refresh tokens.
+ * The Azure identity library is an optional dependency and must be added to the classpath to use this provider.
+ * The AAD token is exchanged on {@code /oauth2/exchange} and the ACR refresh token is sent as an identity token, so
+ * scoped access tokens are obtained by the regular token flow. Only {@code *.azurecr.io} hosts are handled, other
+ * hosts get no header so the provider can be added to a {@link CredentialChain}.
+ */
+@NullMarked
+public final class AcrAuthProvider implements AuthProvider {
+
+    /**
+     * Logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(AcrAuthProvider.class);
+
+    /**
+     * The AAD scope of container registries
+     */
+    static final String ACR_SCOPE = "https://containerregistry.azure.net/.default";
+
+    /**
+     * Refresh tokens are renewed this long before the AAD token expires
+     */
+    private static final Duration REFRESH_MARGIN = Duration.ofMinutes(5);
+
+    /**
+     * The AAD credential
+     */
+    private final TokenCredential credential;
+
+    /**
+     * The HTTP client used for the exchange
+     */
+    private final HttpClient client;
+
+    /**
+     * The clock
+     */
+    private final Clock clock;
+
+    /**
+     * The cached refresh tokens by registry
+     */
+    private final Map<String, CachedToken> tokens = new ConcurrentHashMap<>();
+
+    /**
+     * Lock held while exchanging a token
+     */
+    private final ReentrantLock refreshLock = new ReentrantLock();
+
+    /**
+     * Constructor
+     * @param credential The AAD credential
+     * @param client The HTTP client
+     * @param clock The clock
+     */
+    AcrAuthProvider(TokenCredential credential, HttpClient client, Clock clock) {
+        this.credential = credential;
+        this.client = client;
+        this.clock = clock;
+    }
+
+    /**
+     * Create a provider using the default Azure credential (environment, workload identity, managed identity and
+     * developer tools)
+     * @return The provider
+     */
+    public static AcrAuthProvider create() {
+        return of(new DefaultAzureCredentialBuilder().build());
+    }
+
+    /**
+     * Create a provider using the given AAD credential
+     * @param credential The AAD credential
+     * @return The provider
+     */
+    public static AcrAuthProvider of(TokenCredential credential) {
+        return new AcrAuthProvider(credential, HttpClient.Builder.builder().build(), Clock.systemUTC());
+    }
+
+    /**
+     * Return if the host is an Azure Container Registry
+     * @param host The registry host
+     * @return True if the host is an Azure Container Registry
+     */
+    public static boolean isAcrHost(String host) {
+        return host.toLowerCase(Locale.ROOT).endsWith(".azurecr.io");
+    }
+
+    @Override
+    public @Nullable String getAuthHeader(ContainerRef registry) {
+        String host = registry.getRegistry().toLowerCase(Locale.ROOT);
+        if (!isAcrHost(host)) {
+            return null;
+        }
+        CachedToken token = tokens.get(host);
+        if (!isFresh(token)) {
+            refreshLock.lock();
+            try {
+                token = tokens.get(host);
+                if (!isFresh(token)) {
+                    token = exchange(registry, host);
+                    tokens.put(host, token);
+                }
+            } finally {
+                refreshLock.unlock();
+            }
+        }
+        String credentials = Const.IDENTITY_TOKEN_USERNAME + ":" + token.refreshToken();
+        return "Basic " + Base64.getEncoder().encodeToString(credentials.getBytes(StandardCharsets.UTF_8));
+    }
+
+    @Override
+    public AuthScheme getAuthScheme() {
+        return AuthScheme.BASIC;
+    }
+
+    /**
+     * Return if the token can still be used
+     * @param token The cached token or null
+     * @return True if not expiring soon
+     */
+    private boolean isFresh(@Nullable CachedToken token) {
+        return token != null && clock.instant().isBefore(token.expiresAt().minus(REFRESH_MARGIN));
+    }
+
+    /**
+     * Exchange an AAD token for an ACR refresh token
+     * @param registry The container ref
+     * @param host The registry host
+     * @return The refresh token
+     */
+    private CachedToken exchange(ContainerRef registry, String host) {
+        AccessToken aadToken = credential.getTokenSync(new TokenRequestContext().addScopes(ACR_SCOPE));
+        if (aadToken == null) {
+            throw new OrasException("No AAD token available for %s".formatted(host));
+        }
+        LOG.debug("Exchanging AAD token for an ACR refresh token on {}", host);
+        Map<String, String> form = new LinkedHashMap<>();
+        form.put("grant_type", "access_token");
+        form.put("service", host);
+        form.put("access_token", aadToken.getToken());
+        byte[] body = form.entrySet().stream()
+                .map(entry -> URLEncoder.encode(entry.getKey(), StandardCharsets.UTF_8) + "="
+                        + URLEncoder.encode(entry.getValue(), StandardCharsets.UTF_8))
+                .collect(Collectors.joining("&"))
+                .getBytes(StandardCharsets.UTF_8);
+        HttpClient.ResponseWrapper<String> response = client.post(
+                URI.create("https://%s/oauth2/exchange".formatted(host)),
+                body,
+                Map.of(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_FORM_URLENCODED_HEADER_VALUE),
+                Scopes.of(registry),
+                new NoAuthProvider());
+        if (response.statusCode() < 200 || response.statusCode() >= 300) {
+            throw new OrasException(
+                    response.statusCode(),
+                    "Unable to exchange AAD token for an ACR refresh token on %s".formatted(host));
+        }
+        ExchangeResponse exchange = JsonUtils.fromJson(response.response(), ExchangeResponse.class);
+        if (exchange.refreshToken() == null) {
+            throw new OrasException("No refresh token returned by %s".formatted(host));
+        }
+        Instant expiresAt = aadToken.getExpiresAt() != null
+                ? aadToken.getExpiresAt().toInstant()
+                : clock.instant().plus(Duration.ofHours(1));
+        return new CachedToken(exchange.refreshToken(), expiresAt);
+    }
+
+    /**
+     * The response of the exchange endpoint
+     * @param refreshToken The ACR refresh token
+     */
+    @OrasModel
+    private record ExchangeResponse(@JsonProperty("refresh_token") @Nullable String refreshToken) {}
+
+    /**
+     * A cached refresh token
+     * @param refreshToken The ACR refresh token
+     * @param expiresAt The expiry
+     */
+    private record CachedToken(String refreshToken, Instant expiresAt) {}
+}
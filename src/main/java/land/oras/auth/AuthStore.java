@@ -22,11 +22,11 @@ package land.oras.auth;
 
 import com.fasterxml.jackson.annotation.JsonProperty;
 import java.io.IOException;
-import java.io.OutputStream;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.util.ArrayList;
+import java.util.Base64;
 import java.util.List;
 import java.util.Map;
 import java.util.Objects;
@@ -34,6 +34,7 @@ import java.util.concurrent.ConcurrentHashMap;
 import land.oras.ContainerRef;
 import land.oras.OrasModel;
 import land.oras.exception.OrasException;
+import land.oras.utils.Const;
 import land.oras.utils.JsonUtils;
 import org.jspecify.annotations.NullMarked;
 import org.jspecify.annotations.Nullable;
@@ -90,7 +91,8 @@ public class AuthStore {
     /**
      * Creates a new FileStore from default location.
      * If the {@code REGISTRY_AUTH_FILE} environment variable is set it is used exclusively.
-     * Otherwise, the Docker config and (when {@code XDG_RUNTIME_DIR} is set) the Podman auth file are searched.
+     * Otherwise, the Docker config (from {@code DOCKER_CONFIG} or {@code ~/.docker}) and (when {@code XDG_RUNTIME_DIR}
+     * is set) the Podman auth file are searched.
      *
      * @return FileStore instance.
      */
@@ -103,6 +105,45 @@ public class AuthStore {
         return newStore(defaultAuthPaths());
     }
 
+    /**
+     * Creates a new FileStore from a Kubernetes {@code kubernetes.io/dockerconfigjson} secret payload.
+     * The payload is the value of the {@code .dockerconfigjson} key, either decoded or base64 encoded as found in the
+     * secret data. Only the {@code auths} section is used, credential helpers of the payload are ignored.
+     *
+     * @param payload The secret payload.
+     * @return FileStore instance.
+     */
+    public static AuthStore fromDockerConfigJson(byte[] payload) {
+        String json = new String(payload, StandardCharsets.UTF_8).trim();
+        if (!json.startsWith("{")) {
+            try {
+                json = new String(Base64.getMimeDecoder().decode(json), StandardCharsets.UTF_8);
+            } catch (IllegalArgumentException e) {
+                throw new OrasException("Invalid dockerconfigjson payload: neither JSON nor base64", e);
+            }
+        }
+        ConfigFile configFile = JsonUtils.fromJson(json, ConfigFile.class);
+        if (configFile.auths() == null) {
+            throw new OrasException("Invalid dockerconfigjson payload: missing auths section");
+        }
+        return new AuthStore(Config.load(List.of(new ConfigFile(configFile.auths(), null, null))));
+    }
+
+    /**
+     * Creates a new FileStore from a mounted Kubernetes {@code kubernetes.io/dockerconfigjson} secret file.
+     * For example {@code /var/run/secrets/pull-secret/.dockerconfigjson}.
+     *
+     * @param path Path to the mounted secret file.
+     * @return FileStore instance.
+     */
+    public static AuthStore fromDockerConfigJson(Path path) {
+        try {
+            return fromDockerConfigJson(Files.readAllBytes(path));
+        } catch (IOException e) {
+            throw new OrasException("Unable to read dockerconfigjson file: " + path, e);
+        }
+    }
+
     /**
      * Returns the ordered list of auth file paths to search when no {@code REGISTRY_AUTH_FILE} is set.
      * Docker config is always included; the Podman auth file is added when {@code XDG_RUNTIME_DIR} is set.
@@ -110,7 +151,10 @@ public class AuthStore {
      * @return list of candidate paths.
      */
     private static List<Path> defaultAuthPaths() {
-        Path dockerPath = Path.of(System.getProperty("user.home"), ".docker", "config.json");
+        String dockerConfigDir = System.getenv("DOCKER_CONFIG");
+        Path dockerPath = dockerConfigDir != null
+                ? Path.of(dockerConfigDir, "config.json")
+                : Path.of(System.getProperty("user.home"), ".docker", "config.json");
         String xdgRuntimeDir = System.getenv("XDG_RUNTIME_DIR");
         if (xdgRuntimeDir != null) {
             // https://docs.podman.io/en/stable/markdown/podman-login.1.html#description
@@ -139,7 +183,7 @@ public class AuthStore {
         if (helper == null) {
             return null;
         }
-        return "docker-credential-" + helper;
+        return CredentialHelper.BINARY_PREFIX + helper;
     }
 
     /**
@@ -150,7 +194,7 @@ public class AuthStore {
      */
     @OrasModel
     record ConfigFile(
-            Map<String, Map<String, String>> auths,
+            @Nullable Map<String, Map<String, String>> auths,
             @Nullable Map<String, String> credHelpers,
             @Nullable String credsStore) {
 
@@ -208,8 +252,21 @@ public class AuthStore {
                         configFile.credsStore != null
                                 ? Map.of(ALL_REGISTRIES_HELPER, configFile.credsStore)
                                 : Map.of());
-                configFile.auths.forEach((host, value) -> {
+                Map<String, Map<String, String>> auths = configFile.auths != null ? configFile.auths : Map.of();
+                auths.forEach((server, value) -> {
+                    String host = normalizeHost(server);
                     String auth = value.get("auth");
+                    String username = value.get("username");
+                    String password = value.get("password");
+                    String identityToken = value.get("identitytoken");
+                    if (identityToken != null && !identityToken.isEmpty()) {
+                        config.credentialStore.put(host, new Credential(Const.IDENTITY_TOKEN_USERNAME, identityToken));
+                        return;
+                    }
+                    if ((auth == null || auth.isEmpty()) && username != null && password != null) {
+                        config.credentialStore.put(host, new Credential(username, password));
+                        return;
+                    }
                     if (auth != null) {
                         String base64Decoded =
                                 new String(java.util.Base64.getDecoder().decode(auth), StandardCharsets.UTF_8);
@@ -227,6 +284,25 @@ public class AuthStore {
             return config;
         }
 
+        /**
+         * Normalize a server address from the auths section to the registry host used by container references.
+         * Docker stores Docker Hub credentials under the legacy {@code https://index.docker.io/v1/} key.
+         * @param server The server address
+         * @return The normalized host
+         */
+        static String normalizeHost(String server) {
+            String host = server.replaceFirst("^https?://", "");
+            if (host.endsWith("/")) {
+                host = host.substring(0, host.length() - 1);
+            }
+            if (host.equals("index.docker.io/v1")
+                    || host.equals("index.docker.io")
+                    || host.equals("registry-1.docker.io")) {
+                return Const.DEFAULT_REGISTRY;
+            }
+            return host;
+        }
+
         /**
          * Retrieves the {@code Credential} associated with the specified containerRef.
          * Implements hierarchical credential lookup from most-specific to least-specific.
@@ -272,7 +348,11 @@ public class AuthStore {
             if (helperSuffix != null) {
                 try {
                     LOG.debug("Using credential helper '{}' for registry '{}'", helperSuffix, registry);
-                    return getFromCredentialHelper(helperSuffix, registry);
+                    Credential credential =
+                            getFromCredentialHelper(helperSuffix, CredentialHelper.serverAddress(registry));
+                    if (credential != null) {
+                        return credential;
+                    }
                 } catch (OrasException e) {
                     LOG.warn("Failed to get credential from helper for registry {}: {}", registry, e.getMessage());
                 }
@@ -283,7 +363,11 @@ public class AuthStore {
             if (helperSuffix != null) {
                 try {
                     LOG.debug("Using all-registries credential helper for registry '{}'", registry);
-                    return getFromCredentialHelper(helperSuffix, registry);
+                    Credential credential =
+                            getFromCredentialHelper(helperSuffix, CredentialHelper.serverAddress(registry));
+                    if (credential != null) {
+                        return credential;
+                    }
                 } catch (OrasException e) {
                     LOG.warn(
                             "Failed to get credential from all-registries helper for registry {}: {}",
@@ -295,43 +379,10 @@ public class AuthStore {
             return null;
         }
 
-        private static Credential getFromCredentialHelper(String suffix, String hostname) throws OrasException {
-
+        private static @Nullable Credential getFromCredentialHelper(String suffix, String hostname)
+                throws OrasException {
             LOG.debug("Looking for credential helper 'docker-credential-{}' for hostname '{}'", suffix, hostname);
-
-            String binary = "docker-credential-" + suffix;
-            ProcessBuilder pb = new ProcessBuilder(binary, "get");
-
-            try {
-                Process proc = pb.start();
-
-                // Hostname is in stdin
-                try (OutputStream os = proc.getOutputStream()) {
-                    os.write(hostname.getBytes(StandardCharsets.UTF_8));
-                    os.flush();
-                }
-
-                // Wait
-                int exit = proc.waitFor();
-                if (exit != 0) {
-                    String stderr = new String(proc.getErrorStream().readAllBytes(), StandardCharsets.UTF_8);
-                    String stdout = new String(proc.getInputStream().readAllBytes(), StandardCharsets.UTF_8);
-                    String message = "Credential helper '%s' exited with code %d and error: '%s' and stdout '%s'."
-                            .formatted(binary, exit, stderr.trim(), stdout.trim());
-                    LOG.warn(message);
-                    throw new OrasException(message);
-                }
-
-                return JsonUtils.fromJson(proc.getInputStream(), CredentialHelperResponse.class)
-                        .asCredential();
-
-            } catch (IOException e) {
-                LOG.warn("Failed to execute credential helper '{}': {}", binary, e.getMessage());
-                throw new OrasException("Credential helper '" + binary + "' not found or IO error", e);
-            } catch (InterruptedException e) {
-                LOG.warn("Credential helper execution interrupted: {}", e.getMessage());
-                throw new OrasException("Credential helper execution interrupted", e);
-            }
+            return CredentialHelper.of(suffix).get(hostname);
         }
     }
 
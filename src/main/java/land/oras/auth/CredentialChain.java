@@ -0,0 +1,268 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Locale;
+import java.util.Map;
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * CredentialChain is an implementation of the {@link AuthProvider} interface that tries multiple providers in order.
+ * The first provider returning an authentication header wins. Per-host overrides take precedence over the chain.
+ * When no provider returns a header the request is sent anonymously.
+ */
+@NullMarked
+public final class CredentialChain implements AuthProvider {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(CredentialChain.class);
+
+    /**
+     * Default environment variable holding the username
+     */
+    public static final String ENV_USERNAME = "ORAS_USERNAME";
+
+    /**
+     * Default environment variable holding the password
+     */
+    public static final String ENV_PASSWORD = "ORAS_PASSWORD";
+
+    private final List<AuthProvider> providers;
+    private final Map<String, AuthProvider> overrides;
+
+    private CredentialChain(List<AuthProvider> providers, Map<String, AuthProvider> overrides) {
+        this.providers = Collections.unmodifiableList(providers);
+        this.overrides = Collections.unmodifiableMap(overrides);
+    }
+
+    /**
+     * Create the default chain: environment variables, docker config (including credential helpers) and anonymous
+     * @return The default chain
+     */
+    public static CredentialChain defaults() {
+        return builder().withEnvironment().withAuthStore().withAnonymous().build();
+    }
+
+    /**
+     * Create a new builder
+     * @return The builder
+     */
+    public static Builder builder() {
+        return new Builder();
+    }
+
+    /**
+     * Get the providers of the chain in order
+     * @return The providers
+     */
+    public List<AuthProvider> getProviders() {
+        return providers;
+    }
+
+    /**
+     * Get the per-host overrides
+     * @return The overrides keyed by lowercase host
+     */
+    public Map<String, AuthProvider> getOverrides() {
+        return overrides;
+    }
+
+    @Override
+    @Nullable
+    public String getAuthHeader(ContainerRef registry) {
+        AuthProvider override = overrides.get(registry.getRegistry().toLowerCase(Locale.ROOT));
+        if (override != null) {
+            LOG.debug("Using override provider for host {}", registry.getRegistry());
+            return override.getAuthHeader(registry);
+        }
+        for (AuthProvider provider : providers) {
+            try {
+                String header = provider.getAuthHeader(registry);
+                if (header != null) {
+                    LOG.debug(
+                            "Using provider {} for host {}",
+                            provider.getClass().getSimpleName(),
+                            registry.getRegistry());
+                    return header;
+                }
+            } catch (OrasException e) {
+                LOG.debug(
+                        "Provider {} failed for host {}: {}",
+                        provider.getClass().getSimpleName(),
+                        registry.getRegistry(),
+                        e.getMessage());
+            }
+        }
+        LOG.debug("No credentials found for host {}, using anonymous access", registry.getRegistry());
+        return null;
+    }
+
+    @Override
+    public AuthScheme getAuthScheme() {
+        for (AuthProvider provider : providers) {
+            if (provider.getAuthScheme() != AuthScheme.NONE) {
+                return provider.getAuthScheme();
+            }
+        }
+        for (AuthProvider provider : overrides.values()) {
+            if (provider.getAuthScheme() != AuthScheme.NONE) {
+                return provider.getAuthScheme();
+            }
+        }
+        return AuthScheme.NONE;
+    }
+
+    /**
+     * Provider reading username and password from environment variables
+     */
+    private record EnvironmentProvider(String usernameVariable, String passwordVariable) implements AuthProvider {
+
+        @Override
+        @Nullable
+        public String getAuthHeader(ContainerRef registry) {
+            String username = System.getenv(usernameVariable);
+            String password = System.getenv(passwordVariable);
+            if (username == null || username.isEmpty() || password == null) {
+                return null;
+            }
+            return new UsernamePasswordProvider(username, password).getAuthHeader(registry);
+        }
+
+        @Override
+        public AuthScheme getAuthScheme() {
+            return AuthScheme.BASIC;
+        }
+    }
+
+    /**
+     * Builder for the credential chain
+     */
+    public static final class Builder {
+
+        private final List<AuthProvider> providers = new ArrayList<>();
+        private final Map<String, AuthProvider> overrides = new LinkedHashMap<>();
+
+        private Builder() {}
+
+        /**
+         * Append a provider to the chain
+         * @param provider The provider
+         * @return The builder
+         */
+        public Builder withProvider(AuthProvider provider) {
+            providers.add(provider);
+            return this;
+        }
+
+        /**
+         * Append explicit credentials to the chain
+         * @param username The username
+         * @param password The password
+         * @return The builder
+         */
+        public Builder withCredentials(String username, String password) {
+            return withProvider(new UsernamePasswordProvider(username, password));
+        }
+
+        /**
+         * Append credentials read from the {@value CredentialChain#ENV_USERNAME} and
+         * {@value CredentialChain#ENV_PASSWORD} environment variables
+         * @return The builder
+         */
+        public Builder withEnvironment() {
+            return withEnvironment(ENV_USERNAME, ENV_PASSWORD);
+        }
+
+        /**
+         * Append credentials read from the given environment variables
+         * @param usernameVariable The environment variable holding the username
+         * @param passwordVariable The environment variable holding the password
+         * @return The builder
+         */
+        public Builder withEnvironment(String usernameVariable, String passwordVariable) {
+            return withProvider(new EnvironmentProvider(usernameVariable, passwordVariable));
+        }
+
+        /**
+         * Append credentials from the default docker config files and their credential helpers
+         * @return The builder
+         */
+        public Builder withAuthStore() {
+            return withProvider(new AuthStoreAuthenticationProvider());
+        }
+
+        /**
+         * Append credentials from the given auth store
+         * @param authStore The auth store
+         * @return The builder
+         */
+        public Builder withAuthStore(AuthStore authStore) {
+            return withProvider(new AuthStoreAuthenticationProvider(authStore));
+        }
+
+        /**
+         * Append a credential helper to the chain
+         * @param name The helper name without the {@code docker-credential-} prefix
+         * @return The builder
+         */
+        public Builder withCredentialHelper(String name) {
+            return withProvider(new CredentialHelperProvider(name));
+        }
+
+        /**
+         * Append anonymous access to the chain. Anonymous access is always the implicit last resort.
+         * @return The builder
+         */
+        public Builder withAnonymous() {
+            return withProvider(new NoAuthProvider());
+        }
+
+        /**
+         * Use the given provider for a host instead of the chain
+         * @param host The registry host, optionally with port
+         * @param provider The provider
+         * @return The builder
+         */
+        public Builder withHostOverride(String host, AuthProvider provider) {
+            overrides.put(host.toLowerCase(Locale.ROOT), provider);
+            return this;
+        }
+
+        /**
+         * Build the chain
+         * @return The credential chain
+         */
+        public CredentialChain build() {
+            return new CredentialChain(new ArrayList<>(providers), new LinkedHashMap<>(overrides));
+        }
+    }
+}
@@ -0,0 +1,280 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.OutputStream;
+import java.nio.charset.StandardCharsets;
+import java.time.Duration;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+import java.util.concurrent.CompletableFuture;
+import java.util.concurrent.TimeUnit;
+import land.oras.auth.AuthStore.Credential;
+import land.oras.auth.AuthStore.CredentialHelperResponse;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.JsonUtils;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * Execute an external docker credential helper binary ({@code docker-credential-<name>}) using the
+ * get/store/erase/list protocol.
+ * Reference: <a href="https://github.com/docker/docker-credential-helpers">Docker credential helpers</a>
+ */
+@NullMarked
+public final class CredentialHelper {
+
+    private static final Logger LOG = LoggerFactory.getLogger(CredentialHelper.class);
+
+    /**
+     * Prefix of all credential helper binaries
+     */
+    public static final String BINARY_PREFIX = "docker-credential-";
+
+    /**
+     * Default timeout for a helper execution
+     */
+    public static final Duration DEFAULT_TIMEOUT = Duration.ofSeconds(30);
+
+    /**
+     * Message returned by helpers when no credential exists for the server
+     */
+    private static final String NOT_FOUND_MESSAGE = "credentials not found in native keychain";
+
+    /**
+     * Legacy server address used by Docker for Docker Hub credentials
+     */
+    private static final String DOCKER_HUB_SERVER_ADDRESS = "https://index.docker.io/v1/";
+
+    /**
+     * The binary to execute
+     */
+    private final String binary;
+
+    /**
+     * The timeout for a single execution
+     */
+    private final Duration timeout;
+
+    /**
+     * Additional environment variables of the helper process
+     */
+    private final Map<String, String> environment;
+
+    private CredentialHelper(String binary, Duration timeout, Map<String, String> environment) {
+        this.binary = binary;
+        this.timeout = timeout;
+        this.environment = environment;
+    }
+
+    /**
+     * Create a credential helper for the given helper name. For example {@code osxkeychain} or {@code ecr-login}
+     * @param name The helper name without the {@code docker-credential-} prefix
+     * @return The credential helper
+     */
+    public static CredentialHelper of(String name) {
+        Objects.requireNonNull(name, "Helper name cannot be null");
+        return new CredentialHelper(BINARY_PREFIX + name, DEFAULT_TIMEOUT, Map.of());
+    }
+
+    /**
+     * Return a copy of this helper with the given timeout
+     * @param timeout The timeout for a single execution
+     * @return The credential helper
+     */
+    public CredentialHelper withTimeout(Duration timeout) {
+        return new CredentialHelper(binary, timeout, environment);
+    }
+
+    /**
+     * Return a copy of this helper with additional environment variables passed to the helper process
+     * @param environment The environment variables
+     * @return The credential helper
+     */
+    public CredentialHelper withEnvironment(Map<String, String> environment) {
+        return new CredentialHelper(binary, timeout, Map.copyOf(environment));
+    }
+
+    /**
+     * Get the binary executed by this helper
+     * @return The binary name
+     */
+    public String getBinary() {
+        return binary;
+    }
+
+    /**
+     * Get the timeout of a single execution
+     * @return The timeout
+     */
+    public Duration getTimeout() {
+        return timeout;
+    }
+
+    /**
+     * Get the additional environment variables of the helper process
+     * @return The environment variables
+     */
+    public Map<String, String> getEnvironment() {
+        return environment;
+    }
+
+    /**
+     * Get the credential for the given server
+     * @param serverUrl The server URL or hostname
+     * @return The credential or null if the helper doesn't have credential for this server
+     * @throws OrasException if the helper fails
+     */
+    public @Nullable Credential get(String serverUrl) throws OrasException {
+        Result result = execute("get", serverUrl);
+        if (result.exitCode() != 0) {
+            if (result.output().contains(NOT_FOUND_MESSAGE)) {
+                LOG.debug("No credential found by helper '{}' for '{}'", binary, serverUrl);
+                return null;
+            }
+            throw failure("get", result);
+        }
+        return JsonUtils.fromJson(result.output(), CredentialHelperResponse.class).asCredential();
+    }
+
+    /**
+     * Store the credential for the given server
+     * @param serverUrl The server URL or hostname
+     * @param credential The credential
+     * @throws OrasException if the helper fails
+     */
+    public void store(String serverUrl, Credential credential) throws OrasException {
+        String payload = JsonUtils.toJson(
+                new CredentialHelperResponse(serverUrl, credential.username(), credential.password()));
+        Result result = execute("store", payload);
+        if (result.exitCode() != 0) {
+            throw failure("store", result);
+        }
+    }
+
+    /**
+     * Erase the credential for the given server
+     * @param serverUrl The server URL or hostname
+     * @throws OrasException if the helper fails
+     */
+    public void erase(String serverUrl) throws OrasException {
+        Result result = execute("erase", serverUrl);
+        if (result.exitCode() != 0) {
+            throw failure("erase", result);
+        }
+    }
+
+    /**
+     * List the servers the helper has credentials for
+     * @return The usernames by server URL
+     * @throws OrasException if the helper fails
+     */
+    @SuppressWarnings("unchecked")
+    public Map<String, String> list() throws OrasException {
+        Result result = execute("list", "");
+        if (result.exitCode() != 0) {
+            throw failure("list", result);
+        }
+        if (result.output().isEmpty()) {
+            return Map.of();
+        }
+        return Map.copyOf(JsonUtils.fromJson(result.output(), Map.class));
+    }
+
+    /**
+     * Return the server address to send to credential helpers for the given registry
+     * @param registry The registry
+     * @return The server address
+     */
+    static String serverAddress(String registry) {
+        return registry.equals(Const.DEFAULT_REGISTRY) ? DOCKER_HUB_SERVER_ADDRESS : registry;
+    }
+
+    private OrasException failure(String action, Result result) {
+        String message = "Credential helper '%s' %s exited with code %d and error: '%s' and stdout '%s'."
+                .formatted(binary, action, result.exitCode(), result.error(), result.output());
+        LOG.warn(message);
+        return new OrasException(message);
+    }
+
+    private Result execute(String action, String input) throws OrasException {
+        LOG.debug("Executing credential helper '{} {}'", binary, action);
+        ProcessBuilder pb = new ProcessBuilder(List.of(binary, action));
+        pb.environment().putAll(environment);
+        Process proc;
+        try {
+            proc = pb.start();
+        } catch (IOException e) {
+            LOG.warn("Failed to execute credential helper '{}': {}", binary, e.getMessage());
+            throw new OrasException("Credential helper '" + binary + "' not found or IO error", e);
+        }
+        try {
+            // Read outputs concurrently to avoid blocking on full pipes
+            CompletableFuture<String> stdout = readAsync(proc.getInputStream());
+            CompletableFuture<String> stderr = readAsync(proc.getErrorStream());
+
+            // Input is in stdin
+            try (OutputStream os = proc.getOutputStream()) {
+                os.write(input.getBytes(StandardCharsets.UTF_8));
+                os.flush();
+            }
+
+            if (!proc.waitFor(timeout.toMillis(), TimeUnit.MILLISECONDS)) {
+                proc.destroyForcibly();
+                throw new OrasException(
+                        "Credential helper '%s' %s timed out after %s".formatted(binary, action, timeout));
+            }
+            return new Result(proc.exitValue(), stdout.join().trim(), stderr.join().trim());
+        } catch (IOException e) {
+            proc.destroyForcibly();
+            throw new OrasException("Failed to communicate with credential helper '" + binary + "'", e);
+        } catch (InterruptedException e) {
+            proc.destroyForcibly();
+            Thread.currentThread().interrupt();
+            LOG.warn("Credential helper execution interrupted: {}", e.getMessage());
+            throw new OrasException("Credential helper execution interrupted", e);
+        }
+    }
+
+    private static CompletableFuture<String> readAsync(InputStream is) {
+        return CompletableFuture.supplyAsync(() -> {
+            try (is) {
+                return new String(is.readAllBytes(), StandardCharsets.UTF_8);
+            } catch (IOException e) {
+                return "";
+            }
+        });
+    }
+
+    /**
+     * Result of a helper execution
+     * @param exitCode The exit code
+     * @param output The standard output
+     * @param error The standard error
+     */
+    private record Result(int exitCode, String output, String error) {}
+}
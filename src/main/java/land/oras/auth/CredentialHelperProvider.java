@@ -0,0 +1,69 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import land.oras.ContainerRef;
+import land.oras.auth.AuthStore.Credential;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * CredentialHelperProvider is an implementation of the {@link AuthProvider} interface.
+ * It retrieves credentials from an external docker credential helper and generates a Basic Authentication header.
+ */
+@NullMarked
+public final class CredentialHelperProvider implements AuthProvider {
+
+    private final CredentialHelper credentialHelper;
+
+    /**
+     * Constructor.
+     *
+     * @param name The helper name without the {@code docker-credential-} prefix
+     */
+    public CredentialHelperProvider(String name) {
+        this(CredentialHelper.of(name));
+    }
+
+    /**
+     * Constructor.
+     *
+     * @param credentialHelper The credential helper to retrieve credentials from.
+     */
+    public CredentialHelperProvider(CredentialHelper credentialHelper) {
+        this.credentialHelper = credentialHelper;
+    }
+
+    @Override
+    @Nullable
+    public String getAuthHeader(ContainerRef registry) {
+        Credential credential = credentialHelper.get(CredentialHelper.serverAddress(registry.getRegistry()));
+        if (credential == null) {
+            return null;
+        }
+        return new UsernamePasswordProvider(credential.username(), credential.password()).getAuthHeader(registry);
+    }
+
+    @Override
+    public AuthScheme getAuthScheme() {
+        return AuthScheme.BASIC;
+    }
+}
@@ -0,0 +1,221 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.time.Clock;
+import java.time.Duration;
+import java.time.Instant;
+import java.util.Locale;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.locks.ReentrantLock;
+import java.util.function.Function;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import software.amazon.awssdk.auth.credentials.AwsCredentialsProvider;
+import software.amazon.awssdk.auth.credentials.DefaultCredentialsProvider;
+import software.amazon.awssdk.core.exception.SdkException;
+import software.amazon.awssdk.regions.Region;
+import software.amazon.awssdk.services.ecr.EcrClient;
+import software.amazon.awssdk.services.ecr.model.AuthorizationData;
+import software.amazon.awssdk.services.ecr.model.GetAuthorizationTokenResponse;
+
+/**
+ * A provider obtaining Amazon ECR authorization tokens with the AWS SDK.
+ * The AWS SDK ECR client is an optional dependency and must be added to the classpath to use this provider.
+ * Only private ECR hosts ({@code <account>.dkr.ecr.<region>.amazonaws.com}) are handled, other hosts get no header
+ * so the provider can be added to a {@link CredentialChain}. Tokens are cached per region and refreshed before
+ * their 12 hours expiry.
+ */
+@NullMarked
+public final class EcrAuthProvider implements AuthProvider {
+
+    /**
+     * Logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(EcrAuthProvider.class);
+
+    /**
+     * Private ECR hosts, with the region as second group
+     */
+    private static final Pattern ECR_HOST =
+            Pattern.compile("^(\\d{12})\\.dkr\\.ecr(?:-fips)?\\.([a-z0-9-]+)\\.amazonaws\\.com(?:\\.cn)?$");
+
+    /**
+     * Tokens are refreshed this long before they expire
+     */
+    private static final Duration REFRESH_MARGIN = Duration.ofMinutes(5);
+
+    /**
+     * Create the ECR client of a region
+     */
+    private final Function<Region, EcrClient> clientFactory;
+
+    /**
+     * The clock
+     */
+    private final Clock clock;
+
+    /**
+     * The clients by region
+     */
+    private final Map<Region, EcrClient> clients = new ConcurrentHashMap<>();
+
+    /**
+     * The cached tokens by region
+     */
+    private final Map<Region, CachedToken> tokens = new ConcurrentHashMap<>();
+
+    /**
+     * Lock held while refreshing a token
+     */
+    private final ReentrantLock refreshLock = new ReentrantLock();
+
+    /**
+     * Constructor
+     * @param clientFactory The factory of ECR clients
+     * @param clock The clock
+     */
+    EcrAuthProvider(Function<Region, EcrClient> clientFactory, Clock clock) {
+        this.clientFactory = clientFactory;
+        this.clock = clock;
+    }
+
+    /**
+     * Create a provider using the default AWS credentials chain (environment, profiles, container and instance
+     * credentials)
+     * @return The provider
+     */
+    public static EcrAuthProvider create() {
+        return create(DefaultCredentialsProvider.builder().build());
+    }
+
+    /**
+     * Create a provider using the given AWS credentials
+     * @param credentialsProvider The AWS credentials provider
+     * @return The provider
+     */
+    public static EcrAuthProvider create(AwsCredentialsProvider credentialsProvider) {
+        return new EcrAuthProvider(
+                region -> EcrClient.builder()
+                        .region(region)
+                        .credentialsProvider(credentialsProvider)
+                        .build(),
+                Clock.systemUTC());
+    }
+
+    /**
+     * Create a provider using the given ECR client for every region
+     * @param client The ECR client
+     * @return The provider
+     */
+    public static EcrAuthProvider of(EcrClient client) {
+        return new EcrAuthProvider(region -> client, Clock.systemUTC());
+    }
+
+    /**
+     * Return if the host is a private ECR registry
+     * @param host The registry host
+     * @return True if the host is a private ECR registry
+     */
+    public static boolean isEcrHost(String host) {
+        return ECR_HOST.matcher(host.toLowerCase(Locale.ROOT)).matches();
+    }
+
+    @Override
+    public @Nullable String getAuthHeader(ContainerRef registry) {
+        Matcher matcher = ECR_HOST.matcher(registry.getRegistry().toLowerCase(Locale.ROOT));
+        if (!matcher.matches()) {
+            return null;
+        }
+        Region region = Region.of(matcher.group(2));
+        CachedToken token = tokens.get(region);
+        if (!isFresh(token)) {
+            refreshLock.lock();
+            try {
+                token = tokens.get(region);
+                if (!isFresh(token)) {
+                    token = fetchToken(region);
+                    tokens.put(region, token);
+                }
+            } finally {
+                refreshLock.unlock();
+            }
+        }
+        return "Basic " + token.token();
+    }
+
+    @Override
+    public AuthScheme getAuthScheme() {
+        return AuthScheme.BASIC;
+    }
+
+    /**
+     * Return if the token can still be used
+     * @param token The cached token or null
+     * @return True if not expiring soon
+     */
+    private boolean isFresh(@Nullable CachedToken token) {
+        return token != null && clock.instant().isBefore(token.expiresAt().minus(REFRESH_MARGIN));
+    }
+
+    /**
+     * Fetch a new authorization token
+     * @param region The region
+     * @return The token
+     */
+    private CachedToken fetchToken(Region region) {
+        LOG.debug("Requesting ECR authorization token for region {}", region);
+        try {
+            EcrClient client = clients.get(region);
+            if (client == null) {
+                client = clientFactory.apply(region);
+                clients.put(region, client);
+            }
+            GetAuthorizationTokenResponse response = client.getAuthorizationToken();
+            if (!response.hasAuthorizationData() || response.authorizationData().isEmpty()) {
+                throw new OrasException("No ECR authorization data returned for region %s".formatted(region));
+            }
+            AuthorizationData data = response.authorizationData().get(0);
+            Instant expiresAt = data.expiresAt();
+            if (expiresAt == null) {
+                expiresAt = clock.instant().plus(Duration.ofHours(12));
+            }
+            // The token is already the base64 of AWS:<password>
+            return new CachedToken(data.authorizationToken(), expiresAt);
+        } catch (SdkException e) {
+            throw new OrasException("Failed to get ECR authorization token for region %s".formatted(region), e);
+        }
+    }
+
+    /**
+     * A cached authorization token
+     * @param token The base64 encoded credentials
+     * @param expiresAt The expiry
+     */
+    private record CachedToken(String token, Instant expiresAt) {}
+}
@@ -0,0 +1,143 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import com.google.auth.oauth2.AccessToken;
+import com.google.auth.oauth2.GoogleCredentials;
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+import java.util.Locale;
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * A provider exchanging Google Application Default Credentials for Artifact Registry and Container Registry
+ * access tokens.
+ * The Google auth library is an optional dependency and must be added to the classpath to use this provider.
+ * Only {@code *.pkg.dev}, {@code gcr.io} and {@code *.gcr.io} hosts are handled, other hosts get no header so the
+ * provider can be added to a {@link CredentialChain}. Access tokens are refreshed by the credentials before they
+ * expire.
+ */
+@NullMarked
+public final class GarAuthProvider implements AuthProvider {
+
+    /**
+     * Logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(GarAuthProvider.class);
+
+    /**
+     * The username used with access tokens
+     */
+    static final String ACCESS_TOKEN_USERNAME = "oauth2accesstoken";
+
+    /**
+     * The OAuth2 scope granting registry access
+     */
+    static final String CLOUD_PLATFORM_SCOPE = "https://www.googleapis.com/auth/cloud-platform";
+
+    /**
+     * The credentials
+     */
+    private final GoogleCredentials credentials;
+
+    /**
+     * Constructor
+     * @param credentials The credentials
+     */
+    private GarAuthProvider(GoogleCredentials credentials) {
+        this.credentials = credentials;
+    }
+
+    /**
+     * Create a provider using the Application Default Credentials (environment, gcloud configuration and metadata
+     * server)
+     * @return The provider
+     */
+    public static GarAuthProvider create() {
+        try {
+            return of(GoogleCredentials.getApplicationDefault());
+        } catch (IOException e) {
+            throw new OrasException("Failed to load Google application default credentials", e);
+        }
+    }
+
+    /**
+     * Create a provider using the given credentials
+     * @param credentials The credentials
+     * @return The provider
+     */
+    public static GarAuthProvider of(GoogleCredentials credentials) {
+        if (credentials.createScopedRequired()) {
+            credentials = credentials.createScoped(CLOUD_PLATFORM_SCOPE);
+        }
+        return new GarAuthProvider(credentials);
+    }
+
+    /**
+     * Return if the host is an Artifact Registry or Container Registry host
+     * @param host The registry host
+     * @return True if the host is a Google registry
+     */
+    public static boolean isGoogleHost(String host) {
+        String normalized = host.toLowerCase(Locale.ROOT);
+        return normalized.endsWith(".pkg.dev") || normalized.equals("gcr.io") || normalized.endsWith(".gcr.io");
+    }
+
+    @Override
+    public @Nullable String getAuthHeader(ContainerRef registry) {
+        if (!isGoogleHost(registry.getRegistry())) {
+            return null;
+        }
+        String token = accessToken();
+        return "Basic "
+                + Base64.getEncoder()
+                        .encodeToString((ACCESS_TOKEN_USERNAME + ":" + token).getBytes(StandardCharsets.UTF_8));
+    }
+
+    @Override
+    public AuthScheme getAuthScheme() {
+        return AuthScheme.BASIC;
+    }
+
+    /**
+     * Get a valid access token, refreshing it if needed
+     * @return The access token
+     */
+    private String accessToken() {
+        try {
+            credentials.refreshIfExpired();
+        } catch (IOException e) {
+            throw new OrasException("Failed to refresh Google access token", e);
+        }
+        AccessToken token = credentials.getAccessToken();
+        if (token == null) {
+            throw new OrasException("No Google access token available");
+        }
+        LOG.debug("Using Google access token expiring at {}", token.getExpirationTime());
+        return token.getTokenValue();
+    }
+}
@@ -0,0 +1,125 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Per-host connection settings, similar to the {@code plain_http} and {@code skip_verify} options of containerd
+ * {@code hosts.toml} files. Allows a single registry client to talk to a local plain HTTP registry and production
+ * HTTPS registries at the same time.
+ */
+@NullMarked
+public final class HostConfig {
+
+    /**
+     * Use plain HTTP instead of HTTPS
+     */
+    private final boolean plainHttp;
+
+    /**
+     * Skip TLS verification
+     */
+    private final boolean skipTlsVerify;
+
+    /**
+     * Use HTTP/1.1 only, even if the host supports HTTP/2
+     */
+    private final boolean http1;
+
+    private HostConfig(boolean plainHttp, boolean skipTlsVerify, boolean http1) {
+        this.plainHttp = plainHttp;
+        this.skipTlsVerify = skipTlsVerify;
+        this.http1 = http1;
+    }
+
+    /**
+     * Create a host configuration
+     * @param plainHttp Use plain HTTP instead of HTTPS
+     * @param skipTlsVerify Skip TLS verification
+     * @return The host configuration
+     */
+    public static HostConfig of(boolean plainHttp, boolean skipTlsVerify) {
+        return new HostConfig(plainHttp, skipTlsVerify, false);
+    }
+
+    /**
+     * Host reached over HTTPS with TLS verification
+     * @return The host configuration
+     */
+    public static HostConfig secure() {
+        return new HostConfig(false, false, false);
+    }
+
+    /**
+     * Host reached over plain HTTP
+     * @return The host configuration
+     */
+    public static HostConfig plainHttp() {
+        return new HostConfig(true, false, false);
+    }
+
+    /**
+     * Host reached over HTTPS without TLS verification
+     * @return The host configuration
+     */
+    public static HostConfig skipTlsVerify() {
+        return new HostConfig(false, true, false);
+    }
+
+    /**
+     * Return a copy of this configuration using HTTP/1.1 only for the host, for registries mishandling HTTP/2
+     * @param http1 Use HTTP/1.1 only
+     * @return The host configuration
+     */
+    public HostConfig withHttp1(boolean http1) {
+        return new HostConfig(plainHttp, skipTlsVerify, http1);
+    }
+
+    /**
+     * Whether the host is reached over plain HTTP
+     * @return True if plain HTTP
+     */
+    public boolean isPlainHttp() {
+        return plainHttp;
+    }
+
+    /**
+     * Whether TLS verification is skipped for the host
+     * @return True if TLS verification is skipped
+     */
+    public boolean isSkipTlsVerify() {
+        return skipTlsVerify;
+    }
+
+    /**
+     * Whether the host is reached over HTTP/1.1 only
+     * @return True if HTTP/2 is disabled for the host
+     */
+    public boolean isHttp1() {
+        return http1;
+    }
+
+    @Override
+    public String toString() {
+        return "HostConfig{plainHttp=" + plainHttp + ", skipTlsVerify=" + skipTlsVerify + ", http1=" + http1 + "}";
+    }
+}
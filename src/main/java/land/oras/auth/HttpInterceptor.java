@@ -0,0 +1,127 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.io.IOException;
+import java.net.http.HttpRequest;
+import java.net.http.HttpResponse;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Intercept every HTTP request sent to registries and token endpoints, including retries, redirects and token
+ * exchanges. An interceptor can replace the request, for example to add headers or sign it, and observe or replace
+ * the response returned by {@link Chain#proceed(HttpRequest)}.
+ * Interceptors run in registration order and might be invoked concurrently from different threads.
+ * By default they run {@link Stage#AFTER_AUTH after} the {@code Authorization} header is attached.
+ */
+@NullMarked
+@FunctionalInterface
+public interface HttpInterceptor {
+
+    /**
+     * When the interceptor runs relative to authentication
+     */
+    enum Stage {
+
+        /**
+         * Before the {@code Authorization} header is attached. The interceptor never sees credentials and can't
+         * change them
+         */
+        BEFORE_AUTH,
+
+        /**
+         * After the {@code Authorization} header is attached, for example to sign the final request
+         */
+        AFTER_AUTH
+    }
+
+    /**
+     * The chain of the remaining interceptors and the transport
+     */
+    @NullMarked
+    interface Chain {
+
+        /**
+         * The request to send
+         * @return The request
+         */
+        HttpRequest request();
+
+        /**
+         * Send the request to the next interceptor or the transport
+         * @param request The request, either {@link #request()} or a modified copy
+         * @return The response
+         * @throws IOException If an I/O error occurs
+         * @throws InterruptedException If the operation is interrupted
+         */
+        HttpResponse<?> proceed(HttpRequest request) throws IOException, InterruptedException;
+    }
+
+    /**
+     * Intercept a request. Implementations must call {@link Chain#proceed(HttpRequest)} and should return its
+     * response, since its body matches the body handler of the SDK
+     * @param chain The chain
+     * @return The response
+     * @throws IOException If an I/O error occurs
+     * @throws InterruptedException If the operation is interrupted
+     */
+    HttpResponse<?> intercept(Chain chain) throws IOException, InterruptedException;
+
+    /**
+     * When the interceptor runs
+     * @return The stage, {@link Stage#AFTER_AUTH} by default
+     */
+    default Stage stage() {
+        return Stage.AFTER_AUTH;
+    }
+
+    /**
+     * Return an interceptor running at the given stage
+     * @param stage The stage
+     * @param interceptor The interceptor
+     * @return The interceptor
+     */
+    static HttpInterceptor at(Stage stage, HttpInterceptor interceptor) {
+        return new HttpInterceptor() {
+            @Override
+            public HttpResponse<?> intercept(Chain chain) throws IOException, InterruptedException {
+                return interceptor.intercept(chain);
+            }
+
+            @Override
+            public Stage stage() {
+                return stage;
+            }
+        };
+    }
+
+    /**
+     * Return an interceptor adding a header to every request, replacing any existing value
+     * @param name The header name
+     * @param value The header value
+     * @return The interceptor
+     */
+    static HttpInterceptor header(String name, String value) {
+        return chain -> chain.proceed(HttpRequest.newBuilder(chain.request(), (n, v) -> !n.equalsIgnoreCase(name))
+                .header(name, value)
+                .build());
+    }
+}
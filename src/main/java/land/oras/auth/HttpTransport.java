@@ -0,0 +1,59 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.io.IOException;
+import java.net.http.HttpRequest;
+import java.net.http.HttpResponse;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * The transport sending HTTP requests on behalf of the {@link HttpClient}.
+ * Authentication, retries and redirects are handled by the {@link HttpClient}; a transport only sends one request.
+ * Implement this interface to route requests through another HTTP library (OkHttp, Apache HttpClient, ...)
+ * or use {@link #of(java.net.http.HttpClient)} to reuse a pre-configured JDK client.
+ * TLS, proxy and timeout settings of the registry are not applied to a custom transport.
+ */
+@NullMarked
+@FunctionalInterface
+public interface HttpTransport {
+
+    /**
+     * Send the request
+     * @param request The request
+     * @param handler The body handler for the response
+     * @param <T> The response body type
+     * @return The response
+     * @throws IOException If an I/O error occurs
+     * @throws InterruptedException If the operation is interrupted
+     */
+    <T> HttpResponse<T> send(HttpRequest request, HttpResponse.BodyHandler<T> handler)
+            throws IOException, InterruptedException;
+
+    /**
+     * Create a transport backed by a JDK HTTP client
+     * @param client The client
+     * @return The transport
+     */
+    static HttpTransport of(java.net.http.HttpClient client) {
+        return client::send;
+    }
+}
@@ -0,0 +1,336 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.io.IOException;
+import java.net.Authenticator;
+import java.net.InetSocketAddress;
+import java.net.PasswordAuthentication;
+import java.net.Proxy;
+import java.net.ProxySelector;
+import java.net.SocketAddress;
+import java.net.URI;
+import java.net.URLDecoder;
+import java.nio.charset.StandardCharsets;
+import java.util.Arrays;
+import java.util.List;
+import java.util.Locale;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * HTTP(S) proxy configuration.
+ * By default, the standard {@code https_proxy}, {@code http_proxy} and {@code no_proxy} environment variables are
+ * honored (lowercase or uppercase).
+ * Note that the JDK disables Basic proxy authentication for HTTPS tunnels unless the
+ * {@code jdk.http.auth.tunneling.disabledSchemes} system property is set to an empty value.
+ */
+@NullMarked
+public final class ProxyConfig {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(ProxyConfig.class);
+
+    /**
+     * Proxy for plain HTTP requests
+     */
+    private final @Nullable InetSocketAddress httpProxy;
+
+    /**
+     * Proxy for HTTPS requests
+     */
+    private final @Nullable InetSocketAddress httpsProxy;
+
+    /**
+     * The proxy username
+     */
+    private final @Nullable String username;
+
+    /**
+     * The proxy password
+     */
+    private final @Nullable String password;
+
+    /**
+     * Hosts reached without proxy
+     */
+    private final List<String> nonProxyHosts;
+
+    private ProxyConfig(
+            @Nullable InetSocketAddress httpProxy,
+            @Nullable InetSocketAddress httpsProxy,
+            @Nullable String username,
+            @Nullable String password,
+            List<String> nonProxyHosts) {
+        this.httpProxy = httpProxy;
+        this.httpsProxy = httpsProxy;
+        this.username = username;
+        this.password = password;
+        this.nonProxyHosts = List.copyOf(nonProxyHosts);
+    }
+
+    /**
+     * Create a proxy configuration using the same proxy for HTTP and HTTPS requests
+     * @param host The proxy host
+     * @param port The proxy port
+     * @return The proxy configuration
+     */
+    public static ProxyConfig of(String host, int port) {
+        InetSocketAddress address = InetSocketAddress.createUnresolved(host, port);
+        return new ProxyConfig(address, address, null, null, List.of());
+    }
+
+    /**
+     * Create a configuration connecting directly to all hosts, ignoring the environment variables
+     * @return The proxy configuration
+     */
+    public static ProxyConfig direct() {
+        return new ProxyConfig(null, null, null, null, List.of());
+    }
+
+    /**
+     * Create a proxy configuration from the standard environment variables
+     * @return The proxy configuration or null if no proxy is configured
+     */
+    public static @Nullable ProxyConfig fromEnvironment() {
+        return fromEnvironment(System.getenv());
+    }
+
+    /**
+     * Create a proxy configuration from the given environment
+     * @param env The environment variables
+     * @return The proxy configuration or null if no proxy is configured
+     */
+    static @Nullable ProxyConfig fromEnvironment(Map<String, String> env) {
+        URI httpsUri = parseProxyUrl(getEnv(env, "https_proxy"));
+        URI httpUri = parseProxyUrl(getEnv(env, "http_proxy"));
+        if (httpsUri == null && httpUri == null) {
+            return null;
+        }
+        String userInfo = httpsUri != null ? httpsUri.getRawUserInfo() : null;
+        if (userInfo == null && httpUri != null) {
+            userInfo = httpUri.getRawUserInfo();
+        }
+        String user = null;
+        String pass = null;
+        if (userInfo != null) {
+            int separator = userInfo.indexOf(':');
+            user = decode(separator < 0 ? userInfo : userInfo.substring(0, separator));
+            pass = separator < 0 ? "" : decode(userInfo.substring(separator + 1));
+        }
+        String noProxy = getEnv(env, "no_proxy");
+        List<String> nonProxyHosts = noProxy == null
+                ? List.of()
+                : Arrays.stream(noProxy.split(","))
+                        .map(String::trim)
+                        .filter(h -> !h.isEmpty())
+                        .toList();
+        return new ProxyConfig(toAddress(httpUri), toAddress(httpsUri), user, pass, nonProxyHosts);
+    }
+
+    /**
+     * Return a copy of this configuration with proxy credentials
+     * @param username The proxy username
+     * @param password The proxy password
+     * @return The new configuration
+     */
+    public ProxyConfig withCredentials(String username, String password) {
+        return new ProxyConfig(httpProxy, httpsProxy, username, password, nonProxyHosts);
+    }
+
+    /**
+     * Return a copy of this configuration with hosts reached without proxy.
+     * Entries match the host and its subdomains; {@code *} disables the proxy for all hosts.
+     * @param nonProxyHosts The hosts
+     * @return The new configuration
+     */
+    public ProxyConfig withNonProxyHosts(List<String> nonProxyHosts) {
+        return new ProxyConfig(httpProxy, httpsProxy, username, password, nonProxyHosts);
+    }
+
+    /**
+     * Get the proxy used for plain HTTP requests
+     * @return The proxy address or null
+     */
+    public @Nullable InetSocketAddress getHttpProxy() {
+        return httpProxy;
+    }
+
+    /**
+     * Get the proxy used for HTTPS requests
+     * @return The proxy address or null
+     */
+    public @Nullable InetSocketAddress getHttpsProxy() {
+        return httpsProxy;
+    }
+
+    /**
+     * Get the proxy username
+     * @return The username or null
+     */
+    public @Nullable String getUsername() {
+        return username;
+    }
+
+    /**
+     * Get the hosts reached without proxy
+     * @return The hosts
+     */
+    public List<String> getNonProxyHosts() {
+        return nonProxyHosts;
+    }
+
+    /**
+     * Whether the host is reached without proxy
+     * @param host The host
+     * @return True if the proxy is bypassed
+     */
+    public boolean isNonProxyHost(String host) {
+        String lowerHost = host.toLowerCase(Locale.ROOT);
+        for (String entry : nonProxyHosts) {
+            String pattern = entry.toLowerCase(Locale.ROOT);
+            if (pattern.equals("*")) {
+                return true;
+            }
+            // Strip port
+            int portSeparator = pattern.lastIndexOf(':');
+            if (portSeparator > 0 && pattern.indexOf(':') == portSeparator) {
+                pattern = pattern.substring(0, portSeparator);
+            }
+            if (pattern.startsWith("*.")) {
+                pattern = pattern.substring(1);
+            }
+            if (pattern.startsWith(".")) {
+                if (lowerHost.endsWith(pattern) || lowerHost.equals(pattern.substring(1))) {
+                    return true;
+                }
+            } else if (lowerHost.equals(pattern) || lowerHost.endsWith("." + pattern)) {
+                return true;
+            }
+        }
+        return false;
+    }
+
+    /**
+     * Get the proxy to use for the URI
+     * @param uri The URI
+     * @return The proxy address or null to connect directly
+     */
+    public @Nullable InetSocketAddress getProxy(URI uri) {
+        if (uri.getHost() == null || isNonProxyHost(uri.getHost())) {
+            return null;
+        }
+        return "https".equalsIgnoreCase(uri.getScheme()) ? httpsProxy : httpProxy;
+    }
+
+    /**
+     * Create a proxy selector for this configuration
+     * @return The proxy selector
+     */
+    public ProxySelector toProxySelector() {
+        return new ProxySelector() {
+            @Override
+            public List<Proxy> select(URI uri) {
+                InetSocketAddress address = getProxy(uri);
+                if (address == null) {
+                    return List.of(Proxy.NO_PROXY);
+                }
+                LOG.trace("Using proxy {} for {}", address, uri);
+                return List.of(new Proxy(Proxy.Type.HTTP, address));
+            }
+
+            @Override
+            public void connectFailed(URI uri, SocketAddress address, IOException e) {
+                LOG.debug("Connection to proxy {} failed for {}: {}", address, uri, e.getMessage());
+            }
+        };
+    }
+
+    /**
+     * Create an authenticator answering proxy authentication challenges
+     * @return The authenticator or null if no credentials are configured
+     */
+    public @Nullable Authenticator toAuthenticator() {
+        if (username == null || password == null) {
+            return null;
+        }
+        String user = username;
+        char[] pass = password.toCharArray();
+        return new Authenticator() {
+            @Override
+            protected @Nullable PasswordAuthentication getPasswordAuthentication() {
+                if (getRequestorType() != RequestorType.PROXY) {
+                    return null;
+                }
+                return new PasswordAuthentication(user, pass);
+            }
+        };
+    }
+
+    private static @Nullable String getEnv(Map<String, String> env, String name) {
+        String value = env.get(name);
+        if (value == null || value.isBlank()) {
+            value = env.get(name.toUpperCase(Locale.ROOT));
+        }
+        return value == null || value.isBlank() ? null : value.trim();
+    }
+
+    private static @Nullable URI parseProxyUrl(@Nullable String value) {
+        if (value == null) {
+            return null;
+        }
+        String url = value.contains("://") ? value : "http://" + value;
+        try {
+            URI uri = URI.create(url);
+            if (uri.getHost() == null) {
+                throw new OrasException("Invalid proxy URL: %s".formatted(value));
+            }
+            return uri;
+        } catch (IllegalArgumentException e) {
+            throw new OrasException("Invalid proxy URL: %s".formatted(value), e);
+        }
+    }
+
+    private static @Nullable InetSocketAddress toAddress(@Nullable URI uri) {
+        if (uri == null) {
+            return null;
+        }
+        int port = uri.getPort() != -1 ? uri.getPort() : ("https".equalsIgnoreCase(uri.getScheme()) ? 443 : 80);
+        return InetSocketAddress.createUnresolved(uri.getHost(), port);
+    }
+
+    private static String decode(String value) {
+        // User info is percent-encoded, not form-encoded: keep '+' as is
+        return URLDecoder.decode(value.replace("+", "%2B"), StandardCharsets.UTF_8);
+    }
+
+    @Override
+    public String toString() {
+        return "ProxyConfig{" + "httpProxy=" + httpProxy + ", httpsProxy=" + httpsProxy + ", nonProxyHosts="
+                + nonProxyHosts + '}';
+    }
+}
@@ -169,6 +169,35 @@ public class RegistriesConf {
         public PullFromMirror effectivePullFromMirror() {
             return pullFromMirror != null ? pullFromMirror : PullFromMirror.ALL;
         }
+
+        /**
+         * Create a mirror reached over HTTPS and used for all pull operations.
+         * @param location The mirror registry location (host[:port][/path]).
+         * @return the mirror config
+         */
+        public static MirrorConfig of(String location) {
+            return new MirrorConfig(location, null, null);
+        }
+
+        /**
+         * Return true if this mirror may be used to pull the given reference according to its pull-from-mirror
+         * setting.
+         * @param ref the container reference to pull
+         * @return true if the mirror is applicable
+         */
+        public boolean appliesTo(ContainerRef ref) {
+            return appliesTo(effectivePullFromMirror(), ref);
+        }
+
+        private static boolean appliesTo(PullFromMirror pullFromMirror, ContainerRef ref) {
+            boolean refHasDigest = ref.getDigest() != null && !ref.getDigest().isEmpty();
+            boolean refHasTag = ref.getTag() != null && !ref.getTag().isEmpty();
+            return switch (pullFromMirror) {
+                case DIGEST_ONLY -> refHasDigest;
+                case TAG_ONLY -> refHasTag;
+                case ALL -> true;
+            };
+        }
     }
 
     /**
@@ -504,18 +533,10 @@ public class RegistriesConf {
             return Collections.emptyList();
         }
         boolean registryDigestOnly = matchingConfig.get().isMirrorByDigestOnly();
-        boolean refHasDigest = ref.getDigest() != null && !ref.getDigest().isEmpty();
-        boolean refHasTag = ref.getTag() != null && !ref.getTag().isEmpty();
         return matchingConfig.get().mirrors().stream()
-                .filter(mirror -> {
-                    PullFromMirror effective =
-                            registryDigestOnly ? PullFromMirror.DIGEST_ONLY : mirror.effectivePullFromMirror();
-                    return switch (effective) {
-                        case DIGEST_ONLY -> refHasDigest;
-                        case TAG_ONLY -> refHasTag;
-                        case ALL -> true;
-                    };
-                })
+                .filter(mirror -> registryDigestOnly
+                        ? MirrorConfig.appliesTo(PullFromMirror.DIGEST_ONLY, ref)
+                        : mirror.appliesTo(ref))
                 .collect(Collectors.toUnmodifiableList());
     }
 
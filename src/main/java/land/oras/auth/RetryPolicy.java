@@ -0,0 +1,303 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.io.IOException;
+import java.time.Duration;
+import java.time.ZonedDateTime;
+import java.time.format.DateTimeFormatter;
+import java.time.format.DateTimeParseException;
+import java.util.concurrent.ThreadLocalRandom;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Retry policy for transient failures: HTTP 429, HTTP 5xx and network errors such as connection resets.
+ * Delays grow exponentially with a random jitter and are capped by a maximum delay.
+ * The delay indicated by a {@code Retry-After} header on HTTP 429 and 503 responses takes precedence over the backoff.
+ * Requests whose body cannot be replayed (streamed uploads) are not retried unless explicitly allowed.
+ * Expired tokens are not subject to this policy: a 401 always triggers a single token refresh.
+ */
+@NullMarked
+public final class RetryPolicy {
+
+    /**
+     * Maximum number of attempts (1 = no retry)
+     */
+    private final int maxAttempts;
+
+    /**
+     * Delay before the first retry
+     */
+    private final Duration initialDelay;
+
+    /**
+     * Upper bound of the delay between two attempts
+     */
+    private final Duration maxDelay;
+
+    /**
+     * Factor applied to the delay after each attempt
+     */
+    private final double multiplier;
+
+    /**
+     * Random jitter as a fraction of the delay (0 = no jitter)
+     */
+    private final double jitter;
+
+    /**
+     * Whether requests with a non-replayable body are retried
+     */
+    private final boolean retryNonIdempotent;
+
+    private RetryPolicy(
+            int maxAttempts,
+            Duration initialDelay,
+            Duration maxDelay,
+            double multiplier,
+            double jitter,
+            boolean retryNonIdempotent) {
+        if (maxAttempts < 1) {
+            throw new IllegalArgumentException("maxAttempts must be >= 1");
+        }
+        if (initialDelay.isNegative()) {
+            throw new IllegalArgumentException("initialDelay must be >= 0");
+        }
+        if (maxDelay.isNegative()) {
+            throw new IllegalArgumentException("maxDelay must be >= 0");
+        }
+        if (multiplier < 1) {
+            throw new IllegalArgumentException("multiplier must be >= 1");
+        }
+        if (jitter < 0 || jitter > 1) {
+            throw new IllegalArgumentException("jitter must be between 0 and 1");
+        }
+        this.maxAttempts = maxAttempts;
+        this.initialDelay = initialDelay;
+        this.maxDelay = maxDelay;
+        this.multiplier = multiplier;
+        this.jitter = jitter;
+        this.retryNonIdempotent = retryNonIdempotent;
+    }
+
+    /**
+     * The default policy: 3 attempts, 500ms initial delay doubling up to 30s with 20% jitter
+     * @return The default policy
+     */
+    public static RetryPolicy defaults() {
+        return new RetryPolicy(3, Duration.ofMillis(500), Duration.ofSeconds(30), 2.0, 0.2, false);
+    }
+
+    /**
+     * A policy that never retries
+     * @return The policy
+     */
+    public static RetryPolicy none() {
+        return defaults().withMaxAttempts(1);
+    }
+
+    /**
+     * Return a copy of this policy with the given maximum number of attempts
+     * @param maxAttempts Maximum attempts (must be &gt;= 1)
+     * @return The new policy
+     */
+    public RetryPolicy withMaxAttempts(int maxAttempts) {
+        return new RetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter, retryNonIdempotent);
+    }
+
+    /**
+     * Return a copy of this policy with the given initial delay
+     * @param initialDelay The delay before the first retry
+     * @return The new policy
+     */
+    public RetryPolicy withInitialDelay(Duration initialDelay) {
+        return new RetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter, retryNonIdempotent);
+    }
+
+    /**
+     * Return a copy of this policy with the given maximum delay
+     * @param maxDelay The upper bound of the delay between two attempts
+     * @return The new policy
+     */
+    public RetryPolicy withMaxDelay(Duration maxDelay) {
+        return new RetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter, retryNonIdempotent);
+    }
+
+    /**
+     * Return a copy of this policy with the given backoff multiplier
+     * @param multiplier Factor applied to the delay after each attempt (must be &gt;= 1)
+     * @return The new policy
+     */
+    public RetryPolicy withMultiplier(double multiplier) {
+        return new RetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter, retryNonIdempotent);
+    }
+
+    /**
+     * Return a copy of this policy with the given jitter
+     * @param jitter Random jitter as a fraction of the delay, between 0 and 1
+     * @return The new policy
+     */
+    public RetryPolicy withJitter(double jitter) {
+        return new RetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter, retryNonIdempotent);
+    }
+
+    /**
+     * Return a copy of this policy that also retries requests whose body cannot be replayed
+     * @param retryNonIdempotent True to retry non-replayable requests
+     * @return The new policy
+     */
+    public RetryPolicy withRetryNonIdempotent(boolean retryNonIdempotent) {
+        return new RetryPolicy(maxAttempts, initialDelay, maxDelay, multiplier, jitter, retryNonIdempotent);
+    }
+
+    /**
+     * Get the maximum number of attempts
+     * @return The maximum number of attempts
+     */
+    public int getMaxAttempts() {
+        return maxAttempts;
+    }
+
+    /**
+     * Get the initial delay
+     * @return The initial delay
+     */
+    public Duration getInitialDelay() {
+        return initialDelay;
+    }
+
+    /**
+     * Get the maximum delay
+     * @return The maximum delay
+     */
+    public Duration getMaxDelay() {
+        return maxDelay;
+    }
+
+    /**
+     * Get the backoff multiplier
+     * @return The multiplier
+     */
+    public double getMultiplier() {
+        return multiplier;
+    }
+
+    /**
+     * Get the jitter
+     * @return The jitter as a fraction of the delay
+     */
+    public double getJitter() {
+        return jitter;
+    }
+
+    /**
+     * Whether requests with a non-replayable body are retried
+     * @return True if non-replayable requests are retried
+     */
+    public boolean isRetryNonIdempotent() {
+        return retryNonIdempotent;
+    }
+
+    /**
+     * Get the number of attempts for a request
+     * @param idempotent Whether the request can be safely replayed
+     * @return The number of attempts
+     */
+    public int getMaxAttempts(boolean idempotent) {
+        return idempotent || retryNonIdempotent ? maxAttempts : 1;
+    }
+
+    /**
+     * Whether the status code is transient and the request should be retried
+     * @param statusCode The status code
+     * @return True if retryable
+     */
+    public boolean isRetryableStatus(int statusCode) {
+        return statusCode == 429 || (statusCode >= 500 && statusCode <= 599);
+    }
+
+    /**
+     * Whether the exception is transient and the request should be retried
+     * @param e The exception
+     * @return True if retryable
+     */
+    public boolean isRetryableException(Exception e) {
+        return e instanceof IOException;
+    }
+
+    /**
+     * Compute the delay before the next attempt
+     * @param attempt The zero-based attempt that just failed
+     * @return The delay in milliseconds
+     */
+    public long computeDelay(int attempt) {
+        double delay = initialDelay.toMillis() * Math.pow(multiplier, Math.min(attempt, 30));
+        if (jitter > 0) {
+            delay = delay * (1 - jitter + ThreadLocalRandom.current().nextDouble() * 2 * jitter);
+        }
+        return Math.min((long) delay, maxDelay.toMillis());
+    }
+
+    /**
+     * Compute the delay before the next attempt, honoring the delay indicated by the server
+     * @param attempt The zero-based attempt that just failed
+     * @param retryAfter The delay indicated by the server or null to use the backoff
+     * @return The delay in milliseconds, capped by the maximum delay
+     */
+    public long computeDelay(int attempt, @Nullable Duration retryAfter) {
+        if (retryAfter == null) {
+            return computeDelay(attempt);
+        }
+        return Math.min(Math.max(0, retryAfter.toMillis()), maxDelay.toMillis());
+    }
+
+    /**
+     * Parse a {@code Retry-After} header value given in seconds or as an HTTP date
+     * @param value The header value
+     * @return The delay or null if the value is missing or invalid
+     */
+    public static @Nullable Duration parseRetryAfter(@Nullable String value) {
+        if (value == null || value.isBlank()) {
+            return null;
+        }
+        String trimmed = value.trim();
+        try {
+            return Duration.ofSeconds(Math.max(0, Long.parseLong(trimmed)));
+        } catch (NumberFormatException e) {
+            // Not delta-seconds, try HTTP date
+        }
+        try {
+            ZonedDateTime date = ZonedDateTime.parse(trimmed, DateTimeFormatter.RFC_1123_DATE_TIME);
+            Duration delay = Duration.between(ZonedDateTime.now(date.getZone()), date);
+            return delay.isNegative() ? Duration.ZERO : delay;
+        } catch (DateTimeParseException e) {
+            return null;
+        }
+    }
+
+    @Override
+    public String toString() {
+        return "RetryPolicy{" + "maxAttempts=" + maxAttempts + ", initialDelay=" + initialDelay + ", maxDelay="
+                + maxDelay + ", multiplier=" + multiplier + ", jitter=" + jitter + ", retryNonIdempotent="
+                + retryNonIdempotent + '}';
+    }
+}
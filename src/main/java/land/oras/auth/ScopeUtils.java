@@ -59,6 +59,29 @@ final class ScopeUtils {
         }
         return cleanScopes(cleaned);
     }
+    /**
+     * Check if the granted scopes include the requested scope. Repository and registry scopes are included when a
+     * granted scope on the same resource has all the requested actions (or the wildcard action).
+     * @param granted the granted scopes
+     * @param requested the requested scope
+     * @return true if the requested scope is granted
+     */
+    static boolean grants(List<String> granted, String requested) {
+        if (granted.contains(requested)) {
+            return true;
+        }
+        int i = requested.lastIndexOf(':');
+        if (i == -1 || requested.indexOf(':') == i) {
+            return false;
+        }
+        String resource = requested.substring(0, i + 1);
+        List<String> actions = Arrays.asList(requested.substring(i + 1).split(","));
+        return granted.stream()
+                .filter(scope -> scope.startsWith(resource) && scope.indexOf(':', resource.length()) == -1)
+                .map(scope -> Arrays.asList(scope.substring(resource.length()).split(",")))
+                .anyMatch(grantedActions -> grantedActions.contains("*") || grantedActions.containsAll(actions));
+    }
+
     /**
      * Create a scope for a repository.
      * @param ref the container reference
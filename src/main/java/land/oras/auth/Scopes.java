@@ -120,6 +120,17 @@ public final class Scopes {
                 containerRef, service, ScopeUtils.appendRepositoryScope(this.scopes, containerRef, newScopes));
     }
 
+    /**
+     * Return a new copy of the Scopes object with added scopes on another repository, for example pull on the
+     * source repository of a copy or mount, so a single token covers both repositories
+     * @param repository The other repository
+     * @param newScopes The scopes to add on the other repository
+     * @return A new Scopes object with the given scopes
+     */
+    public Scopes withAddedRepositoryScopes(ContainerRef repository, Scope... newScopes) {
+        return new Scopes(containerRef, service, ScopeUtils.appendRepositoryScope(this.scopes, repository, newScopes));
+    }
+
     /**
      * Return a new copy of the Scopes object with the given scopes
      * @param globalScopes The global scopes to add
@@ -169,6 +180,15 @@ public final class Scopes {
         return new Scopes(containerRef, service, ScopeUtils.cleanScopes(newScopes));
     }
 
+    /**
+     * Return a new copy of the Scopes object with the scopes granted by a token in place of the requested ones
+     * @param granted The granted scopes
+     * @return A new Scopes object with the granted scopes
+     */
+    Scopes withGrantedScopes(List<String> granted) {
+        return new Scopes(containerRef, service, ScopeUtils.cleanScopes(granted));
+    }
+
     /**
      * Return a new copy of the Scopes object with the given service
      * @param service The service to set
@@ -227,6 +247,21 @@ public final class Scopes {
         return scopes.stream().anyMatch(s -> !s.startsWith("repository:") && !s.startsWith("registry:"));
     }
 
+    /**
+     * Return if a token granted for these scopes also grants all the given scopes (same registry and service and
+     * every requested action included)
+     * @param other The requested scopes
+     * @return True if these scopes include the other scopes
+     */
+    public boolean includes(Scopes other) {
+        if (other.scopes.isEmpty()
+                || !Objects.equals(getService(), other.getService())
+                || !Objects.equals(getRegistry(), other.getRegistry())) {
+            return false;
+        }
+        return other.scopes.stream().allMatch(scope -> ScopeUtils.grants(scopes, scope));
+    }
+
     /**
      * Return if these are pull-only scopes (i.e. all scopes end with ":pull" and there are no global scopes)
      * @return True if these are pull-only scopes, false otherwise
@@ -0,0 +1,118 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import java.net.http.HttpResponse;
+import java.nio.ByteBuffer;
+import java.util.List;
+import java.util.concurrent.CompletionStage;
+import java.util.concurrent.Flow;
+import land.oras.exception.ContentTooLargeException;
+import land.oras.utils.Const;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Body subscriber failing with a {@link ContentTooLargeException} as soon as the body exceeds a maximum size.
+ * The announced Content-Length is checked before reading any byte.
+ * @param <T> The body type
+ */
+@NullMarked
+final class SizeLimitedBodySubscriber<T> implements HttpResponse.BodySubscriber<T> {
+
+    private final HttpResponse.BodySubscriber<T> delegate;
+    private final long maxSize;
+    private final long contentLength;
+    private @Nullable Flow.Subscription subscription;
+    private long received;
+    private boolean exceeded;
+
+    private SizeLimitedBodySubscriber(HttpResponse.BodySubscriber<T> delegate, long maxSize, long contentLength) {
+        this.delegate = delegate;
+        this.maxSize = maxSize;
+        this.contentLength = contentLength;
+    }
+
+    /**
+     * Limit the body size of a handler
+     * @param handler The handler
+     * @param maxSize The maximum size in bytes
+     * @param <T> The body type
+     * @return The size limited handler
+     */
+    static <T> HttpResponse.BodyHandler<T> limit(HttpResponse.BodyHandler<T> handler, long maxSize) {
+        return responseInfo -> new SizeLimitedBodySubscriber<>(
+                handler.apply(responseInfo),
+                maxSize,
+                responseInfo.headers().firstValueAsLong(Const.CONTENT_LENGTH_HEADER).orElse(-1));
+    }
+
+    @Override
+    public CompletionStage<T> getBody() {
+        return delegate.getBody();
+    }
+
+    @Override
+    public void onSubscribe(Flow.Subscription subscription) {
+        this.subscription = subscription;
+        delegate.onSubscribe(subscription);
+        if (contentLength > maxSize) {
+            exceed(contentLength);
+        }
+    }
+
+    @Override
+    public void onNext(List<ByteBuffer> items) {
+        if (exceeded) {
+            return;
+        }
+        for (ByteBuffer item : items) {
+            received += item.remaining();
+        }
+        if (received > maxSize) {
+            exceed(received);
+            return;
+        }
+        delegate.onNext(items);
+    }
+
+    @Override
+    public void onError(Throwable throwable) {
+        if (!exceeded) {
+            delegate.onError(throwable);
+        }
+    }
+
+    @Override
+    public void onComplete() {
+        if (!exceeded) {
+            delegate.onComplete();
+        }
+    }
+
+    private void exceed(long size) {
+        exceeded = true;
+        if (subscription != null) {
+            subscription.cancel();
+        }
+        delegate.onError(new ContentTooLargeException(maxSize, size));
+    }
+}
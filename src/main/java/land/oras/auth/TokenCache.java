@@ -26,14 +26,20 @@ import com.github.benmanes.caffeine.cache.Expiry;
 import io.micrometer.core.instrument.MeterRegistry;
 import io.micrometer.core.instrument.Metrics;
 import io.micrometer.core.instrument.binder.cache.CaffeineCacheMetrics;
+import java.nio.charset.StandardCharsets;
+import java.util.Objects;
+import java.util.Set;
 import java.util.concurrent.TimeUnit;
+import java.util.stream.Collectors;
+import land.oras.utils.SupportedAlgorithm;
 import org.jspecify.annotations.NullMarked;
 import org.jspecify.annotations.Nullable;
 import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 
 /**
- * Cache for storing token responses based on scopes.
+ * Cache for storing token responses based on the granted scopes and the identity of the credentials used to obtain
+ * them, so tokens are never shared between registries or credentials.
  */
 @NullMarked
 public final class TokenCache {
@@ -48,6 +54,22 @@ public final class TokenCache {
      */
     public static final int MAX_CACHE_SIZE = 500;
 
+    /**
+     * Tokens are evicted this many seconds before their expiry so a fresh token is requested before the old one
+     * is rejected in the middle of a long operation
+     */
+    public static final int REFRESH_MARGIN_SECONDS = 30;
+
+    /**
+     * Maximum number of scopes requested in a single token call when widening the scopes of cached tokens
+     */
+    public static final int MAX_WIDENED_SCOPES = 10;
+
+    /**
+     * Identity of requests without credentials
+     */
+    static final String ANONYMOUS_IDENTITY = "anonymous";
+
     /**
      * Logger for this class
      */
@@ -60,30 +82,37 @@ public final class TokenCache {
         // Private constructor to prevent instantiation
     }
 
+    /**
+     * A cache key
+     * @param identity The identity of the credentials
+     * @param scopes The granted scopes
+     */
+    private record Key(String identity, Scopes scopes) {}
+
     /**
      * The cache
      */
-    private static final Cache<Scopes, HttpClient.TokenResponse> CACHE;
+    private static final Cache<Key, HttpClient.TokenResponse> CACHE;
 
     static {
         CACHE = Caffeine.newBuilder()
                 .maximumSize(MAX_CACHE_SIZE)
                 .recordStats()
-                .expireAfter(new Expiry<Scopes, HttpClient.TokenResponse>() {
+                .expireAfter(new Expiry<Key, HttpClient.TokenResponse>() {
                     @Override
-                    public long expireAfterCreate(Scopes key, HttpClient.TokenResponse token, long currentTime) {
+                    public long expireAfterCreate(Key key, HttpClient.TokenResponse token, long currentTime) {
                         return getExpiration(token);
                     }
 
                     @Override
                     public long expireAfterUpdate(
-                            Scopes key, HttpClient.TokenResponse token, long currentTime, long currentDuration) {
+                            Key key, HttpClient.TokenResponse token, long currentTime, long currentDuration) {
                         return currentDuration;
                     }
 
                     @Override
                     public long expireAfterRead(
-                            Scopes key, HttpClient.TokenResponse token, long currentTime, long currentDuration) {
+                            Key key, HttpClient.TokenResponse token, long currentTime, long currentDuration) {
                         return currentDuration;
                     }
                 })
@@ -107,14 +136,36 @@ public final class TokenCache {
     }
 
     /**
-     * Put a token response in the cache with the associated scopes.
-     * @param scopes The scopes associated with the token response
+     * Get the identity of the credentials sent with a request. Only a digest of the authentication header is kept.
+     * @param authHeader The authentication header or null for anonymous requests
+     * @return The identity
+     */
+    static String identity(@Nullable String authHeader) {
+        if (authHeader == null) {
+            return ANONYMOUS_IDENTITY;
+        }
+        return SupportedAlgorithm.SHA256.digest(authHeader.getBytes(StandardCharsets.UTF_8));
+    }
+
+    /**
+     * Put a token response obtained without credentials in the cache with the granted scopes.
+     * @param scopes The scopes granted by the token response
      * @param token The token response to be cached
      */
     public static void put(Scopes scopes, HttpClient.TokenResponse token) {
+        put(ANONYMOUS_IDENTITY, scopes, token);
+    }
+
+    /**
+     * Put a token response in the cache with the granted scopes.
+     * @param identity The identity of the credentials used to obtain the token
+     * @param scopes The scopes granted by the token response
+     * @param token The token response to be cached
+     */
+    static void put(String identity, Scopes scopes, HttpClient.TokenResponse token) {
         Scopes newScopes = scopes.getService() != null ? scopes : scopes.withService(token.service());
         LOG.trace("Caching token for scopes: {}", newScopes);
-        CACHE.put(newScopes, token);
+        CACHE.put(new Key(identity, newScopes), token);
         if (newScopes.getService() != null) {
             LOG.trace("Caching service '{}' for registry '{}'", newScopes.getService(), newScopes.getRegistry());
             SERVICE_CACHE.put(scopes.getRegistry(), newScopes.getService());
@@ -123,28 +174,39 @@ public final class TokenCache {
         if (scopes.hasGlobalScopes()) {
             Scopes newScopesWithService =
                     scopes.withService(newScopes.getService()).withOnlyGlobalScopes();
-            CACHE.put(newScopesWithService, token);
+            CACHE.put(new Key(identity, newScopesWithService), token);
             Scopes newScopesWithoutGlobal =
                     scopes.withService(newScopes.getService()).withoutGlobalScopes();
-            CACHE.put(newScopesWithoutGlobal, token);
+            CACHE.put(new Key(identity, newScopesWithoutGlobal), token);
         }
     }
 
     /**
-     * Get a token response from the cache based on the provided scopes.
+     * Get a token response obtained without credentials from the cache based on the provided scopes.
      * @param scopes The scopes to look up in the cache
-     * @return the token response associated with the scopes, or null if not found or expired
+     * @return the token response granting the scopes, or null if not found or expired
      */
     public static HttpClient.@Nullable TokenResponse get(Scopes scopes) {
+        return get(ANONYMOUS_IDENTITY, scopes);
+    }
+
+    /**
+     * Get a token response from the cache based on the provided scopes.
+     * Only tokens obtained with the same credentials and granting every requested action are returned.
+     * @param identity The identity of the credentials of the request
+     * @param scopes The scopes to look up in the cache
+     * @return the token response granting the scopes, or null if not found or expired
+     */
+    static HttpClient.@Nullable TokenResponse get(String identity, Scopes scopes) {
         String service =
                 scopes.getService() != null ? scopes.getService() : SERVICE_CACHE.getIfPresent(scopes.getRegistry());
-        HttpClient.TokenResponse token = CACHE.getIfPresent(scopes);
+        HttpClient.TokenResponse token = CACHE.getIfPresent(new Key(identity, scopes));
         if (token != null) {
             LOG.trace("Direct cache hit for scopes: {}", scopes);
             return token;
         }
         // Try lookup with service
-        token = CACHE.getIfPresent(scopes.withService(service));
+        token = CACHE.getIfPresent(new Key(identity, scopes.withService(service)));
         if (token != null) {
             LOG.trace("Cache lookup for scopes: {}, found with service '{}'", scopes, service);
             return token;
@@ -152,45 +214,112 @@ public final class TokenCache {
         // Check englobing scopes
         if (scopes.isPullOnly() && !scopes.hasGlobalScopes()) {
             Scopes newScopes = scopes.withService(service).withAddedRegistryScopes(Scope.PUSH); // Just add push scope
-            token = CACHE.getIfPresent(newScopes);
+            token = CACHE.getIfPresent(new Key(identity, newScopes));
             if (token != null) {
                 LOG.trace("Cache lookup for scopes: {}, found with push scope", scopes);
                 return token;
             }
             newScopes = newScopes.withService(service).withAddedRegistryScopes(Scope.DELETE); // Add delete scope
-            token = CACHE.getIfPresent(newScopes);
+            token = CACHE.getIfPresent(new Key(identity, newScopes));
             if (token != null) {
                 LOG.trace("Cache lookup for scopes: {}, found with push and delete scopes", scopes);
                 return token;
             }
             newScopes = newScopes.withService(service).withRegistryScopes(Scope.ALL); // All replace all scopes
-            token = CACHE.getIfPresent(newScopes);
+            token = CACHE.getIfPresent(new Key(identity, newScopes));
             if (token != null) {
                 LOG.trace("Cache lookup for scopes: {}, found with all scopes", scopes);
                 return token;
             }
-            return null;
         }
         if (scopes.hasGlobalScopes()) {
-            token = CACHE.getIfPresent(scopes.withOnlyGlobalScopes());
+            token = CACHE.getIfPresent(new Key(identity, scopes.withOnlyGlobalScopes()));
             if (token != null) {
                 LOG.trace("Cache lookup for scopes: {}, found with only global scopes", scopes);
                 return token;
             }
         }
+        // Check tokens granted for multiple scopes
+        Scopes withService = scopes.withService(service);
+        for (var entry : CACHE.asMap().entrySet()) {
+            Key key = entry.getKey();
+            if (key.identity().equals(identity) && key.scopes().includes(withService)) {
+                LOG.trace("Cache lookup for scopes: {}, found with wider scopes {}", scopes, key.scopes());
+                return entry.getValue();
+            }
+        }
         LOG.trace("Cache miss for scopes: {}", scopes);
         return null;
     }
 
+    /**
+     * Widen the scopes of a token request without credentials with the actions granted by the cached tokens.
+     * @param scopes The scopes needed by the operation
+     * @return The scopes to request
+     */
+    public static Scopes widen(Scopes scopes) {
+        return widen(ANONYMOUS_IDENTITY, scopes);
+    }
+
+    /**
+     * Widen the scopes of a token request with the actions granted by the cached tokens of the same credentials on
+     * the same repositories, so a new token covers both the previous and the new operation (for example pull then
+     * push) instead of replacing each other. The scopes are not widened beyond {@link #MAX_WIDENED_SCOPES}.
+     * @param identity The identity of the credentials of the request
+     * @param scopes The scopes needed by the operation
+     * @return The scopes to request
+     */
+    static Scopes widen(String identity, Scopes scopes) {
+        if (scopes.hasGlobalScopes()) {
+            return scopes;
+        }
+        Set<String> resources =
+                scopes.getScopes().stream().map(TokenCache::resource).collect(Collectors.toSet());
+        Scopes widened = scopes;
+        for (Key key : CACHE.asMap().keySet()) {
+            Scopes cached = key.scopes();
+            if (!key.identity().equals(identity)
+                    || !Objects.equals(cached.getService(), scopes.getService())
+                    || !Objects.equals(cached.getRegistry(), scopes.getRegistry())
+                    || cached.hasGlobalScopes()) {
+                continue;
+            }
+            for (String scope : cached.getScopes()) {
+                if (resources.contains(resource(scope)) && widened.getScopes().size() < MAX_WIDENED_SCOPES) {
+                    widened = widened.withNewScope(scope);
+                }
+            }
+        }
+        if (!widened.equals(scopes)) {
+            LOG.debug("Widened scopes {} to {}", scopes.getScopes(), widened.getScopes());
+        }
+        return widened;
+    }
+
+    /**
+     * Get the resource of a scope (for example {@code repository:library/alpine})
+     * @param scope The scope
+     * @return The resource
+     */
+    private static String resource(String scope) {
+        int i = scope.lastIndexOf(':');
+        return i == -1 ? scope : scope.substring(0, i);
+    }
+
     /**
      * Get the expiration time for a token response.
+     * Tokens living longer than twice the refresh margin expire {@link #REFRESH_MARGIN_SECONDS} earlier.
      * @param token The token response
      * @return the expiration time in nanoseconds or 60 seconds if expires_in is not provided
      */
-    private static long getExpiration(HttpClient.TokenResponse token) {
+    static long getExpiration(HttpClient.TokenResponse token) {
         if (token.expires_in() == null) {
             return TimeUnit.SECONDS.toNanos(60);
         }
-        return TimeUnit.SECONDS.toNanos(token.expires_in());
+        long expiresIn = token.expires_in();
+        if (expiresIn > 2L * REFRESH_MARGIN_SECONDS) {
+            return TimeUnit.SECONDS.toNanos(expiresIn - REFRESH_MARGIN_SECONDS);
+        }
+        return TimeUnit.SECONDS.toNanos(expiresIn);
     }
 }
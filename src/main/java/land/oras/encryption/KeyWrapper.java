@@ -0,0 +1,75 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.encryption;
+
+import land.oras.utils.Const;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Wrap the symmetric key of encrypted layers for recipients, and unwrap it with private keys, like the key wrappers
+ * of containerd's ocicrypt. The wrapped keys are stored in the {@link #getAnnotationId()} annotation of the layer.
+ * The SDK provides {@link JweKeyWrapper}; other schemes like PGP or PKCS#11 can be plugged by implementing this
+ * interface with their ocicrypt annotation.
+ */
+@NullMarked
+public interface KeyWrapper {
+
+    /**
+     * The annotation of keys wrapped as JWE
+     */
+    String JWE_ANNOTATION = Const.ANNOTATION_ENC_KEYS_PREFIX + "jwe";
+
+    /**
+     * The annotation of keys wrapped with PGP
+     */
+    String PGP_ANNOTATION = Const.ANNOTATION_ENC_KEYS_PREFIX + "pgp";
+
+    /**
+     * The annotation of keys wrapped as PKCS#7
+     */
+    String PKCS7_ANNOTATION = Const.ANNOTATION_ENC_KEYS_PREFIX + "pkcs7";
+
+    /**
+     * The annotation of keys wrapped with PKCS#11
+     */
+    String PKCS11_ANNOTATION = Const.ANNOTATION_ENC_KEYS_PREFIX + "pkcs11";
+
+    /**
+     * The annotation storing the wrapped keys
+     * @return The annotation, starting with {@value Const#ANNOTATION_ENC_KEYS_PREFIX}
+     */
+    String getAnnotationId();
+
+    /**
+     * Wrap the private options of a layer, holding its symmetric key, for all the recipients of this wrapper
+     * @param optsData The JSON of the private options
+     * @return The wrapped options
+     */
+    byte[] wrapKeys(byte[] optsData);
+
+    /**
+     * Unwrap the private options of a layer
+     * @param annotation One entry of the annotation, base64 decoded
+     * @return The JSON of the private options, or null if no private key of this wrapper can unwrap them
+     */
+    byte @Nullable [] unwrapKey(byte[] annotation);
+}
@@ -0,0 +1,349 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.encryption;
+
+import java.io.FilterInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.OutputStream;
+import java.nio.charset.StandardCharsets;
+import java.security.GeneralSecurityException;
+import java.security.MessageDigest;
+import java.security.SecureRandom;
+import java.util.Arrays;
+import java.util.Base64;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import javax.crypto.Cipher;
+import javax.crypto.Mac;
+import javax.crypto.spec.IvParameterSpec;
+import javax.crypto.spec.SecretKeySpec;
+import land.oras.Layer;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.JsonUtils;
+import land.oras.utils.SupportedAlgorithm;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Encrypt and decrypt layers compatible with containerd's ocicrypt. Layers are encrypted with
+ * {@value #CIPHER} using a random key per layer, whose private options are wrapped for each recipient by the
+ * {@link KeyWrapper}s and stored in annotations. The media type of encrypted layers ends with
+ * {@value Const#ENCRYPTED_MEDIA_TYPE_SUFFIX}.
+ */
+@NullMarked
+public final class LayerEncryption {
+
+    /**
+     * The symmetric cipher of the layers
+     */
+    public static final String CIPHER = "AES_256_CTR_HMAC_SHA256";
+
+    private static final int KEY_SIZE = 32;
+    private static final int NONCE_SIZE = 16;
+    private static final int BUFFER_SIZE = 8192;
+
+    private final List<KeyWrapper> keyWrappers;
+    private final SecureRandom random = new SecureRandom();
+
+    private LayerEncryption(List<KeyWrapper> keyWrappers) {
+        this.keyWrappers = List.copyOf(keyWrappers);
+    }
+
+    /**
+     * Create the encryption using the given key wrappers, to wrap keys when pushing and unwrap them when pulling
+     * @param keyWrappers The key wrappers
+     * @return The layer encryption
+     */
+    public static LayerEncryption of(KeyWrapper... keyWrappers) {
+        if (keyWrappers.length == 0) {
+            throw new OrasException("At least one key wrapper is required to encrypt layers");
+        }
+        return new LayerEncryption(Arrays.asList(keyWrappers));
+    }
+
+    /**
+     * Get the key wrappers
+     * @return The key wrappers
+     */
+    public List<KeyWrapper> getKeyWrappers() {
+        return keyWrappers;
+    }
+
+    /**
+     * Check if a layer is encrypted
+     * @param layer The layer
+     * @return True if the media type of the layer is an encrypted media type
+     */
+    public static boolean isEncrypted(Layer layer) {
+        return layer.getMediaType().endsWith(Const.ENCRYPTED_MEDIA_TYPE_SUFFIX);
+    }
+
+    /**
+     * Return the layer as it was before encryption, without the encrypted media type suffix and the encryption
+     * annotations. The digest and size are the ones of the encrypted blob
+     * @param layer The encrypted layer
+     * @return The layer
+     */
+    public static Layer toDecrypted(Layer layer) {
+        String mediaType = layer.getMediaType();
+        Map<String, String> annotations = new LinkedHashMap<>(layer.getAnnotations());
+        annotations.keySet().removeIf(key -> key.equals(Const.ANNOTATION_ENC_PUBOPTS)
+                || key.startsWith(Const.ANNOTATION_ENC_KEYS_PREFIX));
+        return layer.withMediaType(
+                        mediaType.substring(0, mediaType.length() - Const.ENCRYPTED_MEDIA_TYPE_SUFFIX.length()))
+                .withAnnotations(annotations);
+    }
+
+    /**
+     * Encrypt a layer
+     * @param plaintext The content of the layer. Not closed by this method
+     * @param ciphertext The stream receiving the encrypted content. Not closed by this method
+     * @return The encryption annotations to add to the layer
+     */
+    public Map<String, String> encrypt(InputStream plaintext, OutputStream ciphertext) {
+        byte[] key = new byte[KEY_SIZE];
+        byte[] nonce = new byte[NONCE_SIZE];
+        random.nextBytes(key);
+        random.nextBytes(nonce);
+        try {
+            Cipher cipher = Cipher.getInstance("AES/CTR/NoPadding");
+            cipher.init(Cipher.ENCRYPT_MODE, new SecretKeySpec(key, "AES"), new IvParameterSpec(nonce));
+            Mac mac = Mac.getInstance("HmacSHA256");
+            mac.init(new SecretKeySpec(key, "HmacSHA256"));
+            // ocicrypt always records the SHA-256 digest of the plaintext
+            MessageDigest digest = SupportedAlgorithm.SHA256.newMessageDigest();
+            byte[] buffer = new byte[BUFFER_SIZE];
+            int read;
+            while ((read = plaintext.read(buffer)) != -1) {
+                digest.update(buffer, 0, read);
+                byte[] encrypted = cipher.update(buffer, 0, read);
+                if (encrypted != null) {
+                    mac.update(encrypted);
+                    ciphertext.write(encrypted);
+                }
+            }
+            byte[] encrypted = cipher.doFinal();
+            mac.update(encrypted);
+            ciphertext.write(encrypted);
+
+            Map<String, Object> privateOptions = Map.of(
+                    "symkey", encode(key),
+                    "digest", SupportedAlgorithm.SHA256.formatDigest(digest.digest()),
+                    "cipheroptions", Map.of("nonce", encode(nonce)));
+            byte[] optsData = JsonUtils.toJson(privateOptions).getBytes(StandardCharsets.UTF_8);
+            Map<String, String> annotations = new HashMap<>();
+            for (KeyWrapper keyWrapper : keyWrappers) {
+                annotations.merge(
+                        keyWrapper.getAnnotationId(),
+                        encode(keyWrapper.wrapKeys(optsData)),
+                        (existing, wrapped) -> existing + "," + wrapped);
+            }
+            Map<String, Object> publicOptions = Map.of("cipher", CIPHER, "hmac", encode(mac.doFinal()));
+            annotations.put(
+                    Const.ANNOTATION_ENC_PUBOPTS,
+                    encode(JsonUtils.toJson(publicOptions).getBytes(StandardCharsets.UTF_8)));
+            return annotations;
+        } catch (GeneralSecurityException | IOException e) {
+            throw new OrasException("Failed to encrypt layer", e);
+        }
+    }
+
+    /**
+     * Decrypt a layer with the first key wrapper able to unwrap its key. The HMAC and the digest of the plaintext
+     * are verified at the end of the stream
+     * @param layer The encrypted layer
+     * @param ciphertext The encrypted content of the layer
+     * @return The decrypted content
+     */
+    @SuppressWarnings("unchecked")
+    public InputStream decrypt(Layer layer, InputStream ciphertext) {
+        Map<String, String> annotations = layer.getAnnotations();
+        String pubopts = annotations.get(Const.ANNOTATION_ENC_PUBOPTS);
+        if (pubopts == null) {
+            throw new OrasException("Missing %s annotation on encrypted layer %s"
+                    .formatted(Const.ANNOTATION_ENC_PUBOPTS, layer.getDigest()));
+        }
+        Map<String, Object> publicOptions =
+                JsonUtils.fromJson(new String(decode(pubopts), StandardCharsets.UTF_8), Map.class);
+        if (!CIPHER.equals(publicOptions.get("cipher"))) {
+            throw new OrasException("Unsupported cipher %s of layer %s"
+                    .formatted(publicOptions.get("cipher"), layer.getDigest()));
+        }
+        Map<String, Object> privateOptions = unwrap(layer);
+        Map<String, Object> cipherOptions = new HashMap<>();
+        if (publicOptions.get("cipheroptions") instanceof Map<?, ?> options) {
+            cipherOptions.putAll((Map<String, Object>) options);
+        }
+        if (privateOptions.get("cipheroptions") instanceof Map<?, ?> options) {
+            cipherOptions.putAll((Map<String, Object>) options);
+        }
+        byte[] key = decode((String) privateOptions.get("symkey"));
+        if (key.length != KEY_SIZE || cipherOptions.get("nonce") == null) {
+            throw new OrasException("Invalid key of layer %s".formatted(layer.getDigest()));
+        }
+        try {
+            Cipher cipher = Cipher.getInstance("AES/CTR/NoPadding");
+            cipher.init(
+                    Cipher.DECRYPT_MODE,
+                    new SecretKeySpec(key, "AES"),
+                    new IvParameterSpec(decode((String) cipherOptions.get("nonce"))));
+            Mac mac = Mac.getInstance("HmacSHA256");
+            mac.init(new SecretKeySpec(key, "HmacSHA256"));
+            return new DecryptingInputStream(
+                    ciphertext,
+                    cipher,
+                    mac,
+                    decode((String) publicOptions.get("hmac")),
+                    (String) privateOptions.get("digest"),
+                    layer.getDigest());
+        } catch (GeneralSecurityException e) {
+            throw new OrasException("Failed to decrypt layer %s".formatted(layer.getDigest()), e);
+        }
+    }
+
+    @SuppressWarnings("unchecked")
+    private Map<String, Object> unwrap(Layer layer) {
+        for (KeyWrapper keyWrapper : keyWrappers) {
+            String value = layer.getAnnotations().get(keyWrapper.getAnnotationId());
+            if (value == null) {
+                continue;
+            }
+            // One entry per wrapping, each possibly for several recipients
+            for (String entry : value.split(",")) {
+                byte[] optsData = keyWrapper.unwrapKey(decode(entry));
+                if (optsData != null) {
+                    return JsonUtils.fromJson(new String(optsData, StandardCharsets.UTF_8), Map.class);
+                }
+            }
+        }
+        throw new OrasException("No key available to decrypt layer %s".formatted(layer.getDigest()));
+    }
+
+    private static String encode(byte[] data) {
+        return Base64.getEncoder().encodeToString(data);
+    }
+
+    private static byte[] decode(String data) {
+        return Base64.getDecoder().decode(data);
+    }
+
+    /**
+     * Decrypt the content while reading, verifying the HMAC of the ciphertext and the digest of the plaintext at
+     * the end of the stream
+     */
+    private static final class DecryptingInputStream extends FilterInputStream {
+
+        private final Cipher cipher;
+        private final Mac mac;
+        private final byte[] expectedHmac;
+        private final @Nullable String expectedDigest;
+        private final String layerDigest;
+        private final MessageDigest digest = SupportedAlgorithm.SHA256.newMessageDigest();
+        private boolean verified;
+
+        private DecryptingInputStream(
+                InputStream in,
+                Cipher cipher,
+                Mac mac,
+                byte[] expectedHmac,
+                @Nullable String expectedDigest,
+                String layerDigest) {
+            super(in);
+            this.cipher = cipher;
+            this.mac = mac;
+            this.expectedHmac = expectedHmac;
+            this.expectedDigest = expectedDigest;
+            this.layerDigest = layerDigest;
+        }
+
+        @Override
+        public int read() throws IOException {
+            byte[] b = new byte[1];
+            // CTR decrypts as many bytes as read
+            int read = read(b, 0, 1);
+            return read == -1 ? -1 : b[0] & 0xff;
+        }
+
+        @Override
+        public int read(byte[] b, int off, int len) throws IOException {
+            int read = super.read(b, off, len);
+            if (read == -1) {
+                verify();
+                return -1;
+            }
+            mac.update(b, off, read);
+            try {
+                int decrypted = cipher.update(b, off, read, b, off);
+                digest.update(b, off, decrypted);
+                return decrypted;
+            } catch (GeneralSecurityException e) {
+                throw new IOException("Failed to decrypt layer %s".formatted(layerDigest), e);
+            }
+        }
+
+        @Override
+        public long skip(long n) throws IOException {
+            // Skipped bytes must be decrypted and verified too
+            byte[] buffer = new byte[(int) Math.min(n, BUFFER_SIZE)];
+            long skipped = 0;
+            while (skipped < n) {
+                int read = read(buffer, 0, (int) Math.min(buffer.length, n - skipped));
+                if (read == -1) {
+                    break;
+                }
+                skipped += read;
+            }
+            return skipped;
+        }
+
+        @Override
+        public boolean markSupported() {
+            return false;
+        }
+
+        @Override
+        public synchronized void mark(int readlimit) {}
+
+        @Override
+        public synchronized void reset() throws IOException {
+            throw new IOException("mark/reset not supported");
+        }
+
+        private void verify() {
+            if (verified) {
+                return;
+            }
+            verified = true;
+            if (!MessageDigest.isEqual(expectedHmac, mac.doFinal())) {
+                throw new OrasException("HMAC mismatch of encrypted layer %s".formatted(layerDigest));
+            }
+            String actual = SupportedAlgorithm.SHA256.formatDigest(digest.digest());
+            if (expectedDigest != null && !expectedDigest.equals(actual)) {
+                throw new OrasException("Digest mismatch of decrypted layer %s: %s != %s"
+                        .formatted(layerDigest, expectedDigest, actual));
+            }
+        }
+    }
+}
@@ -0,0 +1,67 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.exception;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Exception thrown when a manifest or blob exceeds the maximum size accepted by the client
+ */
+@NullMarked
+public class ContentTooLargeException extends OrasException {
+
+    /**
+     * The maximum size
+     */
+    private final long maxSize;
+
+    /**
+     * The announced size or the number of bytes received when the limit was exceeded
+     */
+    private final long size;
+
+    /**
+     * New exception for content exceeding the maximum size
+     * @param maxSize The maximum size
+     * @param size The announced size or the number of bytes received
+     */
+    public ContentTooLargeException(long maxSize, long size) {
+        super("Content size of %d bytes exceeds the maximum of %d bytes".formatted(size, maxSize));
+        this.maxSize = maxSize;
+        this.size = size;
+    }
+
+    /**
+     * Get the maximum size
+     * @return The maximum size in bytes
+     */
+    public long getMaxSize() {
+        return maxSize;
+    }
+
+    /**
+     * Get the announced size or the number of bytes received when the limit was exceeded
+     * @return The size in bytes
+     */
+    public long getSize() {
+        return size;
+    }
+}
@@ -0,0 +1,109 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.exception;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Exception thrown when downloaded content doesn't match its expected digest or size
+ */
+@NullMarked
+public class DigestMismatchException extends OrasException {
+
+    /**
+     * The expected digest
+     */
+    private final String expectedDigest;
+
+    /**
+     * The computed digest
+     */
+    private final String actualDigest;
+
+    /**
+     * The expected size or -1 if not checked
+     */
+    private final long expectedSize;
+
+    /**
+     * The actual size or -1 if not checked
+     */
+    private final long actualSize;
+
+    /**
+     * New exception for a digest mismatch
+     * @param expectedDigest The expected digest
+     * @param actualDigest The computed digest
+     */
+    public DigestMismatchException(String expectedDigest, String actualDigest) {
+        super("Digest mismatch: %s != %s".formatted(expectedDigest, actualDigest));
+        this.expectedDigest = expectedDigest;
+        this.actualDigest = actualDigest;
+        this.expectedSize = -1;
+        this.actualSize = -1;
+    }
+
+    /**
+     * New exception for a size mismatch
+     * @param digest The expected digest
+     * @param expectedSize The expected size
+     * @param actualSize The actual size
+     */
+    public DigestMismatchException(String digest, long expectedSize, long actualSize) {
+        super("Size mismatch for %s: expected %d bytes but got %d".formatted(digest, expectedSize, actualSize));
+        this.expectedDigest = digest;
+        this.actualDigest = digest;
+        this.expectedSize = expectedSize;
+        this.actualSize = actualSize;
+    }
+
+    /**
+     * Get the expected digest
+     * @return The expected digest
+     */
+    public String getExpectedDigest() {
+        return expectedDigest;
+    }
+
+    /**
+     * Get the computed digest. Same as the expected digest for a size mismatch
+     * @return The computed digest
+     */
+    public String getActualDigest() {
+        return actualDigest;
+    }
+
+    /**
+     * Get the expected size
+     * @return The expected size or -1 if the size was not checked
+     */
+    public long getExpectedSize() {
+        return expectedSize;
+    }
+
+    /**
+     * Get the actual size
+     * @return The actual size or -1 if the size was not checked
+     */
+    public long getActualSize() {
+        return actualSize;
+    }
+}
@@ -0,0 +1,48 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.exception;
+
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Exception thrown when an operation is cancelled through its {@link land.oras.CancellationToken} or by interrupting
+ * the thread running it
+ */
+@NullMarked
+public class OperationCancelledException extends OrasException {
+
+    /**
+     * New exception for a cancelled operation
+     * @param message The message
+     */
+    public OperationCancelledException(String message) {
+        super(message);
+    }
+
+    /**
+     * New exception for a cancelled operation
+     * @param message The message
+     * @param cause The failure caused by the cancellation
+     */
+    public OperationCancelledException(String message, Throwable cause) {
+        super(message, cause);
+    }
+}
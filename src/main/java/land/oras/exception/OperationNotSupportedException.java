@@ -0,0 +1,70 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.exception;
+
+import java.util.regex.Pattern;
+import land.oras.auth.HttpClient;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Exception thrown when the registry doesn't support an operation, for example deletion disabled by configuration
+ * (HTTP 405) or tag deletion rejected with the {@code UNSUPPORTED} error code
+ */
+@NullMarked
+public class OperationNotSupportedException extends OrasException {
+
+    /**
+     * The error code returned by registries for unsupported operations
+     */
+    public static final String UNSUPPORTED_ERROR_CODE = "UNSUPPORTED";
+
+    /**
+     * Match the unsupported error code in a distribution spec error body
+     */
+    private static final Pattern UNSUPPORTED_PATTERN =
+            Pattern.compile("\"code\"\\s*:\\s*\"%s\"".formatted(UNSUPPORTED_ERROR_CODE));
+
+    /**
+     * New exception for an unsupported operation
+     * @param operation The operation that was rejected
+     * @param response The response
+     */
+    public OperationNotSupportedException(String operation, HttpClient.ResponseWrapper<String> response) {
+        super("Operation not supported by registry: %s (response code: %d)"
+                .formatted(operation, response.statusCode()), response);
+    }
+
+    /**
+     * Return if the response indicates an unsupported operation
+     * @param response The response
+     * @return True if the registry rejected the operation as unsupported
+     */
+    public static boolean isUnsupported(HttpClient.ResponseWrapper<String> response) {
+        if (response.statusCode() == 405) {
+            return true;
+        }
+        if (response.statusCode() < 400) {
+            return false;
+        }
+        String body = response.response();
+        return body != null && UNSUPPORTED_PATTERN.matcher(body).find();
+    }
+}
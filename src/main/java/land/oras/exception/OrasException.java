@@ -62,8 +62,20 @@ public class OrasException extends RuntimeException {
      * @param response The response
      */
     public OrasException(HttpClient.ResponseWrapper<String> response) {
-        this("Response code: " + response.statusCode());
-        String contentType = response.headers().getOrDefault(Const.CONTENT_TYPE_HEADER, "");
+        this("Response code: " + response.statusCode(), response);
+    }
+
+    /**
+     * New exception with a custom message and a response
+     * @param message The message
+     * @param response The response
+     */
+    public OrasException(String message, HttpClient.ResponseWrapper<String> response) {
+        this(message);
+        String contentType = response.headers()
+                .getOrDefault(
+                        Const.CONTENT_TYPE_HEADER,
+                        response.headers().getOrDefault(Const.CONTENT_TYPE_HEADER.toLowerCase(), ""));
         try {
             this.statusCode = response.statusCode();
             if (contentType.contains(Const.DEFAULT_JSON_MEDIA_TYPE)) {
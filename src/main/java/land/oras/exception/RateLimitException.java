@@ -0,0 +1,158 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.exception;
+
+import java.time.Duration;
+import land.oras.auth.HttpClient;
+import land.oras.auth.RetryPolicy;
+import land.oras.utils.Const;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Exception thrown when the registry keeps rejecting requests with HTTP 429 after all retries.
+ * Exposes the rate limit metadata returned by the registry so callers can back off.
+ */
+@NullMarked
+public class RateLimitException extends OrasException {
+
+    /**
+     * The delay indicated by the Retry-After header
+     */
+    private final @Nullable Duration retryAfter;
+
+    /**
+     * The number of requests allowed in the window
+     */
+    private final @Nullable Long limit;
+
+    /**
+     * The number of remaining requests in the window
+     */
+    private final @Nullable Long remaining;
+
+    /**
+     * The rate limit window
+     */
+    private final @Nullable Duration window;
+
+    /**
+     * The source the limit applies to (for example the client IP)
+     */
+    private final @Nullable String source;
+
+    /**
+     * New exception from a 429 response
+     * @param response The response
+     */
+    public RateLimitException(HttpClient.ResponseWrapper<String> response) {
+        super(response);
+        this.retryAfter = RetryPolicy.parseRetryAfter(header(response, Const.RETRY_AFTER_HEADER));
+        String limitHeader = header(response, Const.RATE_LIMIT_LIMIT_HEADER);
+        this.limit = parseCount(limitHeader);
+        this.remaining = parseCount(header(response, Const.RATE_LIMIT_REMAINING_HEADER));
+        this.window = parseWindow(limitHeader);
+        this.source = header(response, Const.RATE_LIMIT_SOURCE_HEADER);
+    }
+
+    /**
+     * Get the delay indicated by the registry before retrying
+     * @return The delay or null if not returned
+     */
+    public @Nullable Duration getRetryAfter() {
+        return retryAfter;
+    }
+
+    /**
+     * Get the number of requests allowed in the window
+     * @return The limit or null if not returned
+     */
+    public @Nullable Long getLimit() {
+        return limit;
+    }
+
+    /**
+     * Get the number of remaining requests in the window
+     * @return The remaining requests or null if not returned
+     */
+    public @Nullable Long getRemaining() {
+        return remaining;
+    }
+
+    /**
+     * Get the rate limit window
+     * @return The window or null if not returned
+     */
+    public @Nullable Duration getWindow() {
+        return window;
+    }
+
+    /**
+     * Get the source the rate limit applies to
+     * @return The source or null if not returned
+     */
+    public @Nullable String getSource() {
+        return source;
+    }
+
+    private static @Nullable String header(HttpClient.ResponseWrapper<String> response, String name) {
+        String value = response.headers().get(name.toLowerCase());
+        return value != null ? value : response.headers().get(name);
+    }
+
+    /**
+     * Parse the count of a header value like {@code 100;w=21600}
+     * @param value The header value
+     * @return The count or null
+     */
+    private static @Nullable Long parseCount(@Nullable String value) {
+        if (value == null) {
+            return null;
+        }
+        try {
+            return Long.parseLong(value.split(";", 2)[0].trim());
+        } catch (NumberFormatException e) {
+            return null;
+        }
+    }
+
+    /**
+     * Parse the window of a header value like {@code 100;w=21600}
+     * @param value The header value
+     * @return The window or null
+     */
+    private static @Nullable Duration parseWindow(@Nullable String value) {
+        if (value == null) {
+            return null;
+        }
+        for (String part : value.split(";")) {
+            String trimmed = part.trim();
+            if (trimmed.startsWith("w=")) {
+                try {
+                    return Duration.ofSeconds(Long.parseLong(trimmed.substring(2)));
+                } catch (NumberFormatException e) {
+                    return null;
+                }
+            }
+        }
+        return null;
+    }
+}
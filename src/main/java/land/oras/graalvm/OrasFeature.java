@@ -0,0 +1,156 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.graalvm;
+
+import java.io.IOException;
+import java.lang.reflect.Executable;
+import java.net.URISyntaxException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.jar.JarEntry;
+import java.util.jar.JarFile;
+import java.util.stream.Stream;
+import land.oras.OrasModel;
+import land.oras.exception.OrasException;
+import org.graalvm.nativeimage.hosted.Feature;
+import org.graalvm.nativeimage.hosted.RuntimeReflection;
+import org.jspecify.annotations.NullMarked;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+/**
+ * GraalVM native-image feature registering every {@link OrasModel} for reflection, so JSON (de)serialization of
+ * manifests, indexes and configurations works in native executables. Enabled by the
+ * {@code META-INF/native-image} metadata bundled in the SDK jar.
+ */
+@NullMarked
+public final class OrasFeature implements Feature {
+
+    /**
+     * The logger
+     */
+    private static final Logger LOG = LoggerFactory.getLogger(OrasFeature.class);
+
+    /**
+     * Constructor
+     */
+    public OrasFeature() {}
+
+    @Override
+    public String getDescription() {
+        return "Registers the ORAS models for reflection";
+    }
+
+    @Override
+    public void beforeAnalysis(BeforeAnalysisAccess access) {
+        List<Class<?>> models = modelClasses(sdkLocation(), access.getApplicationClassLoader());
+        LOG.debug("Registering {} ORAS models for reflection", models.size());
+        models.forEach(OrasFeature::register);
+        // Virtual threads are resolved reflectively since the SDK targets Java 17
+        Class<?> threadBuilder = access.findClassByName("java.lang.Thread$Builder");
+        if (threadBuilder != null) {
+            RuntimeReflection.register(threadBuilder.getMethods());
+            RuntimeReflection.register(optionalMethod(Thread.class, "ofVirtual"));
+        }
+    }
+
+    /**
+     * Find the classes annotated with {@link OrasModel} in a jar or class directory
+     * @param location The jar or class directory
+     * @param classLoader The class loader used to load the classes, without initializing them
+     * @return The model classes
+     */
+    static List<Class<?>> modelClasses(Path location, ClassLoader classLoader) {
+        List<Class<?>> models = new ArrayList<>();
+        for (String className : classNames(location)) {
+            try {
+                Class<?> clazz = Class.forName(className, false, classLoader);
+                if (clazz.isAnnotationPresent(OrasModel.class)) {
+                    models.add(clazz);
+                }
+            } catch (ClassNotFoundException | LinkageError e) {
+                // Classes depending on optional dependencies absent from the image
+                LOG.debug("Skipping class {}: {}", className, e.getMessage());
+            }
+        }
+        return models;
+    }
+
+    private static void register(Class<?> model) {
+        RuntimeReflection.register(model);
+        RuntimeReflection.register(model.getDeclaredConstructors());
+        RuntimeReflection.register(model.getDeclaredMethods());
+        RuntimeReflection.register(model.getDeclaredFields());
+        if (model.isRecord()) {
+            RuntimeReflection.registerAllRecordComponents(model);
+        }
+    }
+
+    private static List<String> classNames(Path location) {
+        try {
+            if (Files.isDirectory(location)) {
+                try (Stream<Path> files = Files.walk(location.resolve("land/oras"))) {
+                    return files.map(location::relativize)
+                            .map(Path::toString)
+                            .map(name -> name.replace(location.getFileSystem().getSeparator(), "/"))
+                            .filter(OrasFeature::isClass)
+                            .map(OrasFeature::className)
+                            .toList();
+                }
+            }
+            try (JarFile jar = new JarFile(location.toFile())) {
+                return jar.stream()
+                        .map(JarEntry::getName)
+                        .filter(name -> name.startsWith("land/oras/") && isClass(name))
+                        .map(OrasFeature::className)
+                        .toList();
+            }
+        } catch (IOException e) {
+            throw new OrasException("Failed to list classes of %s".formatted(location), e);
+        }
+    }
+
+    private static boolean isClass(String name) {
+        return name.endsWith(".class") && !name.endsWith("module-info.class") && !name.endsWith("package-info.class");
+    }
+
+    private static String className(String name) {
+        return name.substring(0, name.length() - ".class".length()).replace('/', '.');
+    }
+
+    private static Path sdkLocation() {
+        try {
+            return Path.of(OrasModel.class.getProtectionDomain().getCodeSource().getLocation().toURI());
+        } catch (URISyntaxException e) {
+            throw new OrasException("Failed to locate the ORAS SDK", e);
+        }
+    }
+
+    private static Executable[] optionalMethod(Class<?> clazz, String name) {
+        try {
+            return new Executable[] {clazz.getMethod(name)};
+        } catch (NoSuchMethodException e) {
+            return new Executable[0];
+        }
+    }
+}
@@ -0,0 +1,187 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.signing;
+
+import java.nio.charset.StandardCharsets;
+import java.security.MessageDigest;
+import java.security.NoSuchAlgorithmException;
+import java.security.cert.CertificateEncodingException;
+import java.security.cert.X509Certificate;
+import java.time.Duration;
+import java.time.Instant;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.HexFormat;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.ArtifactSigner;
+import land.oras.ContainerRef;
+import land.oras.ManifestDescriptor;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.JsonUtils;
+import land.oras.utils.Versions;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Sign artifacts with Notation (Notary Project) signatures, compatible with {@code notation sign} and
+ * {@code notation verify}. The signature envelope is a JWS in JSON serialization using the {@code notary.x509}
+ * signing scheme, attached as a {@value Const#NOTATION_SIGNATURE_ARTIFACT_TYPE} referrer of the artifact.
+ */
+@NullMarked
+public final class NotationSigner implements ArtifactSigner {
+
+    private static final String SIGNING_SCHEME = "notary.x509";
+    private static final String HEADER_SIGNING_SCHEME = "io.cncf.notary.signingScheme";
+    private static final String HEADER_SIGNING_TIME = "io.cncf.notary.signingTime";
+    private static final String HEADER_EXPIRY = "io.cncf.notary.expiry";
+    private static final String HEADER_SIGNING_AGENT = "io.cncf.notary.signingAgent";
+
+    private final SigningKey key;
+    private final @Nullable Duration expiry;
+    private final Map<String, String> userMetadata;
+
+    private NotationSigner(SigningKey key, @Nullable Duration expiry, Map<String, String> userMetadata) {
+        this.key = key;
+        this.expiry = expiry;
+        this.userMetadata = userMetadata;
+    }
+
+    /**
+     * Create a signer
+     * @param key The signing key
+     * @return The signer
+     */
+    public static NotationSigner of(SigningKey key) {
+        return new NotationSigner(key, null, Map.of());
+    }
+
+    /**
+     * Return a copy of this signer producing signatures expiring after the given duration, like the
+     * {@code --expiry} option of {@code notation sign}
+     * @param expiry The validity of the signatures
+     * @return The signer
+     */
+    public NotationSigner withExpiry(Duration expiry) {
+        if (expiry.isNegative() || expiry.isZero()) {
+            throw new OrasException("Signature expiry must be positive");
+        }
+        return new NotationSigner(key, expiry, userMetadata);
+    }
+
+    /**
+     * Return a copy of this signer adding metadata to the signed payload, like the {@code --user-metadata} option
+     * of {@code notation sign}
+     * @param name The metadata key
+     * @param value The metadata value
+     * @return The signer
+     */
+    public NotationSigner withUserMetadata(String name, String value) {
+        Map<String, String> metadata = new LinkedHashMap<>(userMetadata);
+        metadata.put(name, value);
+        return new NotationSigner(key, expiry, Map.copyOf(metadata));
+    }
+
+    /**
+     * Return the signing key
+     * @return The signing key
+     */
+    public SigningKey getKey() {
+        return key;
+    }
+
+    @Override
+    public String getArtifactType() {
+        return Const.NOTATION_SIGNATURE_ARTIFACT_TYPE;
+    }
+
+    @Override
+    public Signature sign(ContainerRef ref, ManifestDescriptor descriptor) {
+        List<X509Certificate> chain = key.getCertificateChain();
+        if (chain.isEmpty()) {
+            throw new OrasException("A certificate chain is required to sign with Notation");
+        }
+        Instant signingTime = Instant.now();
+        List<String> critical = new ArrayList<>(List.of(HEADER_SIGNING_SCHEME));
+        Map<String, Object> protectedHeader = new LinkedHashMap<>();
+        protectedHeader.put("alg", key.getAlgorithm());
+        protectedHeader.put("cty", Const.NOTATION_PAYLOAD_MEDIA_TYPE);
+        protectedHeader.put(HEADER_SIGNING_SCHEME, SIGNING_SCHEME);
+        protectedHeader.put(HEADER_SIGNING_TIME, Const.formatTimestamp(signingTime));
+        if (expiry != null) {
+            critical.add(HEADER_EXPIRY);
+            protectedHeader.put(HEADER_EXPIRY, Const.formatTimestamp(signingTime.plus(expiry)));
+        }
+        protectedHeader.put("crit", critical);
+
+        Map<String, Object> target = new LinkedHashMap<>();
+        target.put("mediaType", descriptor.getMediaType());
+        target.put("digest", descriptor.getDigest());
+        target.put("size", descriptor.getSize());
+        if (!userMetadata.isEmpty()) {
+            target.put("annotations", userMetadata);
+        }
+        String encodedHeader = encode(JsonUtils.toJson(protectedHeader).getBytes(StandardCharsets.UTF_8));
+        String encodedPayload =
+                encode(JsonUtils.toJson(Map.of("targetArtifact", target)).getBytes(StandardCharsets.UTF_8));
+        byte[] signature = key.sign((encodedHeader + "." + encodedPayload).getBytes(StandardCharsets.US_ASCII));
+
+        List<String> certificates = new ArrayList<>();
+        List<String> thumbprints = new ArrayList<>();
+        for (X509Certificate certificate : chain) {
+            byte[] der = encoded(certificate);
+            certificates.add(Base64.getEncoder().encodeToString(der));
+            thumbprints.add(HexFormat.of().formatHex(sha256(der)));
+        }
+        Map<String, Object> envelope = new LinkedHashMap<>();
+        envelope.put("payload", encodedPayload);
+        envelope.put("protected", encodedHeader);
+        envelope.put("header", Map.of("x5c", certificates, HEADER_SIGNING_AGENT, Versions.USER_AGENT_VALUE));
+        envelope.put("signature", encode(signature));
+        return new Signature(
+                Const.NOTATION_JWS_MEDIA_TYPE,
+                JsonUtils.toJson(envelope).getBytes(StandardCharsets.UTF_8),
+                Map.of(Const.ANNOTATION_NOTATION_THUMBPRINT, JsonUtils.toJson(thumbprints)));
+    }
+
+    private static byte[] encoded(X509Certificate certificate) {
+        try {
+            return certificate.getEncoded();
+        } catch (CertificateEncodingException e) {
+            throw new OrasException(
+                    "Failed to encode certificate %s".formatted(certificate.getSubjectX500Principal()), e);
+        }
+    }
+
+    private static byte[] sha256(byte[] data) {
+        try {
+            return MessageDigest.getInstance("SHA-256").digest(data);
+        } catch (NoSuchAlgorithmException e) {
+            throw new OrasException("SHA-256 is not available", e);
+        }
+    }
+
+    private static String encode(byte[] data) {
+        return Base64.getUrlEncoder().withoutPadding().encodeToString(data);
+    }
+}
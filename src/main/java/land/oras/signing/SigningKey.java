@@ -0,0 +1,90 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.signing;
+
+import java.security.PublicKey;
+import java.security.cert.X509Certificate;
+import java.security.interfaces.ECKey;
+import java.security.interfaces.RSAKey;
+import java.util.List;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Key producing the signatures of a {@link NotationSigner}, with the X.509 certificate chain of the signing identity.
+ * The SDK provides {@link LocalSigningKey}; keys held by a KMS or an HSM can be plugged by implementing this interface
+ * with their remote signing operation.
+ */
+@NullMarked
+public interface SigningKey {
+
+    /**
+     * Return the JWS algorithm of the signatures: {@code PS256}, {@code PS384}, {@code PS512}, {@code ES256},
+     * {@code ES384} or {@code ES512}
+     * @return The algorithm
+     */
+    String getAlgorithm();
+
+    /**
+     * Return the certificate chain of the signing key, starting with the signing certificate
+     * @return The certificate chain
+     */
+    List<X509Certificate> getCertificateChain();
+
+    /**
+     * Sign the data with the algorithm of the key. ECDSA signatures are returned in the JWS format, the
+     * concatenation of the R and S values
+     * @param data The data to sign
+     * @return The signature
+     */
+    byte[] sign(byte[] data);
+
+    /**
+     * Return the JWS algorithm Notation uses for a public key: RSASSA-PSS for RSA keys and ECDSA for EC keys,
+     * with the hash matching the key size
+     * @param publicKey The public key, typically of the signing certificate
+     * @return The algorithm
+     * @throws OrasException If the key is not supported by Notation
+     */
+    static String algorithmOf(PublicKey publicKey) {
+        if (publicKey instanceof RSAKey rsa) {
+            return switch (rsa.getModulus().bitLength()) {
+                case 2048 -> "PS256";
+                case 3072 -> "PS384";
+                case 4096 -> "PS512";
+                default -> throw new OrasException("Unsupported RSA key size %d, expected 2048, 3072 or 4096"
+                        .formatted(rsa.getModulus().bitLength()));
+            };
+        }
+        if (publicKey instanceof ECKey ec) {
+            int size = ec.getParams().getCurve().getField().getFieldSize();
+            return switch (size) {
+                case 256 -> "ES256";
+                case 384 -> "ES384";
+                case 521 -> "ES512";
+                default -> throw new OrasException(
+                        "Unsupported EC key size %d, expected P-256, P-384 or P-521".formatted(size));
+            };
+        }
+        throw new OrasException("Unsupported signing key %s, only RSA and EC keys are supported"
+                .formatted(publicKey.getAlgorithm()));
+    }
+}
@@ -22,21 +22,34 @@ package land.oras.utils;
 
 import java.io.BufferedInputStream;
 import java.io.BufferedOutputStream;
+import java.io.File;
+import java.io.FilterInputStream;
 import java.io.IOException;
 import java.io.InputStream;
 import java.io.OutputStream;
 import java.nio.charset.StandardCharsets;
+import java.nio.file.FileVisitOption;
 import java.nio.file.Files;
 import java.nio.file.LinkOption;
 import java.nio.file.Path;
 import java.nio.file.Paths;
+import java.nio.file.StandardCopyOption;
 import java.nio.file.StandardOpenOption;
 import java.nio.file.attribute.BasicFileAttributes;
+import java.nio.file.attribute.FileTime;
 import java.nio.file.attribute.PosixFilePermission;
+import java.security.DigestInputStream;
+import java.security.MessageDigest;
+import java.util.Comparator;
 import java.util.EnumSet;
+import java.util.HashMap;
+import java.util.Map;
 import java.util.Set;
 import java.util.stream.Stream;
 import land.oras.LocalPath;
+import land.oras.OrasModel;
+import land.oras.exception.ContentTooLargeException;
+import land.oras.exception.DigestMismatchException;
 import land.oras.exception.OrasException;
 import org.apache.commons.compress.archivers.ArchiveEntry;
 import org.apache.commons.compress.archivers.tar.TarArchiveEntry;
@@ -48,9 +61,11 @@ import org.apache.commons.compress.archivers.zip.ZipArchiveInputStream;
 import org.apache.commons.compress.archivers.zip.ZipArchiveOutputStream;
 import org.apache.commons.compress.compressors.gzip.GzipCompressorInputStream;
 import org.apache.commons.compress.compressors.gzip.GzipCompressorOutputStream;
+import org.apache.commons.compress.compressors.gzip.GzipParameters;
 import org.apache.commons.compress.compressors.zstandard.ZstdCompressorInputStream;
 import org.apache.commons.compress.compressors.zstandard.ZstdCompressorOutputStream;
 import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
 import org.slf4j.Logger;
 import org.slf4j.LoggerFactory;
 
@@ -70,6 +85,391 @@ public final class ArchiveUtils {
      */
     private ArchiveUtils() {}
 
+    /**
+     * Safeguards applied when extracting archives.
+     * Entries escaping the target directory (directly or through a symlink) are always rejected.
+     */
+    @OrasModel
+    public static final class ExtractOptions {
+
+        private final boolean strictPaths;
+        private final boolean symlinksAllowed;
+        private final long maxEntries;
+        private final long maxSize;
+        private final boolean permissionsPreserved;
+        private final boolean hardlinksPreserved;
+        private final boolean modificationTimePreserved;
+
+        private ExtractOptions(
+                boolean strictPaths,
+                boolean symlinksAllowed,
+                long maxEntries,
+                long maxSize,
+                boolean permissionsPreserved,
+                boolean hardlinksPreserved,
+                boolean modificationTimePreserved) {
+            this.strictPaths = strictPaths;
+            this.symlinksAllowed = symlinksAllowed;
+            this.maxEntries = maxEntries;
+            this.maxSize = maxSize;
+            this.permissionsPreserved = permissionsPreserved;
+            this.hardlinksPreserved = hardlinksPreserved;
+            this.modificationTimePreserved = modificationTimePreserved;
+        }
+
+        /**
+         * Default options: symlinks are allowed, file metadata is restored and there is no limit on the number of
+         * entries or size
+         * @return The default extract options
+         */
+        public static ExtractOptions defaults() {
+            return new ExtractOptions(false, true, 0, 0, true, true, true);
+        }
+
+        /**
+         * Options for archives from untrusted sources: absolute paths, {@code ..} segments and symlinks are rejected
+         * @return The hardened extract options
+         */
+        public static ExtractOptions hardened() {
+            return new ExtractOptions(true, false, 0, 0, true, true, true);
+        }
+
+        /**
+         * Return new options rejecting entries with an absolute path or a {@code ..} segment, even when they
+         * would resolve inside the target directory
+         * @param strictPaths Whether to reject absolute paths and {@code ..} segments
+         * @return New extract options with the strict paths set
+         */
+        public ExtractOptions withStrictPaths(boolean strictPaths) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return new options allowing or rejecting symlink entries
+         * @param symlinksAllowed Whether symlinks are extracted
+         * @return New extract options with the symlinks set
+         */
+        public ExtractOptions withSymlinks(boolean symlinksAllowed) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return new options limiting the number of entries of the archive
+         * @param maxEntries The maximum number of entries. Zero or negative for unlimited
+         * @return New extract options with the maximum number of entries set
+         */
+        public ExtractOptions withMaxEntries(long maxEntries) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return new options limiting the total uncompressed size of the extracted files
+         * @param maxSize The maximum size in bytes. Zero or negative for unlimited
+         * @return New extract options with the maximum size set
+         */
+        public ExtractOptions withMaxSize(long maxSize) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return new options restoring or ignoring the POSIX permissions of the entries.
+         * When ignored, extracted files get the default permissions of the file system
+         * @param permissionsPreserved Whether to restore permissions
+         * @return New extract options with the permissions set
+         */
+        public ExtractOptions withPermissions(boolean permissionsPreserved) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return new options creating hardlinks for hardlink entries, or copies of the linked file when disabled
+         * @param hardlinksPreserved Whether to create hardlinks
+         * @return New extract options with the hardlinks set
+         */
+        public ExtractOptions withHardlinks(boolean hardlinksPreserved) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return new options restoring or ignoring the modification time of the extracted files
+         * @param modificationTimePreserved Whether to restore modification times
+         * @return New extract options with the modification time set
+         */
+        public ExtractOptions withModificationTime(boolean modificationTimePreserved) {
+            return new ExtractOptions(
+                    strictPaths,
+                    symlinksAllowed,
+                    maxEntries,
+                    maxSize,
+                    permissionsPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved);
+        }
+
+        /**
+         * Return whether absolute paths and {@code ..} segments are rejected
+         * @return {@code true} if absolute paths and {@code ..} segments are rejected
+         */
+        public boolean isStrictPaths() {
+            return strictPaths;
+        }
+
+        /**
+         * Return whether symlinks are extracted
+         * @return {@code true} if symlinks are extracted
+         */
+        public boolean isSymlinksAllowed() {
+            return symlinksAllowed;
+        }
+
+        /**
+         * Return the maximum number of entries
+         * @return The maximum number of entries, or {@code 0} if unlimited
+         */
+        public long maxEntries() {
+            return maxEntries;
+        }
+
+        /**
+         * Return the maximum total uncompressed size
+         * @return The maximum size in bytes, or {@code 0} if unlimited
+         */
+        public long maxSize() {
+            return maxSize;
+        }
+
+        /**
+         * Return whether POSIX permissions are restored
+         * @return {@code true} if permissions are restored
+         */
+        public boolean isPermissionsPreserved() {
+            return permissionsPreserved;
+        }
+
+        /**
+         * Return whether hardlink entries are extracted as hardlinks
+         * @return {@code true} if hardlinks are created
+         */
+        public boolean isHardlinksPreserved() {
+            return hardlinksPreserved;
+        }
+
+        /**
+         * Return whether modification times are restored
+         * @return {@code true} if modification times are restored
+         */
+        public boolean isModificationTimePreserved() {
+            return modificationTimePreserved;
+        }
+    }
+
+    /**
+     * Options controlling the file metadata stored when creating tar archives.
+     */
+    @OrasModel
+    public static final class TarOptions {
+
+        private final boolean permissionsPreserved;
+        private final boolean symlinksPreserved;
+        private final boolean hardlinksPreserved;
+        private final boolean modificationTimePreserved;
+        private final boolean reproducible;
+
+        private TarOptions(
+                boolean permissionsPreserved,
+                boolean symlinksPreserved,
+                boolean hardlinksPreserved,
+                boolean modificationTimePreserved,
+                boolean reproducible) {
+            this.permissionsPreserved = permissionsPreserved;
+            this.symlinksPreserved = symlinksPreserved;
+            this.hardlinksPreserved = hardlinksPreserved;
+            this.modificationTimePreserved = modificationTimePreserved;
+            this.reproducible = reproducible;
+        }
+
+        /**
+         * Default options: permissions, symlinks and modification times are stored. Hardlinked files are stored
+         * as regular files
+         * @return The default tar options
+         */
+        public static TarOptions defaults() {
+            return new TarOptions(true, true, false, true, false);
+        }
+
+        /**
+         * Options normalizing file metadata: directories and executable files get {@code 0755} and other files get
+         * {@code 0644}, symlinks are followed and modification times are set to the epoch
+         * @return The normalized tar options
+         */
+        public static TarOptions normalized() {
+            return new TarOptions(false, false, false, false, false);
+        }
+
+        /**
+         * Options creating bit-identical archives for identical content, so the layer digest is stable across builds
+         * @return The reproducible tar options
+         * @see #withReproducible(boolean)
+         */
+        public static TarOptions reproducible() {
+            return new TarOptions(true, true, false, true, true);
+        }
+
+        /**
+         * Return new options storing the POSIX permissions of the files, or normalized permissions when disabled
+         * @param permissionsPreserved Whether to store permissions
+         * @return New tar options with the permissions set
+         */
+        public TarOptions withPermissions(boolean permissionsPreserved) {
+            return new TarOptions(
+                    permissionsPreserved,
+                    symlinksPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved,
+                    reproducible);
+        }
+
+        /**
+         * Return new options storing symlinks as symlink entries, or following them when disabled
+         * @param symlinksPreserved Whether to store symlinks
+         * @return New tar options with the symlinks set
+         */
+        public TarOptions withSymlinks(boolean symlinksPreserved) {
+            return new TarOptions(
+                    permissionsPreserved,
+                    symlinksPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved,
+                    reproducible);
+        }
+
+        /**
+         * Return new options storing files with several links once, next ones being hardlink entries
+         * @param hardlinksPreserved Whether to store hardlinks
+         * @return New tar options with the hardlinks set
+         */
+        public TarOptions withHardlinks(boolean hardlinksPreserved) {
+            return new TarOptions(
+                    permissionsPreserved,
+                    symlinksPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved,
+                    reproducible);
+        }
+
+        /**
+         * Return new options storing the modification time of the files, or the epoch when disabled
+         * @param modificationTimePreserved Whether to store modification times
+         * @return New tar options with the modification time set
+         */
+        public TarOptions withModificationTime(boolean modificationTimePreserved) {
+            return new TarOptions(
+                    permissionsPreserved,
+                    symlinksPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved,
+                    reproducible);
+        }
+
+        /**
+         * Return new options creating bit-identical archives for identical content. Modification, access and change
+         * times are zeroed, whatever the modification time option, on top of the sorted entries and zero UID/GID
+         * of every archive
+         * @param reproducible Whether to create reproducible archives
+         * @return New tar options with the reproducible flag set
+         */
+        public TarOptions withReproducible(boolean reproducible) {
+            return new TarOptions(
+                    permissionsPreserved,
+                    symlinksPreserved,
+                    hardlinksPreserved,
+                    modificationTimePreserved,
+                    reproducible);
+        }
+
+        /**
+         * Return whether POSIX permissions are stored
+         * @return {@code true} if permissions are stored
+         */
+        public boolean isPermissionsPreserved() {
+            return permissionsPreserved;
+        }
+
+        /**
+         * Return whether symlinks are stored as symlink entries
+         * @return {@code true} if symlinks are stored
+         */
+        public boolean isSymlinksPreserved() {
+            return symlinksPreserved;
+        }
+
+        /**
+         * Return whether hardlinks are stored as hardlink entries
+         * @return {@code true} if hardlinks are stored
+         */
+        public boolean isHardlinksPreserved() {
+            return hardlinksPreserved;
+        }
+
+        /**
+         * Return whether modification times are stored
+         * @return {@code true} if modification times are stored
+         */
+        public boolean isModificationTimePreserved() {
+            return modificationTimePreserved;
+        }
+
+        /**
+         * Return whether archives are reproducible
+         * @return {@code true} if archives are bit-identical for identical content
+         */
+        public boolean isReproducible() {
+            return reproducible;
+        }
+    }
+
     /**
      * Create a temporary archive when uploading directory layers
      * @return The path to the archive
@@ -117,7 +517,8 @@ public final class ArchiveUtils {
         try (OutputStream fos = Files.newOutputStream(zipFile);
                 BufferedOutputStream bos = new BufferedOutputStream(fos);
                 ZipArchiveOutputStream zaos = new ZipArchiveOutputStream(bos)) {
-            try (Stream<Path> paths = Files.walk(sourceDir.getPath())) {
+            // Sort entries so the same directory always produces the same archive
+            try (Stream<Path> paths = Files.walk(sourceDir.getPath()).sorted()) {
                 paths.forEach(path -> {
                     LOG.trace("Visiting path: {}", path);
                     try {
@@ -127,7 +528,7 @@ public final class ArchiveUtils {
                             LOG.trace("Skipping root directory: {}", path);
                             return;
                         }
-                        String entryName = relativePath.toString();
+                        String entryName = relativePath.toString().replace(File.separatorChar, '/');
                         if (Files.isSymbolicLink(path)) {
                             LOG.trace("Adding symlink entry to zip: {}", entryName);
                             Path linkTarget = Files.readSymbolicLink(path);
@@ -176,6 +577,8 @@ public final class ArchiveUtils {
 
     /**
      * Create a tar file from a directory.
+     * <p>Entries are sorted by path and use zero UID/GID so that the same directory content produce the same
+     * archive.</p>
      * <p>When {@code includeDirectoryName} is {@code true} (the default behaviour) every entry
      * is prefixed with the source directory's own name, e.g. {@code mydir/blobs/sha256/...}.
      * When {@code false}, entries are stored relative to the source directory itself, e.g.
@@ -187,8 +590,25 @@ public final class ArchiveUtils {
      * @return The local path to the temporary tar file
      */
     public static LocalPath tar(LocalPath sourceDir, boolean includeDirectoryName) {
+        return tar(sourceDir, includeDirectoryName, TarOptions.defaults());
+    }
+
+    /**
+     * Create a tar file from a directory storing file metadata according to the given options.
+     * @param sourceDir The source directory
+     * @param includeDirectoryName {@code true} to prefix entries with the directory name,
+     *                             {@code false} for root-relative entry names
+     * @param options The tar options
+     * @return The local path to the temporary tar file
+     */
+    public static LocalPath tar(LocalPath sourceDir, boolean includeDirectoryName, TarOptions options) {
         Path tarFile = createTempTar();
         boolean isAbsolute = sourceDir.getPath().isAbsolute();
+        // Entry name of the first path of each file having several links
+        Map<Object, String> hardlinks = new HashMap<>();
+        FileVisitOption[] visitOptions = options.isSymlinksPreserved()
+                ? new FileVisitOption[0]
+                : new FileVisitOption[] {FileVisitOption.FOLLOW_LINKS};
         try (OutputStream fos = Files.newOutputStream(tarFile);
 
                 // Output stream chain
@@ -196,7 +616,9 @@ public final class ArchiveUtils {
                 TarArchiveOutputStream taos = new TarArchiveOutputStream(bos)) {
 
             taos.setLongFileMode(TarArchiveOutputStream.LONGFILE_POSIX);
-            try (Stream<Path> paths = Files.walk(sourceDir.getPath())) {
+            // Sort entries so the same directory always produces the same archive
+            try (Stream<Path> paths = Files.walk(sourceDir.getPath(), visitOptions)
+                    .sorted(Comparator.comparing(Path::toString))) {
                 paths.forEach(path -> {
                     LOG.trace("Visiting path: {}", path);
                     try {
@@ -215,13 +637,23 @@ public final class ArchiveUtils {
 
                         TarArchiveEntry entry = null;
                         BasicFileAttributes attrs = Files.readAttributes(path, BasicFileAttributes.class);
+                        String hardlinkTarget = options.isHardlinksPreserved() && attrs.isRegularFile()
+                                ? findHardlink(path, attrs, entryName, hardlinks)
+                                : null;
 
-                        if (Files.isSymbolicLink(path)) {
+                        if (options.isSymlinksPreserved() && Files.isSymbolicLink(path)) {
                             LOG.trace("Adding symlink entry: {}", entryName);
                             Path linkTarget = Files.readSymbolicLink(path);
                             entry = new TarArchiveEntry(entryName, TarArchiveEntry.LF_SYMLINK);
                             entry.setLinkName(linkTarget.toString());
                             entry.setSize(0);
+                            entry.setModTime(Files.getLastModifiedTime(path, LinkOption.NOFOLLOW_LINKS));
+                        } else if (hardlinkTarget != null) {
+                            LOG.trace("Adding hardlink entry: {} -> {}", entryName, hardlinkTarget);
+                            entry = new TarArchiveEntry(entryName, TarArchiveEntry.LF_LINK);
+                            entry.setLinkName(hardlinkTarget);
+                            entry.setSize(0);
+                            entry.setModTime(attrs.lastModifiedTime());
                         } else {
                             LOG.trace("Adding entry: {}", entryName);
                             entry = new TarArchiveEntry(path.toFile(), entryName);
@@ -230,7 +662,7 @@ public final class ArchiveUtils {
 
                         // Get posix permissions
                         int mode;
-                        if (OsUtils.isPosixFileSystemSupported()) {
+                        if (options.isPermissionsPreserved() && OsUtils.isPosixFileSystemSupported()) {
                             Set<PosixFilePermission> permissions = Files.getPosixFilePermissions(path);
                             mode = permissionsToMode(permissions);
                             LOG.trace("Permissions: {}", permissions);
@@ -245,9 +677,17 @@ public final class ArchiveUtils {
                         entry.setUserName("");
                         entry.setGroupName("");
                         entry.setMode(mode);
+                        if (!options.isModificationTimePreserved() || options.isReproducible()) {
+                            entry.setModTime(FileTime.fromMillis(0));
+                        }
+                        if (options.isReproducible()) {
+                            entry.setLastAccessTime(null);
+                            entry.setStatusChangeTime(null);
+                            entry.setCreationTime(null);
+                        }
                         taos.putArchiveEntry(entry);
                         // If it's a regular file, write the file data
-                        if (attrs.isRegularFile() && !entry.isSymbolicLink()) {
+                        if (attrs.isRegularFile() && !entry.isSymbolicLink() && !entry.isLink()) {
                             try (InputStream fis = Files.newInputStream(path)) {
                                 fis.transferTo(taos); // Write file contents to tar
                             }
@@ -266,6 +706,31 @@ public final class ArchiveUtils {
         return LocalPath.of(tarFile, Const.DEFAULT_BLOB_MEDIA_TYPE);
     }
 
+    /**
+     * Find the entry name of a previous path linked to the same file
+     * @param path The path
+     * @param attrs The attributes of the path
+     * @param entryName The entry name of the path
+     * @param hardlinks The entry names of the files with several links seen so far
+     * @return The entry name to link to or null if it's the first path of the file
+     * @throws IOException if the attributes cannot be read
+     */
+    private static @Nullable String findHardlink(
+            Path path, BasicFileAttributes attrs, String entryName, Map<Object, String> hardlinks) throws IOException {
+        Object fileKey = attrs.fileKey();
+        if (fileKey == null || !OsUtils.isPosixFileSystemSupported()) {
+            return null;
+        }
+        try {
+            if (!(Files.getAttribute(path, "unix:nlink") instanceof Integer links) || links < 2) {
+                return null;
+            }
+        } catch (UnsupportedOperationException | IllegalArgumentException e) {
+            return null;
+        }
+        return hardlinks.putIfAbsent(fileKey, entryName);
+    }
+
     /**
      * Create a tar compressed file from a directory
      * @param sourceDir The source directory
@@ -283,6 +748,29 @@ public final class ArchiveUtils {
      * @throws IOException
      */
     static void ensureSafeEntry(ArchiveEntry entry, Path target) throws IOException {
+        ensureSafeEntry(entry, target, ExtractOptions.defaults());
+    }
+
+    /**
+     * Ensure that the entry is safe to extract with the given options
+     * @param entry The archive entry
+     * @param target The target directory
+     * @param options The extract options
+     * @throws IOException
+     */
+    static void ensureSafeEntry(ArchiveEntry entry, Path target, ExtractOptions options) throws IOException {
+        if (options.isStrictPaths()) {
+            String name = entry.getName().replace('\\', '/');
+            if (name.startsWith("/") || Paths.get(entry.getName()).isAbsolute() || name.matches("^[A-Za-z]:.*")) {
+                throw new IOException("Entry has an absolute path: " + entry.getName());
+            }
+            for (String segment : name.split("/")) {
+                if (segment.equals("..")) {
+                    throw new IOException("Entry has a parent directory segment: " + entry.getName());
+                }
+            }
+        }
+
         // Prevent path traversal attacks
         Path outputPath = target.resolve(entry.getName()).normalize();
         Path normalizedTarget = target.toAbsolutePath().normalize();
@@ -295,21 +783,123 @@ public final class ArchiveUtils {
      * Ensure that a symlink entry's link target resolves inside the extraction directory.
      * Stops an archive from planting a symlink that points outside the target tree, which
      * would otherwise let subsequent regular-file entries write outside the target dir.
+     * The link target is resolved from the real parent directory, following the symlinks already extracted
      * @param outputPath The on-disk path where the symlink will be created
      * @param linkTarget The raw link target string from the archive entry
-     * @param target The extraction root
+     * @param realTarget The real path of the extraction root
      * @throws IOException if the link target escapes the extraction root
      */
-    static void ensureSafeSymlinkTarget(Path outputPath, Path linkTarget, Path target) throws IOException {
-        Path normalizedTarget = target.toAbsolutePath().normalize();
-        Path resolved =
-                (linkTarget.isAbsolute() ? linkTarget : outputPath.getParent().resolve(linkTarget)).normalize();
-        if (!resolved.startsWith(normalizedTarget)) {
+    static void ensureSafeSymlinkTarget(Path outputPath, Path linkTarget, Path realTarget) throws IOException {
+        Path resolved = linkTarget.isAbsolute() ? linkTarget.getRoot() : outputPath.getParent().toRealPath();
+        for (Path segment : linkTarget) {
+            String name = segment.toString();
+            if (name.equals("..")) {
+                resolved = resolved.getParent() != null ? resolved.getParent() : resolved;
+            } else if (!name.equals(".")) {
+                resolved = resolved.resolve(name);
+                if (Files.exists(resolved)) {
+                    resolved = resolved.toRealPath();
+                }
+            }
+        }
+        if (!resolved.startsWith(realTarget)) {
             throw new IOException(
                     "Refusing to create symlink that escapes target dir: " + outputPath + " -> " + linkTarget);
         }
     }
 
+    /**
+     * Create the directory of an entry and its missing parents, ensuring that, once symlinks are resolved, they are
+     * inside the extraction directory. Stops entries from being written through a symlink of a previous entry
+     * @param directory The directory to create
+     * @param realTarget The real path of the extraction root
+     * @throws IOException if the directory escapes the extraction root
+     */
+    private static void createDirectories(Path directory, Path realTarget) throws IOException {
+        Path existing = directory;
+        while (existing != null && !Files.exists(existing)) {
+            existing = existing.getParent();
+        }
+        if (existing == null || !existing.toRealPath().startsWith(realTarget)) {
+            throw new IOException("Entry is written outside of the target dir through a symlink: " + directory);
+        }
+        Files.createDirectories(directory);
+    }
+
+    /**
+     * Create a hardlink entry, or a copy of the linked file if hardlinks are not preserved or not supported.
+     * The linked file must be a previous entry of the archive, inside the extraction root once symlinks are resolved
+     * @param outputPath The on-disk path of the entry
+     * @param linkName The link name of the entry, relative to the extraction root
+     * @param target The extraction root
+     * @param realTarget The real path of the extraction root
+     * @param extracted The number of bytes extracted so far
+     * @param options The extract options
+     * @return The number of bytes extracted including the copy if any
+     * @throws IOException if the linked file escapes the extraction root or the link cannot be created
+     */
+    private static long createHardlink(
+            Path outputPath, String linkName, Path target, Path realTarget, long extracted, ExtractOptions options)
+            throws IOException {
+        Path linked = target.resolve(linkName).normalize();
+        if (!linked.startsWith(target.toAbsolutePath().normalize()) || !Files.isRegularFile(linked)) {
+            throw new IOException("Refusing to create hardlink to: " + linkName);
+        }
+        linked = linked.toRealPath();
+        if (!linked.startsWith(realTarget)) {
+            throw new IOException("Refusing to create hardlink through a symlink to: " + linkName);
+        }
+        Files.deleteIfExists(outputPath);
+        if (options.isHardlinksPreserved()) {
+            try {
+                LOG.trace("Creating hardlink {} -> {}", outputPath, linked);
+                Files.createLink(outputPath, linked);
+                return extracted;
+            } catch (UnsupportedOperationException e) {
+                LOG.debug("Hardlinks not supported, copying {}", linkName);
+            }
+        }
+        try (InputStream in = Files.newInputStream(linked);
+                OutputStream out = Files.newOutputStream(outputPath, StandardOpenOption.CREATE_NEW)) {
+            return copyEntry(in, out, extracted, options);
+        }
+    }
+
+    /**
+     * Ensure that the number of entries doesn't exceed the limit
+     * @param entries The number of entries so far
+     * @param options The extract options
+     */
+    private static void ensureEntryCount(long entries, ExtractOptions options) {
+        if (options.maxEntries() > 0 && entries > options.maxEntries()) {
+            throw new OrasException("Archive has more than %d entries".formatted(options.maxEntries()));
+        }
+    }
+
+    /**
+     * Copy an entry enforcing the maximum total size
+     * @param in The entry stream
+     * @param out The output stream
+     * @param extracted The number of bytes extracted so far
+     * @param options The extract options
+     * @return The number of bytes extracted including this entry
+     * @throws IOException if the copy fails
+     */
+    private static long copyEntry(InputStream in, OutputStream out, long extracted, ExtractOptions options)
+            throws IOException {
+        byte[] buffer = new byte[8192];
+        long total = extracted;
+        int read;
+        while ((read = in.read(buffer)) != -1) {
+            total += read;
+            if (options.maxSize() > 0 && total > options.maxSize()) {
+                throw new ContentTooLargeException(options.maxSize(), total);
+            }
+            out.write(buffer, 0, read);
+        }
+        return total;
+    }
+
     /**
      * Extract a tar file to a target directory
      * @param path The tar file
@@ -338,6 +928,125 @@ public final class ArchiveUtils {
         }
     }
 
+    /**
+     * Uncompress a compressed stream and untar it to the target directory on the fly, without writing the archive
+     * to disk. Zip archives are extracted directly.
+     * The stream is read until its end but not closed. Entries are extracted to a staging directory next to the
+     * target and only moved into the target once the content digest is verified and the stream fully read, so a
+     * digest verifying stream leaves nothing in the target on mismatch.
+     * @param is The compressed stream
+     * @param target The target directory
+     * @param mediaType The media type of the compressed stream
+     * @param contentDigest The expected digest of the uncompressed archive or null to skip the verification
+     */
+    public static void uncompressuntar(InputStream is, Path target, String mediaType, @Nullable String contentDigest) {
+        uncompressuntar(is, target, mediaType, contentDigest, ExtractOptions.defaults());
+    }
+
+    /**
+     * Uncompress a compressed stream and untar it to the target directory on the fly with the given safeguards
+     * @param is The compressed stream
+     * @param target The target directory
+     * @param mediaType The media type of the compressed stream
+     * @param contentDigest The expected digest of the uncompressed archive or null to skip the verification
+     * @param options The extract options
+     */
+    public static void uncompressuntar(
+            InputStream is,
+            Path target,
+            String mediaType,
+            @Nullable String contentDigest,
+            ExtractOptions options) {
+        SupportedCompression compression = SupportedCompression.fromMediaType(mediaType);
+        InputStream nonClosing = new FilterInputStream(is) {
+            @Override
+            public void close() {
+                // Closed by the caller
+            }
+        };
+        DigestAlgorithm algorithm = contentDigest != null ? DigestAlgorithms.fromDigest(contentDigest) : null;
+        MessageDigest messageDigest = algorithm != null ? algorithm.newMessageDigest() : null;
+        Path staging = createStagingDir(target);
+        try {
+            if (compression == SupportedCompression.ZIP) {
+                InputStream archive =
+                        messageDigest != null ? new DigestInputStream(nonClosing, messageDigest) : nonClosing;
+                unzip(archive, staging, options);
+            } else {
+                InputStream archive = compression.uncompressStream(new BufferedInputStream(nonClosing));
+                untar(
+                        messageDigest != null ? new DigestInputStream(archive, messageDigest) : archive,
+                        staging,
+                        options);
+            }
+            if (contentDigest != null && algorithm != null && messageDigest != null) {
+                String actualDigest = algorithm.formatDigest(messageDigest.digest());
+                LOG.trace("Expected digest: {}, actual digest: {}", contentDigest, actualDigest);
+                if (!contentDigest.equals(actualDigest)) {
+                    throw new DigestMismatchException(contentDigest, actualDigest);
+                }
+            }
+            try {
+                // Read the remaining bytes (like trailing compression frames) for the caller to verify the blob
+                is.transferTo(OutputStream.nullOutputStream());
+            } catch (IOException e) {
+                throw new OrasException("Failed to read archive stream", e);
+            }
+            moveEntries(staging, target);
+        } finally {
+            deleteStagingDir(staging);
+        }
+    }
+
+    /**
+     * Create a staging directory next to the target directory so entries can be moved into it without copy
+     * @param target The target directory
+     * @return The staging directory
+     */
+    private static Path createStagingDir(Path target) {
+        try {
+            Files.createDirectories(target);
+            Path parent = target.toAbsolutePath().normalize().getParent();
+            return Files.createTempDirectory(parent != null ? parent : target, ".oras-extract-");
+        } catch (IOException e) {
+            throw new OrasException("Failed to create staging directory for " + target, e);
+        }
+    }
+
+    /**
+     * Move the extracted entries of the staging directory into the target directory.
+     * Existing directories are merged, other existing paths (including symlinks) are replaced.
+     * @param source The staging directory or one of its sub directories
+     * @param target The matching directory in the target
+     */
+    private static void moveEntries(Path source, Path target) {
+        try (Stream<Path> entries = Files.list(source)) {
+            for (Path entry : entries.toList()) {
+                Path destination = target.resolve(entry.getFileName().toString());
+                if (Files.isDirectory(entry, LinkOption.NOFOLLOW_LINKS)
+                        && Files.isDirectory(destination, LinkOption.NOFOLLOW_LINKS)) {
+                    moveEntries(entry, destination);
+                } else {
+                    Files.move(entry, destination, StandardCopyOption.REPLACE_EXISTING);
+                }
+            }
+        } catch (IOException e) {
+            throw new OrasException("Failed to move extracted entries to " + target, e);
+        }
+    }
+
+    /**
+     * Delete the staging directory and what is left in it
+     * @param staging The staging directory
+     */
+    private static void deleteStagingDir(Path staging) {
+        try (Stream<Path> paths = Files.walk(staging)) {
+            paths.sorted(Comparator.reverseOrder()).forEach(path -> path.toFile().delete());
+        } catch (IOException e) {
+            LOG.warn("Failed to delete staging directory {}", staging, e);
+        }
+    }
+
     /**
      * Uncompress a compressed file and untar to a temporary directory
      * @param path The compressed file
@@ -391,51 +1100,80 @@ public final class ArchiveUtils {
      * @param target The target directory
      */
     static void unzip(InputStream fis, Path target) {
+        unzip(fis, target, ExtractOptions.defaults());
+    }
+
+    /**
+     * Extract a zip file to a target directory with the given safeguards
+     * @param fis The zip file input stream
+     * @param target The target directory
+     * @param options The extract options
+     */
+    public static void unzip(InputStream fis, Path target, ExtractOptions options) {
         // Open the zip file for reading
         try {
+            Files.createDirectories(target);
+            Path realTarget = target.toRealPath();
             try (BufferedInputStream bis = new BufferedInputStream(fis);
                     ZipArchiveInputStream zais = new ZipArchiveInputStream(bis)) {
                 ZipArchiveEntry entry;
+                long entries = 0;
+                long extracted = 0;
 
                 // Iterate through zip entries
                 while ((entry = zais.getNextEntry()) != null) {
+                    ensureEntryCount(++entries, options);
 
                     // Check if the entry is outside the target directory
-                    ensureSafeEntry(entry, target);
+                    ensureSafeEntry(entry, target, options);
 
                     // Prevent path traversal attacks
                     Path outputPath = target.resolve(entry.getName()).normalize();
 
                     if (entry.isDirectory()) {
                         LOG.debug("Extracting directory: {}", entry.getName());
-                        Files.createDirectories(outputPath);
+                        createDirectories(outputPath, realTarget);
                     }
                     // Check symlink from AsiExtraField
                     else {
                         AsiExtraField asiField = (AsiExtraField) entry.getExtraField(new AsiExtraField().getHeaderId());
                         if (entry.isUnixSymlink() || (asiField != null && asiField.isLink())) {
                             LOG.debug("Extracting symlink: {}", entry.getName());
-                            Files.createDirectories(outputPath.getParent());
+                            if (!options.isSymlinksAllowed()) {
+                                throw new IOException("Refusing to extract symlink: " + entry.getName());
+                            }
+                            createDirectories(outputPath.getParent(), realTarget);
                             String linkStr = asiField != null
                                     ? asiField.getLinkedFile()
                                     : new String(zais.readAllBytes(), StandardCharsets.UTF_8);
                             Path linkPath = Paths.get(linkStr);
-                            ensureSafeSymlinkTarget(outputPath, linkPath, target);
+                            ensureSafeSymlinkTarget(outputPath, linkPath, realTarget);
                             createSymbolicLink(outputPath, linkPath);
                         } else {
                             LOG.debug("Extracting file: {}", entry.getName());
-                            Files.createDirectories(outputPath.getParent());
+                            createDirectories(outputPath.getParent(), realTarget);
+                            // Replace existing paths, never write through a previous link
+                            Files.deleteIfExists(outputPath);
                             try (OutputStream out = Files.newOutputStream(
                                     outputPath,
-                                    StandardOpenOption.CREATE,
-                                    StandardOpenOption.TRUNCATE_EXISTING,
+                                    StandardOpenOption.CREATE_NEW,
                                     StandardOpenOption.WRITE,
                                     LinkOption.NOFOLLOW_LINKS)) {
-                                zais.transferTo(out);
+                                extracted = copyEntry(zais, out, extracted, options);
+                            }
+                            int mode = entry.getUnixMode() & 0777;
+                            if (options.isPermissionsPreserved() && mode != 0 && OsUtils.isPosixFileSystemSupported()) {
+                                Files.setPosixFilePermissions(outputPath, convertToPosixPermissions(mode));
+                            }
+                            if (options.isModificationTimePreserved() && entry.getLastModifiedTime() != null) {
+                                Files.setLastModifiedTime(outputPath, entry.getLastModifiedTime());
                             }
                         }
                     }
                 }
+
+                // Consume the central directory so the whole stream is read
+                bis.transferTo(OutputStream.nullOutputStream());
             }
         } catch (IOException e) {
             throw new OrasException("Failed to extract zip file", e);
@@ -448,18 +1186,33 @@ public final class ArchiveUtils {
      * @param target The target directory
      */
     public static void untar(InputStream fis, Path target) {
+        untar(fis, target, ExtractOptions.defaults());
+    }
+
+    /**
+     * Extract a tar file to a target directory with the given safeguards
+     * @param fis The archive stream
+     * @param target The target directory
+     * @param options The extract options
+     */
+    public static void untar(InputStream fis, Path target, ExtractOptions options) {
 
         // Open the tar.gz file for reading
         try {
+            Files.createDirectories(target);
+            Path realTarget = target.toRealPath();
             try (BufferedInputStream bis = new BufferedInputStream(fis);
                     TarArchiveInputStream tais = new TarArchiveInputStream(bis)) {
 
                 TarArchiveEntry entry;
+                long entries = 0;
+                long extracted = 0;
                 // Iterate through tar entries
                 while ((entry = tais.getNextEntry()) != null) {
+                    ensureEntryCount(++entries, options);
 
                     // Check if the entry is outside the target directory
-                    ensureSafeEntry(entry, target);
+                    ensureSafeEntry(entry, target, options);
 
                     // Prevent path traversal attacks
                     Path outputPath = target.resolve(entry.getName()).normalize();
@@ -468,31 +1221,44 @@ public final class ArchiveUtils {
 
                     if (entry.isDirectory()) {
                         LOG.debug("Extracting directory: {}", entry.getName());
-                        Files.createDirectories(outputPath);
+                        createDirectories(outputPath, realTarget);
                     } else {
                         LOG.trace("Creating directories for file: {}", outputPath.getParent());
-                        Files.createDirectories(outputPath.getParent());
+                        createDirectories(outputPath.getParent(), realTarget);
 
                         // Restore file permissions (optional, based on your need)
                         if (entry.isSymbolicLink()) {
+                            if (!options.isSymlinksAllowed()) {
+                                throw new IOException("Refusing to extract symlink: " + entry.getName());
+                            }
                             Path linkPath = Paths.get(entry.getLinkName());
-                            ensureSafeSymlinkTarget(outputPath, linkPath, target);
+                            ensureSafeSymlinkTarget(outputPath, linkPath, realTarget);
                             createSymbolicLink(outputPath, linkPath);
+                        } else if (entry.isLink()) {
+                            extracted = createHardlink(
+                                    outputPath, entry.getLinkName(), target, realTarget, extracted, options);
                         } else {
+                            // Replace existing paths, never write through a previous link
+                            Files.deleteIfExists(outputPath);
                             try (OutputStream out = Files.newOutputStream(
                                     outputPath,
-                                    StandardOpenOption.CREATE,
-                                    StandardOpenOption.TRUNCATE_EXISTING,
+                                    StandardOpenOption.CREATE_NEW,
                                     StandardOpenOption.WRITE,
                                     LinkOption.NOFOLLOW_LINKS)) {
-                                tais.transferTo(out);
+                                extracted = copyEntry(tais, out, extracted, options);
                             }
-                            if (OsUtils.isPosixFileSystemSupported()) {
+                            if (options.isPermissionsPreserved() && OsUtils.isPosixFileSystemSupported()) {
                                 Files.setPosixFilePermissions(outputPath, convertToPosixPermissions(entry.getMode()));
                             }
+                            if (options.isModificationTimePreserved()) {
+                                Files.setLastModifiedTime(outputPath, entry.getLastModifiedTime());
+                            }
                         }
                     }
                 }
+
+                // Consume the end of archive padding so the whole stream is read
+                bis.transferTo(OutputStream.nullOutputStream());
             }
         } catch (IOException e) {
             throw new OrasException("Failed to extract tar.gz file", e);
@@ -521,10 +1287,10 @@ public final class ArchiveUtils {
 
     static LocalPath compressZstd(LocalPath tarFile) {
         LOG.trace("Compressing tar file to zstd archive");
-        Path tarGzFile = Paths.get(tarFile + ".gz");
+        Path tarZstdFile = Paths.get(tarFile + ".zst");
         try (InputStream fis = Files.newInputStream(tarFile.getPath());
                 BufferedInputStream bis = new BufferedInputStream(fis);
-                OutputStream fos = Files.newOutputStream(tarGzFile);
+                OutputStream fos = Files.newOutputStream(tarZstdFile);
                 BufferedOutputStream bos = new BufferedOutputStream(fos);
                 ZstdCompressorOutputStream zstdos = new ZstdCompressorOutputStream(bos)) {
 
@@ -532,7 +1298,7 @@ public final class ArchiveUtils {
         } catch (IOException e) {
             throw new OrasException("Failed to compress tar file to zstd archive", e);
         }
-        return LocalPath.of(tarGzFile, Const.BLOB_DIR_ZSTD_MEDIA_TYPE);
+        return LocalPath.of(tarZstdFile, Const.BLOB_DIR_ZSTD_MEDIA_TYPE);
     }
 
     static LocalPath compressGzip(LocalPath tarFile) {
@@ -542,7 +1308,7 @@ public final class ArchiveUtils {
                 BufferedInputStream bis = new BufferedInputStream(fis);
                 OutputStream fos = Files.newOutputStream(tarGzFile);
                 BufferedOutputStream bos = new BufferedOutputStream(fos);
-                GzipCompressorOutputStream gzos = new GzipCompressorOutputStream(bos)) {
+                GzipCompressorOutputStream gzos = new GzipCompressorOutputStream(bos, gzipParameters())) {
 
             bis.transferTo(gzos);
         } catch (IOException e) {
@@ -551,6 +1317,18 @@ public final class ArchiveUtils {
         return LocalPath.of(tarGzFile, Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
     }
 
+    /**
+     * Gzip parameters with a fixed header (no file name, zero modification time and unknown OS), so the same tar
+     * always produces the same compressed layer
+     * @return The gzip parameters
+     */
+    private static GzipParameters gzipParameters() {
+        GzipParameters parameters = new GzipParameters();
+        parameters.setModificationTime(0);
+        parameters.setOperatingSystem(255);
+        return parameters;
+    }
+
     static LocalPath uncompressGzip(InputStream inputStream) {
         LOG.trace("Uncompressing tar.gz file");
         Path tarFile = createTempTar();
@@ -566,15 +1344,31 @@ public final class ArchiveUtils {
         return LocalPath.of(tarFile, Const.DEFAULT_BLOB_MEDIA_TYPE);
     }
 
+    static InputStream uncompressGzipStream(InputStream inputStream) {
+        try {
+            return new GzipCompressorInputStream(inputStream);
+        } catch (IOException e) {
+            throw new OrasException("Failed to uncompress tar.gz stream", e);
+        }
+    }
+
+    static InputStream uncompressZstdStream(InputStream inputStream) {
+        try {
+            return new ZstdCompressorInputStream(inputStream);
+        } catch (IOException e) {
+            throw new OrasException("Failed to uncompress tar.zstd stream", e);
+        }
+    }
+
     static LocalPath uncompressZstd(InputStream inputStream) {
         LOG.trace("Uncompressing zstd file");
         Path tarFile = createTempTar();
         try (BufferedInputStream bis = new BufferedInputStream(inputStream);
-                ZstdCompressorInputStream gzis = new ZstdCompressorInputStream(bis);
+                ZstdCompressorInputStream zstdis = new ZstdCompressorInputStream(bis);
                 OutputStream fos = Files.newOutputStream(tarFile);
                 BufferedOutputStream bos = new BufferedOutputStream(fos)) {
 
-            gzis.transferTo(bos);
+            zstdis.transferTo(bos);
         } catch (IOException e) {
             throw new OrasException("Failed to uncompress tar.zstd file", e);
         }
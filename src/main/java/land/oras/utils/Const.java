@@ -98,6 +98,11 @@ public final class Const {
      */
     public static final String JSON_PROPERTY_DATA = "data";
 
+    /**
+     * JSON property for the URLs of a layer
+     */
+    public static final String JSON_PROPERTY_URLS = "urls";
+
     /**
      * Default registry when no unqualified-search-registries is set in the config
      */
@@ -108,6 +113,11 @@ public final class Const {
      */
     public static final String DEFAULT_TAG = "latest";
 
+    /**
+     * Default maximum size of manifests and indexes accepted by the client, as recommended by the distribution spec
+     */
+    public static final long DEFAULT_MAX_MANIFEST_SIZE = 4 * 1024 * 1024;
+
     /**
      * Index file in OCI layout
      */
@@ -189,6 +199,40 @@ public final class Const {
      */
     public static final String DOCKER_INDEX_MEDIA_TYPE = "application/vnd.docker.distribution.manifest.list.v2+json";
 
+    /**
+     * Docker gzip compressed layer media type
+     */
+    public static final String DOCKER_LAYER_MEDIA_TYPE = "application/vnd.docker.image.rootfs.diff.tar.gzip";
+
+    /**
+     * Docker uncompressed layer media type
+     */
+    public static final String DOCKER_UNCOMPRESSED_LAYER_MEDIA_TYPE = "application/vnd.docker.image.rootfs.diff.tar";
+
+    /**
+     * Docker foreign layer media type, for layers that must be pulled from their URLs
+     */
+    public static final String DOCKER_FOREIGN_LAYER_MEDIA_TYPE =
+            "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip";
+
+    /**
+     * OCI non-distributable gzip layer media type, the equivalent of Docker foreign layers
+     */
+    public static final String NON_DISTRIBUTABLE_LAYER_MEDIA_TYPE =
+            "application/vnd.oci.image.layer.nondistributable.v1.tar+gzip";
+
+    /**
+     * OCI non-distributable uncompressed layer media type
+     */
+    public static final String NON_DISTRIBUTABLE_UNCOMPRESSED_LAYER_MEDIA_TYPE =
+            "application/vnd.oci.image.layer.nondistributable.v1.tar";
+
+    /**
+     * OCI non-distributable zstd layer media type
+     */
+    public static final String NON_DISTRIBUTABLE_ZSTD_LAYER_MEDIA_TYPE =
+            "application/vnd.oci.image.layer.nondistributable.v1.tar+zstd";
+
     /**
      * The default manifest media type
      */
@@ -245,6 +289,11 @@ public final class Const {
      */
     public static final String ANNOTATION_REVISION = "org.opencontainers.image.revision";
 
+    /**
+     * Annotation for the authors
+     */
+    public static final String ANNOTATION_AUTHORS = "org.opencontainers.image.authors";
+
     /**
      * Annotation for the base image name
      */
@@ -365,8 +414,16 @@ public final class Const {
      * @return The current timestamp
      */
     public static String currentTimestamp() {
-        return Instant.now()
-                .truncatedTo(java.time.temporal.ChronoUnit.SECONDS)
+        return formatTimestamp(Instant.now());
+    }
+
+    /**
+     * Format an instant for the created annotation, truncated to the seconds in UTC
+     * @param instant The instant
+     * @return The timestamp
+     */
+    public static String formatTimestamp(Instant instant) {
+        return instant.truncatedTo(java.time.temporal.ChronoUnit.SECONDS)
                 .atOffset(ZoneOffset.UTC)
                 .format(DateTimeFormatter.ISO_OFFSET_DATE_TIME);
     }
@@ -381,6 +438,21 @@ public final class Const {
      */
     public static final String ANNOTATION_ORAS_UNPACK = "io.deis.oras.content.unpack";
 
+    /**
+     * Suffix of the media type of encrypted layers
+     */
+    public static final String ENCRYPTED_MEDIA_TYPE_SUFFIX = "+encrypted";
+
+    /**
+     * Annotation of the public options of an encrypted layer, like the cipher and HMAC
+     */
+    public static final String ANNOTATION_ENC_PUBOPTS = "org.opencontainers.image.enc.pubopts";
+
+    /**
+     * Prefix of the annotations holding the wrapped keys of an encrypted layer
+     */
+    public static final String ANNOTATION_ENC_KEYS_PREFIX = "org.opencontainers.image.enc.keys.";
+
     /**
      * Authorization header
      */
@@ -416,6 +488,16 @@ public final class Const {
      */
     public static final String OCI_SUBJECT_HEADER = "OCI-Subject";
 
+    /**
+     * OCI filters applied header, set when the registry filtered the referrers
+     */
+    public static final String OCI_FILTERS_APPLIED_HEADER = "OCI-Filters-Applied";
+
+    /**
+     * Artifact type filter of the referrers API
+     */
+    public static final String REFERRERS_FILTER_ARTIFACT_TYPE = "artifactType";
+
     /**
      * Accept header
      */
@@ -431,11 +513,31 @@ public final class Const {
      */
     public static final String WWW_AUTHENTICATE_HEADER = "WWW-Authenticate";
 
+    /**
+     * Warning header
+     */
+    public static final String WARNING_HEADER = "Warning";
+
     /**
      * Application octet stream header value
      */
     public static final String APPLICATION_OCTET_STREAM_HEADER_VALUE = "application/octet-stream";
 
+    /**
+     * Application form urlencoded header value
+     */
+    public static final String APPLICATION_FORM_URLENCODED_HEADER_VALUE = "application/x-www-form-urlencoded";
+
+    /**
+     * Client ID sent on OAuth2 token requests
+     */
+    public static final String OAUTH2_CLIENT_ID = "oras-java";
+
+    /**
+     * Username used by docker config files and registries when the password is an identity (refresh) token
+     */
+    public static final String IDENTITY_TOKEN_USERNAME = "<token>";
+
     /**
      * Content Range header
      */
@@ -451,6 +553,26 @@ public final class Const {
      */
     public static final String OCI_CHUNK_MIN_LENGTH_HEADER = "OCI-Chunk-Min-Length";
 
+    /**
+     * Retry-After header
+     */
+    public static final String RETRY_AFTER_HEADER = "Retry-After";
+
+    /**
+     * Rate limit header returned by Docker Hub (for example {@code 100;w=21600})
+     */
+    public static final String RATE_LIMIT_LIMIT_HEADER = "RateLimit-Limit";
+
+    /**
+     * Remaining rate limit header returned by Docker Hub (for example {@code 76;w=21600})
+     */
+    public static final String RATE_LIMIT_REMAINING_HEADER = "RateLimit-Remaining";
+
+    /**
+     * Rate limit source header returned by Docker Hub
+     */
+    public static final String RATE_LIMIT_SOURCE_HEADER = "Docker-RateLimit-Source";
+
     /**
      * Metric name for token refresh counter
      */
@@ -466,6 +588,36 @@ public final class Const {
      */
     public static final String METRIC_HTTP_RETRIES = "land.oras.http.client.retries";
 
+    /**
+     * Metric name for registry operations
+     */
+    public static final String METRIC_OPERATIONS = "land.oras.operations";
+
+    /**
+     * Metric name for bytes transferred by blob uploads and downloads
+     */
+    public static final String METRIC_TRANSFER_BYTES = "land.oras.transfer.bytes";
+
+    /**
+     * Metric name for blobs not uploaded because they already exist or were mounted
+     */
+    public static final String METRIC_BLOBS_REUSED = "land.oras.blobs.reused";
+
+    /**
+     * Metric tag for the registry host
+     */
+    public static final String METRIC_TAG_HOST = "host";
+
+    /**
+     * Metric tag for the operation
+     */
+    public static final String METRIC_TAG_OPERATION = "operation";
+
+    /**
+     * Metric tag for the outcome of an operation
+     */
+    public static final String METRIC_TAG_OUTCOME = "outcome";
+
     /**
      * Metric name for token refresh duration
      */
@@ -525,6 +677,26 @@ public final class Const {
      */
     public static final String ANNOTATION_SIGSTORE_BUNDLE_PREDICATE_TYPE = "dev.sigstore.bundle.predicateType";
 
+    /**
+     * Notation (Notary Project) signature artifact type, used as the referrer {@code artifactType}
+     */
+    public static final String NOTATION_SIGNATURE_ARTIFACT_TYPE = "application/vnd.cncf.notary.signature";
+
+    /**
+     * Media type of a Notation signature envelope in JWS JSON serialization
+     */
+    public static final String NOTATION_JWS_MEDIA_TYPE = "application/jose+json";
+
+    /**
+     * Media type of the payload signed in a Notation signature envelope
+     */
+    public static final String NOTATION_PAYLOAD_MEDIA_TYPE = "application/vnd.cncf.notary.payload.v1+json";
+
+    /**
+     * Annotation of a Notation signature manifest listing the SHA-256 thumbprints of the signing certificate chain
+     */
+    public static final String ANNOTATION_NOTATION_THUMBPRINT = "io.cncf.notary.x509chain.thumbprint#S256";
+
     /**
      * The only supported key algorithm for now
      */
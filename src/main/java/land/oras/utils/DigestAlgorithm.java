@@ -0,0 +1,97 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import java.io.InputStream;
+import java.nio.file.Path;
+import java.security.MessageDigest;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * An algorithm used to compute and verify content digests.
+ * Custom algorithms can be registered with {@link DigestAlgorithms#register(DigestAlgorithm)}.
+ * See @link <a href="https://github.com/opencontainers/image-spec/blob/main/descriptor.md#digests">https://github.com/opencontainers/image-spec/blob/main/descriptor.md#digests</a>
+ */
+@NullMarked
+public interface DigestAlgorithm {
+
+    /**
+     * Get the prefix of the digest, for example sha256
+     * @return The prefix
+     */
+    String getPrefix();
+
+    /**
+     * Get the name of the algorithm used to create the message digest
+     * @return The algorithm name
+     */
+    String getAlgorithmName();
+
+    /**
+     * Get the size of the digest
+     * @return The size in bytes
+     */
+    int getSize();
+
+    /**
+     * Create a new message digest to compute the digest incrementally
+     * @return The message digest
+     */
+    default MessageDigest newMessageDigest() {
+        return DigestUtils.newMessageDigest(getAlgorithmName());
+    }
+
+    /**
+     * Format the hash computed by a message digest of this algorithm
+     * @param hashBytes The hash bytes
+     * @return The digest
+     */
+    default String formatDigest(byte[] hashBytes) {
+        return DigestUtils.formatHex(getPrefix(), hashBytes);
+    }
+
+    /**
+     * Digest a byte array
+     * @param bytes The bytes
+     * @return The digest
+     */
+    default String digest(byte[] bytes) {
+        return DigestUtils.digest(newMessageDigest(), getPrefix(), bytes);
+    }
+
+    /**
+     * Digest a file
+     * @param file The file
+     * @return The digest
+     */
+    default String digest(Path file) {
+        return DigestUtils.digest(newMessageDigest(), getPrefix(), file);
+    }
+
+    /**
+     * Digest an input stream
+     * @param inputStream The input stream
+     * @return The digest
+     */
+    default String digest(InputStream inputStream) {
+        return DigestUtils.digest(newMessageDigest(), getPrefix(), inputStream);
+    }
+}
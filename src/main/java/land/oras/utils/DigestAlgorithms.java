@@ -0,0 +1,132 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Registry of the digest algorithms used to verify content.
+ * All {@link SupportedAlgorithm} are registered by default.
+ */
+@NullMarked
+public final class DigestAlgorithms {
+
+    /**
+     * Algorithms by prefix
+     */
+    private static final Map<String, DigestAlgorithm> ALGORITHMS = new LinkedHashMap<>();
+
+    static {
+        for (SupportedAlgorithm algorithm : SupportedAlgorithm.values()) {
+            ALGORITHMS.put(algorithm.getPrefix(), algorithm);
+        }
+    }
+
+    /**
+     * Utils class
+     */
+    private DigestAlgorithms() {}
+
+    /**
+     * Register a digest algorithm. An algorithm with the same prefix is replaced.
+     * @param algorithm The algorithm
+     */
+    public static synchronized void register(DigestAlgorithm algorithm) {
+        if (!algorithm.getPrefix().matches("^[a-z0-9]+(?:[+._-][a-z0-9]+)*$")) {
+            throw new OrasException("Invalid digest algorithm prefix: " + algorithm.getPrefix());
+        }
+        ALGORITHMS.put(algorithm.getPrefix(), algorithm);
+    }
+
+    /**
+     * Get all registered algorithms
+     * @return The algorithms
+     */
+    public static synchronized List<DigestAlgorithm> values() {
+        return Collections.unmodifiableList(new ArrayList<>(ALGORITHMS.values()));
+    }
+
+    /**
+     * Get a registered algorithm by prefix
+     * @param prefix The prefix
+     * @return The algorithm or null if not registered
+     */
+    public static synchronized @Nullable DigestAlgorithm get(String prefix) {
+        return ALGORITHMS.get(prefix);
+    }
+
+    /**
+     * Check if the digest uses a registered algorithm and has the expected size
+     * @param digest The digest
+     * @return True if supported
+     */
+    public static boolean isSupported(@Nullable String digest) {
+        if (digest == null || !SupportedAlgorithm.matchPattern(digest)) {
+            return false;
+        }
+        DigestAlgorithm algorithm = get(digest.substring(0, digest.indexOf(':')));
+        if (algorithm == null) {
+            return false;
+        }
+        String value = digest.substring(algorithm.getPrefix().length() + 1);
+        if (value.length() != algorithm.getSize() * 2) {
+            throw new OrasException("Invalid digest %s, expected size is %d, but got %d"
+                    .formatted(digest, algorithm.getSize(), value.length()));
+        }
+        return true;
+    }
+
+    /**
+     * Get the algorithm from a digest
+     * @param digest The digest
+     * @return The algorithm
+     */
+    public static DigestAlgorithm fromDigest(@Nullable String digest) {
+        if (digest == null) {
+            throw new OrasException("Digest is null");
+        }
+        if (!SupportedAlgorithm.matchPattern(digest)) {
+            throw new OrasException("Invalid digest: " + digest);
+        }
+        DigestAlgorithm algorithm = get(digest.substring(0, digest.indexOf(':')));
+        if (algorithm == null) {
+            throw new OrasException("Unsupported digest: " + digest);
+        }
+        return algorithm;
+    }
+
+    /**
+     * Return the digest without the prefix
+     * @param digest The digest
+     * @return The digest without the prefix
+     */
+    public static String getDigest(String digest) {
+        DigestAlgorithm algorithm = fromDigest(digest);
+        return digest.substring(algorithm.getPrefix().length() + 1);
+    }
+}
@@ -57,8 +57,18 @@ final class DigestUtils {
      * @return The digest
      */
     static String digest(String algorithm, String prefix, Path path) {
+        return digest(newMessageDigest(algorithm), prefix, path);
+    }
+
+    /**
+     * Calculate the digest of a file
+     * @param digest The message digest
+     * @param prefix The prefix
+     * @param path The path
+     * @return The digest
+     */
+    static String digest(MessageDigest digest, String prefix, Path path) {
         try {
-            MessageDigest digest = MessageDigest.getInstance(algorithm);
             try (var channel = FileChannel.open(path, StandardOpenOption.READ)) {
                 long fileSize = channel.size();
                 long position = 0;
@@ -85,15 +95,21 @@ final class DigestUtils {
      * @return The digest
      */
     static String digest(String algorithm, String prefix, byte[] bytes) {
-        try {
-            MessageDigest digest = MessageDigest.getInstance(algorithm);
-            byte[] hashBytes = digest.digest(bytes);
+        return digest(newMessageDigest(algorithm), prefix, bytes);
+    }
 
-            // Convert the byte array to hex
-            return formatHex(prefix, hashBytes);
-        } catch (Exception e) {
-            throw new OrasException("Failed to calculate digest", e);
-        }
+    /**
+     * Calculate the digest of a byte array
+     * @param digest The message digest
+     * @param prefix The prefix
+     * @param bytes bytes
+     * @return The digest
+     */
+    static String digest(MessageDigest digest, String prefix, byte[] bytes) {
+        byte[] hashBytes = digest.digest(bytes);
+
+        // Convert the byte array to hex
+        return formatHex(prefix, hashBytes);
     }
 
     /**
@@ -104,8 +120,18 @@ final class DigestUtils {
      * @return The digest
      */
     static String digest(String algorithm, String prefix, InputStream input) {
+        return digest(newMessageDigest(algorithm), prefix, input);
+    }
+
+    /**
+     * Calculate the digest of a InputStream
+     * @param digest The message digest
+     * @param prefix The prefix
+     * @param input The input
+     * @return The digest
+     */
+    static String digest(MessageDigest digest, String prefix, InputStream input) {
         try {
-            MessageDigest digest = MessageDigest.getInstance(algorithm);
             byte[] buffer = new byte[8192];
             int bytesRead;
             while ((bytesRead = input.read(buffer)) != -1) {
@@ -118,7 +144,20 @@ final class DigestUtils {
         }
     }
 
-    private static String formatHex(String prefix, final byte[] hashBytes) {
+    /**
+     * Create a new message digest
+     * @param algorithm The algorithm
+     * @return The message digest
+     */
+    static MessageDigest newMessageDigest(String algorithm) {
+        try {
+            return MessageDigest.getInstance(algorithm);
+        } catch (Exception e) {
+            throw new OrasException("Unsupported digest algorithm: " + algorithm, e);
+        }
+    }
+
+    static String formatHex(String prefix, final byte[] hashBytes) {
         String formatHex = HEX_FORMAT.formatHex(hashBytes);
         return prefix + ":" + formatHex;
     }
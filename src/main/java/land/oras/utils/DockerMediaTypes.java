@@ -0,0 +1,67 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import java.util.Map;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Utilities for Docker image manifest V2 schema 2 media types and their OCI equivalents
+ */
+@NullMarked
+public final class DockerMediaTypes {
+
+    /**
+     * Docker media types and their OCI equivalents
+     */
+    private static final Map<String, String> OCI_MEDIA_TYPES = Map.ofEntries(
+            Map.entry(Const.DOCKER_MANIFEST_MEDIA_TYPE, Const.DEFAULT_MANIFEST_MEDIA_TYPE),
+            Map.entry(Const.DOCKER_INDEX_MEDIA_TYPE, Const.DEFAULT_INDEX_MEDIA_TYPE),
+            Map.entry(Const.CONFIG_RUNNING_DOCKER_MEDIA_TYPE, Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE),
+            Map.entry(Const.DOCKER_LAYER_MEDIA_TYPE, Const.DEFAULT_BLOB_DIR_MEDIA_TYPE),
+            Map.entry(Const.DOCKER_UNCOMPRESSED_LAYER_MEDIA_TYPE, Const.DEFAULT_BLOB_MEDIA_TYPE),
+            Map.entry(Const.DOCKER_FOREIGN_LAYER_MEDIA_TYPE, Const.NON_DISTRIBUTABLE_LAYER_MEDIA_TYPE));
+
+    /**
+     * Hidden constructor
+     */
+    private DockerMediaTypes() {
+        // Hide constructor
+    }
+
+    /**
+     * Return if the media type is a Docker schema 2 media type with an OCI equivalent
+     * @param mediaType The media type
+     * @return True if it's a Docker media type
+     */
+    public static boolean isDocker(String mediaType) {
+        return OCI_MEDIA_TYPES.containsKey(mediaType);
+    }
+
+    /**
+     * Convert a Docker media type to its OCI equivalent
+     * @param mediaType The media type
+     * @return The OCI media type or the same media type if it's not a Docker media type
+     */
+    public static String toOci(String mediaType) {
+        return OCI_MEDIA_TYPES.getOrDefault(mediaType, mediaType);
+    }
+}
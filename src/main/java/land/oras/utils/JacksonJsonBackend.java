@@ -0,0 +1,130 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import com.fasterxml.jackson.annotation.JsonInclude;
+import java.io.InputStream;
+import java.io.Reader;
+import java.lang.reflect.Type;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+import tools.jackson.core.JacksonException;
+import tools.jackson.core.SerializableString;
+import tools.jackson.core.io.CharacterEscapes;
+import tools.jackson.core.io.SerializedString;
+import tools.jackson.core.json.JsonFactory;
+import tools.jackson.databind.ObjectMapper;
+import tools.jackson.databind.SerializationFeature;
+import tools.jackson.databind.json.JsonMapper;
+
+/**
+ * The default {@link JsonBackend} using Jackson 3.
+ * JSON is written in the canonical form of the Go implementations (compact, map entries sorted by keys and HTML
+ * characters escaped) so manifests and indexes of the same content have the same digest.
+ */
+@NullMarked
+public final class JacksonJsonBackend implements JsonBackend {
+
+    /**
+     * JSON mapper instance
+     */
+    private final ObjectMapper jsonMapper;
+
+    /**
+     * Create the backend
+     */
+    public JacksonJsonBackend() {
+        jsonMapper = JsonMapper.builder(JsonFactory.builder()
+                        .characterEscapes(new GoCharacterEscapes())
+                        .build())
+                .changeDefaultPropertyInclusion(incl -> incl.withValueInclusion(JsonInclude.Include.NON_EMPTY))
+                .enable(SerializationFeature.ORDER_MAP_ENTRIES_BY_KEYS)
+                .build();
+    }
+
+    /**
+     * Escape the characters escaped by Go {@code encoding/json}: {@code <}, {@code >}, {@code &}, U+2028 and U+2029
+     */
+    private static final class GoCharacterEscapes extends CharacterEscapes {
+
+        /**
+         * The escape codes for ASCII characters
+         */
+        private final int[] asciiEscapes;
+
+        private GoCharacterEscapes() {
+            asciiEscapes = CharacterEscapes.standardAsciiEscapesForJSON();
+            asciiEscapes['<'] = CharacterEscapes.ESCAPE_CUSTOM;
+            asciiEscapes['>'] = CharacterEscapes.ESCAPE_CUSTOM;
+            asciiEscapes['&'] = CharacterEscapes.ESCAPE_CUSTOM;
+        }
+
+        @Override
+        public int[] getEscapeCodesForAscii() {
+            return asciiEscapes;
+        }
+
+        @Override
+        public @Nullable SerializableString getEscapeSequence(int ch) {
+            return switch (ch) {
+                case '<', '>', '&', '\u2028', '\u2029' -> new SerializedString("\\u%04x".formatted(ch));
+                default -> null;
+            };
+        }
+    }
+
+    @Override
+    public String toJson(Object object) {
+        try {
+            return jsonMapper.writeValueAsString(object);
+        } catch (JacksonException e) {
+            throw new OrasException("Unable to convert object to JSON string", e);
+        }
+    }
+
+    @Override
+    public <T> T fromJson(String json, Type type) {
+        try {
+            return jsonMapper.readValue(json, jsonMapper.getTypeFactory().constructType(type));
+        } catch (JacksonException e) {
+            throw new OrasException("Unable to parse JSON string", e);
+        }
+    }
+
+    @Override
+    public <T> T fromJson(InputStream is, Type type) {
+        try {
+            return jsonMapper.readValue(is, jsonMapper.getTypeFactory().constructType(type));
+        } catch (JacksonException e) {
+            throw new OrasException("Unable to parse JSON string", e);
+        }
+    }
+
+    @Override
+    public <T> T fromJson(Reader reader, Type type) {
+        try {
+            return jsonMapper.readValue(reader, jsonMapper.getTypeFactory().constructType(type));
+        } catch (JacksonException e) {
+            throw new OrasException("Unable to parse JSON content", e);
+        }
+    }
+}
@@ -0,0 +1,94 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.Reader;
+import java.io.StringWriter;
+import java.lang.reflect.Type;
+import java.nio.charset.StandardCharsets;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.NullMarked;
+
+/**
+ * Backend used by {@link JsonUtils} to serialize and deserialize manifests, indexes, configs and other models.
+ * The bundled {@link JacksonJsonBackend} is used by default. Another backend can be plugged with
+ * {@link JsonUtils#setBackend(JsonBackend)} or by declaring it as a {@link java.util.ServiceLoader} provider in
+ * {@code META-INF/services/land.oras.utils.JsonBackend}.
+ * Implementations must map the models the same way than the default backend: property names of the Jackson
+ * {@code @JsonProperty} annotations and empty values omitted. Pushed content should be written in the canonical
+ * form (compact, map entries sorted by keys and HTML characters escaped) so digests stay stable.
+ * Failures must be reported with an {@link OrasException}.
+ * A backend doesn't remove the Jackson dependency: the models are annotated with Jackson annotations and
+ * {@link YamlUtils} and {@link TomlUtils} always use Jackson.
+ */
+@NullMarked
+public interface JsonBackend {
+
+    /**
+     * Convert an object to a JSON string
+     * @param object The object to convert
+     * @return The JSON string
+     */
+    String toJson(Object object);
+
+    /**
+     * Convert a JSON string to an object
+     * @param json The JSON string
+     * @param type The type of the object
+     * @param <T> The type of the object
+     * @return The object
+     */
+    <T> T fromJson(String json, Type type);
+
+    /**
+     * Convert a JSON input stream to an object. The default implementation reads the whole stream
+     * @param is The JSON input stream
+     * @param type The type of the object
+     * @param <T> The type of the object
+     * @return The object
+     */
+    default <T> T fromJson(InputStream is, Type type) {
+        try {
+            return fromJson(new String(is.readAllBytes(), StandardCharsets.UTF_8), type);
+        } catch (IOException e) {
+            throw new OrasException("Unable to read JSON content", e);
+        }
+    }
+
+    /**
+     * Convert a JSON content to an object. The default implementation reads the whole content
+     * @param reader The reader of the JSON content
+     * @param type The type of the object
+     * @param <T> The type of the object
+     * @return The object
+     */
+    default <T> T fromJson(Reader reader, Type type) {
+        try {
+            StringWriter writer = new StringWriter();
+            reader.transferTo(writer);
+            return fromJson(writer.toString(), type);
+        } catch (IOException e) {
+            throw new OrasException("Unable to read JSON content", e);
+        }
+    }
+}
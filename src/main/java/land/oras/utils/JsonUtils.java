@@ -20,7 +20,6 @@
 
 package land.oras.utils;
 
-import com.fasterxml.jackson.annotation.JsonInclude;
 import java.io.IOException;
 import java.io.InputStream;
 import java.io.Reader;
@@ -28,23 +27,31 @@ import java.lang.reflect.Type;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
+import java.util.Iterator;
+import java.util.Objects;
+import java.util.ServiceLoader;
 import land.oras.exception.OrasException;
 import org.jspecify.annotations.NullMarked;
-import tools.jackson.core.JacksonException;
-import tools.jackson.databind.ObjectMapper;
-import tools.jackson.databind.json.JsonMapper;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
 
 /**
  * Utility class for JSON operations.
- * Use Jackson 3 internally for JSON operations
+ * Delegate to a {@link JsonBackend}, by default the first provider found by the {@link ServiceLoader} or the
+ * bundled {@link JacksonJsonBackend}
  */
 @NullMarked
 public final class JsonUtils {
 
     /**
-     * JSON mapper instance
+     * Logger
      */
-    private static final ObjectMapper jsonMapper;
+    private static final Logger LOG = LoggerFactory.getLogger(JsonUtils.class);
+
+    /**
+     * The JSON backend
+     */
+    private static volatile JsonBackend backend = loadBackend();
 
     /**
      * Utils class
@@ -53,10 +60,35 @@ public final class JsonUtils {
         // Hide constructor
     }
 
-    static {
-        jsonMapper = JsonMapper.builder()
-                .changeDefaultPropertyInclusion(incl -> incl.withValueInclusion(JsonInclude.Include.NON_EMPTY))
-                .build();
+    /**
+     * Load the first JSON backend provider, default to Jackson
+     * @return The backend
+     */
+    private static JsonBackend loadBackend() {
+        Iterator<JsonBackend> providers =
+                ServiceLoader.load(JsonBackend.class, JsonUtils.class.getClassLoader()).iterator();
+        if (providers.hasNext()) {
+            JsonBackend provider = providers.next();
+            LOG.debug("Using JSON backend {}", provider.getClass().getName());
+            return provider;
+        }
+        return new JacksonJsonBackend();
+    }
+
+    /**
+     * Get the JSON backend
+     * @return The backend
+     */
+    public static JsonBackend getBackend() {
+        return backend;
+    }
+
+    /**
+     * Replace the JSON backend used by all (de)serialization of the SDK
+     * @param jsonBackend The backend
+     */
+    public static void setBackend(JsonBackend jsonBackend) {
+        backend = Objects.requireNonNull(jsonBackend, "jsonBackend");
     }
 
     /**
@@ -65,11 +97,7 @@ public final class JsonUtils {
      * @return The JSON string
      */
     public static String toJson(Object object) {
-        try {
-            return jsonMapper.writeValueAsString(object);
-        } catch (JacksonException e) {
-            throw new OrasException("Unable to convert object to JSON string", e);
-        }
+        return backend.toJson(object);
     }
 
     /**
@@ -80,11 +108,7 @@ public final class JsonUtils {
      * @return The object
      */
     public static <T> T fromJson(String json, Class<T> clazz) {
-        try {
-            return jsonMapper.readValue(json, clazz);
-        } catch (JacksonException e) {
-            throw new OrasException("Unable to parse JSON string", e);
-        }
+        return backend.fromJson(json, clazz);
     }
 
     /**
@@ -95,11 +119,7 @@ public final class JsonUtils {
      * @return The object
      */
     public static <T> T fromJson(InputStream is, Class<T> clazz) {
-        try {
-            return jsonMapper.readValue(is, clazz);
-        } catch (JacksonException e) {
-            throw new OrasException("Unable to parse JSON string", e);
-        }
+        return backend.fromJson(is, clazz);
     }
 
     /**
@@ -112,13 +132,7 @@ public final class JsonUtils {
      * @return The object
      */
     public static <T> T fromJson(Path path, Class<T> clazz) {
-        try {
-            return jsonMapper.readValue(Files.readString(path, StandardCharsets.UTF_8), clazz);
-        } catch (IOException e) {
-            throw new OrasException("Unable to read JSON file due to IO error", e);
-        } catch (JacksonException e) {
-            throw new OrasException("Unable to parse JSON file", e);
-        }
+        return fromJson(path, (Type) clazz);
     }
 
     /**
@@ -131,15 +145,13 @@ public final class JsonUtils {
      * @throws OrasException If an I/O error occurs while reading the file or the JSON is invalid.
      */
     public static <T> T fromJson(Path path, Type type) {
+        String json;
         try {
-            return jsonMapper.readValue(
-                    Files.readString(path, StandardCharsets.UTF_8),
-                    jsonMapper.getTypeFactory().constructType(type));
+            json = Files.readString(path, StandardCharsets.UTF_8);
         } catch (IOException e) {
             throw new OrasException("Unable to read JSON file due to IO error", e);
-        } catch (JacksonException e) {
-            throw new OrasException("Unable to parse JSON file", e);
         }
+        return backend.fromJson(json, type);
     }
 
     /**
@@ -152,11 +164,7 @@ public final class JsonUtils {
      * @throws OrasException If an error occurs while reading the input or the JSON format is invalid.
      */
     public static <T> T fromJson(Reader reader, Type type) {
-        try {
-            return jsonMapper.readValue(reader, jsonMapper.getTypeFactory().constructType(type));
-        } catch (JacksonException e) {
-            throw new OrasException("Unable to parse JSON content", e);
-        }
+        return backend.fromJson(reader, type);
     }
 
     /**
@@ -0,0 +1,203 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import java.net.URI;
+import java.util.Collection;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.TreeSet;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * Logging utilities redacting secrets from requests and responses before they are logged
+ */
+@NullMarked
+public final class LogUtils {
+
+    /**
+     * The replacement value of secrets
+     */
+    public static final String REDACTED = "<redacted>";
+
+    /**
+     * Headers whose values are always redacted
+     */
+    private static final Set<String> SECRET_HEADERS = caseInsensitive(List.of(
+            Const.AUTHORIZATION_HEADER,
+            "Proxy-Authorization",
+            "Cookie",
+            "Set-Cookie",
+            "X-Registry-Auth",
+            "X-Amz-Security-Token"));
+
+    /**
+     * Query parameters, JSON fields and form fields whose values are redacted
+     */
+    private static final Set<String> SECRET_FIELDS = caseInsensitive(List.of(
+            "token",
+            "access_token",
+            "refresh_token",
+            "id_token",
+            "identitytoken",
+            "registrytoken",
+            "password",
+            "secret",
+            "client_secret",
+            "auth",
+            "sig",
+            "signature",
+            "X-Amz-Signature",
+            "X-Amz-Security-Token",
+            "X-Amz-Credential",
+            "X-Goog-Signature",
+            "X-Goog-Credential"));
+
+    /**
+     * JSON string field
+     */
+    private static final Pattern JSON_FIELD =
+            Pattern.compile("\"([A-Za-z_\\-]+)\"(\\s*:\\s*)\"((?:[^\"\\\\]|\\\\.)*)\"");
+
+    /**
+     * Form or query field
+     */
+    private static final Pattern FORM_FIELD = Pattern.compile("(^|[?&])([^=&?]+)=([^&]*)");
+
+    /**
+     * Basic or bearer credentials
+     */
+    private static final Pattern CREDENTIALS = Pattern.compile("(?i)\\b(Basic|Bearer)\\s+[A-Za-z0-9\\-_.~+/]+=*");
+
+    /**
+     * Hidden constructor
+     */
+    private LogUtils() {}
+
+    /**
+     * Redact the values of secret headers
+     * @param headers The headers
+     * @param <V> The type of header value
+     * @return A copy of the headers safe to log
+     */
+    public static <V> Map<String, @Nullable Object> redactHeaders(Map<String, V> headers) {
+        return redactHeaders(headers, Set.of());
+    }
+
+    /**
+     * Redact the values of secret headers and of the given sensitive headers
+     * @param headers The headers
+     * @param sensitiveHeaders The names of additional headers to redact, case insensitive
+     * @param <V> The type of header value
+     * @return A copy of the headers safe to log
+     */
+    public static <V> Map<String, @Nullable Object> redactHeaders(
+            Map<String, V> headers, Collection<String> sensitiveHeaders) {
+        Set<String> sensitive = sensitiveHeaders.isEmpty() ? Set.of() : caseInsensitive(sensitiveHeaders);
+        Map<String, @Nullable Object> redacted = new LinkedHashMap<>();
+        headers.forEach((name, value) -> {
+            if (SECRET_HEADERS.contains(name) || sensitive.contains(name)) {
+                redacted.put(name, value instanceof Collection<?> ? List.of(REDACTED) : REDACTED);
+            } else {
+                redacted.put(name, value);
+            }
+        });
+        return redacted;
+    }
+
+    /**
+     * Redact the user info and secret query parameters of a URI
+     * @param uri The URI
+     * @return The URI safe to log
+     */
+    public static String redactUri(@Nullable URI uri) {
+        if (uri == null) {
+            return "null";
+        }
+        return redactUri(uri.toString());
+    }
+
+    /**
+     * Redact the user info and secret query parameters of a URI
+     * @param uri The URI
+     * @return The URI safe to log
+     */
+    public static String redactUri(@Nullable String uri) {
+        if (uri == null) {
+            return "null";
+        }
+        String redacted = uri.replaceFirst("^([a-zA-Z][a-zA-Z0-9+.\\-]*://)[^/@?#]+@", "$1" + REDACTED + "@");
+        int query = redacted.indexOf('?');
+        if (query < 0) {
+            return redacted;
+        }
+        return redacted.substring(0, query) + redactFormFields(redacted.substring(query));
+    }
+
+    /**
+     * Redact tokens, passwords and credentials from a request or response body
+     * @param body The body
+     * @return The body safe to log
+     */
+    public static String redactBody(@Nullable String body) {
+        if (body == null) {
+            return "null";
+        }
+        Matcher matcher = JSON_FIELD.matcher(body);
+        StringBuilder sb = new StringBuilder();
+        while (matcher.find()) {
+            String replacement = SECRET_FIELDS.contains(matcher.group(1))
+                    ? "\"" + matcher.group(1) + "\"" + matcher.group(2) + "\"" + REDACTED + "\""
+                    : matcher.group();
+            matcher.appendReplacement(sb, Matcher.quoteReplacement(replacement));
+        }
+        matcher.appendTail(sb);
+        String redacted = sb.toString();
+        if (!redacted.trim().startsWith("{") && !redacted.trim().startsWith("[")) {
+            redacted = redactFormFields(redacted);
+        }
+        return CREDENTIALS.matcher(redacted).replaceAll("$1 " + REDACTED);
+    }
+
+    private static String redactFormFields(String value) {
+        Matcher matcher = FORM_FIELD.matcher(value);
+        StringBuilder sb = new StringBuilder();
+        while (matcher.find()) {
+            String replacement = SECRET_FIELDS.contains(matcher.group(2))
+                    ? matcher.group(1) + matcher.group(2) + "=" + REDACTED
+                    : matcher.group();
+            matcher.appendReplacement(sb, Matcher.quoteReplacement(replacement));
+        }
+        matcher.appendTail(sb);
+        return sb.toString();
+    }
+
+    private static Set<String> caseInsensitive(Collection<String> values) {
+        Set<String> set = new TreeSet<>(String.CASE_INSENSITIVE_ORDER);
+        set.addAll(values);
+        return set;
+    }
+}
@@ -22,6 +22,7 @@ package land.oras.utils;
 
 import java.io.InputStream;
 import java.nio.file.Path;
+import java.security.MessageDigest;
 import java.util.regex.Pattern;
 import land.oras.exception.OrasException;
 import org.jspecify.annotations.NullMarked;
@@ -31,9 +32,10 @@ import org.jspecify.annotations.Nullable;
  * Supported algorithms for digest.
  * See @link <a href="https://github.com/opencontainers/image-spec/blob/main/descriptor.md#digests">https://github.com/opencontainers/image-spec/blob/main/descriptor.md#digests</a>
  * See @link <a href="https://github.com/opencontainers/image-spec/blob/main/descriptor.md#registered-algorithms">https://github.com/opencontainers/image-spec/blob/main/descriptor.md#registered-algorithms</a>
+ * Other algorithms can be registered with {@link DigestAlgorithms#register(DigestAlgorithm)}.
  */
 @NullMarked
-public enum SupportedAlgorithm {
+public enum SupportedAlgorithm implements DigestAlgorithm {
 
     /**
      * SHA-1
@@ -98,6 +100,7 @@ public enum SupportedAlgorithm {
      * Get the prefix
      * @return The prefix
      */
+    @Override
     public String getPrefix() {
         return prefix;
     }
@@ -106,6 +109,7 @@ public enum SupportedAlgorithm {
      * Get the algorithm
      * @return The algorithm
      */
+    @Override
     public String getAlgorithmName() {
         return algorithm;
     }
@@ -114,6 +118,7 @@ public enum SupportedAlgorithm {
      * Get the size of the digest
      * @return The size
      */
+    @Override
     public int getSize() {
         return size;
     }
@@ -123,6 +128,7 @@ public enum SupportedAlgorithm {
      * @param bytes The bytes
      * @return The digest
      */
+    @Override
     public String digest(byte[] bytes) {
         return DigestUtils.digest(algorithm, prefix, bytes);
     }
@@ -132,6 +138,7 @@ public enum SupportedAlgorithm {
      * @param file The file
      * @return The digest
      */
+    @Override
     public String digest(Path file) {
         return DigestUtils.digest(algorithm, prefix, file);
     }
@@ -141,10 +148,30 @@ public enum SupportedAlgorithm {
      * @param inputStream The input stream
      * @return The digest
      */
+    @Override
     public String digest(InputStream inputStream) {
         return DigestUtils.digest(algorithm, prefix, inputStream);
     }
 
+    /**
+     * Create a new message digest to compute the digest incrementally, for example while streaming
+     * @return The message digest
+     */
+    @Override
+    public MessageDigest newMessageDigest() {
+        return DigestUtils.newMessageDigest(algorithm);
+    }
+
+    /**
+     * Format the hash computed by a message digest of this algorithm
+     * @param hashBytes The hash bytes
+     * @return The digest
+     */
+    @Override
+    public String formatDigest(byte[] hashBytes) {
+        return DigestUtils.formatHex(prefix, hashBytes);
+    }
+
     /**
      * Check if the algorithm match pattern
      * @param digest The digest
@@ -49,22 +49,32 @@ public enum SupportedCompression {
         } catch (Exception e) {
             throw new OrasException("Failed to copy stream to temporary file", e);
         }
-    })),
+    }), (is -> is)),
 
     /**
      * ZIP
      */
-    ZIP(Const.ZIP_MEDIA_TYPE, "zip", ArchiveUtils::zip, ArchiveUtils::unzip),
+    ZIP(Const.ZIP_MEDIA_TYPE, "zip", ArchiveUtils::zip, ArchiveUtils::unzip, (is -> is)),
 
     /**
      * GZIP
      */
-    GZIP(Const.DEFAULT_BLOB_DIR_MEDIA_TYPE, "gz", ArchiveUtils::compressGzip, ArchiveUtils::uncompressGzip),
+    GZIP(
+            Const.DEFAULT_BLOB_DIR_MEDIA_TYPE,
+            "gz",
+            ArchiveUtils::compressGzip,
+            ArchiveUtils::uncompressGzip,
+            ArchiveUtils::uncompressGzipStream),
 
     /**
      * ZSTD
      */
-    ZSTD(Const.BLOB_DIR_ZSTD_MEDIA_TYPE, "zst", ArchiveUtils::compressZstd, ArchiveUtils::uncompressZstd);
+    ZSTD(
+            Const.BLOB_DIR_ZSTD_MEDIA_TYPE,
+            "zst",
+            ArchiveUtils::compressZstd,
+            ArchiveUtils::uncompressZstd,
+            ArchiveUtils::uncompressZstdStream);
 
     /**
      * The media type
@@ -86,6 +96,11 @@ public enum SupportedCompression {
      */
     private final Function<InputStream, LocalPath> uncompressFunction;
 
+    /**
+     * The function wrapping a compressed stream into an uncompressed stream
+     */
+    private final Function<InputStream, InputStream> uncompressStreamFunction;
+
     /**
      * Get the supported compression
      * @param mediaType The media type
@@ -94,11 +109,13 @@ public enum SupportedCompression {
             String mediaType,
             String fileExtension,
             Function<LocalPath, LocalPath> compressFunction,
-            Function<InputStream, LocalPath> uncompressFunction) {
+            Function<InputStream, LocalPath> uncompressFunction,
+            Function<InputStream, InputStream> uncompressStreamFunction) {
         this.mediaType = mediaType;
         this.fileExtension = fileExtension;
         this.compressFunction = compressFunction;
         this.uncompressFunction = uncompressFunction;
+        this.uncompressStreamFunction = uncompressStreamFunction;
     }
 
     /**
@@ -143,14 +160,24 @@ public enum SupportedCompression {
         return uncompressFunction.apply(inputStream);
     }
 
+    /**
+     * Uncompress on the fly without writing to disk
+     * @param inputStream The compressed input stream
+     * @return The uncompressed input stream
+     */
+    InputStream uncompressStream(InputStream inputStream) {
+        return uncompressStreamFunction.apply(inputStream);
+    }
+
     /**
      * Get the algorithm from a digest
      * @param mediaType The media type
      * @return The supported algorithm
      */
     public static SupportedCompression fromMediaType(String mediaType) {
+        String ociMediaType = DockerMediaTypes.toOci(mediaType);
         for (SupportedCompression compression : SupportedCompression.values()) {
-            if (mediaType.equalsIgnoreCase(compression.getMediaType())) {
+            if (ociMediaType.equalsIgnoreCase(compression.getMediaType())) {
                 return compression;
             }
         }
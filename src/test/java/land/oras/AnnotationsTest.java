@@ -24,8 +24,12 @@ import static org.junit.jupiter.api.Assertions.assertEquals;
 import static org.junit.jupiter.api.Assertions.assertFalse;
 import static org.junit.jupiter.api.Assertions.assertTrue;
 
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
 import java.util.Map;
 import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
 import org.junit.jupiter.api.parallel.Execution;
 import org.junit.jupiter.api.parallel.ExecutionMode;
 
@@ -61,6 +65,35 @@ class AnnotationsTest {
         assertFalse(annotations.hasFileAnnotations("nonexistent.txt"));
     }
 
+    @Test
+    void shouldReadAnnotationFile(@TempDir Path dir) throws IOException {
+        Path file = Files.writeString(dir.resolve("annotations.json"), sampleAnnotations());
+        Annotations annotations = Annotations.fromPath(file);
+
+        // Assertion
+        assertEquals(Annotations.fromJson(sampleAnnotations()), annotations);
+        assertEquals("world", annotations.configAnnotations().get("hello"));
+        assertEquals("bar", annotations.manifestAnnotations().get("foo"));
+        assertEquals("more cream", annotations.getFileAnnotations("cake.txt").get("fun"));
+    }
+
+    @Test
+    void shouldMergeAnnotations() {
+        Annotations annotations = Annotations.fromJson(sampleAnnotations())
+                .merge(Annotations.empty()
+                        .withManifestAnnotations(Map.of("foo", "baz", "other", "value"))
+                        .withConfigAnnotations(Map.of("config", "value"))
+                        .withFileAnnotations("cake.txt", Map.of("size", "large")));
+
+        // Assertion
+        assertEquals("baz", annotations.manifestAnnotations().get("foo"));
+        assertEquals("value", annotations.manifestAnnotations().get("other"));
+        assertEquals("world", annotations.configAnnotations().get("hello"));
+        assertEquals("value", annotations.configAnnotations().get("config"));
+        assertEquals("more cream", annotations.getFileAnnotations("cake.txt").get("fun"));
+        assertEquals("large", annotations.getFileAnnotations("cake.txt").get("size"));
+    }
+
     @Test
     public void toJson() {
         Annotations annotations = new Annotations(
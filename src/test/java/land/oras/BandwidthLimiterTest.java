@@ -0,0 +1,62 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.util.concurrent.TimeUnit;
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class BandwidthLimiterTest {
+
+    @Test
+    void shouldThrottleStream() throws IOException {
+        byte[] data = new byte[2048];
+        BandwidthLimiter limiter = BandwidthLimiter.of(4096);
+        long start = System.nanoTime();
+        byte[] read;
+        try (InputStream is = new ThrottledInputStream(new ByteArrayInputStream(data), limiter)) {
+            read = is.readAllBytes();
+        }
+        long elapsed = TimeUnit.NANOSECONDS.toMillis(System.nanoTime() - start);
+
+        // Assertion
+        assertArrayEquals(data, read);
+        assertEquals(4096, limiter.getBytesPerSecond());
+        assertTrue(elapsed >= 400, "Reading 2 KiB at 4 KiB/s should take about 500ms but took %dms".formatted(elapsed));
+    }
+
+    @Test
+    void shouldRejectInvalidLimit() {
+        assertThrows(OrasException.class, () -> BandwidthLimiter.of(0));
+        assertThrows(OrasException.class, () -> BandwidthLimiter.of(-1));
+    }
+}
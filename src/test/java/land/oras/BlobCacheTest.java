@@ -0,0 +1,94 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.attribute.FileTime;
+import land.oras.exception.OrasException;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class BlobCacheTest {
+
+    @TempDir
+    private Path cacheDir;
+
+    @Test
+    void shouldCacheBlobs() throws IOException {
+        BlobCache cache = BlobCache.of(cacheDir, 1024);
+        byte[] data = "blob-data".getBytes(StandardCharsets.UTF_8);
+        String digest = SupportedAlgorithm.SHA256.digest(data);
+        assertFalse(cache.contains(digest));
+        assertTrue(cache.get(digest).isEmpty());
+
+        cache.put(digest, data);
+
+        // Assertion
+        assertTrue(cache.contains(digest));
+        Path cached = cache.get(digest).orElseThrow();
+        assertArrayEquals(data, Files.readAllBytes(cached));
+        assertEquals(cacheDir.resolve("blobs").resolve("sha256").resolve(SupportedAlgorithm.getDigest(digest)), cached);
+        assertEquals(data.length, cache.size());
+
+        cache.remove(digest);
+        assertFalse(cache.contains(digest));
+    }
+
+    @Test
+    void shouldEvictLeastRecentlyUsedBlobs() throws IOException {
+        BlobCache cache = BlobCache.of(cacheDir, 20);
+        byte[] first = "first-blob".getBytes(StandardCharsets.UTF_8);
+        byte[] second = "second-blob".getBytes(StandardCharsets.UTF_8);
+        String firstDigest = SupportedAlgorithm.SHA256.digest(first);
+        String secondDigest = SupportedAlgorithm.SHA256.digest(second);
+        cache.put(firstDigest, first);
+        Files.setLastModifiedTime(cache.get(firstDigest).orElseThrow(), FileTime.fromMillis(1000));
+
+        // Adding the second blob exceeds the maximum size
+        cache.put(secondDigest, second);
+
+        // Assertion
+        assertFalse(cache.contains(firstDigest));
+        assertTrue(cache.contains(secondDigest));
+        assertEquals(second.length, cache.size());
+
+        cache.clear();
+        assertEquals(0, cache.size());
+    }
+
+    @Test
+    void shouldRejectInvalidSize() {
+        assertThrows(OrasException.class, () -> BlobCache.of(cacheDir, 0));
+    }
+}
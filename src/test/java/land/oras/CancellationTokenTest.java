@@ -0,0 +1,136 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertDoesNotThrow;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.concurrent.CompletableFuture;
+import java.util.concurrent.atomic.AtomicInteger;
+import land.oras.exception.OperationCancelledException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class CancellationTokenTest {
+
+    @TempDir
+    private Path tempDir;
+
+    @Test
+    void shouldRunCallbacksOnce() {
+        CancellationToken token = CancellationToken.create();
+        AtomicInteger calls = new AtomicInteger();
+        token.onCancel(calls::incrementAndGet);
+        token.onCancel(() -> {
+            throw new IllegalStateException("failing callback");
+        });
+        token.onCancel(calls::incrementAndGet);
+        assertFalse(token.isCancelled());
+        assertDoesNotThrow(token::throwIfCancelled);
+
+        token.cancel();
+        token.cancel();
+
+        // Assertion
+        assertTrue(token.isCancelled());
+        assertEquals(2, calls.get());
+        assertThrows(OperationCancelledException.class, token::throwIfCancelled);
+    }
+
+    @Test
+    void shouldRunCallbackImmediatelyWhenAlreadyCancelled() {
+        CancellationToken token = CancellationToken.create();
+        token.cancel();
+        AtomicInteger calls = new AtomicInteger();
+        token.onCancel(calls::incrementAndGet);
+
+        // Assertion
+        assertEquals(1, calls.get());
+    }
+
+    @Test
+    void shouldCancelWhenLinkedFutureIsCancelled() {
+        CancellationToken token = CancellationToken.create();
+        CompletableFuture<String> completed = token.linkTo(new CompletableFuture<>());
+        completed.complete("done");
+        assertFalse(token.isCancelled());
+
+        CompletableFuture<String> future = token.linkTo(new CompletableFuture<>());
+        future.cancel(true);
+
+        // Assertion
+        assertTrue(token.isCancelled());
+    }
+
+    @Test
+    void shouldFailReadingAfterCancel() throws IOException {
+        CancellationToken token = CancellationToken.create();
+        byte[] data = "hello".getBytes(StandardCharsets.UTF_8);
+        try (InputStream is = new CancellableInputStream(new ByteArrayInputStream(data), token)) {
+            assertEquals('h', is.read());
+            token.cancel();
+
+            // Assertion
+            OperationCancelledException e = assertThrows(OperationCancelledException.class, is::readAllBytes);
+            assertEquals("Operation cancelled", e.getMessage());
+        }
+    }
+
+    @Test
+    void shouldNotLeavePartialFilesWhenPullIsCancelled() throws IOException {
+        Path path = tempDir.resolve("layout");
+        OCILayout layout = OCILayout.builder()
+                .defaults(path)
+                .withStorage(LayoutStorage.inMemory())
+                .build();
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path));
+        Path file = Files.writeString(tempDir.resolve("hello.txt"), "hello");
+        layout.pushArtifact(ref, OCI.PushOptions.defaults(), LocalPath.of(file, "text/plain"));
+
+        CancellationToken token = CancellationToken.create();
+        token.cancel();
+        Path target = Files.createDirectories(tempDir.resolve("target"));
+
+        // Assertion
+        assertThrows(
+                OperationCancelledException.class,
+                () -> layout.pullArtifact(ref, target, OCI.PullOptions.defaults().withCancellation(token)));
+        assertFalse(Files.exists(target.resolve("hello.txt")));
+        assertThrows(
+                OperationCancelledException.class,
+                () -> layout.pushArtifact(
+                        ref.withTag("cancelled"),
+                        OCI.PushOptions.defaults().withCancellation(token),
+                        LocalPath.of(file, "text/plain")));
+    }
+}
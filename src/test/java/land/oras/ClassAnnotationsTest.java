@@ -29,6 +29,7 @@ import java.util.HashSet;
 import java.util.Set;
 import land.oras.auth.HttpClient;
 import land.oras.exception.Error;
+import land.oras.utils.ArchiveUtils;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.parallel.Execution;
 import org.junit.jupiter.api.parallel.ExecutionMode;
@@ -49,10 +50,12 @@ class ClassAnnotationsTest {
                     .loadClasses());
 
             // Check number of classes
-            assertEquals(42, modelClasses.size());
+            assertEquals(50, modelClasses.size());
 
             // Check classes
             assertTrue(modelClasses.contains(Annotations.class));
+            assertTrue(modelClasses.contains(ArchiveUtils.ExtractOptions.class));
+            assertTrue(modelClasses.contains(ArchiveUtils.TarOptions.class));
             assertTrue(modelClasses.contains(ArtifactType.class));
             assertTrue(modelClasses.contains(Config.class));
             assertTrue(modelClasses.contains(CopyUtils.CopyOptions.class));
@@ -60,6 +63,10 @@ class ClassAnnotationsTest {
             assertTrue(modelClasses.contains(Describable.class));
             assertTrue(modelClasses.contains(Error.class));
             assertTrue(modelClasses.contains(HttpClient.TokenResponse.class));
+            assertTrue(modelClasses.contains(ImageConfig.class));
+            assertTrue(modelClasses.contains(ImageConfig.ContainerConfig.class));
+            assertTrue(modelClasses.contains(ImageConfig.History.class));
+            assertTrue(modelClasses.contains(ImageConfig.RootFs.class));
             assertTrue(modelClasses.contains(Index.class));
             assertTrue(modelClasses.contains(Layer.class));
             assertTrue(modelClasses.contains(Manifest.class));
@@ -86,7 +93,7 @@ class ClassAnnotationsTest {
                     .loadClasses());
 
             // Check number of classes
-            assertEquals(10, modelClasses.size());
+            assertEquals(11, modelClasses.size());
         }
     }
 
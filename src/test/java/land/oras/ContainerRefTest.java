@@ -210,6 +210,32 @@ class ContainerRefTest {
                         Registry.builder().withRegistry("foo.io").build()));
     }
 
+    @Test
+    void shouldReturnWithoutDigest() {
+        ContainerRef containerRef = ContainerRef.parse("docker.io/library/alpine:latest@sha256:1234567890abcdef");
+        ContainerRef withoutDigest = containerRef.withoutDigest();
+        assertNull(withoutDigest.getDigest());
+        assertEquals("latest", withoutDigest.getTag());
+        assertEquals(
+                "registry-1.docker.io/v2/library/alpine/manifests/stable",
+                withoutDigest.withTag("stable").getManifestsPath());
+    }
+
+    @Test
+    void shouldTruncateReferrersTag() {
+        ContainerRef containerRef = ContainerRef.parse("localhost:5000/library/foo");
+        String sha256 = SupportedAlgorithm.SHA256.digest("foo".getBytes());
+        String sha512 = SupportedAlgorithm.SHA512.digest("foo".getBytes());
+
+        // Assertion
+        assertEquals(sha256.replace(':', '-'), containerRef.withDigest(sha256).withReferrersTag().getTag());
+        String tag = containerRef.withDigest(sha512).withReferrersTag().getTag();
+        assertEquals("sha512-" + sha512.substring("sha512:".length(), "sha512:".length() + 64), tag);
+        assertEquals(71, tag.length());
+        assertNull(containerRef.withDigest(sha512).withReferrersTag().getDigest());
+        assertThrows(OrasException.class, () -> containerRef.withReferrersTag());
+    }
+
     @Test
     void shouldParseImageWithAllParts() {
         ContainerRef containerRef = ContainerRef.parse("docker.io/library/foo/alpine:latest@sha256:1234567890abcdef");
@@ -0,0 +1,100 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.StandardOpenOption;
+import java.util.List;
+import land.oras.utils.FakeRegistry;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class CopyCheckpointTest {
+
+    @TempDir
+    private Path dir;
+
+    @Test
+    void shouldPersistCompletedKeys() throws Exception {
+        Path file = dir.resolve("checkpoint");
+        CopyCheckpoint checkpoint = CopyCheckpoint.file(file);
+        checkpoint.markCompleted("localhost:5000/library/a@sha256:1");
+        checkpoint.markCompleted("localhost:5000/library/a@sha256:1");
+        checkpoint.markCompleted("localhost:5000/library/a:1.0@sha256:2");
+        // Interrupted write
+        Files.writeString(file, "localhost:5000/library/a@sha", StandardOpenOption.APPEND);
+
+        CopyCheckpoint resumed = CopyCheckpoint.file(file);
+
+        // Assertion
+        assertTrue(resumed.isCompleted("localhost:5000/library/a@sha256:1"));
+        assertTrue(resumed.isCompleted("localhost:5000/library/a:1.0@sha256:2"));
+        assertFalse(resumed.isCompleted("localhost:5000/library/a@sha256:3"));
+        assertEquals(3, Files.readAllLines(file).size());
+        assertFalse(CopyCheckpoint.none().isCompleted("localhost:5000/library/a@sha256:1"));
+    }
+
+    @Test
+    void shouldSkipCompletedContent() throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Registry registry = Registry.builder().withInsecure(true).build();
+            ContainerRef source = ContainerRef.parse("%s/library/source:1.0".formatted(fake.getRegistry()));
+            ContainerRef target = ContainerRef.parse("%s/library/target:1.0".formatted(fake.getRegistry()));
+            Manifest manifest = registry.pushArtifact(
+                    source,
+                    LocalPath.of(Files.writeString(dir.resolve("a.txt"), "a")),
+                    LocalPath.of(Files.writeString(dir.resolve("b.txt"), "b")));
+            String first = manifest.getLayers().get(0).getDigest();
+            String second = manifest.getLayers().get(1).getDigest();
+            String prefix = "%s/library/target".formatted(fake.getRegistry());
+
+            // Resume a copy interrupted after the first layer
+            CopyCheckpoint checkpoint = CopyCheckpoint.inMemory();
+            CopyUtils.CopyOptions options = CopyUtils.CopyOptions.shallow().withCheckpoint(checkpoint);
+            checkpoint.markCompleted("%s@%s".formatted(prefix, first));
+            CopyUtils.copy(registry, source, registry, target, options);
+
+            // Assertion
+            assertFalse(fake.hasBlob("library/target", first));
+            assertTrue(fake.hasBlob("library/target", second));
+            assertTrue(checkpoint.isCompleted("%s@%s".formatted(prefix, second)));
+            assertTrue(checkpoint.isCompleted("%s@%s".formatted(prefix, manifest.getConfig().getDigest())));
+            assertTrue(checkpoint.isCompleted("%s:1.0@%s".formatted(prefix, manifest.getDigest())));
+            assertEquals(List.of("1.0"), fake.getTags("library/target"));
+
+            // Completed manifests are not copied again
+            ContainerRef other = ContainerRef.parse("%s/library/other:1.0".formatted(fake.getRegistry()));
+            checkpoint.markCompleted("%s/library/other:1.0@%s".formatted(fake.getRegistry(), manifest.getDigest()));
+            CopyUtils.copy(registry, source, registry, other, options);
+
+            // Assertion
+            assertEquals(List.of(), fake.getTags("library/other"));
+        }
+    }
+}
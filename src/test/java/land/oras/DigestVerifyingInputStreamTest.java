@@ -0,0 +1,78 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import land.oras.exception.DigestMismatchException;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class DigestVerifyingInputStreamTest {
+
+    private static final byte[] DATA = "blob-data".getBytes(StandardCharsets.UTF_8);
+
+    @Test
+    void shouldReadMatchingContent() throws IOException {
+        String digest = SupportedAlgorithm.SHA256.digest(DATA);
+        try (InputStream is = new DigestVerifyingInputStream(new ByteArrayInputStream(DATA), digest, DATA.length)) {
+            assertArrayEquals(DATA, is.readAllBytes());
+        }
+    }
+
+    @Test
+    void shouldVerifySkippedBytes() throws IOException {
+        String digest = SupportedAlgorithm.SHA256.digest(DATA);
+        try (InputStream is = new DigestVerifyingInputStream(new ByteArrayInputStream(DATA), digest, -1)) {
+            assertEquals(5, is.skip(5));
+            assertEquals(DATA.length - 5, is.readAllBytes().length);
+        }
+    }
+
+    @Test
+    void shouldThrowOnDigestMismatch() throws IOException {
+        String digest = SupportedAlgorithm.SHA256.digest("other-data".getBytes(StandardCharsets.UTF_8));
+        try (InputStream is = new DigestVerifyingInputStream(new ByteArrayInputStream(DATA), digest, -1)) {
+            DigestMismatchException e = assertThrows(DigestMismatchException.class, is::readAllBytes);
+            assertEquals(digest, e.getExpectedDigest());
+            assertEquals(SupportedAlgorithm.SHA256.digest(DATA), e.getActualDigest());
+        }
+    }
+
+    @Test
+    void shouldThrowOnSizeMismatch() throws IOException {
+        String digest = SupportedAlgorithm.SHA256.digest(DATA);
+        try (InputStream is = new DigestVerifyingInputStream(new ByteArrayInputStream(DATA), digest, 42)) {
+            DigestMismatchException e = assertThrows(DigestMismatchException.class, is::readAllBytes);
+            assertEquals(42, e.getExpectedSize());
+            assertEquals(DATA.length, e.getActualSize());
+        }
+    }
+}
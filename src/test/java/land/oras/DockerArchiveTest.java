@@ -0,0 +1,121 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNotEquals;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.List;
+import land.oras.exception.OrasException;
+import land.oras.utils.ArchiveUtils;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class DockerArchiveTest {
+
+    @TempDir
+    private Path tempDir;
+
+    @Test
+    void shouldSaveAndLoadDockerArchive() throws IOException {
+        Path sourcePath = tempDir.resolve("source");
+        OCILayout source = OCILayout.Builder.builder().defaults(sourcePath).build();
+        LayoutRef sourceRef = LayoutRef.parse("%s:1.0.0".formatted(sourcePath));
+
+        // Gzip layer and image config
+        Path content = tempDir.resolve("content");
+        Files.createDirectories(content);
+        Files.writeString(content.resolve("hello.txt"), "hello");
+        LocalPath layerFile = ArchiveUtils.tarcompress(LocalPath.of(content), Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+        Layer layer = source.pushBlob(sourceRef, layerFile.getPath()).withMediaType(Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+        byte[] configData = "{\"architecture\":\"amd64\",\"os\":\"linux\"}".getBytes(StandardCharsets.UTF_8);
+        Config config =
+                Config.fromBlob(Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE, source.pushBlob(sourceRef, configData));
+        source.pushManifest(sourceRef, Manifest.empty().withConfig(config).withLayers(List.of(layer)));
+
+        Path tarball = tempDir.resolve("image.tar");
+        DockerArchive.save(source, sourceRef, "my-app:1.0.0", tarball);
+
+        // Assertion
+        Path extracted = ArchiveUtils.untar(tarball);
+        String manifestJson = Files.readString(extracted.resolve("manifest.json"));
+        assertTrue(manifestJson.contains("\"RepoTags\":[\"my-app:1.0.0\"]"), manifestJson);
+        String configFile = "%s.json".formatted(SupportedAlgorithm.getDigest(config.getDigest()));
+        assertTrue(manifestJson.contains("\"Config\":\"%s\"".formatted(configFile)), manifestJson);
+        assertEquals("{\"architecture\":\"amd64\",\"os\":\"linux\"}", Files.readString(extracted.resolve(configFile)));
+        try (InputStream is = Files.newInputStream(layerFile.getPath())) {
+            LocalPath uncompressed = ArchiveUtils.uncompress(is, Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+            String diffId = SupportedAlgorithm.SHA256.digest(uncompressed.getPath());
+            assertTrue(Files.exists(extracted.resolve("%s/layer.tar".formatted(SupportedAlgorithm.getDigest(diffId)))));
+        }
+
+        // Load into another layout
+        Path targetPath = tempDir.resolve("target");
+        OCILayout target = OCILayout.Builder.builder().defaults(targetPath).build();
+        LayoutRef targetRef = LayoutRef.parse("%s:loaded".formatted(targetPath));
+        Manifest loaded = DockerArchive.load(target, targetRef, tarball);
+
+        assertEquals(config.getDigest(), loaded.getConfig().getDigest());
+        assertEquals(Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE, loaded.getConfig().getMediaType());
+        assertEquals(1, loaded.getLayers().size());
+        assertEquals(Const.DEFAULT_BLOB_MEDIA_TYPE, loaded.getLayers().get(0).getMediaType());
+        assertNotEquals(layer.getDigest(), loaded.getLayers().get(0).getDigest());
+        assertEquals(
+                loaded.getDescriptor().getDigest(),
+                target.getManifest(targetRef).getDescriptor().getDigest());
+    }
+
+    @Test
+    void shouldRejectArchiveWithoutManifest() throws IOException {
+        Path content = tempDir.resolve("not-docker");
+        Files.createDirectories(content);
+        Files.writeString(content.resolve("file.txt"), "content");
+        LocalPath tarball = ArchiveUtils.tar(LocalPath.of(content));
+
+        Path targetPath = tempDir.resolve("target-invalid");
+        OCILayout target = OCILayout.Builder.builder().defaults(targetPath).build();
+        LayoutRef targetRef = LayoutRef.parse("%s:invalid".formatted(targetPath));
+        assertThrows(OrasException.class, () -> DockerArchive.load(target, targetRef, tarball.getPath()));
+    }
+
+    @Test
+    void shouldRejectNonImageArtifact() {
+        Path sourcePath = tempDir.resolve("artifact");
+        OCILayout source = OCILayout.Builder.builder().defaults(sourcePath).build();
+        LayoutRef sourceRef = LayoutRef.parse("%s:artifact".formatted(sourcePath));
+        source.pushManifest(sourceRef, Manifest.empty());
+        assertThrows(
+                OrasException.class,
+                () -> DockerArchive.save(source, sourceRef, "artifact:latest", tempDir.resolve("artifact.tar")));
+    }
+}
@@ -0,0 +1,123 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertSame;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class FileStoreTest {
+
+    @TempDir
+    private Path tempDir;
+
+    @Test
+    void shouldDetectMediaTypes() throws IOException {
+        Path json = Files.writeString(tempDir.resolve("config.json"), "{}");
+        Path archive = Files.writeString(tempDir.resolve("archive.tar.gz"), "not really gzip");
+        Path gzip = Files.write(tempDir.resolve("data.bin"), new byte[] {(byte) 0x1f, (byte) 0x8b, 8, 0});
+        Path unknown = Files.writeString(tempDir.resolve("unknown"), "hello");
+
+        try (FileStore store = FileStore.create()) {
+            assertEquals(Const.DEFAULT_JSON_MEDIA_TYPE, store.add(json).getMediaType());
+            assertEquals(Const.DEFAULT_BLOB_DIR_MEDIA_TYPE, store.add(archive).getMediaType());
+            assertEquals("application/gzip", store.add(gzip).getMediaType());
+            assertEquals(Const.DEFAULT_BLOB_MEDIA_TYPE, store.add(unknown).getMediaType());
+            assertEquals("text/plain", FileStore.create().add(unknown, "text/plain").getMediaType());
+        }
+    }
+
+    @Test
+    void shouldUseCustomResolver() throws IOException {
+        Path json = Files.writeString(tempDir.resolve("values.json"), "{}");
+        Path text = Files.writeString(tempDir.resolve("readme.txt"), "hello");
+        MediaTypeResolver resolver = path -> path.toString().endsWith(".json") ? "application/vnd.test+json" : null;
+
+        try (FileStore store = FileStore.create(resolver.orElse(MediaTypeResolver.defaults()))) {
+            assertEquals("application/vnd.test+json", store.add(json).getMediaType());
+            assertEquals("text/plain", store.add(text).getMediaType());
+        }
+    }
+
+    @Test
+    void shouldDeduplicateBlobs() throws IOException {
+        Path first = Files.writeString(tempDir.resolve("first.txt"), "same");
+        Path second = Files.writeString(tempDir.resolve("second.txt"), "same");
+        Path other = Files.createDirectory(tempDir.resolve("other"));
+        Path duplicate = Files.writeString(other.resolve("first.txt"), "different");
+
+        try (FileStore store = FileStore.create()) {
+            Layer layer = store.add(first);
+            assertSame(layer, store.add(first));
+            Layer secondLayer = store.add(second);
+
+            // Two layers for the same blob
+            assertEquals(layer.getDigest(), secondLayer.getDigest());
+            assertEquals(2, store.getLayers().size());
+            assertEquals("second.txt", store.getLayers().get(1).getAnnotations().get(Const.ANNOTATION_TITLE));
+            assertArrayEquals("same".getBytes(), store.getBlob(layer.getDigest()));
+
+            // Same name but different content
+            OrasException e = assertThrows(OrasException.class, () -> store.add(duplicate));
+            assertEquals("Duplicate file name 'first.txt' with different content", e.getMessage());
+            assertFalse(store.exists(SupportedAlgorithm.SHA256.digest("unknown".getBytes())));
+        }
+    }
+
+    @Test
+    void shouldPackDirectory() throws IOException {
+        Path dir = Files.createDirectory(tempDir.resolve("dir"));
+        Files.writeString(dir.resolve("file.txt"), "hello");
+
+        try (FileStore store = FileStore.create()) {
+            Layer layer = store.add(dir);
+            assertEquals(Const.DEFAULT_BLOB_DIR_MEDIA_TYPE, layer.getMediaType());
+            assertEquals("dir", layer.getAnnotations().get(Const.ANNOTATION_TITLE));
+            assertEquals("true", layer.getAnnotations().get(Const.ANNOTATION_ORAS_UNPACK));
+            assertTrue(layer.getAnnotations().containsKey(Const.ANNOTATION_ORAS_CONTENT_DIGEST));
+            assertTrue(store.exists(layer.getDigest()));
+            assertEquals(layer.getDigest(), SupportedAlgorithm.SHA256.digest(store.getBlob(layer.getDigest())));
+        }
+    }
+
+    @Test
+    void shouldFailOnMissingFile() {
+        try (FileStore store = FileStore.create()) {
+            assertThrows(OrasException.class, () -> store.add(tempDir.resolve("missing")));
+            assertThrows(OrasException.class, () -> store.fetch("sha256:unknown"));
+        }
+    }
+}
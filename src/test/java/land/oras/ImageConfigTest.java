@@ -0,0 +1,111 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNotNull;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+import java.util.List;
+import java.util.Map;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class ImageConfigTest {
+
+    @Test
+    void shouldParseImageConfig() {
+        // language=json
+        String json = """
+                {
+                  "created": "2024-01-01T00:00:00Z",
+                  "architecture": "arm64",
+                  "os": "linux",
+                  "variant": "v8",
+                  "config": {
+                    "User": "1000",
+                    "ExposedPorts": {"8080/tcp": {}},
+                    "Env": ["PATH=/usr/bin"],
+                    "Entrypoint": ["/app"],
+                    "Cmd": ["--help"],
+                    "WorkingDir": "/work",
+                    "Labels": {"org.opencontainers.image.source": "https://github.com/oras-project/oras-java"}
+                  },
+                  "rootfs": {"type": "layers", "diff_ids": ["sha256:a", "sha256:b"]},
+                  "history": [{"created_by": "COPY app /app", "empty_layer": false}],
+                  "unknown": "ignored"
+                }
+                """;
+        ImageConfig imageConfig = ImageConfig.fromJson(json);
+
+        // Assertion
+        assertEquals("2024-01-01T00:00:00Z", imageConfig.created());
+        assertEquals(Platform.linuxArm64V8(), imageConfig.getPlatform());
+        assertEquals(List.of("PATH=/usr/bin"), imageConfig.getEnv());
+        assertEquals(List.of("/app"), imageConfig.getEntrypoint());
+        assertEquals(List.of("--help"), imageConfig.getCmd());
+        assertEquals(List.of("sha256:a", "sha256:b"), imageConfig.getDiffIds());
+        assertEquals(
+                "https://github.com/oras-project/oras-java",
+                imageConfig.getLabels().get("org.opencontainers.image.source"));
+        assertNotNull(imageConfig.config());
+        assertEquals("1000", imageConfig.config().user());
+        assertEquals("/work", imageConfig.config().workingDir());
+        assertTrue(imageConfig.config().exposedPorts().containsKey("8080/tcp"));
+        assertEquals("COPY app /app", imageConfig.history().get(0).createdBy());
+        assertEquals(Boolean.FALSE, imageConfig.history().get(0).emptyLayer());
+    }
+
+    @Test
+    void shouldBuildImageConfig() {
+        ImageConfig imageConfig = ImageConfig.builder()
+                .withCreated("2024-01-01T00:00:00Z")
+                .withPlatform(Platform.linuxAmd64())
+                .withEnv("JAVA_HOME", "/opt/java")
+                .withEntrypoint(List.of("java", "-jar", "/app.jar"))
+                .withExposedPort("8080/tcp")
+                .withLabel("version", "1.0")
+                .withDiffId("sha256:a")
+                .withHistory(new ImageConfig.History(null, "COPY app.jar /app.jar", null, null, null))
+                .build();
+
+        // Assertion
+        assertEquals(
+                "{\"created\":\"2024-01-01T00:00:00Z\",\"architecture\":\"amd64\",\"os\":\"linux\",\"config\":{\"ExposedPorts\":{\"8080/tcp\":{}},\"Env\":[\"JAVA_HOME=/opt/java\"],\"Entrypoint\":[\"java\",\"-jar\",\"/app.jar\"],\"Labels\":{\"version\":\"1.0\"}},\"rootfs\":{\"type\":\"layers\",\"diff_ids\":[\"sha256:a\"]},\"history\":[{\"created_by\":\"COPY app.jar /app.jar\"}]}",
+                imageConfig.toJson());
+        assertEquals(imageConfig, ImageConfig.fromJson(imageConfig.toJson()));
+        assertEquals(Map.of("version", "1.0"), imageConfig.getLabels());
+
+        // As manifest config
+        Config config = Config.fromImageConfig(imageConfig);
+        byte[] data = imageConfig.toJson().getBytes(StandardCharsets.UTF_8);
+        assertEquals(Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE, config.getMediaType());
+        assertEquals(SupportedAlgorithm.SHA256.digest(data), config.getDigest());
+        assertEquals((long) data.length, config.getSize());
+        assertEquals(Base64.getEncoder().encodeToString(data), config.getData());
+    }
+}
@@ -186,6 +186,92 @@ class IndexTest {
         assertNull(notFound);
     }
 
+    @Test
+    void shouldComposeMultiPlatformIndex() {
+        ManifestDescriptor amd64 = ManifestDescriptor.of(
+                Const.DEFAULT_MANIFEST_MEDIA_TYPE, SupportedAlgorithm.SHA256.digest("amd64".getBytes()), 10);
+        ManifestDescriptor arm64 = ManifestDescriptor.of(
+                Const.DEFAULT_MANIFEST_MEDIA_TYPE, SupportedAlgorithm.SHA256.digest("arm64".getBytes()), 20);
+        ManifestDescriptor amd64v2 = ManifestDescriptor.of(
+                Const.DEFAULT_MANIFEST_MEDIA_TYPE, SupportedAlgorithm.SHA256.digest("amd64v2".getBytes()), 30);
+
+        Index index = Index.fromManifests(List.of())
+                .withPlatformManifest(amd64, Platform.linuxAmd64())
+                .withPlatformManifest(arm64, Platform.linuxArm64V8());
+        assertEquals(2, index.getManifests().size());
+        assertEquals(Platform.linuxArm64V8(), index.getManifests().get(1).getPlatform());
+
+        // Replace existing platform
+        index = index.withPlatformManifest(amd64v2, Platform.linuxAmd64());
+        assertEquals(2, index.getManifests().size());
+        ManifestDescriptor linux = index.findUnique(Platform.linuxAmd64());
+        assertNotNull(linux);
+        assertEquals(amd64v2.getDigest(), linux.getDigest());
+
+        // Remove platform
+        index = index.withRemovedPlatform(Platform.linuxArm64V8());
+        assertEquals(1, index.getManifests().size());
+        assertNull(index.findUnique(Platform.linuxArm64V8()));
+    }
+
+    @Test
+    void shouldSelectPlatformAndConvertDockerManifestList() {
+        // language=JSON
+        String json =
+                """
+                {
+                  "schemaVersion": 2,
+                  "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json",
+                  "manifests": [
+                    {
+                      "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+                      "digest": "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+                      "size": 528,
+                      "platform": {
+                        "architecture": "amd64",
+                        "os": "linux"
+                      }
+                    },
+                    {
+                      "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+                      "digest": "sha256:2222222222222222222222222222222222222222222222222222222222222222",
+                      "size": 529,
+                      "platform": {
+                        "architecture": "arm64",
+                        "os": "linux",
+                        "variant": "v8"
+                      }
+                    }
+                  ]
+                }
+                """;
+        Index index = Index.fromJson(json);
+
+        // Assertion
+        assertEquals(Const.DOCKER_INDEX_MEDIA_TYPE, index.getMediaType());
+        ManifestDescriptor arm64 = index.findUnique(Platform.linuxArm64V8());
+        assertNotNull(arm64);
+        assertEquals(
+                "sha256:2222222222222222222222222222222222222222222222222222222222222222", arm64.getDigest());
+
+        Index converted = index.toOci();
+        assertEquals(Const.DEFAULT_INDEX_MEDIA_TYPE, converted.getMediaType());
+        assertNull(converted.getJson());
+        assertEquals(2, converted.getManifests().size());
+        for (ManifestDescriptor descriptor : converted.getManifests()) {
+            assertEquals(Const.DEFAULT_MANIFEST_MEDIA_TYPE, descriptor.getMediaType());
+        }
+        ManifestDescriptor convertedAmd64 = converted.findUnique(Platform.linuxAmd64());
+        assertNotNull(convertedAmd64);
+        assertEquals(
+                "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+                convertedAmd64.getDigest());
+        assertEquals(528, convertedAmd64.getSize());
+
+        // OCI index are not converted
+        assertSame(converted, converted.toOci());
+    }
+
     @Test
     void shouldAddSubject() {
         Index index = Index.fromManifests(List.of());
@@ -196,7 +282,7 @@ class IndexTest {
         assertEquals("sha256:123", subject.getDigest());
         assertEquals(123, subject.getSize());
         assertEquals(
-                "{\"schemaVersion\":2,\"mediaType\":\"application/vnd.oci.image.index.v1+json\",\"subject\":{\"mediaType\":\"application/vnd.oci.image.manifest.v1+json\",\"digest\":\"sha256:123\",\"size\":123},\"manifests\":[]}",
+                "{\"schemaVersion\":2,\"mediaType\":\"application/vnd.oci.image.index.v1+json\",\"manifests\":[],\"subject\":{\"mediaType\":\"application/vnd.oci.image.manifest.v1+json\",\"digest\":\"sha256:123\",\"size\":123}}",
                 index.toJson());
     }
 
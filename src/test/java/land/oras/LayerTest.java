@@ -21,10 +21,15 @@
 package land.oras;
 
 import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
 import static org.junit.jupiter.api.Assertions.assertNotEquals;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertTrue;
 
 import java.nio.file.Files;
 import java.nio.file.Path;
+import java.util.List;
+import java.util.Map;
 import land.oras.utils.SupportedAlgorithm;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.io.TempDir;
@@ -138,7 +143,28 @@ class LayerTest {
                 json);
     }
 
-    private String emptyLayer() {
+    @Test
+    void shouldReadUrlsOfNonDistributableLayer() {
+        Layer layer = Layer.fromJson(
+                """
+                {
+                  "mediaType": "application/vnd.docker.image.rootfs.foreign.diff.tar.gzip",
+                  "digest": "sha256:abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+                  "size": 32654,
+                  "urls": ["https://mcr.microsoft.com/v2/windows/blobs/sha256:abcdef"]
+                }
+                """);
+
+        // Assertion
+        assertTrue(layer.isNonDistributable());
+        assertEquals(List.of("https://mcr.microsoft.com/v2/windows/blobs/sha256:abcdef"), layer.getUrls());
+        assertEquals(layer.getUrls(), layer.withAnnotations(Map.of("foo", "bar")).getUrls());
+        assertTrue(layer.toJson().contains("\"urls\":[\"https://mcr.microsoft.com/v2/windows/blobs/sha256:abcdef\"]"));
+        assertFalse(Layer.fromJson(sampleLayer()).isNonDistributable());
+        assertNull(Layer.fromJson(sampleLayer()).getUrls());
+    }
+
+        private String emptyLayer() {
         return """
             {
               "mediaType": "application/vnd.oci.empty.v1+json",
@@ -0,0 +1,221 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.concurrent.ConcurrentHashMap;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class LayoutStorageTest {
+
+    @TempDir
+    private Path tempDir;
+
+    @Test
+    void shouldWriteReadAndListDirectoryEntries() {
+        LayoutStorage storage = LayoutStorage.directory(tempDir.resolve("layout"));
+        storage.write("blobs/sha256/b", "b".getBytes(StandardCharsets.UTF_8));
+        storage.write("blobs/sha256/a", "a".getBytes(StandardCharsets.UTF_8));
+        storage.write(Const.OCI_LAYOUT_INDEX, "{}".getBytes(StandardCharsets.UTF_8));
+
+        // Assertion
+        assertTrue(storage.exists("blobs/sha256/a"));
+        assertFalse(storage.exists("blobs/sha256/c"));
+        assertEquals(1, storage.size("blobs/sha256/b"));
+        assertEquals("{}", new String(storage.readAllBytes(Const.OCI_LAYOUT_INDEX), StandardCharsets.UTF_8));
+        assertEquals(List.of("blobs/sha256/a", "blobs/sha256/b"), storage.list("blobs/"));
+        assertEquals(List.of(), storage.list("blobs/sha512/"));
+        assertTrue(Files.exists(tempDir.resolve("layout/blobs/sha256/a")));
+        assertTrue(storage.delete("blobs/sha256/a"));
+        assertFalse(storage.delete("blobs/sha256/a"));
+    }
+
+    @Test
+    void shouldNotCreateEntryWhenWriteFails() {
+        LayoutStorage storage = LayoutStorage.directory(tempDir.resolve("failing"));
+        InputStream failing = new InputStream() {
+            @Override
+            public int read() throws IOException {
+                throw new IOException("Connection reset");
+            }
+        };
+
+        // Assertion
+        assertThrows(OrasException.class, () -> storage.write("blobs/sha256/a", failing));
+        assertFalse(storage.exists("blobs/sha256/a"));
+        assertEquals(List.of(), storage.list(""));
+    }
+
+    @Test
+    void shouldRejectEntriesOutsideOfDirectory() {
+        LayoutStorage storage = LayoutStorage.directory(tempDir.resolve("escape"));
+
+        // Assertion
+        assertThrows(OrasException.class, () -> storage.write("../outside", new byte[0]));
+        assertFalse(Files.exists(tempDir.resolve("outside")));
+    }
+
+    @Test
+    void shouldPushAndPullWithInMemoryStorage() throws IOException {
+        Path path = tempDir.resolve("memory");
+        OCILayout layout = OCILayout.builder()
+                .defaults(path)
+                .withStorage(LayoutStorage.inMemory())
+                .build();
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path));
+        Path file = Files.writeString(tempDir.resolve("hello.txt"), "hello");
+
+        Manifest manifest = layout.pushArtifact(ref, LocalPath.of(file, "text/plain"));
+        layout.tag(ref, "stable");
+        Path pulled = Files.createDirectories(tempDir.resolve("pulled"));
+        layout.pullArtifact(ref.withTag("stable"), pulled, false);
+
+        // Assertion
+        assertFalse(Files.exists(path), "Nothing must be written to the layout path");
+        assertEquals("hello", Files.readString(pulled.resolve("hello.txt")));
+        assertEquals(List.of("latest", "stable"), layout.getTags(ref).tags());
+        assertEquals(
+                manifest.getDescriptor().getDigest(),
+                layout.getManifest(ref).getDescriptor().getDigest());
+        assertTrue(layout.getStorage().exists(Const.OCI_LAYOUT_FILE));
+    }
+
+    @Test
+    void shouldGarbageCollectAndArchiveCustomStorage() throws IOException {
+        RecordingStorage storage = new RecordingStorage(LayoutStorage.inMemory());
+        Path path = tempDir.resolve("custom");
+        OCILayout layout = OCILayout.builder().defaults(path).withStorage(storage).build();
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path));
+        Path file = Files.writeString(tempDir.resolve("artifact.txt"), "artifact");
+        Manifest manifest = layout.pushArtifact(ref, LocalPath.of(file, "text/plain"));
+        String orphan = SupportedAlgorithm.SHA256.digest("orphan".getBytes(StandardCharsets.UTF_8));
+        layout.pushBlob(ref.withDigest(orphan), "orphan".getBytes(StandardCharsets.UTF_8));
+
+        OCILayout.GarbageCollectionResult result = layout.gc();
+        Path tarFile = tempDir.resolve("custom.tar");
+        layout.toTar(tarFile);
+        OCILayout read;
+        try (InputStream is = Files.newInputStream(tarFile)) {
+            read = OCILayout.fromTar(is, tempDir.resolve("custom-read"));
+        }
+
+        // Assertion
+        assertEquals(List.of(orphan), result.removed());
+        assertTrue(storage.written.contains("blobs/sha256/%s".formatted(SupportedAlgorithm.getDigest(orphan))));
+        assertTrue(storage.written.contains(Const.OCI_LAYOUT_INDEX));
+        assertEquals(
+                manifest.getDescriptor().getDigest(),
+                read.getManifest(LayoutRef.parse("%s:latest".formatted(tempDir.resolve("custom-read"))))
+                        .getDescriptor()
+                        .getDigest());
+    }
+
+    @Test
+    void shouldRejectMismatchingStreamWithoutStoringBlob() {
+        Path path = tempDir.resolve("mismatch");
+        OCILayout layout = OCILayout.builder()
+                .defaults(path)
+                .withStorage(LayoutStorage.inMemory())
+                .build();
+        String digest = SupportedAlgorithm.SHA256.digest("expected".getBytes(StandardCharsets.UTF_8));
+        LayoutRef ref = LayoutRef.parse("%s@%s".formatted(path, digest));
+
+        // Assertion
+        assertThrows(
+                OrasException.class,
+                () -> layout.pushBlob(
+                        ref, 5, () -> new ByteArrayInputStream("wrong".getBytes(StandardCharsets.UTF_8)), Map.of()));
+        assertFalse(layout.getStorage().exists("blobs/sha256/%s".formatted(SupportedAlgorithm.getDigest(digest))));
+    }
+
+    @Test
+    void shouldRejectCustomStorageForTarLayout() {
+        OCILayout.Builder builder =
+                OCILayout.builder().defaults(tempDir.resolve("layout.tar")).withStorage(LayoutStorage.inMemory());
+
+        // Assertion
+        assertThrows(OrasException.class, builder::build);
+    }
+
+    /**
+     * A storage recording the written entries, like a client of an object store would
+     */
+    private static final class RecordingStorage implements LayoutStorage {
+
+        private final LayoutStorage delegate;
+        private final Set<String> written = ConcurrentHashMap.newKeySet();
+
+        private RecordingStorage(LayoutStorage delegate) {
+            this.delegate = delegate;
+        }
+
+        @Override
+        public boolean exists(String name) {
+            return delegate.exists(name);
+        }
+
+        @Override
+        public long size(String name) {
+            return delegate.size(name);
+        }
+
+        @Override
+        public InputStream read(String name) {
+            return delegate.read(name);
+        }
+
+        @Override
+        public void write(String name, InputStream data) {
+            written.add(name);
+            delegate.write(name, data);
+        }
+
+        @Override
+        public boolean delete(String name) {
+            return delegate.delete(name);
+        }
+
+        @Override
+        public List<String> list(String prefix) {
+            return delegate.list(prefix);
+        }
+    }
+}
@@ -22,7 +22,13 @@ package land.oras;
 
 import static org.junit.jupiter.api.Assertions.*;
 
+import java.nio.charset.StandardCharsets;
 import java.nio.file.Path;
+import java.util.List;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.parallel.Execution;
 import org.junit.jupiter.api.parallel.ExecutionMode;
@@ -104,6 +110,51 @@ class ManifestTest {
                 json);
     }
 
+    @Test
+    void shouldSerializeInCanonicalOrder() {
+        Manifest manifest = Manifest.empty()
+                .withLayers(List.of(Layer.fromDigest("sha256:abc", 1)))
+                .withSubject(Subject.of(Const.DEFAULT_MANIFEST_MEDIA_TYPE, "sha256:123", 123))
+                .withAnnotations(Map.of("org.opencontainers.image.title", "b", "org.opencontainers.image.created", "a"));
+        String json = manifest.toJson();
+
+        // Assertion
+        assertTrue(json.indexOf("\"config\"") < json.indexOf("\"layers\""));
+        assertTrue(json.indexOf("\"layers\"") < json.indexOf("\"subject\""));
+        assertTrue(json.indexOf("\"subject\"") < json.indexOf("\"annotations\""));
+        assertTrue(json.indexOf("image.created") < json.indexOf("image.title"));
+        assertEquals(json, Manifest.fromJson(json).toJson());
+    }
+
+    @Test
+    void shouldSetSubjectFromDescriptor() {
+        String digest = SupportedAlgorithm.SHA256.digest("subject".getBytes(StandardCharsets.UTF_8));
+        Manifest manifest =
+                Manifest.empty().withSubject(Descriptor.of(digest, 10L, Const.DEFAULT_MANIFEST_MEDIA_TYPE));
+
+        // Assertion
+        assertNotNull(manifest.getSubject());
+        assertEquals(digest, manifest.getSubject().getDigest());
+        assertEquals(10, manifest.getSubject().getSize());
+        assertEquals(Const.DEFAULT_MANIFEST_MEDIA_TYPE, manifest.getSubject().getMediaType());
+    }
+
+    @Test
+    void shouldRejectInvalidSubjectDescriptor() {
+        Manifest manifest = Manifest.empty();
+        String digest = SupportedAlgorithm.SHA256.digest("subject".getBytes(StandardCharsets.UTF_8));
+        assertThrows(
+                OrasException.class,
+                () -> manifest.withSubject(Descriptor.of("invalid", 10L, Const.DEFAULT_MANIFEST_MEDIA_TYPE)));
+        assertThrows(
+                OrasException.class,
+                () -> manifest.withSubject(Descriptor.of("sha256:123", 10L, Const.DEFAULT_MANIFEST_MEDIA_TYPE)));
+        assertThrows(OrasException.class, () -> manifest.withSubject(Descriptor.of(digest, 2L, "")));
+        assertThrows(
+                OrasException.class,
+                () -> manifest.withSubject(Descriptor.of(digest, -1L, Const.DEFAULT_MANIFEST_MEDIA_TYPE)));
+    }
+
     @Test
     void shouldReadDigestTopLevelDescriptor() {
         String json =
@@ -155,6 +206,46 @@ class ManifestTest {
              """;
     }
 
+    @Test
+    void shouldConvertDockerManifestToOci() {
+        // language=JSON
+        String json =
+                """
+                {
+                  "schemaVersion": 2,
+                  "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+                  "config": {
+                    "mediaType": "application/vnd.docker.container.image.v1+json",
+                    "digest": "sha256:abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+                    "size": 7023
+                  },
+                  "layers": [
+                    {
+                      "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+                      "digest": "sha256:1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+                      "size": 32654
+                    }
+                  ]
+                }
+                """;
+        Manifest manifest = Manifest.fromJson(json);
+        Manifest converted = manifest.toOci();
+
+        // Assertion
+        assertEquals(Const.DOCKER_MANIFEST_MEDIA_TYPE, manifest.getMediaType());
+        assertEquals(Const.DEFAULT_MANIFEST_MEDIA_TYPE, converted.getMediaType());
+        assertEquals(Const.CONFIG_RUNNING_CONTAINER_MEDIA_TYPE, converted.getConfig().getMediaType());
+        assertEquals(manifest.getConfig().getDigest(), converted.getConfig().getDigest());
+        assertEquals(Const.DEFAULT_BLOB_DIR_MEDIA_TYPE, converted.getLayers().get(0).getMediaType());
+        assertEquals(manifest.getLayers().get(0).getDigest(), converted.getLayers().get(0).getDigest());
+        assertNull(converted.getJson());
+        assertTrue(converted.toJson().contains(Const.DEFAULT_MANIFEST_MEDIA_TYPE));
+
+        // OCI manifests are not converted
+        Manifest oci = Manifest.fromJson(sampleManifest());
+        assertSame(oci, oci.toOci());
+    }
+
     /**
      * A sample manifest
      * @return The manifest
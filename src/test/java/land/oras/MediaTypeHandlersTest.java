@@ -0,0 +1,134 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertSame;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.List;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class MediaTypeHandlersTest {
+
+    @TempDir
+    private Path layoutPath;
+
+    @TempDir
+    private Path extractDir;
+
+    @Test
+    void shouldRegisterHandlers() {
+        String mediaType = "application/vnd.test.register.v1";
+        GreetingHandler handler = new GreetingHandler(mediaType);
+        MediaTypeHandlers.register(handler);
+        try {
+            // Assertion
+            assertSame(handler, MediaTypeHandlers.get(mediaType));
+            assertSame(handler, MediaTypeHandlers.get(mediaType, Greeting.class));
+            assertThrows(OrasException.class, () -> MediaTypeHandlers.get(mediaType, String.class));
+            assertThrows(OrasException.class, () -> MediaTypeHandlers.marshal(mediaType, "not a greeting"));
+        } finally {
+            MediaTypeHandlers.unregister(mediaType);
+        }
+        assertNull(MediaTypeHandlers.get(mediaType));
+        assertThrows(OrasException.class, () -> MediaTypeHandlers.get(mediaType, Greeting.class));
+    }
+
+    @Test
+    void shouldPushFetchAndUnpackCustomMediaTypes() throws IOException {
+        String mediaType = "application/vnd.test.greeting.v1";
+        MediaTypeHandlers.register(new GreetingHandler(mediaType));
+        try {
+            LayoutRef layoutRef = LayoutRef.parse("%s:greeting".formatted(layoutPath.toString()));
+            OCILayout ociLayout = OCILayout.Builder.builder().defaults(layoutPath).build();
+            Layer layer = ociLayout
+                    .pushContent(layoutRef, mediaType, new Greeting("hello"))
+                    .withAnnotations(Map.of(Const.ANNOTATION_TITLE, "greeting"));
+            ociLayout.pushManifest(layoutRef, Manifest.empty().withLayers(List.of(layer)));
+
+            // Assertion
+            assertEquals(mediaType, layer.getMediaType());
+            assertEquals(new Greeting("hello"), ociLayout.fetchContent(layoutRef, layer, Greeting.class));
+
+            // The handler unpacks instead of writing the blob as a file
+            ociLayout.pullArtifact(layoutRef, extractDir, false);
+            assertEquals("hello world", Files.readString(extractDir.resolve("greeting.txt")));
+        } finally {
+            MediaTypeHandlers.unregister(mediaType);
+        }
+    }
+
+    record Greeting(String message) {}
+
+    private static final class GreetingHandler implements MediaTypeHandler<Greeting> {
+
+        private final String mediaType;
+
+        private GreetingHandler(String mediaType) {
+            this.mediaType = mediaType;
+        }
+
+        @Override
+        public String getMediaType() {
+            return mediaType;
+        }
+
+        @Override
+        public Class<Greeting> getType() {
+            return Greeting.class;
+        }
+
+        @Override
+        public Greeting unmarshal(InputStream input) throws IOException {
+            return new Greeting(new String(input.readAllBytes(), StandardCharsets.UTF_8));
+        }
+
+        @Override
+        public byte[] marshal(Greeting value) {
+            return value.message().getBytes(StandardCharsets.UTF_8);
+        }
+
+        @Override
+        public boolean isUnpack() {
+            return true;
+        }
+
+        @Override
+        public void unpack(InputStream input, Layer layer, Path directory) throws IOException {
+            Greeting greeting = unmarshal(input);
+            Files.writeString(directory.resolve("greeting.txt"), greeting.message() + " world");
+        }
+    }
+}
@@ -0,0 +1,78 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertSame;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class MemoryStoreTest {
+
+    @Test
+    void shouldAddLayers() {
+        MemoryStore store = MemoryStore.create();
+        Layer layer = store.add("hello.txt", "hello", "text/plain");
+        assertSame(layer, store.add("hello.txt", "hello", "text/plain"));
+        Layer untitled = store.add("hello".getBytes(), Const.DEFAULT_BLOB_MEDIA_TYPE);
+
+        // Assertion
+        assertEquals(2, store.getLayers().size());
+        assertEquals(SupportedAlgorithm.SHA256.digest("hello".getBytes()), layer.getDigest());
+        assertEquals(layer.getDigest(), untitled.getDigest());
+        assertEquals(5, layer.getSize());
+        assertEquals("text/plain", layer.getMediaType());
+        assertEquals("hello.txt", layer.getAnnotations().get(Const.ANNOTATION_TITLE));
+        assertTrue(untitled.getAnnotations().isEmpty());
+        assertArrayEquals("hello".getBytes(), store.getBlob(layer.getDigest()));
+        assertNull(store.getConfig());
+        String blob = store.addBlob("{}".getBytes());
+        assertEquals(2, store.getLayers().size());
+        assertTrue(store.exists(blob));
+
+        OrasException e = assertThrows(OrasException.class, () -> store.add("hello.txt", "world", "text/plain"));
+        assertEquals("Duplicate file name 'hello.txt' with different content", e.getMessage());
+        assertThrows(OrasException.class, () -> store.fetch("sha256:unknown"));
+    }
+
+    @Test
+    void shouldSetConfig() {
+        MemoryStore store = MemoryStore.create(SupportedAlgorithm.SHA512);
+        Config config = store.setConfig("{\"foo\":\"bar\"}", "application/vnd.test.config.v1+json");
+
+        // Assertion
+        assertSame(config, store.getConfig());
+        assertEquals("application/vnd.test.config.v1+json", config.getMediaType());
+        assertEquals(SupportedAlgorithm.SHA512.digest("{\"foo\":\"bar\"}".getBytes()), config.getDigest());
+        assertTrue(store.exists(config.getDigest()));
+        assertTrue(store.getLayers().isEmpty());
+    }
+}
@@ -0,0 +1,74 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.List;
+import java.util.Map;
+import land.oras.utils.Const;
+import land.oras.utils.FakeRegistry;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+/**
+ * Smoke tests also run as a native image with {@code mvn -Pnative test}. Only covers what needs reflection or
+ * other native-image configuration, the rest of the suite only runs on the JVM.
+ */
+@Execution(ExecutionMode.CONCURRENT)
+class NativeImageSmokeTest {
+
+    @TempDir
+    private Path dir;
+
+    @Test
+    void shouldSerializeModels() {
+        Manifest manifest = Manifest.empty()
+                .withLayers(List.of(Layer.fromDigest("sha256:%s".formatted("a".repeat(64)), 12)))
+                .withAnnotations(Map.of(Const.ANNOTATION_TITLE, "hello.txt"));
+        Index index = Index.fromManifests(List.of(ManifestDescriptor.of(manifest)));
+
+        // Assertion
+        assertEquals(manifest.toJson(), Manifest.fromJson(manifest.toJson()).toJson());
+        assertEquals(index.toJson(), Index.fromJson(index.toJson()).toJson());
+        assertEquals("hello.txt", Manifest.fromJson(manifest.toJson()).getAnnotations().get(Const.ANNOTATION_TITLE));
+    }
+
+    @Test
+    void shouldPushAndPull() throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Registry registry = Registry.builder().withInsecure(true).build();
+            ContainerRef ref = ContainerRef.parse("%s/library/smoke:1.0".formatted(fake.getRegistry()));
+            Path file = Files.writeString(dir.resolve("hello.txt"), "hello");
+            Path target = Files.createDirectory(dir.resolve("out"));
+
+            registry.pushArtifact(ref, LocalPath.of(file));
+            registry.pullArtifact(ref, target, false);
+
+            // Assertion
+            assertEquals("hello", Files.readString(target.resolve("hello.txt")));
+        }
+    }
+}
@@ -27,14 +27,21 @@ import java.io.InputStream;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
+import java.util.ArrayList;
 import java.util.List;
 import java.util.Map;
 import java.util.Set;
+import java.util.concurrent.CopyOnWriteArrayList;
+import java.util.concurrent.atomic.AtomicLong;
+import java.util.stream.Stream;
 import land.oras.exception.OrasException;
 import land.oras.policy.ContainersPolicy;
 import land.oras.utils.Const;
 import land.oras.utils.SupportedAlgorithm;
 import land.oras.utils.ZotContainer;
+import org.apache.commons.compress.archivers.tar.TarArchiveEntry;
+import org.apache.commons.compress.archivers.tar.TarArchiveInputStream;
+import org.apache.commons.compress.archivers.tar.TarArchiveOutputStream;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.io.TempDir;
 import org.junit.jupiter.api.parallel.Execution;
@@ -62,6 +69,183 @@ class OCILayoutTest {
     @Container
     private final ZotContainer registry = new ZotContainer().withStartupAttempts(3);
 
+    @Test
+    void shouldPackManifest() {
+        Path path = layoutPath.resolve("shouldPackManifest");
+        LayoutRef layoutRef = LayoutRef.parse("%s:packed".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+
+        Manifest manifest = ociLayout.packManifest(
+                layoutRef, ArtifactType.from("application/vnd.test.packed"), OCI.PackOptions.defaults());
+
+        // Assertion
+        assertEquals("application/vnd.test.packed", manifest.getArtifactType().getMediaType());
+        assertEquals(Const.DEFAULT_EMPTY_MEDIA_TYPE, manifest.getConfig().getMediaType());
+        assertEquals(1, manifest.getLayers().size());
+        assertEquals(Const.DEFAULT_EMPTY_MEDIA_TYPE, manifest.getLayers().get(0).getMediaType());
+        assertNotNull(manifest.getAnnotations().get(Const.ANNOTATION_CREATED));
+        assertBlobExists(path, manifest.getDescriptor().getDigest());
+        assertBlobExists(path, manifest.getConfig().getDigest());
+    }
+
+    @Test
+    void shouldPushArtifactWithAnnotationFile() throws IOException {
+        Path path = layoutPath.resolve("shouldPushArtifactWithAnnotationFile");
+        LayoutRef layoutRef = LayoutRef.parse("%s:annotated".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        Path file = Files.writeString(blobDir.resolve("cake.txt"), "cake");
+        Path annotationFile = Files.writeString(
+                blobDir.resolve("annotations.json"),
+                """
+                {
+                  "$config": {"hello": "world"},
+                  "$manifest": {"foo": "bar"},
+                  "cake.txt": {"%s": "cake.txt", "fun": "more cream"}
+                }
+                """
+                        .formatted(Const.ANNOTATION_TITLE));
+
+        Manifest manifest = ociLayout.pushArtifact(
+                layoutRef,
+                ArtifactType.from("application/vnd.test.annotated"),
+                Annotations.fromPath(annotationFile),
+                LocalPath.of(file, "text/plain"));
+
+        // Assertion
+        assertEquals("bar", manifest.getAnnotations().get("foo"));
+        assertEquals("world", manifest.getConfig().getAnnotations().get("hello"));
+        Layer layer = manifest.getLayers().get(0);
+        assertEquals("more cream", layer.getAnnotations().get("fun"));
+        assertEquals("cake.txt", layer.getAnnotations().get(Const.ANNOTATION_TITLE));
+    }
+
+    @Test
+    void shouldPushArtifactFromFileStore() throws IOException {
+        Path path = layoutPath.resolve("shouldPushArtifactFromFileStore");
+        LayoutRef layoutRef = LayoutRef.parse("%s:store".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        Path files = Files.createDirectories(path.resolveSibling("shouldPushArtifactFromFileStoreFiles"));
+        Path json = Files.writeString(files.resolve("values.json"), "{}");
+        Path copy = Files.writeString(files.resolve("copy.json"), "{}");
+
+        Manifest manifest;
+        try (FileStore store = FileStore.create()) {
+            store.add(json);
+            store.add(copy);
+            manifest = ociLayout.pushArtifact(
+                    layoutRef, ArtifactType.from("application/vnd.test.store"), Annotations.empty(), store);
+        }
+
+        // Assertion
+        assertEquals(2, manifest.getLayers().size());
+        assertEquals(Const.DEFAULT_JSON_MEDIA_TYPE, manifest.getLayers().get(0).getMediaType());
+        String digest = manifest.getLayers().get(0).getDigest();
+        assertEquals(digest, manifest.getLayers().get(1).getDigest());
+        assertBlobExists(path, digest);
+        assertEquals("{}", new String(ociLayout.getBlob(layoutRef.withDigest(digest))));
+    }
+
+    @Test
+    void shouldPushConfigOnlyArtifactFromMemoryStore() {
+        Path path = layoutPath.resolve("shouldPushConfigOnlyArtifactFromMemoryStore");
+        LayoutRef layoutRef = LayoutRef.parse("%s:memory".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        MemoryStore store = MemoryStore.create();
+        Config config = store.setConfig("{\"foo\":\"bar\"}", "application/vnd.test.config.v1+json");
+
+        Manifest manifest = ociLayout.pushArtifact(
+                layoutRef, ArtifactType.from("application/vnd.test.memory"), Annotations.empty(), store);
+
+        // Assertion
+        assertEquals(config.getDigest(), manifest.getConfig().getDigest());
+        assertEquals("application/vnd.test.config.v1+json", manifest.getConfig().getMediaType());
+        assertEquals(1, manifest.getLayers().size());
+        assertEquals(Const.DEFAULT_EMPTY_MEDIA_TYPE, manifest.getLayers().get(0).getMediaType());
+        assertBlobExists(path, config.getDigest());
+        assertEquals(
+                "{\"foo\":\"bar\"}",
+                new String(ociLayout.getBlob(layoutRef.withDigest(config.getDigest())), StandardCharsets.UTF_8));
+    }
+
+    @Test
+    void shouldAttachArtifactWithoutFiles() {
+        Path path = layoutPath.resolve("shouldAttachArtifactWithoutFiles");
+        LayoutRef layoutRef = LayoutRef.parse("%s:subject".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        Manifest subject = ociLayout.packManifest(
+                layoutRef, ArtifactType.from("application/vnd.test.subject"), OCI.PackOptions.defaults());
+
+        Manifest attached = ociLayout.attachArtifact(layoutRef, ArtifactType.from("application/vnd.test.attached"));
+
+        // Assertion
+        assertEquals(subject.getDescriptor().getDigest(), attached.getSubject().getDigest());
+        assertEquals(1, attached.getLayers().size());
+        assertEquals(Const.DEFAULT_EMPTY_MEDIA_TYPE, attached.getLayers().get(0).getMediaType());
+        assertNotNull(attached.getAnnotations().get(Const.ANNOTATION_CREATED));
+        assertBlobExists(path, attached.getConfig().getDigest());
+        assertEquals(1, ociLayout.getReferrers(layoutRef, null).getManifests().size());
+    }
+
+    @Test
+    void shouldTraverseContentGraph() throws IOException {
+        Path path = layoutPath.resolve("shouldTraverseContentGraph");
+        LayoutRef layoutRef = LayoutRef.parse("%s:graph".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        Path file = Files.writeString(blobDir.resolve("graph.txt"), "graph");
+        Manifest manifest = ociLayout.pushArtifact(
+                layoutRef, ArtifactType.from("application/vnd.test.graph"), LocalPath.of(file, "text/plain"));
+        Manifest attached = ociLayout.attachArtifact(layoutRef, ArtifactType.from("application/vnd.test.attached"));
+        Index index = ociLayout.pushIndex(
+                layoutRef.withTag("graph-index"), Index.fromManifests(List.of(manifest.getDescriptor())));
+
+        // Assertion
+        List<ManifestDescriptor> successors = ociLayout.getSuccessors(layoutRef, manifest.getDescriptor());
+        assertEquals(2, successors.size());
+        assertEquals(manifest.getConfig().getDigest(), successors.get(0).getDigest());
+        assertEquals(manifest.getLayers().get(0).getDigest(), successors.get(1).getDigest());
+
+        List<ManifestDescriptor> attachedSuccessors = ociLayout.getSuccessors(layoutRef, attached.getDescriptor());
+        assertEquals(
+                manifest.getDescriptor().getDigest(),
+                attachedSuccessors.get(attachedSuccessors.size() - 1).getDigest(),
+                "Subject should be a successor");
+
+        List<ManifestDescriptor> indexSuccessors = ociLayout.getSuccessors(layoutRef, index.getDescriptor());
+        assertEquals(1, indexSuccessors.size());
+        assertEquals(manifest.getDescriptor().getDigest(), indexSuccessors.get(0).getDigest());
+
+        List<ManifestDescriptor> predecessors = ociLayout.getPredecessors(layoutRef, manifest.getDescriptor());
+        assertEquals(1, predecessors.size());
+        assertEquals(attached.getDescriptor().getDigest(), predecessors.get(0).getDigest());
+
+        // Blobs have no successors
+        assertEquals(0, ociLayout.getSuccessors(layoutRef, successors.get(1)).size());
+    }
+
+    @Test
+    void shouldValidatePackedManifest() {
+        OrasException exception = assertThrows(
+                OrasException.class, () -> OCI.buildPackedManifest(null, OCI.PackOptions.defaults()));
+        assertEquals("Artifact type is required when the config is empty", exception.getMessage());
+
+        OCI.PackOptions invalidCreated =
+                OCI.PackOptions.defaults().withAnnotations(Map.of(Const.ANNOTATION_CREATED, "yesterday"));
+        exception = assertThrows(
+                OrasException.class,
+                () -> OCI.buildPackedManifest(ArtifactType.from("application/vnd.test"), invalidCreated));
+        assertTrue(exception.getMessage().startsWith("Invalid date and time format"));
+
+        // Created annotation is kept and artifact type is optional with a non empty config
+        Config config = Config.fromBlob("application/vnd.test.config.v1+json", Layer.fromDigest("sha256:abc", 10));
+        Manifest manifest = OCI.buildPackedManifest(
+                null,
+                OCI.PackOptions.defaults()
+                        .withConfig(config)
+                        .withAnnotations(Map.of(Const.ANNOTATION_CREATED, "2024-01-01T00:00:00Z")));
+        assertEquals("2024-01-01T00:00:00Z", manifest.getAnnotations().get(Const.ANNOTATION_CREATED));
+        assertEquals("application/vnd.test.config.v1+json", manifest.getConfig().getMediaType());
+    }
+
     @Test
     void shouldPushEmptyManifest() {
         Path path = layoutPath.resolve("shouldPushManifest");
@@ -725,6 +909,164 @@ class OCILayoutTest {
         assertBlobContent(path, digest, "hi");
     }
 
+    @Test
+    void shouldNotifyTransferListener() throws IOException {
+
+        Path path = layoutPath.resolve("shouldNotifyTransferListener");
+        Path blob = blobDir.resolve("listener.txt");
+        Files.writeString(blob, "hello");
+        String digest = SupportedAlgorithm.SHA256.digest(blob);
+
+        List<String> events = new ArrayList<>();
+        AtomicLong transferred = new AtomicLong();
+        TransferListener listener = new TransferListener() {
+            @Override
+            public void onStarted(String digest, long totalSize) {
+                events.add("started:%s:%d".formatted(digest, totalSize));
+            }
+
+            @Override
+            public void onProgress(String digest, long bytesTransferred, long totalSize) {
+                transferred.set(bytesTransferred);
+            }
+
+            @Override
+            public void onCompleted(String digest, long totalSize) {
+                events.add("completed:%s:%d".formatted(digest, totalSize));
+            }
+        };
+
+        OCILayout ociLayout = OCILayout.Builder.builder()
+                .defaults(path)
+                .withTransferListener(listener)
+                .build();
+        ociLayout.pushBlob(LayoutRef.of(ociLayout, digest), blob);
+
+        assertBlobContent(path, digest, "hello");
+        assertEquals(5, transferred.get());
+        assertEquals(List.of("started:%s:5".formatted(digest), "completed:%s:5".formatted(digest)), events);
+    }
+
+    @Test
+    void shouldNotifyOperationListener() throws IOException {
+
+        Path path = layoutPath.resolve("shouldNotifyOperationListener");
+        Path targetPath = layoutPath.resolve("shouldNotifyOperationListenerTarget");
+        Path file = blobDir.resolve("operation.txt");
+        Files.writeString(file, "operation");
+        String digest = SupportedAlgorithm.SHA256.digest(file);
+
+        List<String> events = new CopyOnWriteArrayList<>();
+        OperationListener listener = new OperationListener() {
+            @Override
+            public void onResolved(Ref<?> ref, Descriptor descriptor) {
+                events.add("resolved:%s".formatted(descriptor.getDigest()));
+            }
+
+            @Override
+            public void onBlobPushed(Ref<?> ref, Layer layer) {
+                events.add("pushed:%s".formatted(layer.getDigest()));
+            }
+
+            @Override
+            public void onBlobSkipped(Ref<?> ref, String digest) {
+                events.add("skipped:%s".formatted(digest));
+            }
+
+            @Override
+            public void onManifestPushed(Ref<?> ref, Manifest manifest) {
+                events.add("manifest:%s".formatted(manifest.getDigest()));
+            }
+
+            @Override
+            public void onCopyStarted(Ref<?> sourceRef, Ref<?> targetRef) {
+                events.add("copy-started");
+            }
+
+            @Override
+            public void onCopyCompleted(Ref<?> sourceRef, Ref<?> targetRef) {
+                events.add("copy-completed");
+            }
+        };
+
+        OCILayout ociLayout = OCILayout.Builder.builder()
+                .defaults(path)
+                .withOperationListener(listener)
+                .build();
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path.toString()));
+        Manifest manifest = ociLayout.pushArtifact(
+                ref, ArtifactType.from("foo/bar"), Annotations.empty(), LocalPath.of(file, "text/plain"));
+
+        // Assertion
+        assertTrue(events.contains("pushed:%s".formatted(digest)), events.toString());
+        assertTrue(events.contains("manifest:%s".formatted(manifest.getDigest())), events.toString());
+        assertFalse(events.contains("skipped:%s".formatted(digest)), events.toString());
+
+        // Blobs are skipped on second push
+        events.clear();
+        ociLayout.pushArtifact(
+                ref, ArtifactType.from("foo/bar"), Annotations.empty(), LocalPath.of(file, "text/plain"));
+        assertTrue(events.contains("skipped:%s".formatted(digest)), events.toString());
+        assertFalse(events.contains("pushed:%s".formatted(digest)), events.toString());
+
+        // Copy notifies the listener of the source
+        events.clear();
+        OCILayout targetLayout = OCILayout.Builder.builder().defaults(targetPath).build();
+        CopyUtils.copy(
+                ociLayout,
+                ref,
+                targetLayout,
+                LayoutRef.parse("%s:latest".formatted(targetPath.toString())),
+                CopyUtils.CopyOptions.shallow());
+        assertEquals("copy-started", events.get(0));
+        assertEquals("copy-completed", events.get(events.size() - 1));
+        assertTrue(events.stream().anyMatch(e -> e.startsWith("resolved:")), events.toString());
+    }
+
+    @Test
+    void shouldPullAllFilesAndManageTags() throws IOException {
+
+        Path path = layoutPath.resolve("shouldPullAllFilesAndManageTags");
+        Path file1 = blobDir.resolve("file1.txt");
+        Path file2 = blobDir.resolve("file2.txt");
+        Files.writeString(file1, "foo");
+        Files.writeString(file2, "bar");
+
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        Manifest manifest = ociLayout.pushArtifact(
+                ref,
+                ArtifactType.from("foo/bar"),
+                Annotations.empty(),
+                LocalPath.of(file1, "text/plain"),
+                LocalPath.of(file2, "text/plain"));
+
+        // All files are pulled
+        Path pullDir = extractDir.resolve("shouldPullAllFilesAndManageTags");
+        Files.createDirectories(pullDir);
+        ociLayout.pullArtifact(ref, pullDir, false);
+        assertEquals("foo", Files.readString(pullDir.resolve("file1.txt")));
+        assertEquals("bar", Files.readString(pullDir.resolve("file2.txt")));
+
+        // Tag existing manifest
+        ManifestDescriptor tagged = ociLayout.tag(ref, "v1");
+        assertEquals(manifest.getDescriptor().getDigest(), tagged.getDigest());
+        assertEquals(List.of("latest", "v1"), ociLayout.getTags(ref).tags());
+        assertEquals(List.of("v1"), ociLayout.getTags(ref, 10, "latest").tags());
+        assertEquals(
+                manifest.getDescriptor().getDigest(),
+                ociLayout.getManifest(ref.withTag("v1")).getDescriptor().getDigest());
+
+        // Delete tags
+        ociLayout.deleteTag(ref.withTag("latest"));
+        assertEquals(List.of("v1"), ociLayout.getTags(ref).tags());
+        ociLayout.deleteTag(ref.withTag("v1"));
+        assertEquals(List.of(), ociLayout.getTags(ref).tags());
+
+        // Manifest still reachable by digest
+        assertNotNull(ociLayout.getManifest(ref.withTag(manifest.getDescriptor().getDigest())));
+    }
+
     @Test
     void shouldFailToPushBlobViaStreamWithoutDigest() {
         Path path = layoutPath.resolve("shouldFailToPushBlobViaStreamWithoutDigest");
@@ -958,6 +1300,40 @@ class OCILayoutTest {
                 ociLayoutPath, "sha256:e094bc809626f0a401a40d75c56df478e546902ff812772c4594265203b23980"); // hi2.txt
     }
 
+    @Test
+    void testShouldCopyIndexWithReferrersGraph() throws IOException {
+
+        // Source with an index, a referrer of the index and a referrer of the referrer
+        Path sourcePath = layoutPath.resolve("testShouldCopyIndexWithReferrersGraphSource");
+        LayoutRef sourceRef = LayoutRef.parse("%s:multi".formatted(sourcePath.toString()));
+        OCILayout source = OCILayout.builder().defaults(sourcePath).build();
+        Path file = Files.writeString(blobDir.resolve("referrers-graph.txt"), "referrers graph");
+        Manifest manifest = source.pushArtifact(
+                sourceRef.withTag("app"), ArtifactType.from("application/vnd.test.app"), LocalPath.of(file));
+        Index index = source.pushIndex(sourceRef, Index.fromManifests(List.of(manifest.getDescriptor())));
+        Manifest sbom = source.attachArtifact(sourceRef, ArtifactType.from("application/spdx+json"));
+        Manifest signature = source.attachArtifact(
+                sourceRef.withDigest(sbom.getDescriptor().getDigest()),
+                ArtifactType.from("application/vnd.dev.cosign.artifact.sig.v1+json"));
+
+        // Target
+        Path targetPath = layoutPath.resolve("testShouldCopyIndexWithReferrersGraphTarget");
+        LayoutRef targetRef = LayoutRef.parse("%s:multi".formatted(targetPath.toString()));
+        OCILayout target = OCILayout.builder().defaults(targetPath).build();
+
+        CopyUtils.copy(source, sourceRef, target, targetRef, CopyUtils.CopyOptions.deep());
+
+        // Assertion
+        assertOciLayout(targetPath);
+        String indexDigest = index.getDescriptor().getDigest();
+        Referrers referrers = target.getReferrers(targetRef.withDigest(indexDigest), null);
+        assertEquals(1, referrers.getManifests().size());
+        assertEquals(sbom.getDescriptor().getDigest(), referrers.getManifests().get(0).getDigest());
+        Manifest copiedSignature = target.getManifest(targetRef.withDigest(signature.getDescriptor().getDigest()));
+        assertEquals(sbom.getDescriptor().getDigest(), copiedSignature.getSubject().getDigest());
+        assertBlobExists(targetPath, SupportedAlgorithm.SHA256.digest(file));
+    }
+
     @Test
     void testShouldCopyFromOciLayoutIntoOciLayoutNonRecursive() throws IOException {
 
@@ -1288,6 +1664,13 @@ class OCILayoutTest {
                 "Expect blob to be absent");
     }
 
+    private Path getBlobPath(Path ociLayoutPath, String digest) {
+        return ociLayoutPath
+                .resolve("blobs")
+                .resolve(SupportedAlgorithm.fromDigest(digest).getPrefix())
+                .resolve(SupportedAlgorithm.getDigest(digest));
+    }
+
     private void assertBlobContent(Path ociLayoutPath, String digest, String content) throws IOException {
         assertEquals(
                 content,
@@ -1431,6 +1814,64 @@ class OCILayoutTest {
         assertEquals("hello from tar", Files.readString(extracted));
     }
 
+    @Test
+    void shouldWriteAndReadOciArchive() throws IOException {
+        Path path = layoutPath.resolve("shouldWriteAndReadOciArchive");
+        Path artifactFile = blobDir.resolve("hello-archive.txt");
+        Files.writeString(artifactFile, "hello from archive");
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path.toString()));
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(path).build();
+        ociLayout.pushArtifact(ref, LocalPath.of(artifactFile, "text/plain"));
+
+        Path tarFile = layoutPath.resolve("archive.tar");
+        ociLayout.toTar(tarFile);
+
+        // Layout files come first
+        try (TarArchiveInputStream tar = new TarArchiveInputStream(Files.newInputStream(tarFile))) {
+            assertEquals(Const.OCI_LAYOUT_FILE, tar.getNextEntry().getName());
+            assertEquals(Const.OCI_LAYOUT_INDEX, tar.getNextEntry().getName());
+        }
+
+        // Read back into another directory
+        Path readPath = layoutPath.resolve("shouldWriteAndReadOciArchive-read");
+        OCILayout read;
+        try (InputStream is = Files.newInputStream(tarFile)) {
+            read = OCILayout.fromTar(is, readPath);
+        }
+        LayoutRef readRef = LayoutRef.parse("%s:latest".formatted(readPath.toString()));
+        assertEquals(
+                ociLayout.getManifest(ref).getDescriptor().getDigest(),
+                read.getManifest(readRef).getDescriptor().getDigest());
+        Path pullDir = extractDir.resolve("archive-pull-out");
+        Files.createDirectories(pullDir);
+        read.pullArtifact(readRef, pullDir, false);
+        assertEquals("hello from archive", Files.readString(pullDir.resolve("hello-archive.txt")));
+    }
+
+    @Test
+    void shouldRejectOciArchiveWithCorruptedBlob() throws IOException {
+        String digest = SupportedAlgorithm.SHA256.digest("expected".getBytes(StandardCharsets.UTF_8));
+        Path tarFile = layoutPath.resolve("corrupted.tar");
+        try (TarArchiveOutputStream tar = new TarArchiveOutputStream(Files.newOutputStream(tarFile))) {
+            byte[] data = "corrupted".getBytes(StandardCharsets.UTF_8);
+            TarArchiveEntry entry =
+                    new TarArchiveEntry("blobs/sha256/%s".formatted(SupportedAlgorithm.getDigest(digest)));
+            entry.setSize(data.length);
+            tar.putArchiveEntry(entry);
+            tar.write(data);
+            tar.closeArchiveEntry();
+        }
+        Path readPath = layoutPath.resolve("shouldRejectOciArchiveWithCorruptedBlob");
+        try (InputStream is = Files.newInputStream(tarFile)) {
+            assertThrows(OrasException.class, () -> OCILayout.fromTar(is, readPath));
+        }
+
+        // Assertion: neither the blob nor a temporary file is left in the layout
+        try (Stream<Path> files = Files.walk(readPath)) {
+            assertEquals(List.of(), files.filter(Files::isRegularFile).toList());
+        }
+    }
+
     @Test
     void shouldListTagsFromTarBackedLayout() throws IOException {
         // Open the pre-built artifact.tar fixture
@@ -1651,6 +2092,43 @@ class OCILayoutTest {
         assertBlobExists(ociLayoutPath, SupportedAlgorithm.SHA256.digest(artifactFile));
     }
 
+    @Test
+    void shouldGarbageCollectUntaggedManifests() throws IOException {
+        Path ociLayoutPath = layoutPath.resolve("gc-untagged");
+        Path keptFile = blobDir.resolve("gc-untagged-kept.txt");
+        Files.writeString(keptFile, "kept");
+        Path untaggedFile = blobDir.resolve("gc-untagged-removed.txt");
+        Files.writeString(untaggedFile, "untagged-content");
+
+        OCILayout ociLayout =
+                OCILayout.Builder.builder().defaults(ociLayoutPath).build();
+        LayoutRef keptRef = LayoutRef.parse("%s:kept".formatted(ociLayoutPath.toString()));
+        LayoutRef untaggedRef = LayoutRef.parse("%s:untagged".formatted(ociLayoutPath.toString()));
+        ociLayout.pushArtifact(keptRef, LocalPath.of(keptFile, "text/plain"));
+        Manifest untagged = ociLayout.pushArtifact(untaggedRef, LocalPath.of(untaggedFile, "text/plain"));
+        Manifest referrer = ociLayout.attachArtifact(keptRef, ArtifactType.from("application/vnd.test.signature"));
+        ociLayout.deleteTag(untaggedRef);
+
+        // Without removing untagged manifests nothing is collected
+        OCILayout.GarbageCollectionResult result = ociLayout.gc();
+        assertTrue(result.removed().isEmpty());
+        assertEquals(0, result.freedBytes());
+
+        String untaggedDigest = untagged.getDescriptor().getDigest();
+        String untaggedLayer = SupportedAlgorithm.SHA256.digest(untaggedFile);
+        long expectedFreed = Files.size(getBlobPath(ociLayoutPath, untaggedDigest))
+                + Files.size(getBlobPath(ociLayoutPath, untaggedLayer));
+        result = ociLayout.gc(true);
+
+        // Assertions
+        assertEquals(Set.of(untaggedDigest, untaggedLayer), Set.copyOf(result.removed()));
+        assertEquals(expectedFreed, result.freedBytes());
+        assertBlobAbsent(ociLayoutPath, untaggedDigest);
+        assertBlobExists(ociLayoutPath, SupportedAlgorithm.SHA256.digest(keptFile));
+        assertBlobExists(ociLayoutPath, referrer.getDescriptor().getDigest());
+        assertEquals(1, ociLayout.getReferrers(keptRef, null).getManifests().size());
+    }
+
     @Test
     void shouldGarbageCollectMultipleOrphanedBlobs() throws IOException {
         Path ociLayoutPath = layoutPath.resolve("gc-multi-orphan");
@@ -0,0 +1,88 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertInstanceOf;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.util.List;
+import java.util.concurrent.CompletionException;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Executors;
+import java.util.concurrent.atomic.AtomicInteger;
+import java.util.function.Supplier;
+import java.util.stream.IntStream;
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class OCITest {
+
+    @Test
+    void shouldLimitConcurrency() {
+        ExecutorService executorService = Executors.newFixedThreadPool(8);
+        AtomicInteger running = new AtomicInteger();
+        AtomicInteger maxRunning = new AtomicInteger();
+        List<Supplier<Integer>> tasks = IntStream.range(0, 10)
+                .<Supplier<Integer>>mapToObj(i -> () -> {
+                    maxRunning.accumulateAndGet(running.incrementAndGet(), Math::max);
+                    try {
+                        Thread.sleep(20);
+                    } catch (InterruptedException e) {
+                        Thread.currentThread().interrupt();
+                    }
+                    running.decrementAndGet();
+                    return i;
+                })
+                .toList();
+        try {
+            List<Integer> results = OCI.runConcurrently(executorService, 2, tasks);
+
+            // Assertion
+            assertEquals(IntStream.range(0, 10).boxed().toList(), results);
+            assertTrue(maxRunning.get() <= 2, "At most 2 tasks should run at the same time");
+        } finally {
+            executorService.shutdownNow();
+        }
+    }
+
+    @Test
+    void shouldPropagateTaskFailure() {
+        ExecutorService executorService = Executors.newFixedThreadPool(2);
+        List<Supplier<String>> tasks = List.of(() -> "ok", () -> {
+            throw new OrasException("Failed layer");
+        });
+        try {
+            CompletionException e = assertThrows(
+                    CompletionException.class, () -> OCI.runConcurrently(executorService, 0, tasks));
+
+            // Assertion
+            assertInstanceOf(OrasException.class, e.getCause());
+            assertEquals("Failed layer", e.getCause().getMessage());
+        } finally {
+            executorService.shutdownNow();
+        }
+    }
+}
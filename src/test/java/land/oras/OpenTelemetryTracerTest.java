@@ -0,0 +1,124 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+
+import com.github.tomakehurst.wiremock.client.WireMock;
+import com.github.tomakehurst.wiremock.junit5.WireMockRuntimeInfo;
+import com.github.tomakehurst.wiremock.junit5.WireMockTest;
+import io.opentelemetry.api.trace.StatusCode;
+import io.opentelemetry.api.trace.propagation.W3CTraceContextPropagator;
+import io.opentelemetry.context.propagation.ContextPropagators;
+import io.opentelemetry.sdk.OpenTelemetrySdk;
+import io.opentelemetry.sdk.testing.exporter.InMemorySpanExporter;
+import io.opentelemetry.sdk.trace.SdkTracerProvider;
+import io.opentelemetry.sdk.trace.data.SpanData;
+import io.opentelemetry.sdk.trace.export.SimpleSpanProcessor;
+import java.util.List;
+import land.oras.auth.NoAuthProvider;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@WireMockTest
+@Execution(ExecutionMode.SAME_THREAD)
+class OpenTelemetryTracerTest {
+
+    private final InMemorySpanExporter exporter = InMemorySpanExporter.create();
+
+    private final OpenTelemetrySdk openTelemetry = OpenTelemetrySdk.builder()
+            .setTracerProvider(SdkTracerProvider.builder()
+                    .addSpanProcessor(SimpleSpanProcessor.create(exporter))
+                    .build())
+            .setPropagators(ContextPropagators.create(W3CTraceContextPropagator.getInstance()))
+            .build();
+
+    @Test
+    void shouldTraceResolveAndPropagateContext(WireMockRuntimeInfo wmRuntimeInfo) {
+        // language=json
+        String manifestJson =
+                """
+                {"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json",\
+                "config":{"mediaType":"application/vnd.oci.empty.v1+json",\
+                "digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},\
+                "layers":[]}""";
+        String digest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes());
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.head(WireMock.urlEqualTo("/v2/library/traced/manifests/v1"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)));
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/traced/manifests/v1"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)
+                        .withBody(manifestJson)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(new NoAuthProvider())
+                .withInsecure(true)
+                .withOperationTracer(OpenTelemetryTracer.of(openTelemetry))
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/traced:v1".formatted(wmRuntimeInfo.getHttpPort()));
+        registry.getDescriptor(containerRef);
+
+        // Assertion
+        List<SpanData> spans = exporter.getFinishedSpanItems();
+        assertEquals(1, spans.size());
+        SpanData span = spans.get(0);
+        assertEquals("oras resolve", span.getName());
+        assertEquals(OperationTracer.RESOLVE, span.getAttributes().get(OpenTelemetryTracer.OPERATION));
+        assertEquals("library/traced", span.getAttributes().get(OpenTelemetryTracer.REPOSITORY));
+        assertEquals(digest, span.getAttributes().get(OpenTelemetryTracer.DIGEST));
+        assertEquals(Long.valueOf(manifestJson.length()), span.getAttributes().get(OpenTelemetryTracer.BYTES));
+        wireMock.verifyThat(WireMock.getRequestedFor(WireMock.urlEqualTo("/v2/library/traced/manifests/v1"))
+                .withHeader("traceparent", WireMock.containing(span.getSpanContext().getTraceId())));
+    }
+
+    @Test
+    void shouldRecordFailedOperation(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.any(WireMock.urlEqualTo("/v2/library/traced-missing/manifests/v1"))
+                .willReturn(WireMock.aResponse().withStatus(404)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(new NoAuthProvider())
+                .withInsecure(true)
+                .withOperationTracer(OpenTelemetryTracer.of(openTelemetry))
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/traced-missing:v1".formatted(wmRuntimeInfo.getHttpPort()));
+        assertThrows(OrasException.class, () -> registry.getDescriptor(containerRef));
+
+        // Assertion
+        List<SpanData> spans = exporter.getFinishedSpanItems();
+        assertEquals(1, spans.size());
+        assertEquals(StatusCode.ERROR, spans.get(0).getStatus().getStatusCode());
+    }
+}
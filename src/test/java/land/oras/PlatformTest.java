@@ -76,7 +76,7 @@ class PlatformTest {
         Platform platform = Platform.linuxAmd64();
         String json = JsonUtils.toJson(platform);
         // language=json
-        String expected = "{\"os\":\"linux\",\"architecture\":\"amd64\"}";
+        String expected = "{\"architecture\":\"amd64\",\"os\":\"linux\"}";
         assertEquals(expected, json);
     }
 
@@ -87,7 +87,7 @@ class PlatformTest {
         String json = JsonUtils.toJson(platform);
         // language=json
         String expected =
-                "{\"os\":\"linux\",\"architecture\":\"amd64\",\"os.features\":[\"linux-gnu\"],\"features\":[\"sse4\",\"avx2\"]}";
+                "{\"architecture\":\"amd64\",\"os\":\"linux\",\"os.features\":[\"linux-gnu\"],\"features\":[\"sse4\",\"avx2\"]}";
         assertEquals(expected, json);
     }
 
@@ -0,0 +1,91 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+
+import java.time.Instant;
+import java.util.Map;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class ProvenanceTest {
+
+    @Test
+    void shouldStampProvenanceAnnotations() {
+        Provenance provenance = Provenance.empty()
+                .withCreated(Instant.parse("2024-05-01T10:15:30.123Z"))
+                .withAuthors("ORAS maintainers")
+                .withSource("https://github.com/oras-project/oras-java")
+                .withRevision("abc123")
+                .withAnnotationProvider(
+                        manifest -> Map.of("com.example.layers", String.valueOf(manifest.getLayers().size())));
+
+        Manifest manifest = OCI.buildPackedManifest(
+                ArtifactType.from("application/vnd.test"),
+                OCI.PackOptions.defaults().withProvenance(provenance));
+
+        // Assertion
+        Map<String, String> annotations = manifest.getAnnotations();
+        assertEquals("2024-05-01T10:15:30Z", annotations.get(Const.ANNOTATION_CREATED));
+        assertEquals("ORAS maintainers", annotations.get(Const.ANNOTATION_AUTHORS));
+        assertEquals("https://github.com/oras-project/oras-java", annotations.get(Const.ANNOTATION_SOURCE));
+        assertEquals("abc123", annotations.get(Const.ANNOTATION_REVISION));
+        assertEquals("1", annotations.get("com.example.layers"));
+    }
+
+    @Test
+    void shouldKeepExplicitAnnotations() {
+        Provenance provenance = Provenance.empty()
+                .withCreated(Instant.EPOCH)
+                .withRevision("abc123")
+                .withAnnotationProvider(manifest -> Map.of(Const.ANNOTATION_REVISION, "def456"));
+
+        Manifest manifest = OCI.buildPackedManifest(
+                ArtifactType.from("application/vnd.test"),
+                OCI.PackOptions.defaults()
+                        .withAnnotations(Map.of(Const.ANNOTATION_CREATED, "2024-01-01T00:00:00Z"))
+                        .withProvenance(provenance));
+
+        // Assertion
+        assertEquals("2024-01-01T00:00:00Z", manifest.getAnnotations().get(Const.ANNOTATION_CREATED));
+        assertEquals("def456", manifest.getAnnotations().get(Const.ANNOTATION_REVISION));
+    }
+
+    @Test
+    void shouldReadSourceDateEpoch() {
+        // Assertion
+        assertEquals(
+                Instant.ofEpochSecond(1700000000),
+                Provenance.fromEnvironment(Map.of(Provenance.SOURCE_DATE_EPOCH, "1700000000"))
+                        .getCreated());
+        assertNull(Provenance.fromEnvironment(Map.of()).getCreated());
+        assertThrows(
+                OrasException.class,
+                () -> Provenance.fromEnvironment(Map.of(Provenance.SOURCE_DATE_EPOCH, "yesterday")));
+    }
+}
@@ -30,6 +30,7 @@ import java.io.InputStream;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
+import land.oras.auth.RegistriesConf;
 import land.oras.utils.ZotUnsecureContainer;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.io.TempDir;
@@ -94,6 +95,29 @@ class RegistryMirrorTest {
         });
     }
 
+    @Test
+    void shouldFetchManifestViaBuilderMirrorWhenOriginalIsDown(@TempDir Path blobDir) throws Exception {
+
+        // Push a test artifact to the working mirror
+        String mirrorRegistry = mirrorUp.getRegistry();
+        Registry setupRegistry = Registry.builder().insecure(mirrorRegistry).build();
+        Path testFile = createTestFile(blobDir, "builder-mirror-test.txt", "builder mirror content");
+        ContainerRef mirrorArtifact = ContainerRef.parse(mirrorRegistry + "/test/builder-mirror-artifact:v1");
+        setupRegistry.pushArtifact(mirrorArtifact, LocalPath.of(testFile));
+
+        // Same setup as registries.conf but configured on the builder
+        Registry registry = Registry.builder()
+                .insecure()
+                .withMirror("localhost:59998", new RegistriesConf.MirrorConfig("localhost:59999", true, null))
+                .withMirror("localhost:59998", new RegistriesConf.MirrorConfig(mirrorRegistry, true, null))
+                .build();
+        ContainerRef ref = ContainerRef.parse("localhost:59998/test/builder-mirror-artifact:v1");
+        Manifest manifest = registry.getManifest(ref);
+        assertNotNull(manifest, "Manifest should be fetched via the working mirror");
+        Descriptor descriptor = registry.probeDescriptor(ref);
+        assertNotNull(descriptor.getDigest(), "Tag should be resolved via the working mirror");
+    }
+
     @Test
     void shouldPullArtifactViaMirrorWhenOriginalIsDown(@TempDir Path blobDir, @TempDir Path pullDir) throws Exception {
 
@@ -29,18 +29,25 @@ import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
 import java.nio.file.attribute.FileTime;
+import java.security.KeyPair;
+import java.security.cert.X509Certificate;
 import java.util.List;
 import java.util.Map;
 import java.util.Random;
 import java.util.Set;
+import java.util.concurrent.CompletionException;
 import java.util.concurrent.ExecutorService;
 import java.util.concurrent.Executors;
+import land.oras.auth.NoAuthProvider;
 import land.oras.exception.OrasException;
 import land.oras.policy.ContainersPolicy;
+import land.oras.signing.LocalSigningKey;
+import land.oras.signing.NotationSigner;
 import land.oras.utils.Const;
 import land.oras.utils.RegistryContainer;
 import land.oras.utils.SupportedAlgorithm;
 import land.oras.utils.SupportedCompression;
+import land.oras.utils.TlsUtils;
 import land.oras.utils.ZotContainer;
 import land.oras.utils.ZotUnsecureContainer;
 import org.junit.jupiter.api.BeforeEach;
@@ -392,6 +399,42 @@ class RegistryTest {
         });
     }
 
+    @Test
+    void shouldPushAndPullArtifactAsync() throws IOException {
+        Registry registry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/artifact-async".formatted(this.registry.getRegistry()));
+        Path file1 = blobDir.resolve("async.txt");
+        Files.writeString(file1, "foobar");
+
+        // Push
+        Manifest manifest = registry.pushArtifactAsync(containerRef, LocalPath.of(file1)).join();
+        assertNotNull(manifest);
+
+        // Get manifest
+        Manifest fetched = registry.getManifestAsync(containerRef).join();
+        assertEquals(manifest.getLayers().size(), fetched.getLayers().size());
+
+        // Pull
+        registry.pullArtifactAsync(containerRef, artifactDir, OCI.PullOptions.overwrite()).join();
+        assertEquals("foobar", Files.readString(artifactDir.resolve("async.txt")));
+
+        // Push blob
+        Layer layer = registry.pushBlobAsync(containerRef, "hello".getBytes()).join();
+        assertEquals("sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", layer.getDigest());
+
+        // Failure is propagated
+        CompletionException e = assertThrows(
+                CompletionException.class,
+                () -> registry.getManifestAsync(containerRef.withDigest(
+                                "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"))
+                        .join());
+        assertInstanceOf(OrasException.class, e.getCause());
+    }
+
     @Test
     void shouldPushAndGetBlobThenDeleteWithSha512() {
         Registry registry = Registry.Builder.builder()
@@ -644,6 +687,36 @@ class RegistryTest {
         });
     }
 
+    @Test
+    void shouldPushManifestWithMultipleTags() {
+        Registry registry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .build();
+
+        ContainerRef containerRef = ContainerRef.parse("%s/library/multi-tags".formatted(this.registry.getRegistry()));
+        List<ContainerRef> refs =
+                registry.pushManifest(containerRef, Manifest.empty(), List.of("1.2.3", "1.2", "latest", "1.2"));
+
+        // Assert
+        assertEquals(3, refs.size());
+        assertEquals("1.2.3", refs.get(0).getTag());
+        assertEquals("1.2", refs.get(1).getTag());
+        assertEquals("latest", refs.get(2).getTag());
+        String digest = registry.getManifest(refs.get(0)).getDescriptor().getDigest();
+        for (ContainerRef ref : refs) {
+            assertEquals(digest, registry.getManifest(ref).getDescriptor().getDigest());
+        }
+        List<String> tags = registry.getTags(containerRef).tags();
+        assertTrue(tags.containsAll(List.of("1.2.3", "1.2", "latest")));
+
+        // Digest references cannot be tagged
+        assertThrows(
+                OrasException.class,
+                () -> registry.pushManifest(containerRef.withDigest(digest), Manifest.empty(), List.of("latest")));
+        assertThrows(OrasException.class, () -> registry.pushManifest(containerRef, Manifest.empty(), List.of()));
+    }
+
     @Test
     @Execution(ExecutionMode.SAME_THREAD)
     void shouldPushManifestWithRegistryConfig(@TempDir Path homeDir) throws Exception {
@@ -1043,6 +1116,38 @@ class RegistryTest {
         });
     }
 
+    @Test
+    void shouldSignPushedArtifacts() throws IOException {
+        KeyPair keyPair = TlsUtils.generateKeyPair();
+        X509Certificate certificate = TlsUtils.generateCaCertificate("oras-test", keyPair);
+        NotationSigner signer = NotationSigner.of(LocalSigningKey.of(keyPair.getPrivate(), List.of(certificate)));
+        Registry registry = Registry.Builder.builder()
+                .defaults()
+                .withInsecure(true)
+                .withSigner(signer)
+                .build();
+
+        Path file = blobDir.resolve("signed.txt");
+        Files.writeString(file, "signed");
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/signed:latest".formatted(this.unsecureRegistry.getRegistry()));
+        Manifest manifest = registry.pushArtifact(containerRef, LocalPath.of(file));
+
+        Referrers referrers = registry.getReferrers(
+                containerRef.withDigest(manifest.getDescriptor().getDigest()),
+                ArtifactType.from(Const.NOTATION_SIGNATURE_ARTIFACT_TYPE));
+
+        // Assertion
+        assertEquals(1, referrers.getManifests().size(), "Should have one signature");
+        ManifestDescriptor signatureDescriptor = referrers.getManifests().get(0);
+        Manifest signature = registry.getManifest(containerRef.withDigest(signatureDescriptor.getDigest()));
+        assertEquals(Const.NOTATION_SIGNATURE_ARTIFACT_TYPE, signature.getArtifactTypeAsString());
+        assertEquals(1, signature.getLayers().size());
+        assertEquals(Const.NOTATION_JWS_MEDIA_TYPE, signature.getLayers().get(0).getMediaType());
+        assertEquals(manifest.getDescriptor().getDigest(), signature.getSubject().getDigest());
+        assertNotNull(signature.getAnnotations().get(Const.ANNOTATION_NOTATION_THUMBPRINT));
+    }
+
     @Test
     void testShouldFailReferrerWithoutDigest() {
         Registry registry = Registry.Builder.builder()
@@ -1183,6 +1288,34 @@ class RegistryTest {
         }
     }
 
+    @Test
+    void testShouldStreamCopyBetweenRegistriesWithSeparateAuth() throws IOException {
+        Registry sourceRegistry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .build();
+
+        ContainerRef containerSource =
+                ContainerRef.parse("%s/library/artifact-stream-source:foo".formatted(this.registry.getRegistry()));
+        Path file1 = blobDir.resolve("stream-source.txt");
+        Files.writeString(file1, "streamed");
+        sourceRegistry.pushArtifact(containerSource, LocalPath.of(file1));
+
+        // Target registry without authentication
+        try (RegistryContainer otherRegistryContainer = new RegistryContainer()) {
+            otherRegistryContainer.start();
+            Registry targetRegistry = Registry.Builder.builder()
+                    .withAuthProvider(new NoAuthProvider())
+                    .withInsecure(true)
+                    .build();
+            ContainerRef containerTarget = ContainerRef.parse(
+                    "%s/library/artifact-stream-target:bar".formatted(otherRegistryContainer.getRegistry()));
+            CopyUtils.copy(sourceRegistry, containerSource, targetRegistry, containerTarget);
+            targetRegistry.pullArtifact(containerTarget, artifactDir, true);
+            assertEquals("streamed", Files.readString(artifactDir.resolve("stream-source.txt")));
+        }
+    }
+
     @Test
     void testShouldCopySingleFromDigest() throws IOException {
         // Copy to same registry
@@ -1380,6 +1513,50 @@ class RegistryTest {
         assertTrue(Files.exists(extractPath.resolve("hi2.txt")), "hi2.txt should exist");
     }
 
+    @Test
+    void shouldRoundTripArtifactThroughOciLayout(@TempDir Path layoutDir) throws IOException {
+        Registry registry = Registry.builder().withInsecure(true).build();
+        ContainerRef sourceRef =
+                ContainerRef.parse("%s/library/round-trip-source:v1".formatted(this.unsecureRegistry.getRegistry()));
+        ContainerRef targetRef =
+                ContainerRef.parse("%s/library/round-trip-target:v1".formatted(this.unsecureRegistry.getRegistry()));
+        Path file = blobDir.resolve("round-trip.txt");
+        Path signatureFile = blobDir.resolve("round-trip.sig");
+        Files.writeString(file, "air-gapped");
+        Files.writeString(signatureFile, "signature");
+        Annotations annotations = Annotations.ofManifest(Map.of("com.example.release", "1.0"))
+                .withFileAnnotations("round-trip.txt", Map.of("com.example.file", "data"));
+        Manifest manifest = registry.pushArtifact(
+                sourceRef, ArtifactType.from("application/vnd.example"), annotations, LocalPath.of(file));
+        Manifest referrer = registry.attachArtifact(
+                sourceRef, ArtifactType.from("application/vnd.example.signature"), LocalPath.of(signatureFile));
+
+        // Registry to layout, then layout to registry
+        OCILayout ociLayout = OCILayout.Builder.builder().defaults(layoutDir).build();
+        LayoutRef layoutRef = LayoutRef.parse("%s:v1".formatted(layoutDir));
+        CopyUtils.copy(registry, sourceRef, ociLayout, layoutRef, CopyUtils.CopyOptions.deep());
+        CopyUtils.copy(ociLayout, layoutRef, registry, targetRef, CopyUtils.CopyOptions.deep());
+
+        // Assertion
+        Manifest sourceManifest = registry.getManifest(sourceRef);
+        Manifest layoutManifest = ociLayout.getManifest(layoutRef);
+        Manifest targetManifest = registry.getManifest(targetRef);
+        String digest = manifest.getDescriptor().getDigest();
+        assertEquals(digest, sourceManifest.getDescriptor().getDigest());
+        assertEquals(digest, layoutManifest.getDescriptor().getDigest());
+        assertEquals(digest, targetManifest.getDescriptor().getDigest());
+        assertEquals(sourceManifest.getAnnotations(), targetManifest.getAnnotations());
+        assertEquals("1.0", targetManifest.getAnnotations().get("com.example.release"));
+        assertEquals(sourceManifest.getLayers().get(0).getDigest(), targetManifest.getLayers().get(0).getDigest());
+        assertEquals(
+                sourceManifest.getLayers().get(0).getAnnotations(),
+                targetManifest.getLayers().get(0).getAnnotations());
+        assertEquals("data", targetManifest.getLayers().get(0).getAnnotations().get("com.example.file"));
+        Referrers referrers = registry.getReferrers(targetRef.withDigest(digest), null);
+        assertEquals(1, referrers.getManifests().size());
+        assertEquals(referrer.getDescriptor().getDigest(), referrers.getManifests().get(0).getDigest());
+    }
+
     @Test
     void testNotFailToPullArtifactFromImage() {
 
@@ -1524,6 +1701,49 @@ class RegistryTest {
         assertNotNull(signedPomFileManifest.getAnnotations().get(Const.ANNOTATION_CREATED));
     }
 
+    @Test
+    void shouldRunVerifiersOnPull() throws IOException {
+        ArtifactType signatureType = ArtifactType.from("application/vnd.test.signature");
+        Registry registry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/artifact-verified".formatted(this.registry.getRegistry()));
+
+        Path file = blobDir.resolve("verified.txt");
+        Files.writeString(file, "verified content");
+        registry.pushArtifact(containerRef, LocalPath.of(file));
+        Path signature = blobDir.resolve("verified.txt.sig");
+        Files.writeString(signature, "signature");
+        registry.attachArtifact(containerRef, signatureType, LocalPath.of(signature));
+
+        // Accept only signed artifacts
+        ArtifactVerifier signatureVerifier = context -> {
+            for (ManifestDescriptor referrer : context.getReferrers(signatureType).getManifests()) {
+                for (Layer layer : context.getManifest(referrer.getDigest()).getLayers()) {
+                    byte[] content = context.getBlob(layer.getDigest());
+                    if ("signature".equals(new String(content, StandardCharsets.UTF_8))) {
+                        return true;
+                    }
+                }
+            }
+            return false;
+        };
+        Registry verifying = Registry.Builder.builder().from(registry).withVerifier(signatureVerifier).build();
+        Path verifiedDir = artifactDir.resolve("verified");
+        verifying.pullArtifact(containerRef, verifiedDir, true);
+        assertEquals("verified content", Files.readString(verifiedDir.resolve("verified.txt")));
+
+        // Rejected artifact is never written
+        Registry rejecting = Registry.Builder.builder().from(registry).withVerifier(context -> false).build();
+        Path rejectedDir = artifactDir.resolve("rejected");
+        OrasException exception =
+                assertThrows(OrasException.class, () -> rejecting.pullArtifact(containerRef, rejectedDir, true));
+        assertTrue(exception.getMessage().contains("rejected by verifier"));
+        assertFalse(Files.exists(rejectedDir.resolve("verified.txt")));
+    }
+
     @Test
     void testShouldPushMinimalArtifactThenAttachArtifactToIndex() throws IOException {
 
@@ -1892,6 +2112,21 @@ class RegistryTest {
         registry.deleteBlob(containerRef.withDigest(layer.getDigest()));
     }
 
+    @Test
+    void shouldPushBlobWithConfiguredDigestAlgorithm() {
+        Registry registry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .withDigestAlgorithm(SupportedAlgorithm.SHA512)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/artifact-sha512-default".formatted(this.registry.getRegistry()));
+        byte[] data = "hello".getBytes();
+        Layer layer = registry.pushBlob(containerRef, data);
+        assertEquals(SupportedAlgorithm.SHA512.digest(data), layer.getDigest());
+        assertArrayEquals(data, registry.getBlob(containerRef.withDigest(layer.getDigest())));
+    }
+
     @Test
     void shouldPushAndGetBlobStreamWithSha512() throws IOException {
         Registry registry = Registry.Builder.builder()
@@ -2273,6 +2508,47 @@ class RegistryTest {
         });
     }
 
+    @Test
+    void shouldPullArtifactMatchingPlatformFromIndex() throws IOException {
+        Registry registry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .build();
+
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/platform-pull".formatted(this.registry.getRegistry()));
+
+        Path fileAmd64 = blobDir.resolve("platform-amd64.txt");
+        Files.writeString(fileAmd64, "amd64");
+        Path fileArm64 = blobDir.resolve("platform-arm64.txt");
+        Files.writeString(fileArm64, "arm64");
+
+        Manifest manifestAmd64 = registry.pushArtifact(containerRef.withTag("amd64"), LocalPath.of(fileAmd64));
+        Manifest manifestArm64 = registry.pushArtifact(containerRef.withTag("arm64"), LocalPath.of(fileArm64));
+        Index index = Index.fromManifests(List.of())
+                .withPlatformManifest(manifestAmd64.getDescriptor(), Platform.linuxAmd64())
+                .withPlatformManifest(manifestArm64.getDescriptor(), Platform.linuxArm64V8());
+        registry.pushIndex(containerRef.withTag("latest"), index);
+
+        // Only the matching manifest is pulled
+        Path pullDir = artifactDir.resolve("platform-pull");
+        Files.createDirectories(pullDir);
+        registry.pullArtifact(
+                containerRef.withTag("latest"),
+                pullDir,
+                OCI.PullOptions.defaults().withPlatform(Platform.linuxArm64V8()));
+        assertEquals("arm64", Files.readString(pullDir.resolve("platform-arm64.txt")));
+        assertFalse(Files.exists(pullDir.resolve("platform-amd64.txt")));
+
+        // Fail if required platform is not found
+        assertThrows(
+                OrasException.class,
+                () -> registry.pullArtifact(
+                        containerRef.withTag("latest"),
+                        pullDir,
+                        OCI.PullOptions.defaults().withRequiredPlatform(Platform.windowsAmd64())));
+    }
+
     @Test
     void testShouldCopyIndexWithPlatformFilter() throws IOException {
         Registry registry = Registry.Builder.builder()
@@ -2390,6 +2666,38 @@ class RegistryTest {
         assertEquals("hello chunked artifact", Files.readString(artifactDir.resolve("chunked-artifact.txt")));
     }
 
+    @Test
+    void shouldPushAndPullLayersConcurrently() throws IOException {
+        Registry registry = Registry.Builder.builder()
+                .defaults("myuser", "mypass")
+                .withInsecure(true)
+                .withParallelism(4)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/artifact-concurrent".formatted(this.registry.getRegistry()));
+
+        LocalPath[] paths = new LocalPath[5];
+        for (int i = 0; i < paths.length; i++) {
+            Path file = blobDir.resolve("concurrent-%d.txt".formatted(i));
+            Files.writeString(file, "concurrent layer %d".formatted(i));
+            paths[i] = LocalPath.of(file);
+        }
+
+        Manifest manifest = registry.pushArtifact(containerRef, OCI.PushOptions.defaults().withConcurrency(2), paths);
+
+        // Assertion
+        assertEquals(5, manifest.getLayers().size());
+        assertEquals("concurrent-0.txt", manifest.getLayers().get(0).getAnnotations().get(Const.ANNOTATION_TITLE));
+        assertEquals("concurrent-4.txt", manifest.getLayers().get(4).getAnnotations().get(Const.ANNOTATION_TITLE));
+
+        registry.pullArtifact(containerRef, artifactDir, OCI.PullOptions.overwrite().withConcurrency(2));
+        for (int i = 0; i < paths.length; i++) {
+            assertEquals(
+                    "concurrent layer %d".formatted(i),
+                    Files.readString(artifactDir.resolve("concurrent-%d.txt".formatted(i))));
+        }
+    }
+
     @Test
     void shouldPushArtifactWithChunkedOptionsCustomChunkSizeAndArtifactType() throws IOException {
         Registry registry = Registry.Builder.builder()
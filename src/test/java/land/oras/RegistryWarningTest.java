@@ -0,0 +1,67 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.util.List;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class RegistryWarningTest {
+
+    @Test
+    void shouldParseWarning() {
+        List<RegistryWarning> warnings = RegistryWarning.parse("localhost:5000", "299 - \"This API is deprecated\"");
+
+        // Assertion
+        assertEquals(1, warnings.size());
+        assertEquals(
+                new RegistryWarning(
+                        "localhost:5000",
+                        RegistryWarning.MISCELLANEOUS_PERSISTENT_WARNING,
+                        "-",
+                        "This API is deprecated"),
+                warnings.get(0));
+    }
+
+    @Test
+    void shouldParseMultipleWarningsWithQuotesAndDate() {
+        List<RegistryWarning> warnings = RegistryWarning.parse(
+                "example.com",
+                "299 - \"Quota \\\"team\\\" is 90% used\" \"Wed, 21 Oct 2026 07:28:00 GMT\", 199 registry \"Other\"");
+
+        // Assertion
+        assertEquals(2, warnings.size());
+        assertEquals("Quota \"team\" is 90% used", warnings.get(0).text());
+        assertEquals(199, warnings.get(1).code());
+        assertEquals("registry", warnings.get(1).agent());
+        assertEquals("Other", warnings.get(1).text());
+    }
+
+    @Test
+    void shouldIgnoreMalformedWarning() {
+        assertTrue(RegistryWarning.parse("example.com", "not a warning").isEmpty());
+    }
+}
@@ -21,6 +21,7 @@
 package land.oras;
 
 import static com.github.tomakehurst.wiremock.client.WireMock.*;
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
 import static org.junit.jupiter.api.Assertions.assertEquals;
 import static org.junit.jupiter.api.Assertions.assertFalse;
 import static org.junit.jupiter.api.Assertions.assertInstanceOf;
@@ -28,43 +29,79 @@ import static org.junit.jupiter.api.Assertions.assertNotNull;
 import static org.junit.jupiter.api.Assertions.assertThrows;
 import static org.junit.jupiter.api.Assertions.assertTrue;
 
+import ch.qos.logback.classic.Logger;
+import ch.qos.logback.classic.spi.ILoggingEvent;
+import ch.qos.logback.core.read.ListAppender;
 import com.github.tomakehurst.wiremock.WireMockServer;
 import com.github.tomakehurst.wiremock.client.WireMock;
 import com.github.tomakehurst.wiremock.core.WireMockConfiguration;
+import com.github.tomakehurst.wiremock.extension.ResponseTransformerV2;
+import com.github.tomakehurst.wiremock.http.HttpHeader;
+import com.github.tomakehurst.wiremock.http.HttpHeaders;
+import com.github.tomakehurst.wiremock.http.RequestMethod;
+import com.github.tomakehurst.wiremock.http.Response;
 import com.github.tomakehurst.wiremock.junit5.WireMockRuntimeInfo;
 import com.github.tomakehurst.wiremock.junit5.WireMockTest;
 import com.github.tomakehurst.wiremock.stubbing.Scenario;
+import com.github.tomakehurst.wiremock.stubbing.ServeEvent;
+import com.github.tomakehurst.wiremock.verification.LoggedRequest;
 import io.micrometer.core.instrument.Counter;
 import io.micrometer.core.instrument.Metrics;
 import io.micrometer.core.instrument.simple.SimpleMeterRegistry;
+import java.io.ByteArrayInputStream;
 import java.io.IOException;
 import java.io.InputStream;
 import java.net.URI;
+import java.net.http.HttpResponse;
 import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
+import java.time.Duration;
 import java.time.ZonedDateTime;
+import java.util.ArrayList;
+import java.util.Arrays;
 import java.util.HashMap;
 import java.util.List;
 import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.concurrent.CopyOnWriteArrayList;
 import java.util.concurrent.ExecutorService;
 import java.util.concurrent.Executors;
+import java.util.concurrent.Future;
+import java.util.concurrent.ThreadPoolExecutor;
 import java.util.concurrent.TimeUnit;
+import java.util.concurrent.TimeoutException;
+import java.util.concurrent.atomic.AtomicInteger;
+import land.oras.auth.AuthProvider;
+import land.oras.auth.AuthScheme;
 import land.oras.auth.AuthStore;
 import land.oras.auth.AuthStoreAuthenticationProvider;
 import land.oras.auth.BearerTokenProvider;
+import land.oras.auth.HostConfig;
 import land.oras.auth.HttpClient;
+import land.oras.auth.HttpInterceptor;
+import land.oras.auth.HttpTransport;
 import land.oras.auth.NoAuthProvider;
+import land.oras.auth.ProxyConfig;
+import land.oras.auth.RetryPolicy;
 import land.oras.auth.Scopes;
 import land.oras.auth.UsernamePasswordProvider;
+import land.oras.exception.ContentTooLargeException;
+import land.oras.exception.DigestMismatchException;
+import land.oras.exception.OperationCancelledException;
+import land.oras.exception.OperationNotSupportedException;
 import land.oras.exception.OrasException;
+import land.oras.exception.RateLimitException;
 import land.oras.utils.Const;
 import land.oras.utils.JsonUtils;
 import land.oras.utils.SupportedAlgorithm;
 import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.condition.EnabledForJreRange;
+import org.junit.jupiter.api.condition.JRE;
 import org.junit.jupiter.api.io.TempDir;
 import org.junit.jupiter.api.parallel.Execution;
 import org.junit.jupiter.api.parallel.ExecutionMode;
+import org.slf4j.LoggerFactory;
 
 @WireMockTest
 @Execution(ExecutionMode.SAME_THREAD)
@@ -167,6 +204,83 @@ class RegistryWireMockTest {
         assertEquals("blob-data", new String(blob));
     }
 
+    @Test
+    void shouldResolveDigestWhenDeletingManifestByTag(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String digest = SupportedAlgorithm.SHA256.digest("manifest".getBytes());
+
+        wireMock.register(head(urlEqualTo("/v2/library/delete-by-tag/manifests/latest"))
+                .willReturn(aResponse().withStatus(200).withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)));
+        wireMock.register(delete(urlEqualTo("/v2/library/delete-by-tag/manifests/%s".formatted(digest)))
+                .willReturn(aResponse().withStatus(202)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/delete-by-tag".formatted(wmRuntimeInfo.getHttpPort()));
+        registry.deleteManifest(containerRef);
+
+        // Assertion
+        wireMock.verifyThat(
+                1, deleteRequestedFor(urlEqualTo("/v2/library/delete-by-tag/manifests/%s".formatted(digest))));
+        wireMock.verifyThat(0, deleteRequestedFor(urlEqualTo("/v2/library/delete-by-tag/manifests/latest")));
+    }
+
+    @Test
+    void shouldThrowTypedExceptionWhenDeleteIsNotAllowed(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String digest = SupportedAlgorithm.SHA256.digest("blob".getBytes());
+
+        wireMock.register(delete(urlPathMatching("/v2/library/delete-disabled/.*"))
+                .willReturn(aResponse().withStatus(405)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/delete-disabled@%s".formatted(wmRuntimeInfo.getHttpPort(), digest));
+
+        // Assertion
+        OperationNotSupportedException manifestException =
+                assertThrows(OperationNotSupportedException.class, () -> registry.deleteManifest(containerRef));
+        assertEquals(405, manifestException.getStatusCode());
+        OperationNotSupportedException blobException =
+                assertThrows(OperationNotSupportedException.class, () -> registry.deleteBlob(containerRef));
+        assertEquals(405, blobException.getStatusCode());
+    }
+
+    @Test
+    void shouldThrowTypedExceptionWhenTagDeleteIsUnsupported(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+
+        wireMock.register(delete(urlEqualTo("/v2/library/delete-tag/manifests/v1"))
+                .willReturn(aResponse()
+                        .withStatus(400)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_JSON_MEDIA_TYPE)
+                        .withBody(
+                                "{\"errors\":[{\"code\":\"UNSUPPORTED\",\"message\":\"tag delete not supported\"}]}")));
+        wireMock.register(delete(urlEqualTo("/v2/library/delete-tag/manifests/v2"))
+                .willReturn(aResponse().withStatus(202)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/delete-tag".formatted(wmRuntimeInfo.getHttpPort()));
+
+        // Assertion
+        assertThrows(OperationNotSupportedException.class, () -> registry.deleteTag(containerRef.withTag("v1")));
+        registry.deleteTag(containerRef.withTag("v2"));
+        wireMock.verifyThat(1, deleteRequestedFor(urlEqualTo("/v2/library/delete-tag/manifests/v2")));
+        assertThrows(
+                OrasException.class,
+                () -> registry.deleteTag(containerRef.withDigest(SupportedAlgorithm.SHA256.digest(new byte[0]))));
+    }
+
     @Test
     void mountBlobShouldReturnFalseOn202(WireMockRuntimeInfo wmRuntimeInfo) throws IOException {
 
@@ -191,6 +305,488 @@ class RegistryWireMockTest {
         assertFalse(registry.mountBlob(containerRef, containerRef), "Mount blob should return false");
     }
 
+    @Test
+    void shouldMountBlobFromSourceRepositoryOnPush(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String digest = SupportedAlgorithm.SHA256.digest("hello".getBytes());
+
+        wireMock.register(head(WireMock.urlPathMatching("/v2/library/artifact-mount/blobs/.*"))
+                .willReturn(WireMock.status(404)));
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/artifact-mount/blobs/uploads/"))
+                .withQueryParam("mount", WireMock.equalTo(digest))
+                .withQueryParam("from", WireMock.equalTo("library/mount-source"))
+                .willReturn(WireMock.status(201)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withMountSource("library/mount-source")
+                .build();
+
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/artifact-mount".formatted(wmRuntimeInfo.getHttpPort()));
+        Layer layer = registry.pushBlob(containerRef, "hello".getBytes());
+
+        // Assertion
+        assertEquals(digest, layer.getDigest());
+        wireMock.verifyThat(
+                1,
+                WireMock.postRequestedFor(WireMock.urlPathEqualTo("/v2/library/artifact-mount/blobs/uploads/"))
+                        .withQueryParam("from", WireMock.equalTo("library/mount-source")));
+        wireMock.verifyThat(0, WireMock.putRequestedFor(WireMock.anyUrl()));
+    }
+
+    @Test
+    void shouldUploadBlobWhenMountIsNotSupported(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+
+        wireMock.register(head(WireMock.urlPathMatching("/v2/library/artifact-mount-fallback/blobs/.*"))
+                .willReturn(WireMock.status(404)));
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/artifact-mount-fallback/blobs/uploads/"))
+                .willReturn(WireMock.status(202).withHeader("Location", "/mount-fallback-upload")));
+        wireMock.register(
+                WireMock.put(WireMock.urlPathMatching("/mount-fallback-upload.*")).willReturn(WireMock.status(201)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withMountSource("library/mount-source")
+                .build();
+
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/artifact-mount-fallback".formatted(wmRuntimeInfo.getHttpPort()));
+        registry.pushBlob(containerRef, "hello".getBytes());
+
+        // Assertion
+        wireMock.verifyThat(
+                1,
+                WireMock.postRequestedFor(
+                                WireMock.urlPathEqualTo("/v2/library/artifact-mount-fallback/blobs/uploads/"))
+                        .withQueryParam("from", WireMock.equalTo("library/mount-source")));
+        wireMock.verifyThat(1, WireMock.putRequestedFor(WireMock.urlPathMatching("/mount-fallback-upload.*")));
+    }
+
+    @Test
+    void shouldPushDockerManifestWithItsMediaType(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        // language=json
+        String manifestJson =
+                """
+                {"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json",\
+                "config":{"mediaType":"application/vnd.docker.container.image.v1+json",\
+                "digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},\
+                "layers":[]}""";
+        String digest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.put(WireMock.urlEqualTo("/v2/library/artifact-docker/manifests/latest"))
+                .willReturn(WireMock.status(201)));
+        wireMock.register(WireMock.head(WireMock.urlEqualTo("/v2/library/artifact-docker/manifests/latest"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DOCKER_MANIFEST_MEDIA_TYPE)));
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/artifact-docker/manifests/latest"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DOCKER_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)
+                        .withBody(manifestJson)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/artifact-docker:latest".formatted(wmRuntimeInfo.getHttpPort()));
+        Manifest pushed = registry.pushManifest(containerRef, Manifest.fromJson(manifestJson));
+
+        // Assertion
+        assertEquals(Const.DOCKER_MANIFEST_MEDIA_TYPE, pushed.getMediaType());
+        assertEquals(digest, pushed.getDigest());
+        wireMock.verifyThat(WireMock.putRequestedFor(
+                        WireMock.urlEqualTo("/v2/library/artifact-docker/manifests/latest"))
+                .withHeader(Const.CONTENT_TYPE_HEADER, WireMock.equalTo(Const.DOCKER_MANIFEST_MEDIA_TYPE))
+                .withRequestBody(WireMock.equalTo(manifestJson)));
+    }
+
+    @Test
+    void shouldTagExistingManifestWithoutTransferringBlobs(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String manifestJson = Manifest.empty().toJson();
+        String digest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/retag/manifests/1.2.3"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)
+                        .withBody(manifestJson)));
+        wireMock.register(WireMock.put(WireMock.urlEqualTo("/v2/library/retag/manifests/stable"))
+                .willReturn(WireMock.status(201)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/retag:1.2.3".formatted(wmRuntimeInfo.getHttpPort()));
+        ContainerRef tagged = registry.tag(containerRef, "stable");
+
+        // Assertion
+        assertEquals("stable", tagged.getTag());
+        wireMock.verifyThat(WireMock.putRequestedFor(WireMock.urlEqualTo("/v2/library/retag/manifests/stable"))
+                .withHeader(Const.CONTENT_TYPE_HEADER, WireMock.equalTo(Const.DEFAULT_MANIFEST_MEDIA_TYPE))
+                .withRequestBody(WireMock.equalTo(manifestJson)));
+        wireMock.verifyThat(0, WireMock.anyRequestedFor(WireMock.urlPathMatching("/v2/library/retag/blobs/.*")));
+    }
+
+    @Test
+    void shouldMountLayersWhenCopyingOnSameRegistry(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        String config = "{\"architecture\":\"amd64\"}";
+        String configDigest = SupportedAlgorithm.SHA256.digest(config.getBytes(StandardCharsets.UTF_8));
+        String layerDigest = SupportedAlgorithm.SHA256.digest("layer".getBytes(StandardCharsets.UTF_8));
+        // language=json
+        String manifestJson =
+                """
+                {"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json",\
+                "config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":"%s","size":%d},\
+                "layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"%s","size":5}]}"""
+                        .formatted(configDigest, config.length(), layerDigest);
+        String manifestDigest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        for (String repository : List.of("promote-src", "promote-dst")) {
+            String url = "/v2/library/%s/manifests/latest".formatted(repository);
+            wireMock.register(WireMock.head(WireMock.urlEqualTo(url))
+                    .willReturn(WireMock.ok()
+                            .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                            .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)));
+            wireMock.register(WireMock.get(WireMock.urlEqualTo(url))
+                    .willReturn(WireMock.ok()
+                            .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                            .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)
+                            .withBody(manifestJson)));
+        }
+        wireMock.register(WireMock.put(WireMock.urlEqualTo("/v2/library/promote-dst/manifests/latest"))
+                .willReturn(WireMock.created()));
+        wireMock.register(WireMock.get(
+                        WireMock.urlEqualTo("/v2/library/promote-src/blobs/%s".formatted(configDigest)))
+                .willReturn(WireMock.ok().withBody(config)));
+        wireMock.register(WireMock.head(WireMock.urlPathMatching("/v2/library/promote-dst/blobs/.*"))
+                .willReturn(WireMock.notFound()));
+
+        // The config is not mountable and uploaded instead
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/promote-dst/blobs/uploads/"))
+                .willReturn(WireMock.status(202).withHeader(Const.LOCATION_HEADER, "/promote-upload")));
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/promote-dst/blobs/uploads/"))
+                .withQueryParam("mount", WireMock.equalTo(layerDigest))
+                .withQueryParam("from", WireMock.equalTo("library/promote-src"))
+                .willReturn(WireMock.created()));
+        wireMock.register(
+                WireMock.put(WireMock.urlPathMatching("/promote-upload.*")).willReturn(WireMock.created()));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef source = ContainerRef.parse(
+                "localhost:%d/library/promote-src:latest".formatted(wmRuntimeInfo.getHttpPort()));
+        ContainerRef target = ContainerRef.parse(
+                "localhost:%d/library/promote-dst:latest".formatted(wmRuntimeInfo.getHttpPort()));
+
+        CopyUtils.copy(registry, source, registry, target);
+
+        // Assertion
+        wireMock.verifyThat(
+                0,
+                WireMock.getRequestedFor(
+                        WireMock.urlEqualTo("/v2/library/promote-src/blobs/%s".formatted(layerDigest))));
+        wireMock.verifyThat(
+                WireMock.postRequestedFor(WireMock.urlPathEqualTo("/v2/library/promote-dst/blobs/uploads/"))
+                        .withQueryParam("mount", WireMock.equalTo(layerDigest)));
+        wireMock.verifyThat(1, WireMock.putRequestedFor(WireMock.urlPathMatching("/promote-upload.*")));
+        wireMock.verifyThat(
+                WireMock.putRequestedFor(WireMock.urlEqualTo("/v2/library/promote-dst/manifests/latest")));
+    }
+
+    @Test
+    void shouldCheckExistenceWithHeadRequests(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        String manifestDigest = SupportedAlgorithm.SHA256.digest("manifest".getBytes(StandardCharsets.UTF_8));
+        String blobDigest = SupportedAlgorithm.SHA256.digest("blob".getBytes(StandardCharsets.UTF_8));
+        String missingDigest = SupportedAlgorithm.SHA256.digest("missing".getBytes(StandardCharsets.UTF_8));
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.head(WireMock.urlEqualTo("/v2/library/artifact-exists/manifests/latest"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE)
+                        .withHeader(Const.CONTENT_LENGTH_HEADER, "512")
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)));
+        wireMock.register(WireMock.head(WireMock.urlEqualTo("/v2/library/artifact-exists/manifests/missing"))
+                .willReturn(WireMock.aResponse().withStatus(404)));
+        wireMock.register(WireMock.head(
+                        WireMock.urlEqualTo("/v2/library/artifact-exists/blobs/%s".formatted(blobDigest)))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_LENGTH_HEADER, "4")
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, blobDigest)));
+        wireMock.register(WireMock.head(
+                        WireMock.urlEqualTo("/v2/library/artifact-exists/blobs/%s".formatted(missingDigest)))
+                .willReturn(WireMock.aResponse().withStatus(404)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/artifact-exists:latest".formatted(wmRuntimeInfo.getHttpPort()));
+
+        // Assertion
+        assertTrue(registry.exists(containerRef));
+        assertFalse(registry.exists(containerRef.withTag("missing")));
+        Descriptor manifest = registry.findDescriptor(containerRef).orElseThrow();
+        assertEquals(manifestDigest, manifest.getDigest());
+        assertEquals(512L, manifest.getSize());
+        assertEquals(Const.DEFAULT_INDEX_MEDIA_TYPE, manifest.getMediaType());
+        assertTrue(registry.findDescriptor(containerRef.withTag("missing")).isEmpty());
+
+        assertTrue(registry.blobExists(containerRef, blobDigest));
+        assertFalse(registry.blobExists(containerRef, missingDigest));
+        Descriptor blob = registry.findBlobDescriptor(containerRef, blobDigest).orElseThrow();
+        assertEquals(blobDigest, blob.getDigest());
+        assertEquals(4L, blob.getSize());
+        assertTrue(registry.findBlobDescriptor(containerRef, missingDigest).isEmpty());
+        wireMock.verifyThat(0, WireMock.getRequestedFor(WireMock.anyUrl()));
+    }
+
+    @Test
+    void shouldCacheTagResolution(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        String manifestJson = Manifest.empty().toJson();
+        String digest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.head(WireMock.urlEqualTo("/v2/library/artifact-cached/manifests/latest"))
+                .willReturn(WireMock.aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)));
+        for (String reference : List.of("latest", digest)) {
+            wireMock.register(WireMock.get(
+                            WireMock.urlEqualTo("/v2/library/artifact-cached/manifests/%s".formatted(reference)))
+                    .willReturn(WireMock.aResponse()
+                            .withStatus(200)
+                            .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                            .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)
+                            .withBody(manifestJson)));
+        }
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withResolveCacheTtl(Duration.ofMinutes(5))
+                .build();
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/artifact-cached:latest".formatted(wmRuntimeInfo.getHttpPort()));
+
+        registry.getManifest(containerRef);
+        Manifest manifest = registry.getManifest(containerRef);
+        Descriptor descriptor = registry.probeDescriptor(containerRef);
+
+        // Assertion
+        assertEquals(digest, manifest.getDigest());
+        assertEquals(digest, descriptor.getDigest());
+        wireMock.verifyThat(
+                1, WireMock.headRequestedFor(WireMock.urlEqualTo("/v2/library/artifact-cached/manifests/latest")));
+        wireMock.verifyThat(
+                1,
+                WireMock.getRequestedFor(
+                        WireMock.urlEqualTo("/v2/library/artifact-cached/manifests/%s".formatted(digest))));
+
+        // Resolve again after invalidation
+        registry.invalidateResolveCache(containerRef);
+        registry.getManifest(containerRef);
+        wireMock.verifyThat(
+                2, WireMock.headRequestedFor(WireMock.urlEqualTo("/v2/library/artifact-cached/manifests/latest")));
+    }
+
+    @Test
+    void shouldNotifyWarningListenerOnce(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/v2/library/artifact-warning/tags/list"))
+                .willReturn(WireMock.okJson("{\"name\":\"library/artifact-warning\",\"tags\":[\"latest\"]}")
+                        .withHeader(
+                                Const.WARNING_HEADER,
+                                "299 - \"Repository is deprecated\"",
+                                "299 - \"Quota almost exceeded\"")));
+
+        List<RegistryWarning> warnings = new CopyOnWriteArrayList<>();
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withWarningListener(warnings::add)
+                .build();
+
+        String host = "localhost:%d".formatted(wmRuntimeInfo.getHttpPort());
+        ContainerRef containerRef = ContainerRef.parse("%s/library/artifact-warning".formatted(host));
+        registry.getTags(containerRef);
+        registry.getTags(containerRef);
+
+        // Assertion
+        assertEquals(
+                List.of(
+                        new RegistryWarning(host, 299, "-", "Repository is deprecated"),
+                        new RegistryWarning(host, 299, "-", "Quota almost exceeded")),
+                warnings);
+    }
+
+    @Test
+    void shouldApplyInterceptors(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/v2/library/artifact-interceptor/tags/list"))
+                .willReturn(WireMock.okJson("{\"name\":\"library/artifact-interceptor\",\"tags\":[\"latest\"]}")));
+
+        List<String> beforeAuth = new CopyOnWriteArrayList<>();
+        List<String> afterAuth = new CopyOnWriteArrayList<>();
+        List<Integer> statusCodes = new CopyOnWriteArrayList<>();
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withInterceptor(HttpInterceptor.header("X-Gateway-Key", "secret"), HttpInterceptor.Stage.BEFORE_AUTH)
+                .withInterceptor(
+                        chain -> {
+                            beforeAuth.add(chain.request()
+                                    .headers()
+                                    .firstValue(Const.AUTHORIZATION_HEADER)
+                                    .orElse("none"));
+                            return chain.proceed(chain.request());
+                        },
+                        HttpInterceptor.Stage.BEFORE_AUTH)
+                .withInterceptor(chain -> {
+                    afterAuth.add(chain.request()
+                            .headers()
+                            .firstValue(Const.AUTHORIZATION_HEADER)
+                            .orElse("none"));
+                    HttpResponse<?> response = chain.proceed(chain.request());
+                    statusCodes.add(response.statusCode());
+                    return response;
+                })
+                .build();
+
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/artifact-interceptor".formatted(wmRuntimeInfo.getHttpPort()));
+        Tags tags = registry.getTags(containerRef);
+
+        // Assertion
+        assertEquals(List.of("latest"), tags.tags());
+        assertEquals(List.of("none"), beforeAuth);
+        assertEquals(List.of(authProvider.getAuthHeader(containerRef)), afterAuth);
+        assertEquals(List.of(200), statusCodes);
+        wireMock.verifyThat(WireMock.getRequestedFor(
+                        WireMock.urlPathEqualTo("/v2/library/artifact-interceptor/tags/list"))
+                .withHeader("X-Gateway-Key", WireMock.equalTo("secret"))
+                .withHeader(Const.AUTHORIZATION_HEADER, WireMock.matching("Basic .+")));
+    }
+
+    @Test
+    void shouldSendUserAgentAndDefaultHeaders(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/v2/library/artifact-headers/tags/list"))
+                .willReturn(WireMock.okJson("{\"name\":\"library/artifact-headers\",\"tags\":[\"latest\"]}")));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withUserAgentSuffix("my-app/1.0")
+                .withDefaultHeaders(Map.of("X-Team", "platform"))
+                .withSensitiveDefaultHeader("X-Api-Key", "secret")
+                .build();
+
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/artifact-headers".formatted(wmRuntimeInfo.getHttpPort()));
+        Tags tags = registry.getTags(containerRef);
+
+        // Assertion
+        assertEquals(List.of("latest"), tags.tags());
+        wireMock.verifyThat(WireMock.getRequestedFor(WireMock.urlPathEqualTo("/v2/library/artifact-headers/tags/list"))
+                .withHeader(Const.USER_AGENT_HEADER, WireMock.matching("ORAS-Java-SDK/.+ my-app/1\\.0"))
+                .withHeader("X-Team", WireMock.equalTo("platform"))
+                .withHeader("X-Api-Key", WireMock.equalTo("secret")));
+        assertThrows(
+                OrasException.class,
+                () -> Registry.builder()
+                        .withDefaultHeader(Const.AUTHORIZATION_HEADER, "Bearer token")
+                        .build());
+    }
+
+    @Test
+    void shouldRecordTransferMetrics(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String digest = SupportedAlgorithm.SHA256.digest("hello".getBytes());
+        String existingDigest = SupportedAlgorithm.SHA256.digest("existing".getBytes());
+
+        wireMock.register(head(WireMock.urlPathEqualTo("/v2/library/artifact-metrics/blobs/%s".formatted(digest)))
+                .willReturn(WireMock.status(404)));
+        wireMock.register(
+                head(WireMock.urlPathEqualTo("/v2/library/artifact-metrics/blobs/%s".formatted(existingDigest)))
+                        .willReturn(WireMock.ok()));
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/artifact-metrics/blobs/uploads/"))
+                .willReturn(WireMock.status(202).withHeader("Location", "/metrics-upload")));
+        wireMock.register(WireMock.put(WireMock.urlPathMatching("/metrics-upload.*"))
+                .willReturn(WireMock.status(201)));
+        wireMock.register(WireMock.get(
+                        WireMock.urlPathEqualTo("/v2/library/artifact-metrics/blobs/%s".formatted(digest)))
+                .willReturn(WireMock.ok().withBody("hello").withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)));
+
+        SimpleMeterRegistry meterRegistry = new SimpleMeterRegistry();
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withMeterRegistry(meterRegistry)
+                .build();
+
+        String host = "localhost:%d".formatted(wmRuntimeInfo.getHttpPort());
+        ContainerRef containerRef = ContainerRef.parse("%s/library/artifact-metrics".formatted(host));
+        registry.pushBlob(containerRef, "hello".getBytes());
+        registry.pushBlob(containerRef, "existing".getBytes());
+        registry.getBlob(containerRef.withDigest(digest));
+
+        // Assertion
+        assertEquals(
+                5.0,
+                meterRegistry
+                        .counter(
+                                Const.METRIC_TRANSFER_BYTES,
+                                Const.METRIC_TAG_HOST,
+                                host,
+                                Const.METRIC_TAG_OPERATION,
+                                "push")
+                        .count());
+        assertEquals(
+                5.0,
+                meterRegistry
+                        .counter(
+                                Const.METRIC_TRANSFER_BYTES,
+                                Const.METRIC_TAG_HOST,
+                                host,
+                                Const.METRIC_TAG_OPERATION,
+                                "pull")
+                        .count());
+        assertEquals(
+                1.0,
+                meterRegistry
+                        .counter(Const.METRIC_BLOBS_REUSED, Const.METRIC_TAG_HOST, host, "reason", "exists")
+                        .count());
+    }
+
     @Test
     void shouldRedirectWhenPushingBlob(WireMockRuntimeInfo wmRuntimeInfo) throws IOException {
 
@@ -632,15 +1228,28 @@ class RegistryWireMockTest {
         wireMock.register(
                 WireMock.put(WireMock.urlPathMatching(uploadPath + "12345.*")).willReturn(WireMock.created()));
 
-        Registry registry =
-                Registry.Builder.builder().withInsecure(true).withRetryDelay(0).build();
         ContainerRef ref = ContainerRef.parse("%s/library/artifact-text".formatted(registryUrl));
-
         Path testFile = configDir.resolve("test-data.temp");
         Files.writeString(testFile, "Test Content");
 
-        try (InputStream inputStream = Files.newInputStream(testFile)) {
-            Layer layer = registry.pushBlob(ref, inputStream);
+        // POST is not retried by default
+        Registry registry =
+                Registry.Builder.builder().withInsecure(true).withRetryDelay(0).build();
+        try (InputStream inputStream = Files.newInputStream(testFile)) {
+            assertThrows(OrasException.class, () -> registry.pushBlob(ref, inputStream));
+        }
+        WireMock.verify(1, postRequestedFor(urlPathMatching(uploadPath + ".*")));
+
+        // Explicitly allowed
+        wireMock.resetScenarios();
+        Registry retrying = Registry.Builder.builder()
+                .withInsecure(true)
+                .withRetryPolicy(RetryPolicy.defaults()
+                        .withInitialDelay(Duration.ZERO)
+                        .withRetryNonIdempotent(true))
+                .build();
+        try (InputStream inputStream = Files.newInputStream(testFile)) {
+            Layer layer = retrying.pushBlob(ref, inputStream);
             assertNotNull(layer);
             assertNotNull(layer.getDigest());
         }
@@ -668,6 +1277,148 @@ class RegistryWireMockTest {
                         WireMock.urlEqualTo("/token?scope=repository:library/get-auth-token:pull&service=localhost")));
     }
 
+    @Test
+    void shouldUseOAuth2RefreshTokenGrantForIdentityToken(WireMockRuntimeInfo wmRuntimeInfo) {
+        byte[] blob = oauth2Scenario(
+                wmRuntimeInfo,
+                "post-refresh-token",
+                new UsernamePasswordProvider(Const.IDENTITY_TOKEN_USERNAME, "my-refresh-token"),
+                "grant_type=refresh_token&refresh_token=my-refresh-token");
+        assertEquals("blob-data", new String(blob));
+
+        // Identity tokens never use the GET flow when POST succeeds
+        WireMock.verify(0, WireMock.getRequestedFor(WireMock.urlPathEqualTo("/oauth2/post-refresh-token/token")));
+    }
+
+    @Test
+    void shouldFallbackToOAuth2PasswordGrant(WireMockRuntimeInfo wmRuntimeInfo) {
+        byte[] blob = oauth2Scenario(
+                wmRuntimeInfo,
+                "post-password",
+                new UsernamePasswordProvider("user", "p@ss"),
+                "grant_type=password&username=user&password=p%40ss");
+        assertEquals("blob-data", new String(blob));
+
+        // GET flow was tried first
+        WireMock.verify(1, WireMock.getRequestedFor(WireMock.urlPathEqualTo("/oauth2/post-password/token")));
+    }
+
+    @Test
+    void shouldResolveCredentialsOncePerTokenRefresh(WireMockRuntimeInfo wmRuntimeInfo) {
+        AtomicInteger calls = new AtomicInteger();
+        UsernamePasswordProvider delegate = new UsernamePasswordProvider("user", "p@ss");
+        AuthProvider countingProvider = new AuthProvider() {
+            @Override
+            public String getAuthHeader(ContainerRef registry) {
+                calls.incrementAndGet();
+                return delegate.getAuthHeader(registry);
+            }
+
+            @Override
+            public AuthScheme getAuthScheme() {
+                return delegate.getAuthScheme();
+            }
+        };
+        byte[] blob = oauth2Scenario(
+                wmRuntimeInfo,
+                "resolve-once",
+                countingProvider,
+                "grant_type=password&username=user&password=p%40ss");
+        assertEquals("blob-data", new String(blob));
+
+        // Assertion: once for the registry request, once for the refresh using both GET and POST flows
+        WireMock.verify(1, WireMock.getRequestedFor(WireMock.urlPathEqualTo("/oauth2/resolve-once/token")));
+        WireMock.verify(1, WireMock.postRequestedFor(WireMock.urlPathEqualTo("/oauth2/resolve-once/token")));
+        assertEquals(2, calls.get());
+    }
+
+    @Test
+    void shouldFallbackToAnonymousTokenWhenCredentialsAreRejected(WireMockRuntimeInfo wmRuntimeInfo) {
+        String digest = SupportedAlgorithm.SHA256.digest("blob-data".getBytes());
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+
+        // Challenge without token, return data with the anonymous token
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/anonymous-pull/blobs/%s".formatted(digest)))
+                .atPriority(2)
+                .willReturn(WireMock.unauthorized()
+                        .withHeader(
+                                Const.WWW_AUTHENTICATE_HEADER,
+                                "Bearer realm=\"http://localhost:%d/anonymous/token\",service=\"localhost\",scope=\"repository:library/anonymous-pull:pull\""
+                                        .formatted(wmRuntimeInfo.getHttpPort()))));
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/anonymous-pull/blobs/%s".formatted(digest)))
+                .atPriority(1)
+                .withHeader(Const.AUTHORIZATION_HEADER, equalTo("Bearer anonymous-token"))
+                .willReturn(
+                        WireMock.ok().withBody("blob-data").withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)));
+
+        // Stale credentials are rejected, anonymous token is granted
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/anonymous/token"))
+                .atPriority(1)
+                .withHeader(Const.AUTHORIZATION_HEADER, absent())
+                .willReturn(WireMock.okJson(JsonUtils.toJson(
+                        new HttpClient.TokenResponse("anonymous-token", null, null, 300, ZonedDateTime.now())))));
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/anonymous/token"))
+                .atPriority(2)
+                .willReturn(WireMock.unauthorized()));
+
+        ContainerRef containerRef = ContainerRef.parse(
+                "localhost:%d/library/anonymous-pull@%s".formatted(wmRuntimeInfo.getHttpPort(), digest));
+
+        // Disabled fallback surfaces the error
+        Registry strict = Registry.Builder.builder()
+                .withAuthProvider(new UsernamePasswordProvider("stale", "credentials"))
+                .withAnonymousFallback(false)
+                .withInsecure(true)
+                .build();
+        assertThrows(OrasException.class, () -> strict.getBlob(containerRef));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(new UsernamePasswordProvider("stale", "credentials"))
+                .withInsecure(true)
+                .build();
+        assertEquals("blob-data", new String(registry.getBlob(containerRef)));
+    }
+
+    @Test
+    void shouldRequestSourcePullScopeWhenMounting(WireMockRuntimeInfo wmRuntimeInfo) {
+
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String digest = SupportedAlgorithm.SHA256.digest("mount-scope".getBytes());
+
+        // Mount requires a token
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/mount-scope-target/blobs/uploads/"))
+                .inScenario("mount scope")
+                .willReturn(WireMock.unauthorized()
+                        .withHeader(
+                                Const.WWW_AUTHENTICATE_HEADER,
+                                "Bearer realm=\"http://localhost:%d/token\",service=\"localhost\",scope=\"repository:library/mount-scope-target:pull,push\""
+                                        .formatted(wmRuntimeInfo.getHttpPort()))));
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/token"))
+                .inScenario("mount scope")
+                .willSetStateTo("token")
+                .willReturn(WireMock.okJson(JsonUtils.toJson(
+                        new HttpClient.TokenResponse("mount-token", null, null, 300, ZonedDateTime.now())))));
+        wireMock.register(WireMock.post(WireMock.urlPathEqualTo("/v2/library/mount-scope-target/blobs/uploads/"))
+                .inScenario("mount scope")
+                .whenScenarioStateIs("token")
+                .willReturn(WireMock.status(201)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        String host = "localhost:%d".formatted(wmRuntimeInfo.getHttpPort());
+        ContainerRef source = ContainerRef.parse("%s/library/mount-scope-source@%s".formatted(host, digest));
+        ContainerRef target = ContainerRef.parse("%s/library/mount-scope-target@%s".formatted(host, digest));
+
+        // Assertion
+        assertTrue(registry.mountBlob(source, target));
+        wireMock.verifyThat(
+                1,
+                WireMock.getRequestedFor(WireMock.urlEqualTo("/token?scope=repository:library/mount-scope-source:pull"
+                        + "&scope=repository:library/mount-scope-target:pull,push&service=localhost")));
+    }
+
     @Test
     void shouldRefreshExpiredToken(WireMockRuntimeInfo wmRuntimeInfo) {
 
@@ -1062,6 +1813,301 @@ class RegistryWireMockTest {
         assertEquals("Response code: 503", exception.getMessage());
     }
 
+    @Test
+    void shouldRedactErrorBodiesInLogs(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String body = "{\"errors\":[{\"code\":\"DENIED\",\"message\":\"denied\","
+                + "\"detail\":{\"token\":\"s3cr3t-error-token\"}}]}";
+        wireMock.register(get(urlEqualTo("/v2/library/error-logs/tags/list"))
+                .willReturn(aResponse()
+                        .withStatus(403)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, "application/json")
+                        .withBody(body)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef = ContainerRef.parse("%s/library/error-logs".formatted(registryUrl));
+        Logger logger = (Logger) LoggerFactory.getLogger(Registry.class);
+        ListAppender<ILoggingEvent> appender = new ListAppender<>();
+        appender.start();
+        logger.addAppender(appender);
+        try {
+            assertThrows(OrasException.class, () -> registry.getTags(containerRef));
+        } finally {
+            logger.detachAppender(appender);
+        }
+
+        // Assertion
+        List<String> messages = appender.list.stream()
+                .map(ILoggingEvent::getFormattedMessage)
+                .filter(message -> message.contains("DENIED"))
+                .toList();
+        assertFalse(messages.isEmpty(), "Error body should be logged");
+        messages.forEach(message -> assertFalse(message.contains("s3cr3t-error-token"), message));
+    }
+
+    @Test
+    void shouldVerifyDigestOfStreamedAndFetchedBlobs(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir)
+            throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String digest = SupportedAlgorithm.SHA256.digest("blob-data".getBytes());
+
+        // No Docker-Content-Digest header, only the descriptor digest can catch the corruption
+        wireMock.register(get(urlEqualTo("/v2/library/corrupted-stream/blobs/%s".formatted(digest)))
+                .willReturn(aResponse().withStatus(200).withBody("corrupted-data")));
+
+        Registry registry = Registry.Builder.builder().withInsecure(true).build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/corrupted-stream".formatted(registryUrl)).withDigest(digest);
+
+        assertThrows(DigestMismatchException.class, () -> registry.getBlob(containerRef));
+        try (InputStream is = registry.fetchBlob(containerRef)) {
+            assertThrows(DigestMismatchException.class, is::readAllBytes);
+        }
+
+        // Nothing is written to the destination
+        Path target = dir.resolve("blob");
+        assertThrows(DigestMismatchException.class, () -> registry.fetchBlob(containerRef, target));
+        assertFalse(Files.exists(target));
+        try (var files = Files.list(dir)) {
+            assertEquals(0, files.count());
+        }
+    }
+
+    @Test
+    void shouldGetBinaryBlob(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        // Not valid UTF-8
+        byte[] data = new byte[] {(byte) 0x1f, (byte) 0x8b, (byte) 0xff, (byte) 0xfe, 0x00, (byte) 0xc3, 0x28};
+        String digest = SupportedAlgorithm.SHA256.digest(data);
+        String path = "/v2/library/binary-blob/blobs/%s".formatted(digest);
+        wireMock.register(get(urlEqualTo(path))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)
+                        .withBody(data)));
+
+        BlobCache cache = BlobCache.of(dir.resolve("cache"), 1024 * 1024);
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withBlobCache(cache)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/binary-blob".formatted(registryUrl)).withDigest(digest);
+
+        // Assertion
+        assertArrayEquals(data, registry.getBlob(containerRef));
+        assertTrue(cache.contains(digest));
+        assertArrayEquals(data, registry.getBlob(containerRef));
+        wireMock.verifyThat(1, getRequestedFor(urlEqualTo(path)));
+    }
+
+    @Test
+    void shouldResumeInterruptedBlobDownloadWithRangeRequest(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir)
+            throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        byte[] data = new byte[128 * 1024];
+        for (int i = 0; i < data.length; i++) {
+            data[i] = (byte) (i % 251);
+        }
+        int offset = 64 * 1024;
+        String digest = SupportedAlgorithm.SHA256.digest(data);
+        String path = "/v2/library/resume-download/blobs/%s".formatted(digest);
+
+        // The first response announces the full length but the connection closes after the first part
+        wireMock.register(get(urlEqualTo(path))
+                .atPriority(2)
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_LENGTH_HEADER, String.valueOf(data.length))
+                        .withBody(Arrays.copyOfRange(data, 0, offset))));
+        wireMock.register(get(urlEqualTo(path))
+                .atPriority(1)
+                .withHeader(Const.RANGE_HEADER, equalTo("bytes=%d-".formatted(offset)))
+                .willReturn(aResponse()
+                        .withStatus(206)
+                        .withHeader(
+                                Const.CONTENT_RANGE_HEADER,
+                                "bytes %d-%d/%d".formatted(offset, data.length - 1, data.length))
+                        .withBody(Arrays.copyOfRange(data, offset, data.length))));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/resume-download".formatted(registryUrl)).withDigest(digest);
+        Path target = dir.resolve("blob");
+        registry.fetchBlob(containerRef, target);
+
+        // Assertion
+        assertEquals(digest, SupportedAlgorithm.SHA256.digest(target));
+        wireMock.verifyThat(
+                1,
+                getRequestedFor(urlEqualTo(path))
+                        .withHeader(Const.RANGE_HEADER, equalTo("bytes=%d-".formatted(offset))));
+
+        // Without resume attempts the interrupted download fails and nothing is written
+        Registry noResume = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withDownloadResumeAttempts(0)
+                .build();
+        Path other = dir.resolve("other");
+        assertThrows(OrasException.class, () -> noResume.fetchBlob(containerRef, other));
+        assertFalse(Files.exists(other));
+    }
+
+    @Test
+    void shouldRejectOversizedManifestsAndBlobs(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String manifestJson = JsonUtils.toJson(Manifest.empty());
+        String manifestDigest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+        wireMock.register(head(urlEqualTo("/v2/library/size-limits/manifests/latest"))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)));
+        wireMock.register(get(urlEqualTo("/v2/library/size-limits/manifests/latest"))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)
+                        .withBody(manifestJson)));
+        String blobDigest = SupportedAlgorithm.SHA256.digest("blob-data".getBytes());
+        wireMock.register(get(urlEqualTo("/v2/library/size-limits/blobs/%s".formatted(blobDigest)))
+                .willReturn(WireMock.ok("blob-data")));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withMaxManifestSize(16)
+                .withMaxBlobSize(4)
+                .build();
+        ContainerRef containerRef = ContainerRef.parse("%s/library/size-limits:latest".formatted(registryUrl));
+        ContainerRef blobRef = containerRef.withDigest(blobDigest);
+
+        // Assertion
+        ContentTooLargeException e =
+                assertThrows(ContentTooLargeException.class, () -> registry.getManifest(containerRef));
+        assertEquals(16, e.getMaxSize());
+        assertThrows(ContentTooLargeException.class, () -> registry.getBlob(blobRef));
+        assertThrows(ContentTooLargeException.class, () -> registry.fetchBlob(blobRef));
+        Path target = dir.resolve("blob");
+        assertThrows(ContentTooLargeException.class, () -> registry.fetchBlob(blobRef, target));
+        assertFalse(Files.exists(target));
+
+        // Default limits accept the content
+        Registry defaults = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        assertNotNull(defaults.getManifest(containerRef));
+        assertEquals("blob-data", new String(defaults.getBlob(blobRef), StandardCharsets.UTF_8));
+    }
+
+    @Test
+    void shouldServeBlobsFromLocalCache(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir) throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String digest = SupportedAlgorithm.SHA256.digest("blob-data".getBytes());
+        String path = "/v2/library/blob-cache/blobs/%s".formatted(digest);
+        wireMock.register(get(urlEqualTo(path)).willReturn(WireMock.ok("blob-data")));
+
+        BlobCache cache = BlobCache.of(dir.resolve("cache"), 1024 * 1024);
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withBlobCache(cache)
+                .build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/blob-cache".formatted(registryUrl)).withDigest(digest);
+        registry.fetchBlob(containerRef, dir.resolve("first"));
+
+        // Served from the cache, even by another registry sharing it
+        Registry other = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withBlobCache(cache)
+                .build();
+        other.fetchBlob(containerRef, dir.resolve("second"));
+        assertEquals("blob-data", new String(other.getBlob(containerRef), StandardCharsets.UTF_8));
+        try (InputStream is = other.fetchBlob(containerRef)) {
+            assertEquals("blob-data", new String(is.readAllBytes(), StandardCharsets.UTF_8));
+        }
+
+        // Assertion
+        assertTrue(cache.contains(digest));
+        assertEquals("blob-data", Files.readString(dir.resolve("second")));
+        wireMock.verifyThat(1, getRequestedFor(urlEqualTo(path)));
+    }
+
+    @Test
+    void shouldFetchNonDistributableLayerFromUrls(WireMockRuntimeInfo wmRuntimeInfo) throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        byte[] data = "foreign-layer".getBytes(StandardCharsets.UTF_8);
+        String digest = SupportedAlgorithm.SHA256.digest(data);
+        wireMock.register(get(urlEqualTo("/v2/library/foreign/blobs/%s".formatted(digest)))
+                .willReturn(aResponse().withStatus(404)));
+        wireMock.register(get(urlEqualTo("/external/foreign.tar")).willReturn(aResponse().withBody(data)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+        ContainerRef containerRef = ContainerRef.parse("%s/library/foreign:latest".formatted(registryUrl));
+        Layer layer = Layer.fromDigest(digest, data.length)
+                .withMediaType(Const.DOCKER_FOREIGN_LAYER_MEDIA_TYPE)
+                .withUrls(List.of(
+                        "ftp://example.com/foreign.tar", wmRuntimeInfo.getHttpBaseUrl() + "/external/foreign.tar"));
+
+        // Assertion
+        try (InputStream is = registry.fetchLayer(containerRef, layer)) {
+            assertEquals("foreign-layer", new String(is.readAllBytes(), StandardCharsets.UTF_8));
+        }
+        wireMock.verifyThat(
+                getRequestedFor(urlEqualTo("/external/foreign.tar")).withoutHeader(Const.AUTHORIZATION_HEADER));
+
+        // Without URLs the registry error is surfaced
+        Layer withoutUrls = layer.withUrls(List.of());
+        OrasException e = assertThrows(
+                OrasException.class, () -> registry.fetchLayer(containerRef, withoutUrls).close());
+        assertEquals(404, e.getStatusCode());
+    }
+
+        @Test
+    void shouldVerifyDigestOfManifestPulledByDigest(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String manifestJson = Manifest.empty().toJson();
+        String digest = SupportedAlgorithm.SHA256.digest("other".getBytes());
+
+        wireMock.register(any(urlEqualTo("/v2/library/corrupted-manifest/manifests/%s".formatted(digest)))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withBody(manifestJson)));
+
+        Registry registry = Registry.Builder.builder().withInsecure(true).build();
+        ContainerRef containerRef =
+                ContainerRef.parse("%s/library/corrupted-manifest@%s".formatted(registryUrl, digest));
+
+        DigestMismatchException e =
+                assertThrows(DigestMismatchException.class, () -> registry.getManifest(containerRef));
+        assertEquals(digest, e.getExpectedDigest());
+        assertEquals(SupportedAlgorithm.SHA256.digest(manifestJson.getBytes()), e.getActualDigest());
+    }
+
     @Test
     void shouldHandleCorruptedResponse(WireMockRuntimeInfo wmRuntimeInfo) {
         WireMock wireMock = wmRuntimeInfo.getWireMock();
@@ -1092,6 +2138,48 @@ class RegistryWireMockTest {
                 exception.getMessage());
     }
 
+    private byte[] oauth2Scenario(
+            WireMockRuntimeInfo wmRuntimeInfo, String registryName, AuthProvider authProvider, String grant) {
+        String digest = SupportedAlgorithm.SHA256.digest("blob-data".getBytes());
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+
+        // Challenge without token, return data with the access token
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/%s/blobs/%s".formatted(registryName, digest)))
+                .atPriority(2)
+                .willReturn(WireMock.unauthorized()
+                        .withHeader(
+                                Const.WWW_AUTHENTICATE_HEADER,
+                                "Bearer realm=\"http://localhost:%d/oauth2/%s/token\",service=\"localhost\",scope=\"repository:library/%s:pull\""
+                                        .formatted(wmRuntimeInfo.getHttpPort(), registryName, registryName))));
+        wireMock.register(WireMock.get(WireMock.urlEqualTo("/v2/library/%s/blobs/%s".formatted(registryName, digest)))
+                .atPriority(1)
+                .withHeader(Const.AUTHORIZATION_HEADER, equalTo("Bearer %s-access-token".formatted(registryName)))
+                .willReturn(
+                        WireMock.ok().withBody("blob-data").withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, digest)));
+
+        // GET flow is not supported
+        wireMock.register(WireMock.get(WireMock.urlPathEqualTo("/oauth2/%s/token".formatted(registryName)))
+                .willReturn(WireMock.notFound()));
+
+        // POST flow
+        wireMock.register(WireMock.post(WireMock.urlEqualTo("/oauth2/%s/token".formatted(registryName)))
+                .withHeader(Const.CONTENT_TYPE_HEADER, equalTo(Const.APPLICATION_FORM_URLENCODED_HEADER_VALUE))
+                .withRequestBody(containing(grant))
+                .withRequestBody(containing("scope=repository%%3Alibrary%%2F%s%%3Apull".formatted(registryName)))
+                .withRequestBody(containing("client_id=" + Const.OAUTH2_CLIENT_ID))
+                .willReturn(WireMock.okJson(JsonUtils.toJson(new HttpClient.TokenResponse(
+                        null, "%s-access-token".formatted(registryName), null, 300, ZonedDateTime.now())))));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        ContainerRef containerRef =
+                ContainerRef.parse("localhost:%d/library/%s".formatted(wmRuntimeInfo.getHttpPort(), registryName));
+        return registry.getBlob(containerRef.withDigest(digest));
+    }
+
     private byte[] tokenScenario(
             WireMockRuntimeInfo wmRuntimeInfo, String registryName, String token, String accessToken) {
         String digest = SupportedAlgorithm.SHA256.digest("blob-data".getBytes());
@@ -1132,6 +2220,70 @@ class RegistryWireMockTest {
         return registry.getBlob(containerRef.withDigest(digest));
     }
 
+    @Test
+    void shouldEnforceDigestPinning(WireMockRuntimeInfo wmRuntimeInfo) throws Exception {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        byte[] blobContent = "pinned content".getBytes(StandardCharsets.UTF_8);
+        String blobDigest = SupportedAlgorithm.SHA256.digest(blobContent);
+        Layer layer = Layer.fromDigest(blobDigest, blobContent.length)
+                .withAnnotations(Map.of(Const.ANNOTATION_TITLE, "pinned.txt"));
+        String manifestJson = Manifest.empty().withLayers(List.of(layer)).toJson();
+        String manifestDigest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        wireMock.register(head(urlEqualTo("/v2/library/pinned/manifests/latest"))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)));
+        wireMock.register(get(urlEqualTo("/v2/library/pinned/manifests/latest"))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)
+                        .withBody(manifestJson)));
+        wireMock.register(get(urlEqualTo("/v2/library/pinned/blobs/%s".formatted(blobDigest)))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withBody(blobContent)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, blobDigest)));
+
+        ContainerRef containerRef = ContainerRef.parse("%s/library/pinned:latest".formatted(registryUrl));
+        Path outputDir = configDir.resolve("pinned-output");
+        Files.createDirectories(outputDir);
+
+        // Tag references are rejected
+        Registry pinned = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withDigestPinning(true)
+                .build();
+        assertThrows(OrasException.class, () -> pinned.getManifest(containerRef));
+        assertThrows(OrasException.class, () -> pinned.probeDescriptor(containerRef));
+        assertThrows(OrasException.class, () -> pinned.pullArtifact(containerRef, outputDir, true));
+        assertFalse(Files.exists(outputDir.resolve("pinned.txt")));
+
+        // Explicitly allowed tag reference
+        Registry allowed = Registry.Builder.builder()
+                .from(pinned)
+                .withAllowedTagReference(containerRef.toString())
+                .build();
+        String expected = SupportedAlgorithm.SHA256.digest("other".getBytes(StandardCharsets.UTF_8));
+        assertThrows(
+                OrasException.class,
+                () -> allowed.pullArtifactWithDigest(
+                        containerRef, outputDir, OCI.PullOptions.defaults().withExpectedDigest(expected)));
+        assertFalse(Files.exists(outputDir.resolve("pinned.txt")));
+
+        String digest = allowed.pullArtifactWithDigest(
+                containerRef, outputDir, OCI.PullOptions.defaults().withExpectedDigest(manifestDigest));
+
+        // Assertion
+        assertEquals(manifestDigest, digest);
+        assertEquals("pinned content", Files.readString(outputDir.resolve("pinned.txt")));
+    }
+
     @Test
     void pullArtifactShouldRejectInvalidTitleAnnotation(WireMockRuntimeInfo wmRuntimeInfo) throws Exception {
         WireMock wireMock = wmRuntimeInfo.getWireMock();
@@ -1205,11 +2357,130 @@ class RegistryWireMockTest {
     }
 
     @Test
-    void shouldRetryOn429WithRetryAfterHeader(WireMockRuntimeInfo wmRuntimeInfo) {
+    void shouldSendRequestsThroughCustomTransport(WireMockRuntimeInfo wmRuntimeInfo) {
         WireMock wireMock = wmRuntimeInfo.getWireMock();
         String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        wireMock.register(get(urlEqualTo("/v2/library/custom-transport/tags/list"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("custom-transport", List.of("latest"))))));
+
+        java.net.http.HttpClient jdkClient = java.net.http.HttpClient.newHttpClient();
+        List<URI> sent = new java.util.concurrent.CopyOnWriteArrayList<>();
+        HttpTransport transport = new HttpTransport() {
+            @Override
+            public <T> java.net.http.HttpResponse<T> send(
+                    java.net.http.HttpRequest request, java.net.http.HttpResponse.BodyHandler<T> handler)
+                    throws IOException, InterruptedException {
+                sent.add(request.uri());
+                return jdkClient.send(request, handler);
+            }
+        };
 
-        // First call: 429 with Retry-After: 0 (immediate retry in tests)
+        Registry registry =
+                Registry.Builder.builder().withInsecure(true).withTransport(transport).build();
+        ContainerRef ref = ContainerRef.parse("%s/library/custom-transport".formatted(registryUrl));
+        assertEquals(List.of("latest"), registry.getTags(ref).tags());
+        assertEquals(
+                List.of(URI.create("http://%s/v2/library/custom-transport/tags/list".formatted(registryUrl))), sent);
+
+        // TLS settings belong to the transport
+        assertThrows(
+                OrasException.class,
+                () -> Registry.Builder.builder()
+                        .withHttpClient(jdkClient)
+                        .withSkipTlsVerify(true)
+                        .build());
+    }
+
+    @Test
+    void shouldSendRequestsThroughProxy(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+
+        // WireMock acts as the forward proxy for a registry host that doesn't resolve
+        wireMock.register(get(urlEqualTo("/v2/library/proxied/tags/list"))
+                .withHost(equalTo("registry.proxied.test"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("proxied", List.of("latest"))))));
+
+        Registry registry = Registry.Builder.builder()
+                .withInsecure(true)
+                .withProxy(ProxyConfig.of("localhost", wmRuntimeInfo.getHttpPort()))
+                .build();
+        ContainerRef ref = ContainerRef.parse("registry.proxied.test/library/proxied");
+        assertEquals(List.of("latest"), registry.getTags(ref).tags());
+    }
+
+    @Test
+    void shouldListTagsFollowingLinkHeaders(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String path = "/v2/library/paged-tags/tags/list";
+        wireMock.register(get(urlEqualTo(path + "?n=2"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("paged-tags", List.of("a", "b"))))
+                        .withHeader(Const.LINK_HEADER, "<%s?n=2&last=b>; rel=\"next\"".formatted(path))));
+        wireMock.register(get(urlEqualTo(path + "?n=2&last=b"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("paged-tags", List.of("c", "d"))))
+                        .withHeader(Const.LINK_HEADER, "<%s?n=2&last=d>; rel=\"next\"".formatted(path))));
+        wireMock.register(get(urlEqualTo(path + "?n=2&last=d"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("paged-tags", List.of("e"))))));
+
+        Registry registry = Registry.Builder.builder().withInsecure(true).build();
+        ContainerRef ref = ContainerRef.parse("%s/library/paged-tags".formatted(registryUrl));
+
+        // Pages are fetched lazily
+        assertEquals(List.of("a"), registry.listTags(ref, 2, null).limit(1).toList());
+        WireMock.verify(0, getRequestedFor(urlEqualTo(path + "?n=2&last=b")));
+
+        assertEquals(List.of("a", "b", "c", "d", "e"), registry.listTags(ref, 2, null).toList());
+        assertEquals(List.of("c", "d", "e"), registry.listTags(ref, 2, "b").toList());
+    }
+
+    @Test
+    void shouldListRepositoriesFollowingLinkHeaders() {
+        WireMockServer server = new WireMockServer(WireMockConfiguration.options().dynamicPort());
+        server.start();
+        try {
+            String registryUrl = "localhost:%d".formatted(server.port());
+            server.stubFor(get(urlEqualTo("/v2/_catalog?n=2"))
+                    .willReturn(okJson(JsonUtils.toJson(new Repositories(List.of("other/app", "team/app"))))
+                            .withHeader(Const.LINK_HEADER, "</v2/_catalog?n=2&last=team%2Fapp>; rel=\"next\"")));
+            server.stubFor(get(urlEqualTo("/v2/_catalog?n=2&last=team%2Fapp"))
+                    .willReturn(okJson(JsonUtils.toJson(new Repositories(List.of("team/sub/app", "teamx/app"))))));
+
+            Registry registry = Registry.Builder.builder()
+                    .withInsecure(true)
+                    .withRegistry(registryUrl)
+                    .build();
+            assertEquals(
+                    List.of("other/app", "team/app", "team/sub/app", "teamx/app"),
+                    registry.listRepositories(2, null, null).toList());
+            assertEquals(
+                    List.of("team/app", "team/sub/app"),
+                    registry.listRepositories(2, null, "team").toList());
+        } finally {
+            server.stop();
+        }
+    }
+
+    @Test
+    void shouldUsePlainHttpOnlyForConfiguredHost(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        wireMock.register(get(urlEqualTo("/v2/library/plain-http-host/tags/list"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("plain-http-host", List.of("latest"))))));
+
+        // Registry is secure by default, only the local host is reached over plain HTTP
+        Registry registry = Registry.Builder.builder().withHostConfig(registryUrl, HostConfig.plainHttp()).build();
+        assertFalse(registry.isInsecure());
+        ContainerRef ref = ContainerRef.parse("%s/library/plain-http-host".formatted(registryUrl));
+        assertEquals(List.of("latest"), registry.getTags(ref).tags());
+        assertTrue(ref.isInsecure(registry));
+    }
+
+    @Test
+    void shouldRetryOn429WithRetryAfterHeader(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        // First call: 429 with Retry-After: 0 (immediate retry in tests)
         wireMock.register(get(urlEqualTo("/v2/library/rate-limited-retry/tags/list"))
                 .inScenario("rate-limit-retry")
                 .whenScenarioStateIs(Scenario.STARTED)
@@ -1239,6 +2510,69 @@ class RegistryWireMockTest {
         WireMock.verify(2, getRequestedFor(urlEqualTo("/v2/library/rate-limited-retry/tags/list")));
     }
 
+    @Test
+    void shouldExposeRateLimitMetadata(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        wireMock.register(get(urlEqualTo("/v2/library/rate-limit-metadata/tags/list"))
+                .willReturn(aResponse()
+                        .withStatus(429)
+                        .withHeader(Const.RETRY_AFTER_HEADER, "60")
+                        .withHeader(Const.RATE_LIMIT_LIMIT_HEADER, "100;w=21600")
+                        .withHeader(Const.RATE_LIMIT_REMAINING_HEADER, "0;w=21600")
+                        .withHeader(Const.RATE_LIMIT_SOURCE_HEADER, "192.0.2.1")
+                        .withBody("Rate limited")));
+
+        Registry registry = Registry.Builder.builder()
+                .withInsecure(true)
+                .withRetryPolicy(RetryPolicy.none())
+                .build();
+
+        ContainerRef ref = ContainerRef.parse("%s/library/rate-limit-metadata".formatted(registryUrl));
+        RateLimitException exception = assertThrows(RateLimitException.class, () -> registry.getTags(ref));
+
+        // Assertion
+        assertEquals(429, exception.getStatusCode());
+        assertEquals(Duration.ofSeconds(60), exception.getRetryAfter());
+        assertEquals(100L, exception.getLimit());
+        assertEquals(0L, exception.getRemaining());
+        assertEquals(Duration.ofHours(6), exception.getWindow());
+        assertEquals("192.0.2.1", exception.getSource());
+    }
+
+    @Test
+    void shouldRetryOn503WithRetryAfterHeader(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        wireMock.register(get(urlEqualTo("/v2/library/unavailable-retry/tags/list"))
+                .inScenario("unavailable-retry")
+                .whenScenarioStateIs(Scenario.STARTED)
+                .willReturn(aResponse().withStatus(503).withHeader(Const.RETRY_AFTER_HEADER, "0"))
+                .willSetStateTo("retry"));
+        wireMock.register(get(urlEqualTo("/v2/library/unavailable-retry/tags/list"))
+                .inScenario("unavailable-retry")
+                .whenScenarioStateIs("retry")
+                .willReturn(okJson(JsonUtils.toJson(new Tags("unavailable-retry", List.of("latest"))))));
+
+        // The backoff alone would wait far longer than the test timeout
+        Registry registry = Registry.Builder.builder()
+                .withInsecure(true)
+                .withRetryPolicy(RetryPolicy.defaults()
+                        .withJitter(0)
+                        .withInitialDelay(Duration.ofMinutes(1))
+                        .withMaxDelay(Duration.ofMinutes(1)))
+                .build();
+
+        ContainerRef ref = ContainerRef.parse("%s/library/unavailable-retry".formatted(registryUrl));
+        Tags tags = registry.getTags(ref);
+
+        // Assertion
+        assertEquals(List.of("latest"), tags.tags());
+        WireMock.verify(2, getRequestedFor(urlEqualTo("/v2/library/unavailable-retry/tags/list")));
+    }
+
     @Test
     void shouldRetryOn429WithExponentialBackoff(WireMockRuntimeInfo wmRuntimeInfo) {
         WireMock wireMock = wmRuntimeInfo.getWireMock();
@@ -1349,6 +2683,46 @@ class RegistryWireMockTest {
         WireMock.verify(3, getRequestedFor(urlEqualTo("/v2/library/always-fails/tags/list")));
     }
 
+    @Test
+    void shouldNotRetryStreamedUploadUnlessAllowed(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        byte[] content = "streamed".getBytes(StandardCharsets.UTF_8);
+        String digest = SupportedAlgorithm.SHA256.digest(content);
+
+        wireMock.register(head(urlPathMatching("/v2/library/streamed-retry/blobs/.*"))
+                .willReturn(aResponse().withStatus(404)));
+        wireMock.register(post(urlPathEqualTo("/v2/library/streamed-retry/blobs/uploads/"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/streamed")));
+        wireMock.register(put(urlPathEqualTo("/upload/streamed")).willReturn(aResponse().withStatus(500)));
+
+        ContainerRef ref = ContainerRef.parse("%s/library/streamed-retry@%s".formatted(registryUrl, digest));
+
+        // Default policy: the stream is not replayed
+        Registry registry = Registry.Builder.builder()
+                .withInsecure(true)
+                .withRetryDelay(0)
+                .withMaxRetries(3)
+                .build();
+        assertThrows(
+                OrasException.class,
+                () -> registry.pushBlob(ref, content.length, () -> new ByteArrayInputStream(content), Map.of()));
+        WireMock.verify(1, putRequestedFor(urlPathEqualTo("/upload/streamed")));
+
+        // Explicitly allowed
+        Registry retrying = Registry.Builder.builder()
+                .withInsecure(true)
+                .withRetryPolicy(RetryPolicy.defaults()
+                        .withMaxAttempts(3)
+                        .withInitialDelay(Duration.ZERO)
+                        .withRetryNonIdempotent(true))
+                .build();
+        assertThrows(
+                OrasException.class,
+                () -> retrying.pushBlob(ref, content.length, () -> new ByteArrayInputStream(content), Map.of()));
+        WireMock.verify(4, putRequestedFor(urlPathEqualTo("/upload/streamed")));
+    }
+
     @Test
     void shouldNotRetryTokenRefreshRequest(WireMockRuntimeInfo wmRuntimeInfo) {
         WireMock wireMock = wmRuntimeInfo.getWireMock();
@@ -1381,6 +2755,666 @@ class RegistryWireMockTest {
         WireMock.verify(1, getRequestedFor(urlPathEqualTo("/token")));
     }
 
+    @Test
+    void shouldUseChunkedUploadWhenChunkSizeConfigured(WireMockRuntimeInfo wmRuntimeInfo) throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        // Blob does not exist yet
+        wireMock.register(head(urlPathMatching("/v2/library/chunked-builder/blobs/.*"))
+                .willReturn(aResponse().withStatus(404)));
+
+        // Open the upload session
+        wireMock.register(post(urlPathEqualTo("/v2/library/chunked-builder/blobs/uploads/"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/session")));
+
+        // Accept chunks and finalize
+        wireMock.register(patch(urlPathEqualTo("/upload/session"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/session")));
+        wireMock.register(put(urlPathEqualTo("/upload/session")).willReturn(aResponse().withStatus(201)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withChunkSize(4L)
+                .build();
+
+        byte[] content = "hello chunked".getBytes(StandardCharsets.UTF_8);
+        Path blobFile = configDir.resolve("chunked-builder.txt");
+        Files.write(blobFile, content);
+        ContainerRef ref = ContainerRef.parse("%s/library/chunked-builder".formatted(registryUrl));
+
+        Layer layer = registry.pushBlob(ref, blobFile);
+        assertEquals(SupportedAlgorithm.SHA256.digest(content), layer.getDigest());
+
+        // 13 bytes in 4-byte chunks
+        wireMock.verifyThat(4, patchRequestedFor(urlPathEqualTo("/upload/session")));
+        wireMock.verifyThat(
+                1,
+                patchRequestedFor(urlPathEqualTo("/upload/session"))
+                        .withHeader(Const.CONTENT_RANGE_HEADER, equalTo("12-12")));
+        wireMock.verifyThat(1, putRequestedFor(urlPathEqualTo("/upload/session")));
+
+        // Blobs fitting in one chunk are uploaded monolithically, from a file or from a stream
+        byte[] small = "tiny".getBytes(StandardCharsets.UTF_8);
+        Path smallFile = configDir.resolve("chunked-builder-small.txt");
+        Files.write(smallFile, small);
+        registry.pushBlob(ref, smallFile);
+        String smallDigest = SupportedAlgorithm.SHA256.digest(small);
+        registry.pushBlob(ref.withDigest(smallDigest), small.length, () -> new ByteArrayInputStream(small), Map.of());
+        wireMock.verifyThat(4, patchRequestedFor(urlPathEqualTo("/upload/session")));
+        wireMock.verifyThat(3, putRequestedFor(urlPathEqualTo("/upload/session")));
+    }
+
+    @Test
+    void shouldUseHttp1AndLimitRequestsPerHost(WireMockRuntimeInfo wmRuntimeInfo) throws Exception {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        wireMock.register(get(urlEqualTo("/v2/library/limited/tags/list"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("limited", List.of("latest"))))
+                        .withFixedDelay(100)));
+
+        java.net.http.HttpClient jdkClient = java.net.http.HttpClient.newHttpClient();
+        List<java.net.http.HttpClient.Version> versions = new CopyOnWriteArrayList<>();
+        AtomicInteger inFlight = new AtomicInteger();
+        AtomicInteger maxInFlight = new AtomicInteger();
+        HttpTransport transport = new HttpTransport() {
+            @Override
+            public <T> java.net.http.HttpResponse<T> send(
+                    java.net.http.HttpRequest request, java.net.http.HttpResponse.BodyHandler<T> handler)
+                    throws IOException, InterruptedException {
+                request.version().ifPresent(versions::add);
+                maxInFlight.accumulateAndGet(inFlight.incrementAndGet(), Math::max);
+                try {
+                    return jdkClient.send(request, handler);
+                } finally {
+                    inFlight.decrementAndGet();
+                }
+            }
+        };
+
+        Registry registry = Registry.Builder.builder()
+                .withTransport(transport)
+                .withHostConfig(registryUrl, HostConfig.plainHttp().withHttp1(true))
+                .withMaxRequestsPerHost(1)
+                .build();
+        ContainerRef ref = ContainerRef.parse("%s/library/limited".formatted(registryUrl));
+        ExecutorService executor = Executors.newFixedThreadPool(3);
+        try {
+            List<Future<Tags>> futures = new ArrayList<>();
+            for (int i = 0; i < 3; i++) {
+                futures.add(executor.submit(() -> registry.getTags(ref)));
+            }
+            for (Future<Tags> future : futures) {
+                assertEquals(List.of("latest"), future.get().tags());
+            }
+        } finally {
+            executor.shutdownNow();
+        }
+
+        // Assertion
+        assertEquals(1, maxInFlight.get());
+        assertEquals(3, versions.size());
+        assertTrue(versions.stream().allMatch(java.net.http.HttpClient.Version.HTTP_1_1::equals));
+    }
+
+    @Test
+    void shouldHoldHostPermitUntilBodyIsClosed(WireMockRuntimeInfo wmRuntimeInfo) throws Exception {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        byte[] content = "held".getBytes(StandardCharsets.UTF_8);
+        String digest = SupportedAlgorithm.SHA256.digest(content);
+        wireMock.register(get(urlPathEqualTo("/v2/library/held/blobs/%s".formatted(digest)))
+                .willReturn(aResponse().withStatus(200).withBody(content)));
+        wireMock.register(get(urlEqualTo("/v2/library/held/tags/list"))
+                .willReturn(okJson(JsonUtils.toJson(new Tags("held", List.of("latest"))))));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withMaxRequestsPerHost(1)
+                .build();
+        ContainerRef ref = ContainerRef.parse("%s/library/held".formatted(registryUrl));
+        ExecutorService executor = Executors.newSingleThreadExecutor();
+        try {
+            Future<Tags> tags;
+            try (InputStream body = registry.fetchBlob(ref.withDigest(digest))) {
+                tags = executor.submit(() -> registry.getTags(ref));
+
+                // Assertion
+                assertThrows(TimeoutException.class, () -> tags.get(200, TimeUnit.MILLISECONDS));
+                assertArrayEquals(content, body.readAllBytes());
+            }
+            assertEquals(List.of("latest"), tags.get(5, TimeUnit.SECONDS).tags());
+        } finally {
+            executor.shutdownNow();
+        }
+    }
+
+    @Test
+    void shouldAbortUploadSessionWhenCancelled(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir)
+            throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        // Empty config already exists, layer does not
+        wireMock.register(head(urlPathMatching("/v2/library/cancelled/blobs/.*"))
+                .willReturn(aResponse().withStatus(404)));
+        String emptyConfig = SupportedAlgorithm.SHA256.digest("{}".getBytes(StandardCharsets.UTF_8));
+        wireMock.register(head(urlPathEqualTo("/v2/library/cancelled/blobs/%s".formatted(emptyConfig)))
+                .willReturn(aResponse().withStatus(200)));
+        wireMock.register(post(urlPathEqualTo("/v2/library/cancelled/blobs/uploads/"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/cancelled")));
+        wireMock.register(patch(urlPathEqualTo("/upload/cancelled"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/cancelled")));
+        wireMock.register(delete(urlPathEqualTo("/upload/cancelled")).willReturn(aResponse().withStatus(204)));
+
+        // Cancel once the first chunk is read
+        CancellationToken token = CancellationToken.create();
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withTransferListener(new TransferListener() {
+                    @Override
+                    public void onProgress(String digest, long bytesTransferred, long totalSize) {
+                        token.cancel();
+                    }
+                })
+                .build();
+
+        Path file = Files.writeString(dir.resolve("cancelled.txt"), "hello chunked");
+        ContainerRef ref = ContainerRef.parse("%s/library/cancelled".formatted(registryUrl));
+
+        // Assertion
+        assertThrows(
+                OperationCancelledException.class,
+                () -> registry.pushArtifact(
+                        ref,
+                        ArtifactType.unknown(),
+                        Annotations.empty(),
+                        Config.empty(),
+                        OCI.PushOptions.chunked(4L).withCancellation(token),
+                        LocalPath.of(file)));
+        assertTrue(token.isCancelled());
+        wireMock.verifyThat(1, patchRequestedFor(urlPathEqualTo("/upload/cancelled")));
+        wireMock.verifyThat(1, deleteRequestedFor(urlPathEqualTo("/upload/cancelled")));
+        wireMock.verifyThat(0, putRequestedFor(urlPathEqualTo("/upload/cancelled")));
+        wireMock.verifyThat(0, putRequestedFor(urlPathMatching("/v2/library/cancelled/manifests/.*")));
+    }
+
+    @Test
+    @EnabledForJreRange(min = JRE.JAVA_21)
+    void shouldRunTransfersOnVirtualThreads() throws Exception {
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withParallelism(2)
+                .withVirtualThreads(true)
+                .build();
+
+        // Assertion
+        String worker = registry.getExecutorService()
+                .submit(() -> Thread.currentThread().toString())
+                .get();
+        assertTrue(worker.startsWith("VirtualThread"), worker);
+        assertTrue(worker.contains("layer-transfer-worker-"), worker);
+        String async = registry.getAsyncExecutorService()
+                .submit(() -> Thread.currentThread().toString())
+                .get();
+        assertTrue(async.startsWith("VirtualThread"), async);
+    }
+
+    @Test
+    @EnabledForJreRange(max = JRE.JAVA_20)
+    void shouldRequireJava21ForVirtualThreads() {
+        assertThrows(OrasException.class, () -> Registry.Builder.builder().withVirtualThreads(true).build());
+    }
+
+    @Test
+    @EnabledForJreRange(min = JRE.JAVA_21)
+    void shouldRunAsyncOperationsOnVirtualThreadsByDefault() throws Exception {
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        // Assertion
+        String async = registry.getAsyncExecutorService()
+                .submit(() -> Thread.currentThread().toString())
+                .get();
+        assertTrue(async.startsWith("VirtualThread"), async);
+        assertTrue(async.contains("async-worker-"), async);
+    }
+
+    @Test
+    @EnabledForJreRange(max = JRE.JAVA_20)
+    void shouldBoundDefaultAsyncExecutor() throws Exception {
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        // Assertion
+        ThreadPoolExecutor executor = assertInstanceOf(ThreadPoolExecutor.class, registry.getAsyncExecutorService());
+        assertEquals(Registry.DEFAULT_ASYNC_THREADS, executor.getMaximumPoolSize());
+        assertEquals(Registry.DEFAULT_ASYNC_QUEUE_SIZE, executor.getQueue().remainingCapacity());
+        assertTrue(executor.allowsCoreThreadTimeOut());
+        assertTrue(executor.submit(() -> Thread.currentThread().isDaemon()).get());
+    }
+
+    @Test
+    void shouldApplyBufferSizeAndReadTimeout(WireMockRuntimeInfo wmRuntimeInfo, @TempDir Path dir) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        byte[] data = "content streamed with a tiny buffer".getBytes(StandardCharsets.UTF_8);
+        String digest = SupportedAlgorithm.SHA256.digest(data);
+        wireMock.register(get(urlEqualTo("/v2/library/tuning/blobs/%s".formatted(digest)))
+                .willReturn(aResponse().withStatus(200).withBody(data)));
+        wireMock.register(head(urlEqualTo("/v2/library/slow/manifests/latest"))
+                .willReturn(aResponse().withStatus(200).withFixedDelay(2000)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withRetryPolicy(RetryPolicy.none())
+                .withBufferSize(3)
+                .withConnectTimeout(Duration.ofSeconds(5))
+                .withReadTimeout(Duration.ofMillis(200))
+                .build();
+
+        // Assertion
+        Path target = dir.resolve("tuning-blob");
+        registry.fetchBlob(
+                ContainerRef.parse("%s/library/tuning".formatted(registryUrl)).withDigest(digest), target);
+        assertEquals(digest, SupportedAlgorithm.SHA256.digest(target));
+        assertThrows(
+                OrasException.class,
+                () -> registry.probeDescriptor(ContainerRef.parse("%s/library/slow:latest".formatted(registryUrl))));
+
+        // Invalid values are rejected
+        assertThrows(OrasException.class, () -> Registry.Builder.builder().withBufferSize(0));
+        assertThrows(OrasException.class, () -> Registry.Builder.builder().withReadTimeout(Duration.ZERO));
+    }
+
+    @Test
+    void shouldPushBlobStreamOfUnknownLength(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        wireMock.register(post(urlPathEqualTo("/v2/library/unknown-size/blobs/uploads/"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/unknown-size")));
+        wireMock.register(patch(urlPathEqualTo("/upload/unknown-size"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/unknown-size")));
+        wireMock.register(put(urlPathEqualTo("/upload/unknown-size")).willReturn(aResponse().withStatus(201)));
+        wireMock.register(delete(urlPathEqualTo("/upload/unknown-size")).willReturn(aResponse().withStatus(204)));
+
+        List<String> started = new CopyOnWriteArrayList<>();
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withChunkSize(4L)
+                .withTransferListener(new TransferListener() {
+                    @Override
+                    public void onStarted(String digest, long totalSize) {
+                        started.add(digest);
+                    }
+                })
+                .build();
+
+        byte[] content = "generated content".getBytes(StandardCharsets.UTF_8);
+        String digest = SupportedAlgorithm.SHA256.digest(content);
+        ContainerRef ref = ContainerRef.parse("%s/library/unknown-size".formatted(registryUrl));
+
+        Layer layer = registry.pushBlob(ref, new ByteArrayInputStream(content));
+        assertEquals(digest, layer.getDigest());
+        assertEquals(content.length, layer.getSize());
+
+        // 17 bytes in 4-byte chunks, digest only known when committing the upload
+        wireMock.verifyThat(5, patchRequestedFor(urlPathEqualTo("/upload/unknown-size")));
+        wireMock.verifyThat(
+                1,
+                putRequestedFor(urlPathEqualTo("/upload/unknown-size")).withQueryParam("digest", equalTo(digest)));
+
+        // Upload is not committed when the content doesn't match the expected digest
+        ContainerRef wrongDigest = ref.withDigest(SupportedAlgorithm.SHA256.digest("other".getBytes()));
+        assertThrows(
+                DigestMismatchException.class,
+                () -> registry.pushBlob(wrongDigest, new ByteArrayInputStream(content)));
+        wireMock.verifyThat(1, putRequestedFor(urlPathEqualTo("/upload/unknown-size")));
+        wireMock.verifyThat(1, deleteRequestedFor(urlPathEqualTo("/upload/unknown-size")));
+
+        // Transfers are started with the digest of the ref if known
+        assertEquals(List.of("", wrongDigest.getDigest()), started);
+    }
+
+    @Test
+    void shouldResumeChunkedUploadFromAcknowledgedOffset(WireMockRuntimeInfo wmRuntimeInfo) throws IOException {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+
+        wireMock.register(head(urlPathMatching("/v2/library/chunked-resume/blobs/.*"))
+                .willReturn(aResponse().withStatus(404)));
+        wireMock.register(post(urlPathEqualTo("/v2/library/chunked-resume/blobs/uploads/"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/resume")));
+
+        // The first PATCH fails after the registry received the first 6 bytes
+        wireMock.register(patch(urlPathEqualTo("/upload/resume"))
+                .withHeader(Const.CONTENT_RANGE_HEADER, equalTo("0-12"))
+                .willReturn(aResponse().withStatus(500)));
+        wireMock.register(get(urlPathEqualTo("/upload/resume"))
+                .willReturn(aResponse()
+                        .withStatus(204)
+                        .withHeader("Location", "/upload/resume")
+                        .withHeader(Const.RANGE_HEADER, "0-5")));
+        wireMock.register(patch(urlPathEqualTo("/upload/resume"))
+                .withHeader(Const.CONTENT_RANGE_HEADER, equalTo("6-12"))
+                .willReturn(aResponse().withStatus(202).withHeader("Location", "/upload/resume")));
+        wireMock.register(put(urlPathEqualTo("/upload/resume")).willReturn(aResponse().withStatus(201)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .withMaxRetries(2)
+                .withRetryDelay(1)
+                .build();
+
+        byte[] content = "hello chunked".getBytes(StandardCharsets.UTF_8);
+        Path blobFile = configDir.resolve("chunked-resume.txt");
+        Files.write(blobFile, content);
+        ContainerRef ref = ContainerRef.parse("%s/library/chunked-resume".formatted(registryUrl));
+
+        Layer layer = registry.pushBlobChunked(ref, blobFile, 1024L);
+        assertEquals(SupportedAlgorithm.SHA256.digest(content), layer.getDigest());
+
+        // Only the remaining bytes are sent again
+        wireMock.verifyThat(
+                1,
+                patchRequestedFor(urlPathEqualTo("/upload/resume"))
+                        .withHeader(Const.CONTENT_RANGE_HEADER, equalTo("6-12"))
+                        .withRequestBody(equalTo("chunked")));
+        wireMock.verifyThat(1, getRequestedFor(urlPathEqualTo("/upload/resume")));
+    }
+
+    @Test
+    void shouldFollowReferrersPagination(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String digest = SupportedAlgorithm.SHA256.digest("subject".getBytes(StandardCharsets.UTF_8));
+        String sbom = SupportedAlgorithm.SHA256.digest("sbom".getBytes(StandardCharsets.UTF_8));
+        String signature = SupportedAlgorithm.SHA256.digest("signature".getBytes(StandardCharsets.UTF_8));
+
+        // First page with link to next page
+        wireMock.register(get(urlEqualTo("/v2/library/referrers-paged/referrers/%s".formatted(digest)))
+                .willReturn(okJson(Referrers.from(List.of(ManifestDescriptor.of(
+                                        Const.DEFAULT_MANIFEST_MEDIA_TYPE, sbom, 10)))
+                                .toJson())
+                        .withHeader(
+                                Const.LINK_HEADER,
+                                "</v2/library/referrers-paged/referrers/%s?next=2>; rel=\"next\"".formatted(digest))));
+
+        // Second and last page
+        wireMock.register(get(urlEqualTo("/v2/library/referrers-paged/referrers/%s?next=2".formatted(digest)))
+                .willReturn(okJson(Referrers.from(List.of(ManifestDescriptor.of(
+                                Const.DEFAULT_MANIFEST_MEDIA_TYPE, signature, 20)))
+                        .toJson())));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        ContainerRef ref =
+                ContainerRef.parse("%s/library/referrers-paged".formatted(registryUrl)).withDigest(digest);
+        Referrers referrers = registry.getReferrers(ref, null);
+        assertEquals(2, referrers.getManifests().size());
+        assertEquals(sbom, referrers.getManifests().get(0).getDigest());
+        assertEquals(signature, referrers.getManifests().get(1).getDigest());
+    }
+
+    @Test
+    void shouldFilterReferrersClientSideWhenNotAppliedByRegistry(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String digest = SupportedAlgorithm.SHA256.digest("subject".getBytes(StandardCharsets.UTF_8));
+        ManifestDescriptor sbom = ManifestDescriptor.of(
+                        Const.DEFAULT_MANIFEST_MEDIA_TYPE,
+                        SupportedAlgorithm.SHA256.digest("sbom".getBytes(StandardCharsets.UTF_8)),
+                        10)
+                .withArtifactType("application/spdx+json");
+        ManifestDescriptor signature = ManifestDescriptor.of(
+                        Const.DEFAULT_MANIFEST_MEDIA_TYPE,
+                        SupportedAlgorithm.SHA256.digest("signature".getBytes(StandardCharsets.UTF_8)),
+                        20)
+                .withArtifactType("application/vnd.dev.cosign.artifact.sig.v1+json");
+        ArtifactType artifactType = ArtifactType.from("application/spdx+json");
+
+        // Registry applying the filter
+        wireMock.register(get(urlPathEqualTo("/v2/library/referrers-filtered/referrers/%s".formatted(digest)))
+                .withQueryParam("artifactType", equalTo("application/spdx+json"))
+                .willReturn(okJson(Referrers.from(List.of(sbom)).toJson())
+                        .withHeader(Const.OCI_FILTERS_APPLIED_HEADER, "artifactType")));
+
+        // Registry ignoring the filter
+        wireMock.register(get(urlPathEqualTo("/v2/library/referrers-unfiltered/referrers/%s".formatted(digest)))
+                .willReturn(okJson(Referrers.from(List.of(sbom, signature)).toJson())));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        Referrers filtered = registry.getReferrers(
+                ContainerRef.parse("%s/library/referrers-filtered".formatted(registryUrl)).withDigest(digest),
+                artifactType);
+        Referrers unfiltered = registry.getReferrers(
+                ContainerRef.parse("%s/library/referrers-unfiltered".formatted(registryUrl)).withDigest(digest),
+                artifactType);
+
+        // Assertion
+        assertEquals(1, filtered.getManifests().size());
+        assertEquals(sbom.getDigest(), filtered.getManifests().get(0).getDigest());
+        assertEquals(1, unfiltered.getManifests().size());
+        assertEquals(sbom.getDigest(), unfiltered.getManifests().get(0).getDigest());
+    }
+
+    @Test
+    void shouldFallbackToReferrersTagSchema(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String digest = SupportedAlgorithm.SHA256.digest("subject".getBytes(StandardCharsets.UTF_8));
+        String sbom = SupportedAlgorithm.SHA256.digest("sbom".getBytes(StandardCharsets.UTF_8));
+        String signature = SupportedAlgorithm.SHA256.digest("signature".getBytes(StandardCharsets.UTF_8));
+
+        // Referrers API not supported
+        wireMock.register(get(urlPathEqualTo("/v2/library/referrers-fallback/referrers/%s".formatted(digest)))
+                .willReturn(aResponse().withStatus(404)));
+
+        // Fallback index stored under the referrers tag
+        Index index = Index.fromManifests(List.of(
+                ManifestDescriptor.of(Const.DEFAULT_MANIFEST_MEDIA_TYPE, sbom, 10)
+                        .withArtifactType("application/spdx+json"),
+                ManifestDescriptor.of(Const.DEFAULT_MANIFEST_MEDIA_TYPE, signature, 20)
+                        .withArtifactType("application/vnd.dev.sigstore.bundle.v0.3+json")));
+        String referrersTag = digest.replace(':', '-');
+        wireMock.register(get(urlEqualTo("/v2/library/referrers-fallback/manifests/%s".formatted(referrersTag)))
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE)
+                        .withBody(index.toJson())));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        ContainerRef ref =
+                ContainerRef.parse("%s/library/referrers-fallback".formatted(registryUrl)).withDigest(digest);
+        Referrers referrers = registry.getReferrers(ref, null);
+        assertEquals(2, referrers.getManifests().size());
+
+        // Filtered client side
+        referrers = registry.getReferrers(ref, ArtifactType.from("application/spdx+json"));
+        assertEquals(1, referrers.getManifests().size());
+        assertEquals(sbom, referrers.getManifests().get(0).getDigest());
+
+        // No fallback index
+        String other = SupportedAlgorithm.SHA256.digest("other".getBytes(StandardCharsets.UTF_8));
+        wireMock.register(get(urlPathMatching("/v2/library/referrers-fallback/(referrers|manifests)/sha256.%s"
+                        .formatted(SupportedAlgorithm.getDigest(other))))
+                .willReturn(aResponse().withStatus(404)));
+        assertEquals(0, registry.getReferrers(ref.withDigest(other), null).getManifests().size());
+    }
+
+    @Test
+    void shouldUpdateReferrersTagSchemaWhenSubjectHeaderMissing(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String subjectDigest = SupportedAlgorithm.SHA256.digest("subject".getBytes(StandardCharsets.UTF_8));
+        String referrersTag = subjectDigest.replace(':', '-');
+
+        Manifest manifest = Manifest.empty()
+                .withArtifactType(ArtifactType.from("application/spdx+json"))
+                .withSubject(Subject.of(Const.DEFAULT_MANIFEST_MEDIA_TYPE, subjectDigest, 10));
+        String manifestJson = manifest.toJson();
+        String manifestDigest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        // Registry accepts the manifest but doesn't return the OCI-Subject header
+        wireMock.register(put(urlEqualTo("/v2/library/referrers-push/manifests/%s".formatted(manifestDigest)))
+                .willReturn(aResponse().withStatus(201)));
+        wireMock.register(any(urlEqualTo("/v2/library/referrers-push/manifests/%s".formatted(manifestDigest)))
+                .atPriority(10)
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)
+                        .withBody(manifestJson)));
+
+        // Referrers tag doesn't exist until pushed
+        wireMock.register(get(urlEqualTo("/v2/library/referrers-push/manifests/%s".formatted(referrersTag)))
+                .inScenario("referrers tag")
+                .whenScenarioStateIs(Scenario.STARTED)
+                .willReturn(aResponse().withStatus(404)));
+        wireMock.register(put(urlEqualTo("/v2/library/referrers-push/manifests/%s".formatted(referrersTag)))
+                .inScenario("referrers tag")
+                .willSetStateTo("pushed")
+                .willReturn(aResponse().withStatus(201)));
+        wireMock.register(any(urlEqualTo("/v2/library/referrers-push/manifests/%s".formatted(referrersTag)))
+                .inScenario("referrers tag")
+                .whenScenarioStateIs("pushed")
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE)
+                        .withBody(Index.fromManifests(List.of()).toJson())));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        ContainerRef ref = ContainerRef.parse("%s/library/referrers-push".formatted(registryUrl))
+                .withDigest(manifestDigest);
+        registry.pushManifest(ref, manifest);
+
+        WireMock.verify(putRequestedFor(urlEqualTo("/v2/library/referrers-push/manifests/%s".formatted(referrersTag)))
+                .withRequestBody(containing(manifestDigest))
+                .withRequestBody(containing("application/spdx+json")));
+    }
+
+    @Test
+    void shouldNotSignFallbackReferrersIndex() {
+        WireMockServer server =
+                new WireMockServer(WireMockConfiguration.options().dynamicPort().extensions(new ManifestStore()));
+        server.start();
+        try {
+            String registryUrl = "localhost:%d".formatted(server.port());
+
+            // Blobs already exist, manifests are stored without OCI-Subject header and no referrers API
+            server.stubFor(head(urlPathMatching("/v2/library/signed-fallback/blobs/.*"))
+                    .willReturn(aResponse().withStatus(200)));
+            server.stubFor(put(urlPathMatching("/v2/library/signed-fallback/manifests/.*"))
+                    .willReturn(aResponse().withStatus(201)));
+            server.stubFor(any(urlPathMatching("/v2/library/signed-fallback/manifests/.*"))
+                    .atPriority(10)
+                    .willReturn(aResponse().withStatus(404)));
+
+            List<String> signed = new CopyOnWriteArrayList<>();
+            ArtifactSigner signer = new ArtifactSigner() {
+                @Override
+                public String getArtifactType() {
+                    return "application/vnd.test.signature";
+                }
+
+                @Override
+                public Signature sign(ContainerRef ref, ManifestDescriptor descriptor) {
+                    signed.add(descriptor.getDigest());
+                    return new Signature(
+                            "application/vnd.test.signature.v1",
+                            "signature".getBytes(StandardCharsets.UTF_8),
+                            Map.of());
+                }
+            };
+            Registry registry = Registry.Builder.builder()
+                    .withInsecure(true)
+                    .withSigner(signer)
+                    .build();
+
+            ContainerRef ref = ContainerRef.parse("%s/library/signed-fallback:latest".formatted(registryUrl));
+            Manifest pushed = registry.pushManifest(
+                    ref, Manifest.empty().withArtifactType(ArtifactType.from("application/vnd.test.artifact")));
+
+            // Assertion
+            String digest = pushed.getDescriptor().getDigest();
+            assertEquals(List.of(digest), signed);
+            String referrersTag = digest.replace(':', '-');
+            server.verify(
+                    1,
+                    putRequestedFor(urlEqualTo("/v2/library/signed-fallback/manifests/%s".formatted(referrersTag))));
+            server.verify(3, putRequestedFor(urlPathMatching("/v2/library/signed-fallback/manifests/.*")));
+        } finally {
+            server.stop();
+        }
+    }
+
+    @Test
+    void shouldReportSubjectProcessedWhenSubjectHeaderReturned(WireMockRuntimeInfo wmRuntimeInfo) {
+        WireMock wireMock = wmRuntimeInfo.getWireMock();
+        String registryUrl = wmRuntimeInfo.getHttpBaseUrl().replace("http://", "");
+        String subjectDigest = SupportedAlgorithm.SHA256.digest("processed".getBytes(StandardCharsets.UTF_8));
+        String referrersTag = subjectDigest.replace(':', '-');
+
+        Manifest manifest = Manifest.empty()
+                .withArtifactType(ArtifactType.from("application/spdx+json"))
+                .withSubject(Descriptor.of(subjectDigest, 10L, Const.DEFAULT_MANIFEST_MEDIA_TYPE));
+        String manifestJson = manifest.toJson();
+        String manifestDigest = SupportedAlgorithm.SHA256.digest(manifestJson.getBytes(StandardCharsets.UTF_8));
+
+        wireMock.register(put(urlEqualTo("/v2/library/referrers-processed/manifests/%s".formatted(manifestDigest)))
+                .willReturn(aResponse().withStatus(201).withHeader(Const.OCI_SUBJECT_HEADER, subjectDigest)));
+        wireMock.register(any(urlEqualTo("/v2/library/referrers-processed/manifests/%s".formatted(manifestDigest)))
+                .atPriority(10)
+                .willReturn(aResponse()
+                        .withStatus(200)
+                        .withHeader(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_MANIFEST_MEDIA_TYPE)
+                        .withHeader(Const.DOCKER_CONTENT_DIGEST_HEADER, manifestDigest)
+                        .withBody(manifestJson)));
+
+        Registry registry = Registry.Builder.builder()
+                .withAuthProvider(authProvider)
+                .withInsecure(true)
+                .build();
+
+        ContainerRef ref = ContainerRef.parse("%s/library/referrers-processed".formatted(registryUrl))
+                .withDigest(manifestDigest);
+        Registry.PushManifestResult result = registry.pushManifestWithResult(ref, manifest);
+
+        // Assertion
+        assertTrue(result.subjectProcessed());
+        assertEquals(manifestDigest, result.manifest().getDescriptor().getDigest());
+        WireMock.verify(
+                0,
+                putRequestedFor(urlEqualTo("/v2/library/referrers-processed/manifests/%s".formatted(referrersTag))));
+    }
+
     @Test
     void shouldFailChunkedUploadWhenInitiationReturnsNon202(WireMockRuntimeInfo wmRuntimeInfo) throws IOException {
         WireMock wireMock = wmRuntimeInfo.getWireMock();
@@ -1522,6 +3556,44 @@ class RegistryWireMockTest {
     /**
      * Build the JSON of an index whose single entry is another index (the child).
      */
+    /**
+     * Serve back the manifests pushed to the stubbed manifest paths
+     */
+    private static final class ManifestStore implements ResponseTransformerV2 {
+
+        private final Map<String, Response> manifests = new ConcurrentHashMap<>();
+
+        @Override
+        public Response transform(Response response, ServeEvent serveEvent) {
+            LoggedRequest request = serveEvent.getRequest();
+            if (!request.getUrl().contains("/manifests/")) {
+                return response;
+            }
+            if (request.getMethod().equals(RequestMethod.PUT)) {
+                byte[] body = request.getBody();
+                manifests.put(
+                        request.getUrl(),
+                        Response.Builder.like(response)
+                                .but()
+                                .status(200)
+                                .headers(new HttpHeaders(
+                                        new HttpHeader(Const.CONTENT_TYPE_HEADER, request.getHeader("Content-Type")),
+                                        new HttpHeader(
+                                                Const.DOCKER_CONTENT_DIGEST_HEADER,
+                                                SupportedAlgorithm.SHA256.digest(body))))
+                                .body(body)
+                                .build());
+                return response;
+            }
+            return manifests.getOrDefault(request.getUrl(), response);
+        }
+
+        @Override
+        public String getName() {
+            return "manifest-store";
+        }
+    }
+
     private static String nestedIndexJson(String childDigest) {
         return """
                 {
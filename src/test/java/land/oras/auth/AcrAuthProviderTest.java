@@ -0,0 +1,98 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+import static org.mockito.ArgumentMatchers.any;
+import static org.mockito.ArgumentMatchers.eq;
+import static org.mockito.Mockito.mock;
+import static org.mockito.Mockito.times;
+import static org.mockito.Mockito.verify;
+import static org.mockito.Mockito.when;
+
+import com.azure.core.credential.AccessToken;
+import com.azure.core.credential.TokenCredential;
+import java.net.URI;
+import java.nio.charset.StandardCharsets;
+import java.time.Clock;
+import java.time.OffsetDateTime;
+import java.util.Base64;
+import java.util.Map;
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class AcrAuthProviderTest {
+
+    private static final ContainerRef ACR_REF = ContainerRef.parse("myregistry.azurecr.io/library/alpine:latest");
+
+    private static final URI EXCHANGE_URI = URI.create("https://myregistry.azurecr.io/oauth2/exchange");
+
+    @Test
+    void shouldDetectAcrHosts() {
+        assertTrue(AcrAuthProvider.isAcrHost("myregistry.azurecr.io"));
+        assertTrue(AcrAuthProvider.isAcrHost("MyRegistry.AzureCR.io"));
+        assertFalse(AcrAuthProvider.isAcrHost("azurecr.io.example.com"));
+        assertFalse(AcrAuthProvider.isAcrHost("docker.io"));
+    }
+
+    @Test
+    void shouldExchangeAadTokenForRefreshToken() {
+        TokenCredential credential = mock(TokenCredential.class);
+        when(credential.getTokenSync(any()))
+                .thenReturn(new AccessToken("aad", OffsetDateTime.now().plusHours(1)));
+        HttpClient client = mock(HttpClient.class);
+        when(client.post(eq(EXCHANGE_URI), any(), any(), any(), any()))
+                .thenReturn(new HttpClient.ResponseWrapper<>("{\"refresh_token\":\"refresh\"}", 200, Map.of(), null));
+        AcrAuthProvider provider = new AcrAuthProvider(credential, client, Clock.systemUTC());
+        String expected = "Basic "
+                + Base64.getEncoder().encodeToString("<token>:refresh".getBytes(StandardCharsets.UTF_8));
+
+        // Assertion
+        assertEquals(expected, provider.getAuthHeader(ACR_REF));
+        assertEquals(expected, provider.getAuthHeader(ACR_REF));
+        assertNull(provider.getAuthHeader(ContainerRef.parse("docker.io/library/alpine:latest")));
+        assertEquals(AuthScheme.BASIC, provider.getAuthScheme());
+        verify(client, times(1)).post(eq(EXCHANGE_URI), any(), any(), any(), any());
+    }
+
+    @Test
+    void shouldFailWhenExchangeIsRejected() {
+        TokenCredential credential = mock(TokenCredential.class);
+        when(credential.getTokenSync(any()))
+                .thenReturn(new AccessToken("aad", OffsetDateTime.now().plusHours(1)));
+        HttpClient client = mock(HttpClient.class);
+        when(client.post(eq(EXCHANGE_URI), any(), any(), any(), any()))
+                .thenReturn(new HttpClient.ResponseWrapper<>("", 401, Map.of(), null));
+        AcrAuthProvider provider = new AcrAuthProvider(credential, client, Clock.systemUTC());
+
+        // Assertion
+        OrasException e = assertThrows(OrasException.class, () -> provider.getAuthHeader(ACR_REF));
+        assertEquals(401, e.getStatusCode());
+    }
+}
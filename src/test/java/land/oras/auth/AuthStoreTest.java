@@ -24,10 +24,14 @@ import static org.junit.jupiter.api.Assertions.*;
 import static org.junit.jupiter.api.Assumptions.assumeFalse;
 import static org.junit.jupiter.api.Assumptions.assumeTrue;
 
+import java.nio.charset.StandardCharsets;
 import java.nio.file.Files;
 import java.nio.file.Path;
+import java.util.Base64;
 import java.util.List;
 import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
 import org.junit.jupiter.api.BeforeAll;
 import org.junit.jupiter.api.BeforeEach;
 import org.junit.jupiter.api.Test;
@@ -508,6 +512,32 @@ class AuthStoreTest {
         assertEquals(password, credential.password());
     }
 
+    @Test
+    void testIdentityTokenIsReadAsRefreshToken() throws Exception {
+        String auth = java.util.Base64.getEncoder()
+                .encodeToString("user:".getBytes(java.nio.charset.StandardCharsets.UTF_8));
+        // language=json
+        String config =
+                """
+                {
+                    "auths": {
+                        "token.registry.com": { "auth": "%s", "identitytoken": "my-refresh-token" }
+                    }
+                }
+                """
+                        .formatted(auth);
+        Path configFile = tempDir.resolve("identity-token-config.json");
+        Files.writeString(configFile, config);
+
+        AuthStore store = AuthStore.newStore(List.of(configFile));
+        AuthStore.Credential credential = store.get(ContainerRef.parse("token.registry.com/foo/bar:latest"));
+
+        // Assertion
+        assertNotNull(credential);
+        assertEquals(Const.IDENTITY_TOKEN_USERNAME, credential.username());
+        assertEquals("my-refresh-token", credential.password());
+    }
+
     @Test
     void testMalformedEntryIsSkippedWithoutDroppingOtherCredentials() throws Exception {
         String malformed = java.util.Base64.getEncoder()
@@ -587,4 +617,101 @@ class AuthStoreTest {
         assertEquals(user, credential.username());
         assertEquals(password, credential.password());
     }
+
+    @Test
+    void testDockerConfigEnvironmentVariableIsUsed() throws Exception {
+        Path dockerConfigDir = tempDir.resolve("docker-config");
+        Files.createDirectories(dockerConfigDir);
+        // language=json
+        String config =
+                """
+                {
+                    "auths": {
+                        "env.registry.com": { "auth": "dXNlcjpwYXNzd29yZA==" }
+                    }
+                }
+                """;
+        Files.writeString(dockerConfigDir.resolve("config.json"), config);
+
+        new EnvironmentVariables()
+                .set("DOCKER_CONFIG", dockerConfigDir.toAbsolutePath().toString())
+                .remove("REGISTRY_AUTH_FILE")
+                .remove("XDG_RUNTIME_DIR")
+                .execute(() -> {
+                    new SystemProperties("user.home", homeDir.toAbsolutePath().toString()).execute(() -> {
+                        AuthStore authStoreInstance = AuthStore.newStore();
+                        AuthStore.Credential credential =
+                                authStoreInstance.get(ContainerRef.parse("env.registry.com/foo/bar:latest"));
+                        assertNotNull(credential);
+                        assertEquals(USERNAME, credential.username());
+
+                        // ~/.docker/config.json is not used
+                        assertNull(authStoreInstance.get(ContainerRef.parse("registry.example.com/foo/bar:latest")));
+                    });
+                });
+    }
+
+    @Test
+    void testServerAddressesAreNormalized() throws Exception {
+        // language=json
+        String config =
+                """
+                {
+                    "auths": {
+                        "https://index.docker.io/v1/": { "auth": "dXNlcjpwYXNzd29yZA==" },
+                        "https://scheme.registry.com": { "auth": "dXNlcjpwYXNzd29yZA==" },
+                        "plain.registry.com": { "username": "plain", "password": "secret" }
+                    }
+                }
+                """;
+        Path configFile = tempDir.resolve("normalized-config.json");
+        Files.writeString(configFile, config);
+
+        AuthStore store = AuthStore.newStore(List.of(configFile));
+        AuthStore.Credential dockerHub = store.get(ContainerRef.parse("docker.io/library/alpine:latest"));
+        assertNotNull(dockerHub);
+        assertEquals(USERNAME, dockerHub.username());
+
+        AuthStore.Credential scheme = store.get(ContainerRef.parse("scheme.registry.com/foo/bar:latest"));
+        assertNotNull(scheme);
+        assertEquals(PASSWORD, scheme.password());
+
+        AuthStore.Credential plain = store.get(ContainerRef.parse("plain.registry.com/foo/bar:latest"));
+        assertNotNull(plain);
+        assertEquals("plain", plain.username());
+        assertEquals("secret", plain.password());
+    }
+
+    @Test
+    void testShouldReadKubernetesPullSecret() throws Exception {
+        // language=json
+        String payload =
+                """
+                {
+                    "auths": {
+                        "registry.example.com": { "auth": "dXNlcjpwYXNzd29yZA==" }
+                    },
+                    "credsStore": "unknown"
+                }
+                """;
+        Path secretFile = tempDir.resolve(".dockerconfigjson");
+        Files.writeString(secretFile, payload);
+        ContainerRef ref = ContainerRef.parse("registry.example.com/foo/bar:latest");
+
+        AuthStore fromFile = AuthStore.fromDockerConfigJson(secretFile);
+        AuthStore.Credential credential = fromFile.get(ref);
+        assertNotNull(credential);
+        assertEquals(USERNAME, credential.username());
+        assertEquals(PASSWORD, credential.password());
+        assertNull(fromFile.getCredentialHelperBinary(ref));
+
+        // Base64 encoded as in the secret data
+        byte[] encoded = Base64.getEncoder().encode(payload.getBytes(StandardCharsets.UTF_8));
+        AuthStore.Credential fromData = AuthStore.fromDockerConfigJson(encoded).get(ref);
+        assertNotNull(fromData);
+        assertEquals(PASSWORD, fromData.password());
+
+        assertThrows(OrasException.class, () -> AuthStore.fromDockerConfigJson("{}".getBytes(StandardCharsets.UTF_8)));
+        assertThrows(OrasException.class, () -> AuthStore.fromDockerConfigJson(tempDir.resolve("missing")));
+    }
 }
@@ -0,0 +1,106 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNull;
+
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.jspecify.annotations.Nullable;
+import org.junit.jupiter.api.Test;
+import uk.org.webcompere.systemstubs.environment.EnvironmentVariables;
+
+class CredentialChainTest {
+
+    private static final ContainerRef REF = ContainerRef.parse("localhost:5000/library/alpine:latest");
+
+    @Test
+    void shouldUseFirstProviderReturningHeader() {
+        CredentialChain chain = CredentialChain.builder()
+                .withAnonymous()
+                .withProvider(new FailingProvider())
+                .withCredentials("first", "secret")
+                .withCredentials("second", "secret")
+                .build();
+        assertEquals(new UsernamePasswordProvider("first", "secret").getAuthHeader(REF), chain.getAuthHeader(REF));
+        assertEquals(AuthScheme.BASIC, chain.getAuthScheme());
+    }
+
+    @Test
+    void shouldFallbackToAnonymous() {
+        CredentialChain chain = CredentialChain.builder().withAnonymous().build();
+        assertNull(chain.getAuthHeader(REF));
+        assertEquals(AuthScheme.NONE, chain.getAuthScheme());
+        assertEquals(AuthScheme.NONE, CredentialChain.builder().build().getAuthScheme());
+    }
+
+    @Test
+    void shouldUseHostOverride() {
+        CredentialChain chain = CredentialChain.builder()
+                .withCredentials("default", "secret")
+                .withHostOverride("LOCALHOST:5000", new BearerTokenProvider("token"))
+                .withHostOverride("other.example.com", new NoAuthProvider())
+                .build();
+        assertEquals("Bearer token", chain.getAuthHeader(REF));
+        assertNull(chain.getAuthHeader(ContainerRef.parse("other.example.com/library/alpine:latest")));
+        assertEquals(
+                new UsernamePasswordProvider("default", "secret").getAuthHeader(REF),
+                chain.getAuthHeader(ContainerRef.parse("docker.io/library/alpine:latest")));
+    }
+
+    @Test
+    void shouldReadCredentialsFromEnvironment() throws Exception {
+        CredentialChain chain = CredentialChain.builder()
+                .withEnvironment()
+                .withCredentials("explicit", "secret")
+                .build();
+        new EnvironmentVariables()
+                .set(CredentialChain.ENV_USERNAME, "env")
+                .set(CredentialChain.ENV_PASSWORD, "password")
+                .execute(() -> {
+                    assertEquals(
+                            new UsernamePasswordProvider("env", "password").getAuthHeader(REF),
+                            chain.getAuthHeader(REF));
+                });
+        new EnvironmentVariables()
+                .remove(CredentialChain.ENV_USERNAME)
+                .remove(CredentialChain.ENV_PASSWORD)
+                .execute(() -> {
+                    assertEquals(
+                            new UsernamePasswordProvider("explicit", "secret").getAuthHeader(REF),
+                            chain.getAuthHeader(REF));
+                });
+    }
+
+    private static final class FailingProvider implements AuthProvider {
+
+        @Override
+        public @Nullable String getAuthHeader(ContainerRef registry) {
+            throw new OrasException("Provider failure");
+        }
+
+        @Override
+        public AuthScheme getAuthScheme() {
+            return AuthScheme.BASIC;
+        }
+    }
+}
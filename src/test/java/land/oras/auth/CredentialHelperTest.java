@@ -0,0 +1,152 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import static org.junit.jupiter.api.Assertions.*;
+import static org.junit.jupiter.api.Assumptions.assumeFalse;
+import static org.junit.jupiter.api.Assumptions.assumeTrue;
+
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.time.Duration;
+import java.util.Map;
+import java.util.UUID;
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class CredentialHelperTest {
+
+    @BeforeEach
+    void ensureFakeHelper() {
+        assumeFalse(
+                System.getProperty("os.name").toLowerCase().contains("win"),
+                "Skipping test: docker-credential-fake is not supported on Windows");
+        assumeTrue(
+                Files.exists(Path.of("/usr/bin/docker-credential-fake")),
+                "Skipping test: /usr/bin/docker-credential-fake not found");
+    }
+
+    @Test
+    void shouldGetCredential() {
+        CredentialHelper helper = CredentialHelper.of("fake").withTimeout(Duration.ofSeconds(5));
+        assertEquals("docker-credential-fake", helper.getBinary());
+        assertEquals(Duration.ofSeconds(5), helper.getTimeout());
+
+        AuthStore.Credential credential = helper.get("creds.other.com");
+        assertNotNull(credential);
+        assertEquals("user", credential.username());
+        assertEquals("password", credential.password());
+    }
+
+    @Test
+    void shouldStoreListAndEraseCredential() {
+        CredentialHelper helper = CredentialHelper.of("fake");
+        String server = "store-%s.other.com".formatted(UUID.randomUUID());
+        helper.store(server, new AuthStore.Credential("stored-user", "stored-password"));
+
+        // Assertion
+        AuthStore.Credential credential = helper.get(server);
+        assertNotNull(credential);
+        assertEquals("stored-user", credential.username());
+        assertEquals("stored-password", credential.password());
+        assertEquals("stored-user", helper.list().get(server));
+
+        helper.erase(server);
+        assertFalse(helper.list().containsKey(server));
+        OrasException e = assertThrows(OrasException.class, () -> helper.erase(server));
+        assertTrue(e.getMessage().contains("credentials not found in native keychain"), e.getMessage());
+    }
+
+    @Test
+    void shouldReturnNullWhenNotFound() {
+        CredentialHelper helper = CredentialHelper.of("fake");
+        assertNull(helper.get("notfound.other.com"));
+    }
+
+    @Test
+    void shouldReadCredentialsFromFixture(@TempDir Path dir) throws Exception {
+        Path fixture = dir.resolve("fixture.json");
+        // language=JSON
+        Files.writeString(
+                fixture,
+                """
+                {
+                  "hosts": {
+                    "team-a.other.com": { "username": "team-a", "secret": "secret-a" },
+                    "team-b.other.com": { "username": "team-b", "secret": "secret-b" },
+                    "token.other.com": {
+                      "username": "<token>", "secret": "refresh", "expiresAt": "2999-01-01T00:00:00Z"
+                    },
+                    "expired.other.com": {
+                      "username": "<token>", "secret": "old", "expiresAt": "2000-01-01T00:00:00Z"
+                    },
+                    "slow.other.com": { "username": "user", "secret": "password", "delay": "2s" },
+                    "denied.other.com": { "exitCode": 3, "output": "Error: access denied" }
+                  }
+                }
+                """);
+        CredentialHelper helper = CredentialHelper.of("fake")
+                .withEnvironment(Map.of("DOCKER_CREDENTIAL_FAKE_FIXTURE", fixture.toString()));
+
+        // Assertion
+        assertEquals(fixture.toString(), helper.getEnvironment().get("DOCKER_CREDENTIAL_FAKE_FIXTURE"));
+        assertEquals(new AuthStore.Credential("team-a", "secret-a"), helper.get("team-a.other.com"));
+        assertEquals(new AuthStore.Credential("team-b", "secret-b"), helper.get("team-b.other.com"));
+        assertEquals(new AuthStore.Credential("<token>", "refresh"), helper.get("token.other.com"));
+        assertNull(helper.get("expired.other.com"));
+        assertNull(helper.get("unlisted.other.com"));
+        OrasException denied = assertThrows(OrasException.class, () -> helper.get("denied.other.com"));
+        assertTrue(denied.getMessage().contains("exited with code 3"), denied.getMessage());
+        OrasException timeout = assertThrows(
+                OrasException.class,
+                () -> helper.withTimeout(Duration.ofMillis(200)).get("slow.other.com"));
+        assertTrue(timeout.getMessage().contains("timed out"), timeout.getMessage());
+    }
+
+    @Test
+    void shouldReportNonZeroExitCode() {
+        CredentialHelper helper = CredentialHelper.of("fake");
+        OrasException e = assertThrows(OrasException.class, () -> helper.get("error.other.com"));
+        assertTrue(e.getMessage().contains("exited with code 1"), e.getMessage());
+        assertTrue(e.getMessage().contains("Error: Not found"), e.getMessage());
+    }
+
+    @Test
+    void shouldFailWhenBinaryNotFound() {
+        CredentialHelper helper = CredentialHelper.of("does-not-exist");
+        OrasException e = assertThrows(OrasException.class, () -> helper.get("registry.example.com"));
+        assertTrue(e.getMessage().contains("not found"), e.getMessage());
+    }
+
+    @Test
+    void shouldReturnAuthHeaderFromHelper() {
+        CredentialHelperProvider provider = new CredentialHelperProvider("fake");
+        assertEquals(
+                "Basic dXNlcjpwYXNzd29yZA==", provider.getAuthHeader(ContainerRef.parse("creds.other.com/foo/bar")));
+        assertEquals(AuthScheme.BASIC, provider.getAuthScheme());
+    }
+}
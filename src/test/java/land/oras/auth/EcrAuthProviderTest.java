@@ -0,0 +1,98 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+import static org.mockito.Mockito.mock;
+import static org.mockito.Mockito.times;
+import static org.mockito.Mockito.verify;
+import static org.mockito.Mockito.when;
+
+import java.time.Clock;
+import java.time.Duration;
+import java.time.Instant;
+import land.oras.ContainerRef;
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+import software.amazon.awssdk.services.ecr.EcrClient;
+import software.amazon.awssdk.services.ecr.model.AuthorizationData;
+import software.amazon.awssdk.services.ecr.model.EcrException;
+import software.amazon.awssdk.services.ecr.model.GetAuthorizationTokenResponse;
+
+@Execution(ExecutionMode.CONCURRENT)
+class EcrAuthProviderTest {
+
+    private static final ContainerRef ECR_REF =
+            ContainerRef.parse("123456789012.dkr.ecr.eu-west-1.amazonaws.com/library/alpine:latest");
+
+    @Test
+    void shouldDetectEcrHosts() {
+        assertTrue(EcrAuthProvider.isEcrHost("123456789012.dkr.ecr.eu-west-1.amazonaws.com"));
+        assertTrue(EcrAuthProvider.isEcrHost("123456789012.dkr.ecr-fips.us-east-1.amazonaws.com"));
+        assertTrue(EcrAuthProvider.isEcrHost("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn"));
+        assertFalse(EcrAuthProvider.isEcrHost("public.ecr.aws"));
+        assertFalse(EcrAuthProvider.isEcrHost("docker.io"));
+    }
+
+    @Test
+    void shouldCacheAndRefreshTokens() {
+        EcrClient client = mock(EcrClient.class);
+        Instant now = Instant.now();
+        when(client.getAuthorizationToken())
+                .thenReturn(response("expiring", now.plus(Duration.ofMinutes(1))))
+                .thenReturn(response("fresh", now.plus(Duration.ofHours(12))));
+        EcrAuthProvider provider = new EcrAuthProvider(region -> client, Clock.systemUTC());
+
+        // Assertion
+        assertEquals("Basic expiring", provider.getAuthHeader(ECR_REF));
+        assertEquals("Basic fresh", provider.getAuthHeader(ECR_REF));
+        assertEquals("Basic fresh", provider.getAuthHeader(ECR_REF));
+        assertEquals(AuthScheme.BASIC, provider.getAuthScheme());
+        assertNull(provider.getAuthHeader(ContainerRef.parse("docker.io/library/alpine:latest")));
+        verify(client, times(2)).getAuthorizationToken();
+    }
+
+    @Test
+    void shouldWrapSdkErrors() {
+        EcrClient client = mock(EcrClient.class);
+        when(client.getAuthorizationToken())
+                .thenThrow(EcrException.builder().message("denied").build());
+        EcrAuthProvider provider = EcrAuthProvider.of(client);
+
+        // Assertion
+        assertThrows(OrasException.class, () -> provider.getAuthHeader(ECR_REF));
+    }
+
+    private static GetAuthorizationTokenResponse response(String token, Instant expiresAt) {
+        return GetAuthorizationTokenResponse.builder()
+                .authorizationData(AuthorizationData.builder()
+                        .authorizationToken(token)
+                        .expiresAt(expiresAt)
+                        .build())
+                .build();
+    }
+}
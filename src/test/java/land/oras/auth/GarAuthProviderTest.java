@@ -0,0 +1,66 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import com.google.auth.oauth2.AccessToken;
+import com.google.auth.oauth2.GoogleCredentials;
+import java.nio.charset.StandardCharsets;
+import java.time.Duration;
+import java.time.Instant;
+import java.util.Base64;
+import java.util.Date;
+import land.oras.ContainerRef;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class GarAuthProviderTest {
+
+    @Test
+    void shouldDetectGoogleHosts() {
+        assertTrue(GarAuthProvider.isGoogleHost("europe-west1-docker.pkg.dev"));
+        assertTrue(GarAuthProvider.isGoogleHost("gcr.io"));
+        assertTrue(GarAuthProvider.isGoogleHost("eu.gcr.io"));
+        assertFalse(GarAuthProvider.isGoogleHost("notgcr.io"));
+        assertFalse(GarAuthProvider.isGoogleHost("docker.io"));
+    }
+
+    @Test
+    void shouldUseAccessTokenAsPassword() {
+        Date expiry = Date.from(Instant.now().plus(Duration.ofHours(1)));
+        GarAuthProvider provider = GarAuthProvider.of(GoogleCredentials.create(new AccessToken("token", expiry)));
+        String expected = "Basic "
+                + Base64.getEncoder().encodeToString("oauth2accesstoken:token".getBytes(StandardCharsets.UTF_8));
+
+        // Assertion
+        assertEquals(
+                expected, provider.getAuthHeader(ContainerRef.parse("europe-west1-docker.pkg.dev/project/repo/app:1")));
+        assertEquals(expected, provider.getAuthHeader(ContainerRef.parse("gcr.io/project/app:1")));
+        assertNull(provider.getAuthHeader(ContainerRef.parse("docker.io/library/alpine:latest")));
+        assertEquals(AuthScheme.BASIC, provider.getAuthScheme());
+    }
+}
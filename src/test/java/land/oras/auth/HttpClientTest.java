@@ -30,8 +30,13 @@ import java.net.URI;
 import java.net.http.HttpResponse;
 import java.nio.file.Files;
 import java.nio.file.Path;
+import java.util.Base64;
+import java.util.List;
+import javax.net.ssl.X509TrustManager;
+import land.oras.ContainerRef;
 import land.oras.exception.OrasException;
 import land.oras.utils.TlsUtils;
+import org.bouncycastle.util.io.pem.PemObject;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.io.TempDir;
 import org.junit.jupiter.api.parallel.Execution;
@@ -42,6 +47,9 @@ class HttpClientTest {
 
     private static final String ROOT_CA_PEM;
     private static final String ISSUING_CA_PEM;
+    private static final String CLIENT_CERT_PEM;
+    private static final String CLIENT_KEY_PEM;
+    private static final String CLIENT_RSA_KEY_PEM;
 
     static {
         var rootKeyPair = TlsUtils.generateKeyPair();
@@ -52,6 +60,14 @@ class HttpClientTest {
         var issuingCaCert = TlsUtils.generateSignedCertificate(
                 "test-issuing-ca", issuingKeyPair, rootCaCert, rootKeyPair.getPrivate());
         ISSUING_CA_PEM = TlsUtils.toPem(issuingCaCert);
+
+        var clientKeyPair = TlsUtils.generateKeyPair();
+        var clientCert = TlsUtils.generateSignedCertificate(
+                "test-client", clientKeyPair, rootCaCert, rootKeyPair.getPrivate());
+        CLIENT_CERT_PEM = TlsUtils.toPem(clientCert);
+        CLIENT_KEY_PEM = TlsUtils.toPem(
+                new PemObject("PRIVATE KEY", clientKeyPair.getPrivate().getEncoded()));
+        CLIENT_RSA_KEY_PEM = TlsUtils.toPem(clientKeyPair.getPrivate());
     }
 
     @Test
@@ -236,4 +252,112 @@ class HttpClientTest {
                 .withCaContent(ROOT_CA_PEM + ISSUING_CA_PEM)
                 .build());
     }
+
+    @Test
+    void whenClientCertificateThenSucceed(@TempDir Path tempDir) throws IOException {
+        Path certFile = Files.writeString(tempDir.resolve("client.pem"), CLIENT_CERT_PEM);
+        Path keyFile = Files.writeString(tempDir.resolve("client-key.pem"), CLIENT_KEY_PEM);
+        assertDoesNotThrow(() -> HttpClient.Builder.builder()
+                .withClientCertificate(certFile, keyFile)
+                .withCaContent(ROOT_CA_PEM)
+                .build());
+    }
+
+    @Test
+    void whenClientKeyNotPkcs8ThenThrow(@TempDir Path tempDir) throws IOException {
+        Path certFile = Files.writeString(tempDir.resolve("client.pem"), CLIENT_CERT_PEM);
+        Path keyFile = Files.writeString(tempDir.resolve("client-key.pem"), CLIENT_RSA_KEY_PEM);
+        OrasException exception = assertThrows(OrasException.class, () -> HttpClient.Builder.builder()
+                .withClientCertificate(certFile, keyFile)
+                .build());
+        assertTrue(exception.getMessage().contains("Only unencrypted PKCS#8 keys are supported"));
+    }
+
+    @Test
+    void whenClientCertificateDoesNotExistThenThrow(@TempDir Path tempDir) throws IOException {
+        Path keyFile = Files.writeString(tempDir.resolve("client-key.pem"), CLIENT_KEY_PEM);
+        OrasException exception = assertThrows(OrasException.class, () -> HttpClient.Builder.builder()
+                .withClientCertificate(Path.of("/nonexistent/client.pem"), keyFile)
+                .build());
+        assertTrue(exception.getMessage().contains("Unable to configure client certificate"));
+    }
+
+    @Test
+    void whenTrustManagerAndCaContentThenThrow() throws Exception {
+        X509TrustManager trustManager = mock(X509TrustManager.class);
+        OrasException exception = assertThrows(OrasException.class, () -> HttpClient.Builder.builder()
+                .withTrustManager(trustManager)
+                .withCaContent(ROOT_CA_PEM)
+                .build());
+        assertTrue(exception.getMessage().contains("Cannot combine a custom trust manager"));
+    }
+
+    @Test
+    void whenHostnameVerificationDisabledThenSucceed() {
+        assertDoesNotThrow(() -> HttpClient.Builder.builder()
+                .withCaContent(ROOT_CA_PEM)
+                .withHostnameVerification(false)
+                .build());
+    }
+
+    @Test
+    void shouldSkipTlsVerifyOnlyForConfiguredHosts() {
+        HttpClient client = HttpClient.Builder.builder()
+                .withSkipTlsVerifyHosts(List.of("Lab.Example.com", "localhost:5443"))
+                .build();
+        assertTrue(client.isSkipTlsVerifyHost(URI.create("https://lab.example.com/v2/")));
+        assertTrue(client.isSkipTlsVerifyHost(URI.create("https://localhost:5443/v2/")));
+        assertFalse(client.isSkipTlsVerifyHost(URI.create("https://localhost/v2/")));
+        assertFalse(client.isSkipTlsVerifyHost(URI.create("https://ghcr.io/v2/")));
+    }
+
+    @Test
+    void shouldUseHttp1ForConfiguredHosts() {
+        HttpClient client = HttpClient.Builder.builder()
+                .withHttp1Hosts(List.of("Legacy.Example.com", "localhost:5000"))
+                .build();
+        assertTrue(client.isHttp1Host(URI.create("https://legacy.example.com/v2/")));
+        assertTrue(client.isHttp1Host(URI.create("http://localhost:5000/v2/")));
+        assertFalse(client.isHttp1Host(URI.create("http://localhost:5001/v2/")));
+        assertFalse(client.isHttp1Host(URI.create("https://ghcr.io/v2/")));
+
+        HttpClient http1 = HttpClient.Builder.builder().withHttp2(false).build();
+        assertTrue(http1.isHttp1Host(URI.create("https://ghcr.io/v2/")));
+    }
+
+    @Test
+    void shouldReadGrantedScopes() {
+        Scopes scopes = Scopes.of("service", ContainerRef.parse("localhost/library/granted:latest"), Scope.PULL)
+                .withNewScope("repository:library/granted:push");
+
+        // From the scope field
+        HttpClient.TokenResponse scoped = new HttpClient.TokenResponse(
+                "token", null, "service", 300, null, "repository:library/granted:pull");
+        assertEquals(
+                List.of("repository:library/granted:pull"),
+                HttpClient.grantedScopes(scoped, scopes).getScopes());
+
+        // From the access claim of a JWT token
+        String claims =
+                "{\"access\":[{\"type\":\"repository\",\"name\":\"library/granted\",\"actions\":[\"pull\"]}]}";
+        String jwt = "header." + Base64.getUrlEncoder().withoutPadding().encodeToString(claims.getBytes()) + ".sig";
+        HttpClient.TokenResponse jwtToken = new HttpClient.TokenResponse(jwt, null, "service", 300, null);
+        assertEquals(
+                List.of("repository:library/granted:pull"),
+                HttpClient.grantedScopes(jwtToken, scopes).getScopes());
+
+        // Nothing granted
+        String denied = "{\"access\":[]}";
+        HttpClient.TokenResponse deniedToken = new HttpClient.TokenResponse(
+                "header." + Base64.getUrlEncoder().withoutPadding().encodeToString(denied.getBytes()) + ".sig",
+                null,
+                "service",
+                300,
+                null);
+        assertTrue(HttpClient.grantedScopes(deniedToken, scopes).getScopes().isEmpty());
+
+        // Opaque token
+        HttpClient.TokenResponse opaque = new HttpClient.TokenResponse("opaque", null, "service", 300, null);
+        assertEquals(scopes.getScopes(), HttpClient.grantedScopes(opaque, scopes).getScopes());
+    }
 }
@@ -0,0 +1,119 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.auth;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.IOException;
+import java.net.http.HttpTimeoutException;
+import java.time.Duration;
+import java.time.ZoneOffset;
+import java.time.ZonedDateTime;
+import java.time.format.DateTimeFormatter;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class RetryPolicyTest {
+
+    @Test
+    void shouldComputeExponentialBackoffWithoutJitter() {
+        RetryPolicy policy = RetryPolicy.defaults()
+                .withJitter(0)
+                .withInitialDelay(Duration.ofMillis(100))
+                .withMaxDelay(Duration.ofMillis(1000));
+        assertEquals(100, policy.computeDelay(0));
+        assertEquals(200, policy.computeDelay(1));
+        assertEquals(400, policy.computeDelay(2));
+        assertEquals(1000, policy.computeDelay(10));
+        assertEquals(1000, policy.computeDelay(100));
+    }
+
+    @Test
+    void shouldApplyJitterWithinBounds() {
+        RetryPolicy policy = RetryPolicy.defaults()
+                .withJitter(0.5)
+                .withInitialDelay(Duration.ofMillis(1000))
+                .withMaxDelay(Duration.ofSeconds(10));
+        for (int i = 0; i < 100; i++) {
+            long delay = policy.computeDelay(0);
+            assertTrue(delay >= 500 && delay <= 1500, "Delay out of bounds: " + delay);
+        }
+    }
+
+    @Test
+    void shouldOnlyRetryNonIdempotentRequestsWhenAllowed() {
+        RetryPolicy policy = RetryPolicy.defaults().withMaxAttempts(4);
+        assertEquals(4, policy.getMaxAttempts(true));
+        assertEquals(1, policy.getMaxAttempts(false));
+        assertEquals(4, policy.withRetryNonIdempotent(true).getMaxAttempts(false));
+        assertEquals(1, RetryPolicy.none().getMaxAttempts(true));
+    }
+
+    @Test
+    void shouldDetectTransientFailures() {
+        RetryPolicy policy = RetryPolicy.defaults();
+        assertTrue(policy.isRetryableStatus(429));
+        assertTrue(policy.isRetryableStatus(503));
+        assertFalse(policy.isRetryableStatus(404));
+        assertFalse(policy.isRetryableStatus(401));
+        assertTrue(policy.isRetryableException(new IOException("Connection reset")));
+        assertTrue(policy.isRetryableException(new HttpTimeoutException("timeout")));
+        assertFalse(policy.isRetryableException(new IllegalStateException("bug")));
+    }
+
+    @Test
+    void shouldParseRetryAfter() {
+        assertEquals(Duration.ofSeconds(5), RetryPolicy.parseRetryAfter("5"));
+        assertEquals(Duration.ZERO, RetryPolicy.parseRetryAfter(" 0 "));
+        assertEquals(
+                Duration.ZERO,
+                RetryPolicy.parseRetryAfter(DateTimeFormatter.RFC_1123_DATE_TIME.format(
+                        ZonedDateTime.now(ZoneOffset.UTC).minusMinutes(1))));
+        Duration future = RetryPolicy.parseRetryAfter(DateTimeFormatter.RFC_1123_DATE_TIME.format(
+                ZonedDateTime.now(ZoneOffset.UTC).plusMinutes(2)));
+        assertTrue(future != null && future.getSeconds() > 60 && future.getSeconds() <= 120);
+        assertNull(RetryPolicy.parseRetryAfter("soon"));
+        assertNull(RetryPolicy.parseRetryAfter(null));
+    }
+
+    @Test
+    void shouldHonorServerDelayCappedByMaxDelay() {
+        RetryPolicy policy = RetryPolicy.defaults().withJitter(0).withMaxDelay(Duration.ofSeconds(10));
+        assertEquals(3000, policy.computeDelay(0, Duration.ofSeconds(3)));
+        assertEquals(10000, policy.computeDelay(0, Duration.ofMinutes(5)));
+        assertEquals(500, policy.computeDelay(0, null));
+    }
+
+    @Test
+    void shouldRejectInvalidValues() {
+        RetryPolicy policy = RetryPolicy.defaults();
+        assertThrows(IllegalArgumentException.class, () -> policy.withMaxAttempts(0));
+        assertThrows(IllegalArgumentException.class, () -> policy.withInitialDelay(Duration.ofMillis(-1)));
+        assertThrows(IllegalArgumentException.class, () -> policy.withJitter(2));
+        assertThrows(IllegalArgumentException.class, () -> policy.withMultiplier(0.5));
+    }
+}
@@ -22,6 +22,7 @@ package land.oras.auth;
 
 import static org.junit.jupiter.api.Assertions.*;
 
+import java.util.List;
 import land.oras.ContainerRef;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.parallel.Execution;
@@ -68,4 +69,22 @@ class ScopesTest {
         assertEquals(1, newScopes2.getScopes().size());
         assertEquals("repository:library/test:pull,push", newScopes2.getScopes().get(0));
     }
+
+    @Test
+    void shouldIncludeScopesOfMultipleRepositories() {
+        ContainerRef target = ContainerRef.parse("localhost:5000/library/target:latest");
+        ContainerRef source = ContainerRef.parse("localhost:5000/library/source:latest");
+        Scopes scopes = Scopes.of(target, Scope.PULL, Scope.PUSH).withAddedRepositoryScopes(source, Scope.PULL);
+        assertEquals(
+                List.of("repository:library/source:pull", "repository:library/target:pull,push"),
+                scopes.getScopes());
+
+        // Assertion
+        assertTrue(scopes.includes(Scopes.of(source, Scope.PULL)));
+        assertTrue(scopes.includes(Scopes.of(target, Scope.PUSH)));
+        assertFalse(scopes.includes(Scopes.of(source, Scope.PUSH)));
+        assertFalse(scopes.includes(Scopes.of(target, Scope.PULL).withService("other")));
+        assertFalse(scopes.includes(Scopes.of(source)));
+        assertTrue(Scopes.of(target, Scope.ALL).includes(Scopes.of(target, Scope.DELETE)));
+    }
 }
@@ -22,11 +22,13 @@ package land.oras.auth;
 
 import static org.awaitility.Awaitility.await;
 import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNull;
 import static org.junit.jupiter.api.Assertions.assertTrue;
 
 import io.micrometer.core.instrument.FunctionCounter;
 import io.micrometer.core.instrument.MeterRegistry;
 import io.micrometer.core.instrument.simple.SimpleMeterRegistry;
+import java.util.List;
 import java.util.concurrent.TimeUnit;
 import land.oras.ContainerRef;
 import land.oras.TestUtils;
@@ -122,4 +124,78 @@ class TokenCacheTest {
         Scopes pullOnlyScopes = Scopes.of(containerRef, Scope.PULL); // Pull only
         assertEquals(tokenResponse, TokenCache.get(pullOnlyScopes), "Should retrieve the token using pull-only scopes");
     }
+
+    @Test
+    void shouldExpireLongLivedTokensBeforeTheirExpiry() {
+        assertEquals(
+                TimeUnit.SECONDS.toNanos(3600 - TokenCache.REFRESH_MARGIN_SECONDS),
+                TokenCache.getExpiration(new HttpClient.TokenResponse("token", null, "service", 3600, null)));
+        assertEquals(
+                TimeUnit.SECONDS.toNanos(1),
+                TokenCache.getExpiration(new HttpClient.TokenResponse("token", null, "service", 1, null)));
+        assertEquals(
+                TimeUnit.SECONDS.toNanos(60),
+                TokenCache.getExpiration(new HttpClient.TokenResponse("token", null, "service", null, null)));
+    }
+
+    @Test
+    void shouldRetrieveTokenGrantedForMultipleRepositories() {
+        HttpClient.TokenResponse tokenResponse =
+                new HttpClient.TokenResponse("multi-token", null, "dockerhub", 3600, null);
+        ContainerRef target = ContainerRef.parse("docker.io/library/multi-target:latest");
+        ContainerRef source = ContainerRef.parse("docker.io/library/multi-source:latest");
+        Scopes scopes = Scopes.of("dockerhub", target, Scope.PULL, Scope.PUSH)
+                .withAddedRepositoryScopes(source, Scope.PULL);
+        TokenCache.put(scopes, tokenResponse);
+        assertEquals(
+                tokenResponse,
+                TokenCache.get(Scopes.of("dockerhub", source, Scope.PULL)),
+                "Should retrieve the token using the source scope");
+        assertEquals(
+                tokenResponse,
+                TokenCache.get(Scopes.of("dockerhub", target, Scope.PUSH)),
+                "Should retrieve the token using the target scope");
+    }
+
+    @Test
+    void shouldWidenScopesWithCachedActions() {
+        HttpClient.TokenResponse tokenResponse =
+                new HttpClient.TokenResponse("pull-token", null, "dockerhub", 3600, null);
+        ContainerRef containerRef = ContainerRef.parse("docker.io/library/widen:latest");
+        ContainerRef other = ContainerRef.parse("docker.io/library/widen-other:latest");
+        TokenCache.put(Scopes.of("dockerhub", containerRef, Scope.PULL), tokenResponse);
+        TokenCache.put(Scopes.of("dockerhub", other, Scope.PULL), tokenResponse);
+        Scopes widened = TokenCache.widen(Scopes.of("dockerhub", containerRef, Scope.PUSH));
+        assertEquals(List.of("repository:library/widen:pull,push"), widened.getScopes());
+    }
+
+    @Test
+    void shouldNotShareTokensBetweenCredentials() {
+        HttpClient.TokenResponse tokenResponse =
+                new HttpClient.TokenResponse("identity-token", null, "dockerhub", 3600, null);
+        ContainerRef containerRef = ContainerRef.parse("docker.io/library/identity:latest");
+        Scopes scopes = Scopes.of("dockerhub", containerRef, Scope.PULL);
+        String alice = TokenCache.identity("Basic YWxpY2U6c2VjcmV0");
+        TokenCache.put(alice, scopes, tokenResponse);
+
+        // Assertion
+        assertEquals(tokenResponse, TokenCache.get(alice, scopes));
+        assertNull(TokenCache.get(TokenCache.identity("Basic Ym9iOnNlY3JldA=="), scopes));
+        assertNull(TokenCache.get(scopes), "Should not be used for anonymous requests");
+        assertEquals(
+                List.of("repository:library/identity:push"),
+                TokenCache.widen(Scopes.of("dockerhub", containerRef, Scope.PUSH)).getScopes(),
+                "Should not widen with the actions of other credentials");
+    }
+
+    @Test
+    void shouldNotReturnTokenWithoutRequestedAction() {
+        HttpClient.TokenResponse tokenResponse =
+                new HttpClient.TokenResponse("pull-only-token", null, "dockerhub", 3600, null);
+        ContainerRef containerRef = ContainerRef.parse("docker.io/library/granted-pull:latest");
+        TokenCache.put(Scopes.of("dockerhub", containerRef, Scope.PULL), tokenResponse);
+
+        // Assertion
+        assertNull(TokenCache.get(Scopes.of("dockerhub", containerRef, Scope.PULL, Scope.PUSH)));
+    }
 }
@@ -0,0 +1,202 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.encryption;
+
+import static org.junit.jupiter.api.Assertions.assertArrayEquals;
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNotEquals;
+import static org.junit.jupiter.api.Assertions.assertNotNull;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.ByteArrayInputStream;
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.security.KeyPair;
+import java.security.KeyPairGenerator;
+import java.security.NoSuchAlgorithmException;
+import java.util.HashMap;
+import java.util.Map;
+import land.oras.Layer;
+import land.oras.LayoutRef;
+import land.oras.LayoutStorage;
+import land.oras.LocalPath;
+import land.oras.Manifest;
+import land.oras.OCI;
+import land.oras.OCILayout;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.SupportedAlgorithm;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class LayerEncryptionTest {
+
+    @TempDir
+    private Path tempDir;
+
+    @Test
+    void shouldEncryptAndDecryptLayer() throws IOException {
+        KeyPair keyPair = generateKeyPair();
+        byte[] content = "hello encrypted world".getBytes(StandardCharsets.UTF_8);
+
+        ByteArrayOutputStream encrypted = new ByteArrayOutputStream();
+        Map<String, String> annotations = LayerEncryption.of(JweKeyWrapper.forRecipients(keyPair.getPublic()))
+                .encrypt(new ByteArrayInputStream(content), encrypted);
+        Layer layer = encryptedLayer(encrypted.toByteArray(), annotations);
+
+        LayerEncryption decryption = LayerEncryption.of(JweKeyWrapper.forPrivateKeys(keyPair.getPrivate()));
+        byte[] decrypted;
+        try (InputStream is = decryption.decrypt(layer, new ByteArrayInputStream(encrypted.toByteArray()))) {
+            decrypted = is.readAllBytes();
+        }
+
+        // Assertion
+        assertEquals(content.length, encrypted.size());
+        assertFalse(new String(encrypted.toByteArray(), StandardCharsets.UTF_8).contains("hello"));
+        assertTrue(annotations.containsKey(Const.ANNOTATION_ENC_PUBOPTS));
+        assertTrue(annotations.containsKey(KeyWrapper.JWE_ANNOTATION));
+        assertArrayEquals(content, decrypted);
+        assertTrue(LayerEncryption.isEncrypted(layer));
+        Layer plain = LayerEncryption.toDecrypted(layer);
+        assertEquals("text/plain", plain.getMediaType());
+        assertEquals(Map.of(Const.ANNOTATION_TITLE, "hello.txt"), plain.getAnnotations());
+    }
+
+    @Test
+    void shouldFailWithoutMatchingKey() {
+        KeyPair keyPair = generateKeyPair();
+        KeyPair otherKeyPair = generateKeyPair();
+        byte[] content = "secret".getBytes(StandardCharsets.UTF_8);
+
+        ByteArrayOutputStream encrypted = new ByteArrayOutputStream();
+        Map<String, String> annotations = LayerEncryption.of(JweKeyWrapper.forRecipients(keyPair.getPublic()))
+                .encrypt(new ByteArrayInputStream(content), encrypted);
+        Layer layer = encryptedLayer(encrypted.toByteArray(), annotations);
+        LayerEncryption decryption = LayerEncryption.of(JweKeyWrapper.forPrivateKeys(otherKeyPair.getPrivate()));
+
+        // Assertion
+        OrasException e = assertThrows(
+                OrasException.class,
+                () -> decryption.decrypt(layer, new ByteArrayInputStream(encrypted.toByteArray())));
+        assertTrue(e.getMessage().contains("No key available to decrypt layer"));
+    }
+
+    @Test
+    void shouldFailOnTamperedContent() {
+        KeyPair keyPair = generateKeyPair();
+        byte[] content = "do not tamper".getBytes(StandardCharsets.UTF_8);
+
+        ByteArrayOutputStream encrypted = new ByteArrayOutputStream();
+        Map<String, String> annotations = LayerEncryption.of(JweKeyWrapper.forRecipients(keyPair.getPublic()))
+                .encrypt(new ByteArrayInputStream(content), encrypted);
+        byte[] tampered = encrypted.toByteArray();
+        tampered[0] ^= 1;
+        Layer layer = encryptedLayer(tampered, annotations);
+        LayerEncryption decryption = LayerEncryption.of(JweKeyWrapper.forPrivateKeys(keyPair.getPrivate()));
+
+        // Assertion
+        OrasException e = assertThrows(OrasException.class, () -> {
+            try (InputStream is = decryption.decrypt(layer, new ByteArrayInputStream(tampered))) {
+                is.readAllBytes();
+            }
+        });
+        assertTrue(e.getMessage().contains("HMAC mismatch"));
+    }
+
+    @Test
+    void shouldWrapKeysForSeveralRecipients() {
+        KeyPair first = generateKeyPair();
+        KeyPair second = generateKeyPair();
+        KeyPair other = generateKeyPair();
+        byte[] optsData = "{\"symkey\":\"key\"}".getBytes(StandardCharsets.UTF_8);
+
+        byte[] wrapped = JweKeyWrapper.forRecipients(first.getPublic(), second.getPublic()).wrapKeys(optsData);
+
+        // Assertion
+        assertArrayEquals(optsData, JweKeyWrapper.forPrivateKeys(first.getPrivate()).unwrapKey(wrapped));
+        assertArrayEquals(optsData, JweKeyWrapper.forPrivateKeys(second.getPrivate()).unwrapKey(wrapped));
+        assertNull(JweKeyWrapper.forPrivateKeys(other.getPrivate()).unwrapKey(wrapped));
+        assertThrows(OrasException.class, LayerEncryption::of);
+    }
+
+    @Test
+    void shouldPushAndPullEncryptedLayers() throws IOException {
+        KeyPair keyPair = generateKeyPair();
+        Path path = tempDir.resolve("layout");
+        OCILayout layout = OCILayout.builder()
+                .defaults(path)
+                .withStorage(LayoutStorage.inMemory())
+                .build();
+        LayoutRef ref = LayoutRef.parse("%s:latest".formatted(path));
+        Path file = Files.writeString(tempDir.resolve("hello.txt"), "hello");
+
+        Manifest manifest = layout.pushArtifact(
+                ref,
+                OCI.PushOptions.defaults()
+                        .withEncryption(LayerEncryption.of(JweKeyWrapper.forRecipients(keyPair.getPublic()))),
+                LocalPath.of(file, "text/plain"));
+        Layer layer = manifest.getLayers().get(0);
+
+        Path encrypted = Files.createDirectories(tempDir.resolve("encrypted"));
+        layout.pullArtifact(ref, encrypted, OCI.PullOptions.defaults());
+        Path decrypted = Files.createDirectories(tempDir.resolve("decrypted"));
+        layout.pullArtifact(
+                ref,
+                decrypted,
+                OCI.PullOptions.defaults()
+                        .withDecryption(LayerEncryption.of(JweKeyWrapper.forPrivateKeys(keyPair.getPrivate()))));
+
+        // Assertion
+        assertEquals("text/plain+encrypted", layer.getMediaType());
+        assertEquals("hello.txt", layer.getAnnotations().get(Const.ANNOTATION_TITLE));
+        assertNotNull(layer.getAnnotations().get(KeyWrapper.JWE_ANNOTATION));
+        assertNotEquals("hello", Files.readString(encrypted.resolve("hello.txt")));
+        assertEquals("hello", Files.readString(decrypted.resolve("hello.txt")));
+    }
+
+    private static Layer encryptedLayer(byte[] encrypted, Map<String, String> annotations) {
+        Map<String, String> layerAnnotations = new HashMap<>(annotations);
+        layerAnnotations.put(Const.ANNOTATION_TITLE, "hello.txt");
+        return Layer.fromDigest(SupportedAlgorithm.SHA256.digest(encrypted), encrypted.length)
+                .withMediaType("text/plain" + Const.ENCRYPTED_MEDIA_TYPE_SUFFIX)
+                .withAnnotations(layerAnnotations);
+    }
+
+    private static KeyPair generateKeyPair() {
+        try {
+            KeyPairGenerator generator = KeyPairGenerator.getInstance("RSA");
+            generator.initialize(2048);
+            return generator.generateKeyPair();
+        } catch (NoSuchAlgorithmException e) {
+            throw new IllegalStateException(e);
+        }
+    }
+}
@@ -0,0 +1,90 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.graalvm;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+
+import io.github.classgraph.ClassGraph;
+import io.github.classgraph.ScanResult;
+import java.io.InputStream;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.HashSet;
+import java.util.List;
+import java.util.Set;
+import java.util.jar.JarEntry;
+import java.util.jar.JarOutputStream;
+import java.util.stream.Collectors;
+import land.oras.OrasModel;
+import land.oras.Registry;
+import land.oras.Tags;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class OrasFeatureTest {
+
+    @TempDir
+    private Path dir;
+
+    @Test
+    void shouldFindAllModelsInClassDirectory() throws Exception {
+        Path classes = Path.of(OrasModel.class.getProtectionDomain().getCodeSource().getLocation().toURI());
+        try (ScanResult scanResult = new ClassGraph()
+                .enableAnnotationInfo()
+                .overrideClasspath(classes.toString())
+                .acceptPackages("land.oras")
+                .scan()) {
+            Set<String> expected = new HashSet<>(scanResult
+                    .getClassesWithAnnotation(OrasModel.class.getName())
+                    .getNames());
+            Set<String> models = OrasFeature.modelClasses(classes, getClass().getClassLoader()).stream()
+                    .map(Class::getName)
+                    .collect(Collectors.toSet());
+
+            // Assertion
+            assertEquals(expected, models);
+        }
+    }
+
+    @Test
+    void shouldFindModelsInJar() throws Exception {
+        Path jar = dir.resolve("sdk.jar");
+        try (JarOutputStream out = new JarOutputStream(Files.newOutputStream(jar))) {
+            addClass(out, Tags.class);
+            addClass(out, Registry.class);
+        }
+
+        // Assertion
+        assertEquals(List.of(Tags.class), OrasFeature.modelClasses(jar, getClass().getClassLoader()));
+    }
+
+    private static void addClass(JarOutputStream out, Class<?> clazz) throws Exception {
+        String name = clazz.getName().replace('.', '/') + ".class";
+        out.putNextEntry(new JarEntry(name));
+        try (InputStream in = clazz.getClassLoader().getResourceAsStream(name)) {
+            in.transferTo(out);
+        }
+        out.closeEntry();
+    }
+}
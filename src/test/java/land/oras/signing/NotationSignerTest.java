@@ -0,0 +1,174 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.signing;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertNotNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.security.GeneralSecurityException;
+import java.security.KeyPair;
+import java.security.KeyPairGenerator;
+import java.security.MessageDigest;
+import java.security.Signature;
+import java.security.cert.X509Certificate;
+import java.security.spec.ECGenParameterSpec;
+import java.time.Duration;
+import java.util.Base64;
+import java.util.HexFormat;
+import java.util.List;
+import java.util.Map;
+import land.oras.ArtifactSigner;
+import land.oras.ContainerRef;
+import land.oras.ManifestDescriptor;
+import land.oras.exception.OrasException;
+import land.oras.utils.Const;
+import land.oras.utils.JsonUtils;
+import land.oras.utils.TlsUtils;
+import org.bouncycastle.util.io.pem.PemObject;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class NotationSignerTest {
+
+    private static final ManifestDescriptor TARGET = ManifestDescriptor.of(
+            Const.DEFAULT_MANIFEST_MEDIA_TYPE,
+            "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+            2);
+
+    @TempDir
+    private Path tempDir;
+
+    @Test
+    @SuppressWarnings("unchecked")
+    void shouldCreateNotationSignatureEnvelope() throws GeneralSecurityException {
+        KeyPair keyPair = TlsUtils.generateKeyPair();
+        X509Certificate certificate = TlsUtils.generateCaCertificate("oras-test", keyPair);
+        NotationSigner signer = NotationSigner.of(LocalSigningKey.of(keyPair.getPrivate(), List.of(certificate)))
+                .withExpiry(Duration.ofDays(1))
+                .withUserMetadata("pipeline", "release");
+
+        ContainerRef ref = ContainerRef.parse("localhost:5000/library/signed@%s".formatted(TARGET.getDigest()));
+        ArtifactSigner.Signature signature = signer.sign(ref, TARGET);
+        Map<String, Object> envelope =
+                JsonUtils.fromJson(new String(signature.content(), StandardCharsets.UTF_8), Map.class);
+        String encodedHeader = (String) envelope.get("protected");
+        String encodedPayload = (String) envelope.get("payload");
+        Map<String, Object> header = JsonUtils.fromJson(decode(encodedHeader), Map.class);
+        Map<String, Object> payload = JsonUtils.fromJson(decode(encodedPayload), Map.class);
+        Map<String, Object> target = (Map<String, Object>) payload.get("targetArtifact");
+        Map<String, Object> unprotected = (Map<String, Object>) envelope.get("header");
+
+        // Assertion
+        assertEquals(Const.NOTATION_SIGNATURE_ARTIFACT_TYPE, signer.getArtifactType());
+        assertEquals(Const.NOTATION_JWS_MEDIA_TYPE, signature.mediaType());
+        assertEquals("PS256", header.get("alg"));
+        assertEquals(Const.NOTATION_PAYLOAD_MEDIA_TYPE, header.get("cty"));
+        assertEquals("notary.x509", header.get("io.cncf.notary.signingScheme"));
+        assertNotNull(header.get("io.cncf.notary.signingTime"));
+        assertNotNull(header.get("io.cncf.notary.expiry"));
+        assertEquals(List.of("io.cncf.notary.signingScheme", "io.cncf.notary.expiry"), header.get("crit"));
+        assertEquals(Const.DEFAULT_MANIFEST_MEDIA_TYPE, target.get("mediaType"));
+        assertEquals(TARGET.getDigest(), target.get("digest"));
+        assertEquals(2, ((Number) target.get("size")).intValue());
+        assertEquals(Map.of("pipeline", "release"), target.get("annotations"));
+        assertEquals(List.of(Base64.getEncoder().encodeToString(certificate.getEncoded())), unprotected.get("x5c"));
+        String thumbprint = HexFormat.of()
+                .formatHex(MessageDigest.getInstance("SHA-256").digest(certificate.getEncoded()));
+        assertEquals(
+                List.of(thumbprint),
+                JsonUtils.fromJson(signature.annotations().get(Const.ANNOTATION_NOTATION_THUMBPRINT), List.class));
+
+        Signature verifier = LocalSigningKey.createSignature("PS256");
+        verifier.initVerify(certificate.getPublicKey());
+        verifier.update((encodedHeader + "." + encodedPayload).getBytes(StandardCharsets.US_ASCII));
+        assertTrue(verifier.verify(Base64.getUrlDecoder().decode((String) envelope.get("signature"))));
+    }
+
+    @Test
+    void shouldReadSigningKeyFromPem() throws IOException {
+        KeyPair keyPair = TlsUtils.generateKeyPair();
+        X509Certificate certificate = TlsUtils.generateCaCertificate("oras-test", keyPair);
+        Path key = Files.writeString(
+                tempDir.resolve("key.pem"),
+                TlsUtils.toPem(new PemObject("PRIVATE KEY", keyPair.getPrivate().getEncoded())));
+        Path cert = Files.writeString(tempDir.resolve("cert.pem"), TlsUtils.toPem(certificate));
+
+        LocalSigningKey signingKey = LocalSigningKey.fromPem(key, cert);
+
+        // Assertion
+        assertEquals("PS256", signingKey.getAlgorithm());
+        assertEquals(List.of(certificate), signingKey.getCertificateChain());
+    }
+
+    @Test
+    void shouldSignWithEcKeys() throws GeneralSecurityException {
+        KeyPairGenerator generator = KeyPairGenerator.getInstance("EC");
+        generator.initialize(new ECGenParameterSpec("secp384r1"));
+        KeyPair keyPair = generator.generateKeyPair();
+        byte[] data = "signing input".getBytes(StandardCharsets.US_ASCII);
+
+        Signature signer = LocalSigningKey.createSignature("ES384");
+        signer.initSign(keyPair.getPrivate());
+        signer.update(data);
+        byte[] signature = signer.sign();
+        Signature verifier = LocalSigningKey.createSignature("ES384");
+        verifier.initVerify(keyPair.getPublic());
+        verifier.update(data);
+
+        // Assertion
+        assertEquals("ES384", SigningKey.algorithmOf(keyPair.getPublic()));
+        assertEquals(96, signature.length);
+        assertTrue(verifier.verify(signature));
+    }
+
+    @Test
+    void shouldRejectInvalidSigningKeys() throws GeneralSecurityException {
+        KeyPair rsa = TlsUtils.generateKeyPair();
+        X509Certificate certificate = TlsUtils.generateCaCertificate("oras-test", rsa);
+        KeyPairGenerator generator = KeyPairGenerator.getInstance("EC");
+        generator.initialize(new ECGenParameterSpec("secp256r1"));
+        KeyPair ec = generator.generateKeyPair();
+        KeyPairGenerator small = KeyPairGenerator.getInstance("RSA");
+        small.initialize(1024);
+
+        // Assertion
+        assertThrows(OrasException.class, () -> LocalSigningKey.of(rsa.getPrivate(), List.of()));
+        assertThrows(OrasException.class, () -> LocalSigningKey.of(ec.getPrivate(), List.of(certificate)));
+        assertThrows(OrasException.class, () -> SigningKey.algorithmOf(small.generateKeyPair().getPublic()));
+        assertThrows(
+                OrasException.class,
+                () -> NotationSigner.of(LocalSigningKey.of(rsa.getPrivate(), List.of(certificate)))
+                        .withExpiry(Duration.ZERO));
+    }
+
+    private static String decode(String data) {
+        return new String(Base64.getUrlDecoder().decode(data), StandardCharsets.UTF_8);
+    }
+}
@@ -24,15 +24,27 @@ import static org.junit.jupiter.api.Assertions.*;
 import static org.mockito.Mockito.doReturn;
 import static org.mockito.Mockito.mock;
 
+import java.io.FilterInputStream;
 import java.io.IOException;
+import java.io.InputStream;
 import java.nio.file.Files;
+import java.nio.file.LinkOption;
 import java.nio.file.Path;
 import java.nio.file.Paths;
+import java.nio.file.attribute.FileTime;
 import java.nio.file.attribute.PosixFilePermission;
+import java.nio.file.attribute.PosixFilePermissions;
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.List;
 import java.util.Set;
+import java.util.stream.Stream;
 import land.oras.LocalPath;
+import land.oras.exception.ContentTooLargeException;
+import land.oras.exception.DigestMismatchException;
 import land.oras.exception.OrasException;
 import org.apache.commons.compress.archivers.tar.TarArchiveEntry;
+import org.apache.commons.compress.archivers.tar.TarArchiveInputStream;
 import org.apache.commons.compress.archivers.tar.TarArchiveOutputStream;
 import org.junit.jupiter.api.BeforeAll;
 import org.junit.jupiter.api.Disabled;
@@ -255,6 +267,62 @@ class ArchiveUtilsTest {
         assertTrue(Files.exists(temp), "Temp should exist");
     }
 
+    @ParameterizedTest
+    @ValueSource(strings = {Const.DEFAULT_BLOB_DIR_MEDIA_TYPE, Const.BLOB_DIR_ZSTD_MEDIA_TYPE})
+    void shouldStreamExtractCompressedArchive(String mediaType, @TempDir Path target) throws IOException {
+        LocalPath archive = ArchiveUtils.tar(LocalPath.of(archiveDir));
+        String contentDigest = SupportedAlgorithm.SHA256.digest(archive.getPath());
+        Path compressedArchive = ArchiveUtils.compress(archive, mediaType).getPath();
+
+        try (InputStream is = Files.newInputStream(compressedArchive)) {
+            ArchiveUtils.uncompressuntar(is, target.resolve("ok"), mediaType, contentDigest);
+
+            // Assertion
+            assertEquals(-1, is.read(), "Stream should be fully read");
+        }
+        Path extractedDir = target.resolve("ok").resolve(archiveDir.getFileName());
+        assertEquals("file1", Files.readString(extractedDir.resolve("dir1").resolve("file1")));
+        assertEquals("file4", Files.readString(extractedDir.resolve("dir2").resolve("dir3").resolve("file4")));
+
+        // Content digest mismatch
+        try (InputStream is = Files.newInputStream(compressedArchive)) {
+            assertThrows(
+                    DigestMismatchException.class,
+                    () -> ArchiveUtils.uncompressuntar(
+                            is, target.resolve("ko"), mediaType, SupportedAlgorithm.SHA256.digest(new byte[0])));
+        }
+        try (Stream<Path> entries = Files.list(target.resolve("ko"))) {
+            assertEquals(0, entries.count(), "Nothing should be extracted on content digest mismatch");
+        }
+
+        // Blob digest mismatch reported by the stream once fully read
+        String blobDigest = SupportedAlgorithm.SHA256.digest(compressedArchive);
+        try (InputStream is = new FilterInputStream(Files.newInputStream(compressedArchive)) {
+            @Override
+            public int read(byte[] b, int off, int len) throws IOException {
+                int read = super.read(b, off, len);
+                if (read == -1) {
+                    throw new DigestMismatchException(blobDigest, SupportedAlgorithm.SHA256.digest(new byte[0]));
+                }
+                return read;
+            }
+        }) {
+            assertThrows(
+                    DigestMismatchException.class,
+                    () -> ArchiveUtils.uncompressuntar(is, target.resolve("blob-ko"), mediaType, contentDigest));
+        }
+        try (Stream<Path> entries = Files.list(target.resolve("blob-ko"))) {
+            assertEquals(0, entries.count(), "Nothing should be extracted on blob digest mismatch");
+        }
+
+        // No staging directory left next to the targets
+        try (Stream<Path> entries = Files.list(target)) {
+            assertEquals(
+                    List.of("blob-ko", "ko", "ok"),
+                    entries.map(path -> path.getFileName().toString()).sorted().toList());
+        }
+    }
+
     @ParameterizedTest
     @ValueSource(strings = {"jenkins-chart.tgz", "jenkins-sources.tar.gz", "flux-manifests.tgz"})
     @Disabled("https://issues.apache.org/jira/browse/COMPRESS-705")
@@ -274,6 +342,30 @@ class ArchiveUtilsTest {
         ArchiveUtils.unzip(archive, existingArchiveDir);
     }
 
+    @Test
+    void shouldCreateDeterministicTar() throws Exception {
+        LocalPath directory = LocalPath.of(archiveDir);
+        LocalPath first = ArchiveUtils.tarcompress(directory, Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+        LocalPath second = ArchiveUtils.tarcompress(directory, Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+        assertEquals(
+                SupportedAlgorithm.SHA256.digest(first.getPath()),
+                SupportedAlgorithm.SHA256.digest(second.getPath()),
+                "Same directory should produce the same archive");
+
+        // Entries are sorted
+        List<String> names = new ArrayList<>();
+        Path tar = ArchiveUtils.tar(directory).getPath();
+        try (TarArchiveInputStream tais = new TarArchiveInputStream(Files.newInputStream(tar))) {
+            TarArchiveEntry entry;
+            while ((entry = tais.getNextEntry()) != null) {
+                names.add(entry.getName());
+            }
+        }
+        List<String> sorted = new ArrayList<>(names);
+        Collections.sort(sorted);
+        assertEquals(sorted, names, "Entries should be sorted");
+    }
+
     @Test
     void shouldCreateTarZstdAndExtractIt() throws Exception {
         LocalPath directory = LocalPath.of(archiveDir, Const.BLOB_DIR_ZSTD_MEDIA_TYPE);
@@ -283,6 +375,7 @@ class ArchiveUtilsTest {
                 ArchiveUtils.compress(archive, directory.getMediaType()).getPath();
 
         assertTrue(Files.exists(compressedArchive), "Archive should exist");
+        assertTrue(compressedArchive.toString().endsWith(".zst"), "Archive should have zstd extension");
 
         Path uncompressedArchive = ArchiveUtils.uncompress(
                         Files.newInputStream(compressedArchive), Const.BLOB_DIR_ZSTD_MEDIA_TYPE)
@@ -370,6 +463,250 @@ class ArchiveUtilsTest {
         assertFalse(Files.exists(escapeFile), "Symlink-target escape must not create a file outside target");
     }
 
+    @Test
+    void shouldRejectSymlinkChainEscapingTargetOnUntar(@TempDir Path tmp) throws IOException {
+        if (!OsUtils.isPosixFileSystemSupported()) {
+            return;
+        }
+        Path target = tmp.resolve("safe-output");
+        Path mtar = tmp.resolve("malicious.tar");
+        try (TarArchiveOutputStream tout = new TarArchiveOutputStream(Files.newOutputStream(mtar))) {
+            // d -> . stays inside, but d/e -> .. is resolved from the real parent, which is the target itself
+            for (String[] link : List.of(new String[] {"d", "."}, new String[] {"d/e", ".."})) {
+                TarArchiveEntry symlinkEntry = new TarArchiveEntry(link[0], TarArchiveEntry.LF_SYMLINK);
+                symlinkEntry.setLinkName(link[1]);
+                tout.putArchiveEntry(symlinkEntry);
+                tout.closeArchiveEntry();
+            }
+            byte[] data = "should not land outside target\n".getBytes();
+            TarArchiveEntry fileEntry = new TarArchiveEntry("e/ESCAPED.txt");
+            fileEntry.setSize(data.length);
+            tout.putArchiveEntry(fileEntry);
+            tout.write(data);
+            tout.closeArchiveEntry();
+        }
+
+        // Assertion
+        assertThrows(OrasException.class, () -> ArchiveUtils.untar(mtar, target));
+        assertTrue(Files.isSymbolicLink(target.resolve("d")));
+        assertFalse(Files.exists(target.resolve("e"), LinkOption.NOFOLLOW_LINKS));
+        assertFalse(Files.exists(tmp.resolve("ESCAPED.txt")));
+    }
+
+    @Test
+    void shouldRejectDirectoriesCreatedThroughSymlink(@TempDir Path tmp) throws IOException {
+        if (!OsUtils.isPosixFileSystemSupported()) {
+            return;
+        }
+        Path outside = Files.createDirectories(tmp.resolve("outside"));
+        Path target = Files.createDirectories(tmp.resolve("safe-output"));
+        Files.createSymbolicLink(target.resolve("e"), outside);
+
+        Path tar = tmp.resolve("directory.tar");
+        try (TarArchiveOutputStream tout = new TarArchiveOutputStream(Files.newOutputStream(tar))) {
+            tout.putArchiveEntry(new TarArchiveEntry("e/x/"));
+            tout.closeArchiveEntry();
+        }
+        Path zip = tmp.resolve("directory.zip");
+        try (java.util.zip.ZipOutputStream zout = new java.util.zip.ZipOutputStream(Files.newOutputStream(zip))) {
+            zout.putNextEntry(new java.util.zip.ZipEntry("e/y/"));
+            zout.closeEntry();
+            zout.putNextEntry(new java.util.zip.ZipEntry("e/z/file.txt"));
+            zout.write("should not land outside target".getBytes());
+            zout.closeEntry();
+        }
+
+        // Assertion
+        assertThrows(OrasException.class, () -> ArchiveUtils.untar(tar, target));
+        assertThrows(OrasException.class, () -> ArchiveUtils.unzip(zip, target));
+        try (var files = Files.list(outside)) {
+            assertEquals(0, files.count(), "No directory should be created outside of the target");
+        }
+    }
+
+    @Test
+    void shouldRejectHardlinksThroughSymlink(@TempDir Path tmp) throws IOException {
+        if (!OsUtils.isPosixFileSystemSupported()) {
+            return;
+        }
+        Path outside = Files.createDirectories(tmp.resolve("outside"));
+        Files.writeString(outside.resolve("passwd"), "secret");
+        Path target = Files.createDirectories(tmp.resolve("safe-output"));
+        Files.createSymbolicLink(target.resolve("e"), outside);
+
+        Path tar = tmp.resolve("hardlink.tar");
+        try (TarArchiveOutputStream tout = new TarArchiveOutputStream(Files.newOutputStream(tar))) {
+            TarArchiveEntry hardlinkEntry = new TarArchiveEntry("passwd", TarArchiveEntry.LF_LINK);
+            hardlinkEntry.setLinkName("e/passwd");
+            tout.putArchiveEntry(hardlinkEntry);
+            tout.closeArchiveEntry();
+        }
+
+        // Assertion
+        assertThrows(OrasException.class, () -> ArchiveUtils.untar(tar, target));
+        assertThrows(
+                OrasException.class,
+                () -> ArchiveUtils.untar(
+                        Files.newInputStream(tar),
+                        target,
+                        ArchiveUtils.ExtractOptions.defaults().withHardlinks(false)));
+        assertFalse(Files.exists(target.resolve("passwd")));
+    }
+
+    @Test
+    void shouldNotWriteThroughExistingHardlink(@TempDir Path tmp) throws IOException {
+        Path outside = Files.writeString(tmp.resolve("outside.txt"), "secret");
+        Path target = Files.createDirectories(tmp.resolve("output"));
+        try {
+            Files.createLink(target.resolve("file.txt"), outside);
+        } catch (UnsupportedOperationException e) {
+            return;
+        }
+
+        byte[] content = "overwritten".getBytes();
+        Path tar = tmp.resolve("file.tar");
+        try (TarArchiveOutputStream tout = new TarArchiveOutputStream(Files.newOutputStream(tar))) {
+            TarArchiveEntry entry = new TarArchiveEntry("file.txt");
+            entry.setSize(content.length);
+            tout.putArchiveEntry(entry);
+            tout.write(content);
+            tout.closeArchiveEntry();
+        }
+        ArchiveUtils.untar(tar, target);
+
+        // Assertion
+        assertEquals("overwritten", Files.readString(target.resolve("file.txt")));
+        assertEquals("secret", Files.readString(outside));
+    }
+
+    @Test
+    void shouldApplyExtractOptions(@TempDir Path tmp) throws IOException {
+        Path tar = tmp.resolve("archive.tar");
+        try (TarArchiveOutputStream tout = new TarArchiveOutputStream(Files.newOutputStream(tar))) {
+            tout.setLongFileMode(TarArchiveOutputStream.LONGFILE_POSIX);
+            byte[] data = "0123456789".getBytes();
+            for (String name : List.of("dir/file1", "dir/../file2")) {
+                TarArchiveEntry entry = new TarArchiveEntry(name);
+                entry.setSize(data.length);
+                entry.setMode(0644);
+                tout.putArchiveEntry(entry);
+                tout.write(data);
+                tout.closeArchiveEntry();
+            }
+            TarArchiveEntry symlinkEntry = new TarArchiveEntry("link", TarArchiveEntry.LF_SYMLINK);
+            symlinkEntry.setLinkName("dir/file1");
+            tout.putArchiveEntry(symlinkEntry);
+            tout.closeArchiveEntry();
+        }
+
+        // Defaults extract everything staying in the target
+        ArchiveUtils.untar(tar, tmp.resolve("defaults"));
+        assertEquals("0123456789", Files.readString(tmp.resolve("defaults").resolve("file2")));
+
+        // Assertion
+        assertThrows(
+                OrasException.class,
+                () -> ArchiveUtils.untar(
+                        Files.newInputStream(tar),
+                        tmp.resolve("strict"),
+                        ArchiveUtils.ExtractOptions.defaults().withStrictPaths(true)));
+        assertFalse(Files.exists(tmp.resolve("strict").resolve("file2")));
+        assertThrows(
+                OrasException.class,
+                () -> ArchiveUtils.untar(
+                        Files.newInputStream(tar),
+                        tmp.resolve("hardened"),
+                        ArchiveUtils.ExtractOptions.hardened().withStrictPaths(false)));
+        assertFalse(Files.exists(tmp.resolve("hardened").resolve("link")));
+        OrasException e = assertThrows(
+                OrasException.class,
+                () -> ArchiveUtils.untar(
+                        Files.newInputStream(tar),
+                        tmp.resolve("entries"),
+                        ArchiveUtils.ExtractOptions.defaults().withMaxEntries(2)));
+        assertEquals("Archive has more than 2 entries", e.getMessage());
+        ContentTooLargeException tooLarge = assertThrows(
+                ContentTooLargeException.class,
+                () -> ArchiveUtils.untar(
+                        Files.newInputStream(tar),
+                        tmp.resolve("size"),
+                        ArchiveUtils.ExtractOptions.defaults().withMaxSize(15)));
+        assertEquals(15, tooLarge.getMaxSize());
+    }
+
+    @Test
+    void shouldPreserveFileMetadata(@TempDir Path tmp) throws IOException {
+        Path source = tmp.resolve("tool");
+        Files.createDirectories(source);
+        Path script = Files.writeString(source.resolve("run.sh"), "#!/bin/sh\necho hello\n");
+        FileTime modified = FileTime.fromMillis(1_600_000_000_000L);
+        Files.setLastModifiedTime(script, modified);
+        if (OsUtils.isPosixFileSystemSupported()) {
+            Files.setPosixFilePermissions(script, PosixFilePermissions.fromString("rwxr-x---"));
+            Files.createLink(source.resolve("run-link.sh"), script);
+        }
+
+        // Preserved
+        LocalPath archive = ArchiveUtils.tar(
+                LocalPath.of(source), false, ArchiveUtils.TarOptions.defaults().withHardlinks(true));
+        ArchiveUtils.untar(archive.getPath(), tmp.resolve("preserved"));
+
+        // Assertion
+        Path extracted = tmp.resolve("preserved").resolve("run.sh");
+        assertEquals(modified, Files.getLastModifiedTime(extracted));
+        if (OsUtils.isPosixFileSystemSupported()) {
+            assertEquals(PosixFilePermissions.fromString("rwxr-x---"), Files.getPosixFilePermissions(extracted));
+            assertEquals(2, Files.getAttribute(extracted, "unix:nlink"));
+        }
+
+        // Normalized
+        archive = ArchiveUtils.tar(LocalPath.of(source), false, ArchiveUtils.TarOptions.normalized());
+        ArchiveUtils.untar(archive.getPath(), tmp.resolve("normalized"));
+        extracted = tmp.resolve("normalized").resolve("run.sh");
+        assertEquals(FileTime.fromMillis(0), Files.getLastModifiedTime(extracted));
+        if (OsUtils.isPosixFileSystemSupported()) {
+            assertEquals(PosixFilePermissions.fromString("rwxr-xr-x"), Files.getPosixFilePermissions(extracted));
+            assertEquals(1, Files.getAttribute(extracted, "unix:nlink"));
+        }
+
+        // Metadata not restored on extraction
+        ArchiveUtils.untar(
+                Files.newInputStream(archive.getPath()),
+                tmp.resolve("ignored"),
+                ArchiveUtils.ExtractOptions.defaults().withModificationTime(false));
+        assertNotEquals(FileTime.fromMillis(0), Files.getLastModifiedTime(tmp.resolve("ignored").resolve("run.sh")));
+    }
+
+    @Test
+    void shouldCreateReproducibleLayers(@TempDir Path tmp) throws IOException {
+        Path first = tmp.resolve("first").resolve("content");
+        Path second = tmp.resolve("second").resolve("content");
+        for (Path dir : List.of(first, second)) {
+            Files.createDirectories(dir.resolve("sub"));
+            Files.writeString(dir.resolve("sub").resolve("b.txt"), "b");
+            Files.writeString(dir.resolve("a.txt"), "a");
+        }
+        Files.setLastModifiedTime(first.resolve("a.txt"), FileTime.fromMillis(1_000_000_000_000L));
+        Files.setLastModifiedTime(second.resolve("a.txt"), FileTime.fromMillis(1_700_000_000_000L));
+
+        ArchiveUtils.TarOptions options = ArchiveUtils.TarOptions.reproducible();
+        LocalPath firstLayer = ArchiveUtils.compress(
+                ArchiveUtils.tar(LocalPath.of(first), false, options), Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+        LocalPath secondLayer = ArchiveUtils.compress(
+                ArchiveUtils.tar(LocalPath.of(second), false, options), Const.DEFAULT_BLOB_DIR_MEDIA_TYPE);
+
+        // Assertion
+        assertTrue(options.isReproducible());
+        assertEquals(
+                SupportedAlgorithm.SHA256.digest(firstLayer.getPath()),
+                SupportedAlgorithm.SHA256.digest(secondLayer.getPath()));
+        assertNotEquals(
+                SupportedAlgorithm.SHA256.digest(ArchiveUtils.tar(LocalPath.of(first), false)
+                        .getPath()),
+                SupportedAlgorithm.SHA256.digest(ArchiveUtils.tar(LocalPath.of(second), false)
+                        .getPath()));
+    }
+
     @Test
     void shouldUntarOverwriteExistingFiles(@TempDir Path tmp) throws IOException {
         Path target = tmp.resolve("output");
@@ -0,0 +1,101 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertFalse;
+import static org.junit.jupiter.api.Assertions.assertNull;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class DigestAlgorithmsTest {
+
+    /**
+     * A custom algorithm not part of the supported algorithms
+     */
+    private static final DigestAlgorithm SHA3_256 = new DigestAlgorithm() {
+        @Override
+        public String getPrefix() {
+            return "sha3-256";
+        }
+
+        @Override
+        public String getAlgorithmName() {
+            return "SHA3-256";
+        }
+
+        @Override
+        public int getSize() {
+            return 32;
+        }
+    };
+
+    @Test
+    void shouldResolveSupportedAlgorithms() {
+        assertEquals(
+                SupportedAlgorithm.SHA512,
+                DigestAlgorithms.fromDigest(SupportedAlgorithm.SHA512.digest("hello".getBytes())));
+        assertEquals(SupportedAlgorithm.SHA256, DigestAlgorithms.get("sha256"));
+        assertTrue(DigestAlgorithms.values().contains(SupportedAlgorithm.BLAKE3));
+        assertNull(DigestAlgorithms.get("unknown"));
+        assertFalse(DigestAlgorithms.isSupported("unknown:1234"));
+        assertThrows(OrasException.class, () -> DigestAlgorithms.fromDigest("unknown:1234"));
+        assertThrows(OrasException.class, () -> DigestAlgorithms.isSupported("sha512:1234"));
+    }
+
+    @Test
+    void shouldRegisterCustomAlgorithm() {
+        DigestAlgorithms.register(SHA3_256);
+        String digest = SHA3_256.digest("hello".getBytes());
+        assertEquals("sha3-256:3338be694f50c5f338814986cdf0686453a888b84f424d792af4b9202398f392", digest);
+        assertTrue(DigestAlgorithms.isSupported(digest));
+        assertEquals(SHA3_256, DigestAlgorithms.fromDigest(digest));
+        assertEquals(
+                "3338be694f50c5f338814986cdf0686453a888b84f424d792af4b9202398f392", DigestAlgorithms.getDigest(digest));
+    }
+
+    @Test
+    void shouldRejectInvalidPrefix() {
+        DigestAlgorithm invalid = new DigestAlgorithm() {
+            @Override
+            public String getPrefix() {
+                return "SHA:256";
+            }
+
+            @Override
+            public String getAlgorithmName() {
+                return "SHA-256";
+            }
+
+            @Override
+            public int getSize() {
+                return 32;
+            }
+        };
+        assertThrows(OrasException.class, () -> DigestAlgorithms.register(invalid));
+    }
+}
@@ -0,0 +1,611 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import com.sun.net.httpserver.HttpExchange;
+import com.sun.net.httpserver.HttpServer;
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.net.InetAddress;
+import java.net.InetSocketAddress;
+import java.net.URI;
+import java.net.URLDecoder;
+import java.nio.charset.StandardCharsets;
+import java.time.Duration;
+import java.util.ArrayList;
+import java.util.Deque;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.LinkedHashSet;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.TreeMap;
+import java.util.UUID;
+import java.util.concurrent.ConcurrentLinkedDeque;
+import java.util.concurrent.ExecutorService;
+import java.util.concurrent.Executors;
+import java.util.concurrent.atomic.AtomicInteger;
+import java.util.concurrent.locks.ReentrantLock;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+import land.oras.MemoryStore;
+import org.jspecify.annotations.NullMarked;
+import org.jspecify.annotations.Nullable;
+
+/**
+ * A lightweight in-process OCI registry for unit tests that can't run containers.
+ * Content is kept in memory and served over plain HTTP without authentication on an ephemeral port.
+ * Supports push (monolithic and chunked), pull, mount, tags, referrers and catalog, and can inject
+ * failures or slow responses.
+ */
+@NullMarked
+public final class FakeRegistry implements AutoCloseable {
+
+    /**
+     * The distribution API paths. The repository name can contain slashes
+     */
+    private static final Pattern PATH_PATTERN =
+            Pattern.compile("^/v2/(.+?)/(blobs/uploads|blobs|manifests|tags/list|referrers)/?([^/]*)$");
+
+    /**
+     * The Range header of a blob request
+     */
+    private static final Pattern RANGE_PATTERN = Pattern.compile("^bytes=(\\d+)-(\\d*)$");
+
+    private final HttpServer server;
+    private final ExecutorService executor;
+    private final ReentrantLock lock = new ReentrantLock();
+
+    /**
+     * Blob content by digest algorithm prefix. Blobs are shared across repositories
+     */
+    private final Map<String, MemoryStore> stores = new HashMap<>();
+
+    /**
+     * Digests of the blobs linked to each repository
+     */
+    private final Map<String, Set<String>> blobs = new HashMap<>();
+
+    /**
+     * Manifests of each repository by digest, in push order
+     */
+    private final Map<String, Map<String, StoredManifest>> manifests = new TreeMap<>();
+
+    /**
+     * Tags of each repository with the digest they point to
+     */
+    private final Map<String, Map<String, String>> tags = new HashMap<>();
+
+    /**
+     * Upload sessions by id
+     */
+    private final Map<String, ByteArrayOutputStream> uploads = new HashMap<>();
+
+    private final Deque<Integer> failures = new ConcurrentLinkedDeque<>();
+    private final AtomicInteger requestCount = new AtomicInteger();
+    private volatile Duration delay = Duration.ZERO;
+
+    /**
+     * A pushed manifest
+     * @param digest The digest
+     * @param mediaType The media type
+     * @param size The size
+     * @param artifactType The artifact type or config media type
+     * @param subject The digest of the subject
+     * @param annotations The annotations
+     */
+    private record StoredManifest(
+            String digest,
+            String mediaType,
+            long size,
+            @Nullable String artifactType,
+            @Nullable String subject,
+            Map<String, String> annotations) {}
+
+    private FakeRegistry() throws IOException {
+        this.server = HttpServer.create(new InetSocketAddress(InetAddress.getLoopbackAddress(), 0), 0);
+        this.executor = Executors.newCachedThreadPool();
+        this.server.setExecutor(executor);
+        this.server.createContext("/", this::handle);
+    }
+
+    /**
+     * Start a new fake registry on an ephemeral port
+     * @return The started registry
+     */
+    public static FakeRegistry start() {
+        try {
+            FakeRegistry registry = new FakeRegistry();
+            registry.server.start();
+            return registry;
+        } catch (IOException e) {
+            throw new RuntimeException("Failed to start fake registry", e);
+        }
+    }
+
+    /**
+     * Get the registry host:port
+     * @return The registry URL
+     */
+    public String getRegistry() {
+        return "localhost:" + server.getAddress().getPort();
+    }
+
+    /**
+     * Fail the next request with the given status code
+     * @param statusCode The status code, for example 401 or 429
+     * @return The registry
+     */
+    public FakeRegistry failNext(int statusCode) {
+        return failNext(statusCode, 1);
+    }
+
+    /**
+     * Fail the next requests with the given status code. 401 responses carry a challenge the client can't satisfy
+     * and 429 responses ask to retry immediately
+     * @param statusCode The status code, for example 401 or 429
+     * @param times The number of requests to fail
+     * @return The registry
+     */
+    public FakeRegistry failNext(int statusCode, int times) {
+        if (statusCode < 400) {
+            throw new IllegalArgumentException("Status code must be an error: %d".formatted(statusCode));
+        }
+        for (int i = 0; i < times; i++) {
+            failures.add(statusCode);
+        }
+        return this;
+    }
+
+    /**
+     * Delay every response, for example to trigger client timeouts
+     * @param delay The delay
+     * @return The registry
+     */
+    public FakeRegistry withDelay(Duration delay) {
+        if (delay.isNegative()) {
+            throw new IllegalArgumentException("Delay must not be negative");
+        }
+        this.delay = delay;
+        return this;
+    }
+
+    /**
+     * Get the number of requests received, including failed ones
+     * @return The number of requests
+     */
+    public int getRequestCount() {
+        return requestCount.get();
+    }
+
+    /**
+     * Get the tags of a repository
+     * @param name The repository name
+     * @return The sorted tags
+     */
+    public List<String> getTags(String name) {
+        lock.lock();
+        try {
+            return new ArrayList<>(new TreeMap<>(tags.getOrDefault(name, Map.of())).keySet());
+        } finally {
+            lock.unlock();
+        }
+    }
+
+    /**
+     * Check if a blob is linked to a repository
+     * @param name The repository name
+     * @param digest The digest
+     * @return True if the blob exists in the repository
+     */
+    public boolean hasBlob(String name, String digest) {
+        lock.lock();
+        try {
+            return blobs.getOrDefault(name, Set.of()).contains(digest);
+        } finally {
+            lock.unlock();
+        }
+    }
+
+    /**
+     * Stop the registry and discard its content
+     */
+    @Override
+    public void close() {
+        server.stop(0);
+        executor.shutdownNow();
+    }
+
+    private void handle(HttpExchange exchange) throws IOException {
+        try {
+            requestCount.incrementAndGet();
+            if (!delay.isZero()) {
+                Thread.sleep(delay.toMillis());
+            }
+            Integer failure = failures.poll();
+            if (failure != null) {
+                sendFailure(exchange, failure);
+                return;
+            }
+            String path = exchange.getRequestURI().getPath();
+            if (path.equals("/v2/") || path.equals("/v2")) {
+                sendJson(exchange, 200, Map.of(), Map.of());
+                return;
+            }
+            Matcher matcher = PATH_PATTERN.matcher(path);
+            if (!path.equals("/v2/_catalog") && !matcher.matches()) {
+                sendError(exchange, 404, "NAME_UNKNOWN", "Unknown path %s".formatted(path));
+                return;
+            }
+            String method = exchange.getRequestMethod();
+            Map<String, String> query = parseQuery(exchange.getRequestURI());
+            lock.lock();
+            try {
+                if (path.equals("/v2/_catalog")) {
+                    sendJson(exchange, 200, Map.of("repositories", List.copyOf(manifests.keySet())), Map.of());
+                    return;
+                }
+                String name = matcher.group(1);
+                String reference = matcher.group(3);
+                switch (matcher.group(2)) {
+                    case "blobs/uploads" -> handleUpload(exchange, method, name, reference, query);
+                    case "blobs" -> handleBlob(exchange, method, name, reference);
+                    case "manifests" -> handleManifest(exchange, method, name, reference);
+                    case "tags/list" -> handleTags(exchange, name, query);
+                    default -> handleReferrers(exchange, name, reference, query);
+                }
+            } finally {
+                lock.unlock();
+            }
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+        } catch (RuntimeException e) {
+            sendError(exchange, 500, "UNKNOWN", e.getMessage() == null ? e.toString() : e.getMessage());
+        } finally {
+            exchange.close();
+        }
+    }
+
+    private void handleUpload(
+            HttpExchange exchange, String method, String name, String id, Map<String, String> query)
+            throws IOException {
+        byte[] data = exchange.getRequestBody().readAllBytes();
+        if (method.equals("POST")) {
+            String mount = query.get("mount");
+            String from = query.get("from");
+            if (mount != null && from != null && blobs.getOrDefault(from, Set.of()).contains(mount)) {
+                blobs.computeIfAbsent(name, k -> new LinkedHashSet<>()).add(mount);
+                send(exchange, 201, null, blobHeaders(name, mount));
+                return;
+            }
+            String digest = query.get("digest");
+            if (digest != null) {
+                completeUpload(exchange, name, digest, data);
+                return;
+            }
+            String newId = UUID.randomUUID().toString();
+            ByteArrayOutputStream upload = new ByteArrayOutputStream();
+            upload.writeBytes(data);
+            uploads.put(newId, upload);
+            sendUploadStatus(exchange, 202, name, newId, upload);
+            return;
+        }
+        ByteArrayOutputStream upload = uploads.get(id);
+        if (upload == null) {
+            sendError(exchange, 404, "BLOB_UPLOAD_UNKNOWN", "Unknown upload %s".formatted(id));
+            return;
+        }
+        switch (method) {
+            case "PATCH" -> {
+                String contentRange = exchange.getRequestHeaders().getFirst(Const.CONTENT_RANGE_HEADER);
+                if (contentRange != null && !contentRange.startsWith(upload.size() + "-")) {
+                    sendUploadStatus(exchange, 416, name, id, upload);
+                    return;
+                }
+                upload.writeBytes(data);
+                sendUploadStatus(exchange, 202, name, id, upload);
+            }
+            case "GET" -> sendUploadStatus(exchange, 204, name, id, upload);
+            case "PUT" -> {
+                String digest = query.get("digest");
+                if (digest == null) {
+                    sendError(exchange, 400, "DIGEST_INVALID", "Missing digest");
+                    return;
+                }
+                upload.writeBytes(data);
+                uploads.remove(id);
+                completeUpload(exchange, name, digest, upload.toByteArray());
+            }
+            case "DELETE" -> {
+                uploads.remove(id);
+                send(exchange, 204, null, Map.of());
+            }
+            default -> sendError(exchange, 405, "UNSUPPORTED", "Unsupported method %s".formatted(method));
+        }
+    }
+
+    private void completeUpload(HttpExchange exchange, String name, String digest, byte[] data) throws IOException {
+        if (!DigestAlgorithms.isSupported(digest)
+                || !DigestAlgorithms.fromDigest(digest).digest(data).equals(digest)) {
+            sendError(exchange, 400, "DIGEST_INVALID", "Digest does not match content: %s".formatted(digest));
+            return;
+        }
+        store(DigestAlgorithms.fromDigest(digest)).addBlob(data);
+        blobs.computeIfAbsent(name, k -> new LinkedHashSet<>()).add(digest);
+        send(exchange, 201, null, blobHeaders(name, digest));
+    }
+
+    private void handleBlob(HttpExchange exchange, String method, String name, String digest) throws IOException {
+        if (!blobs.getOrDefault(name, Set.of()).contains(digest)) {
+            sendError(exchange, 404, "BLOB_UNKNOWN", "Blob unknown to registry: %s".formatted(digest));
+            return;
+        }
+        if (method.equals("DELETE")) {
+            blobs.get(name).remove(digest);
+            send(exchange, 202, null, Map.of());
+            return;
+        }
+        byte[] data = store(DigestAlgorithms.fromDigest(digest)).getBlob(digest);
+        Map<String, String> headers = new HashMap<>();
+        headers.put(Const.CONTENT_TYPE_HEADER, Const.APPLICATION_OCTET_STREAM_HEADER_VALUE);
+        headers.put(Const.DOCKER_CONTENT_DIGEST_HEADER, digest);
+        String range = exchange.getRequestHeaders().getFirst(Const.RANGE_HEADER);
+        Matcher matcher = range == null ? null : RANGE_PATTERN.matcher(range);
+        if (matcher != null && matcher.matches()) {
+            int start = Integer.parseInt(matcher.group(1));
+            int end = matcher.group(2).isEmpty() ? data.length - 1 : Integer.parseInt(matcher.group(2));
+            if (start >= data.length || end < start) {
+                headers.put(Const.CONTENT_RANGE_HEADER, "bytes */%d".formatted(data.length));
+                send(exchange, 416, null, headers);
+                return;
+            }
+            end = Math.min(end, data.length - 1);
+            headers.put(Const.CONTENT_RANGE_HEADER, "bytes %d-%d/%d".formatted(start, end, data.length));
+            byte[] partial = new byte[end - start + 1];
+            System.arraycopy(data, start, partial, 0, partial.length);
+            send(exchange, 206, partial, headers);
+            return;
+        }
+        send(exchange, 200, data, headers);
+    }
+
+    @SuppressWarnings("unchecked")
+    private void handleManifest(HttpExchange exchange, String method, String name, String reference)
+            throws IOException {
+        boolean byDigest = DigestAlgorithms.isSupported(reference);
+        if (method.equals("PUT")) {
+            byte[] data = exchange.getRequestBody().readAllBytes();
+            DigestAlgorithm algorithm =
+                    byDigest ? DigestAlgorithms.fromDigest(reference) : SupportedAlgorithm.getDefault();
+            String digest = algorithm.digest(data);
+            if (byDigest && !digest.equals(reference)) {
+                sendError(exchange, 400, "DIGEST_INVALID", "Digest does not match content: %s".formatted(reference));
+                return;
+            }
+            Map<String, Object> json = JsonUtils.fromJson(new String(data, StandardCharsets.UTF_8), Map.class);
+            String mediaType = exchange.getRequestHeaders().getFirst(Const.CONTENT_TYPE_HEADER);
+            if (mediaType == null) {
+                mediaType =
+                        (String) json.getOrDefault(Const.JSON_PROPERTY_MEDIA_TYPE, Const.DEFAULT_MANIFEST_MEDIA_TYPE);
+            }
+            String artifactType = (String) json.get(Const.JSON_PROPERTY_ARTIFACT_TYPE);
+            if (artifactType == null && json.get(Const.JSON_PROPERTY_CONFIG) instanceof Map<?, ?> config) {
+                artifactType = (String) config.get(Const.JSON_PROPERTY_MEDIA_TYPE);
+            }
+            String subject = json.get(Const.JSON_PROPERTY_SUBJECT) instanceof Map<?, ?> descriptor
+                    ? (String) descriptor.get(Const.JSON_PROPERTY_DIGEST)
+                    : null;
+            Map<String, String> annotations =
+                    (Map<String, String>) json.getOrDefault(Const.JSON_PROPERTY_ANNOTATIONS, Map.of());
+            store(algorithm).addBlob(data);
+            manifests
+                    .computeIfAbsent(name, k -> new LinkedHashMap<>())
+                    .put(
+                            digest,
+                            new StoredManifest(
+                                    digest, mediaType, data.length, artifactType, subject, Map.copyOf(annotations)));
+            if (!byDigest) {
+                tags.computeIfAbsent(name, k -> new HashMap<>()).put(reference, digest);
+            }
+            Map<String, String> headers = new HashMap<>();
+            headers.put(Const.LOCATION_HEADER, "/v2/%s/manifests/%s".formatted(name, digest));
+            headers.put(Const.DOCKER_CONTENT_DIGEST_HEADER, digest);
+            if (subject != null) {
+                headers.put(Const.OCI_SUBJECT_HEADER, subject);
+            }
+            send(exchange, 201, null, headers);
+            return;
+        }
+        Map<String, StoredManifest> repository = manifests.getOrDefault(name, Map.of());
+        Map<String, String> repositoryTags = tags.computeIfAbsent(name, k -> new HashMap<>());
+        String digest = byDigest ? reference : repositoryTags.get(reference);
+        StoredManifest manifest = digest == null ? null : repository.get(digest);
+        if (manifest == null) {
+            sendError(exchange, 404, "MANIFEST_UNKNOWN", "Manifest unknown: %s".formatted(reference));
+            return;
+        }
+        if (method.equals("DELETE")) {
+            if (byDigest) {
+                repository.remove(digest);
+                repositoryTags.values().removeIf(digest::equals);
+            } else {
+                repositoryTags.remove(reference);
+            }
+            send(exchange, 202, null, Map.of());
+            return;
+        }
+        send(
+                exchange,
+                200,
+                store(DigestAlgorithms.fromDigest(digest)).getBlob(digest),
+                Map.of(Const.CONTENT_TYPE_HEADER, manifest.mediaType(), Const.DOCKER_CONTENT_DIGEST_HEADER, digest));
+    }
+
+    private void handleTags(HttpExchange exchange, String name, Map<String, String> query) throws IOException {
+        if (!manifests.containsKey(name)) {
+            sendError(exchange, 404, "NAME_UNKNOWN", "Repository unknown: %s".formatted(name));
+            return;
+        }
+        List<String> all = new ArrayList<>(new TreeMap<>(tags.getOrDefault(name, Map.of())).keySet());
+        String last = query.get("last");
+        if (last != null) {
+            all.removeIf(tag -> tag.compareTo(last) <= 0);
+        }
+        Map<String, String> headers = new HashMap<>();
+        String n = query.get("n");
+        if (n != null && Integer.parseInt(n) < all.size()) {
+            all = all.subList(0, Integer.parseInt(n));
+            headers.put(
+                    Const.LINK_HEADER,
+                    "</v2/%s/tags/list?n=%s&last=%s>; rel=\"next\"".formatted(name, n, all.get(all.size() - 1)));
+        }
+        Map<String, Object> body = new LinkedHashMap<>();
+        body.put("name", name);
+        body.put("tags", all);
+        sendJson(exchange, 200, body, headers);
+    }
+
+    private void handleReferrers(HttpExchange exchange, String name, String digest, Map<String, String> query)
+            throws IOException {
+        String artifactType = query.get("artifactType");
+        List<Map<String, Object>> descriptors = new ArrayList<>();
+        for (StoredManifest manifest : manifests.getOrDefault(name, Map.of()).values()) {
+            if (!digest.equals(manifest.subject())
+                    || (artifactType != null && !artifactType.equals(manifest.artifactType()))) {
+                continue;
+            }
+            Map<String, Object> descriptor = new LinkedHashMap<>();
+            descriptor.put(Const.JSON_PROPERTY_MEDIA_TYPE, manifest.mediaType());
+            descriptor.put(Const.JSON_PROPERTY_DIGEST, manifest.digest());
+            descriptor.put(Const.JSON_PROPERTY_SIZE, manifest.size());
+            if (manifest.artifactType() != null) {
+                descriptor.put(Const.JSON_PROPERTY_ARTIFACT_TYPE, manifest.artifactType());
+            }
+            if (!manifest.annotations().isEmpty()) {
+                descriptor.put(Const.JSON_PROPERTY_ANNOTATIONS, manifest.annotations());
+            }
+            descriptors.add(descriptor);
+        }
+        Map<String, Object> index = new LinkedHashMap<>();
+        index.put(Const.JSON_PROPERTY_SCHEMA_VERSION, 2);
+        index.put(Const.JSON_PROPERTY_MEDIA_TYPE, Const.DEFAULT_INDEX_MEDIA_TYPE);
+        index.put(Const.JSON_PROPERTY_MANIFESTS, descriptors);
+        Map<String, String> headers = new HashMap<>();
+        headers.put(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_INDEX_MEDIA_TYPE);
+        if (artifactType != null) {
+            headers.put(Const.OCI_FILTERS_APPLIED_HEADER, Const.JSON_PROPERTY_ARTIFACT_TYPE);
+        }
+        send(exchange, 200, JsonUtils.toJson(index).getBytes(StandardCharsets.UTF_8), headers);
+    }
+
+    private MemoryStore store(DigestAlgorithm algorithm) {
+        return stores.computeIfAbsent(algorithm.getPrefix(), k -> MemoryStore.create(algorithm));
+    }
+
+    private static Map<String, String> blobHeaders(String name, String digest) {
+        return Map.of(
+                Const.LOCATION_HEADER,
+                "/v2/%s/blobs/%s".formatted(name, digest),
+                Const.DOCKER_CONTENT_DIGEST_HEADER,
+                digest);
+    }
+
+    private static void sendUploadStatus(
+            HttpExchange exchange, int statusCode, String name, String id, ByteArrayOutputStream upload)
+            throws IOException {
+        Map<String, String> headers = new HashMap<>();
+        headers.put(Const.LOCATION_HEADER, "/v2/%s/blobs/uploads/%s".formatted(name, id));
+        headers.put(Const.RANGE_HEADER, "0-%d".formatted(Math.max(0, upload.size() - 1)));
+        headers.put("Docker-Upload-UUID", id);
+        send(exchange, statusCode, null, headers);
+    }
+
+    private static void sendFailure(HttpExchange exchange, int statusCode) throws IOException {
+        exchange.getRequestBody().readAllBytes();
+        Map<String, String> headers = new HashMap<>();
+        if (statusCode == 401) {
+            headers.put(Const.WWW_AUTHENTICATE_HEADER, "Basic realm=\"fake-registry\"");
+        }
+        if (statusCode == 429) {
+            headers.put(Const.RETRY_AFTER_HEADER, "0");
+        }
+        String code =
+                switch (statusCode) {
+                    case 401 -> "UNAUTHORIZED";
+                    case 403 -> "DENIED";
+                    case 429 -> "TOOMANYREQUESTS";
+                    default -> "UNKNOWN";
+                };
+        sendError(exchange, statusCode, code, "Injected failure", headers);
+    }
+
+    private static void sendError(HttpExchange exchange, int statusCode, String code, String message)
+            throws IOException {
+        sendError(exchange, statusCode, code, message, Map.of());
+    }
+
+    private static void sendError(
+            HttpExchange exchange, int statusCode, String code, String message, Map<String, String> headers)
+            throws IOException {
+        sendJson(exchange, statusCode, Map.of("errors", List.of(Map.of("code", code, "message", message))), headers);
+    }
+
+    private static void sendJson(HttpExchange exchange, int statusCode, Object body, Map<String, String> headers)
+            throws IOException {
+        Map<String, String> allHeaders = new HashMap<>(headers);
+        allHeaders.put(Const.CONTENT_TYPE_HEADER, Const.DEFAULT_JSON_MEDIA_TYPE);
+        send(exchange, statusCode, JsonUtils.toJson(body).getBytes(StandardCharsets.UTF_8), allHeaders);
+    }
+
+    private static void send(HttpExchange exchange, int statusCode, byte @Nullable [] body, Map<String, String> headers)
+            throws IOException {
+        headers.forEach((name, value) -> exchange.getResponseHeaders().set(name, value));
+        boolean head = exchange.getRequestMethod().equals("HEAD");
+        if (body == null || body.length == 0 || statusCode == 204) {
+            exchange.sendResponseHeaders(statusCode, -1);
+            return;
+        }
+        if (head) {
+            exchange.getResponseHeaders().set(Const.CONTENT_LENGTH_HEADER, String.valueOf(body.length));
+            exchange.sendResponseHeaders(statusCode, -1);
+            return;
+        }
+        exchange.sendResponseHeaders(statusCode, body.length);
+        exchange.getResponseBody().write(body);
+    }
+
+    private static Map<String, String> parseQuery(URI uri) {
+        Map<String, String> query = new HashMap<>();
+        String rawQuery = uri.getRawQuery();
+        if (rawQuery == null || rawQuery.isEmpty()) {
+            return query;
+        }
+        for (String parameter : rawQuery.split("&")) {
+            int index = parameter.indexOf('=');
+            String key = index < 0 ? parameter : parameter.substring(0, index);
+            String value = index < 0 ? "" : parameter.substring(index + 1);
+            query.put(
+                    URLDecoder.decode(key, StandardCharsets.UTF_8), URLDecoder.decode(value, StandardCharsets.UTF_8));
+        }
+        return query;
+    }
+}
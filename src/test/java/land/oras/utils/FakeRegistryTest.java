@@ -0,0 +1,160 @@
+/*-
+ * =LICENSE=
+ * ORAS Java SDK
+ * ===
+ * Copyright (C) 2024 - 2026 ORAS
+ * ===
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ * =LICENSEEND=
+ */
+
+package land.oras.utils;
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
+
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.time.Duration;
+import java.util.List;
+import land.oras.Annotations;
+import land.oras.ArtifactType;
+import land.oras.ContainerRef;
+import land.oras.Layer;
+import land.oras.LocalPath;
+import land.oras.Manifest;
+import land.oras.Referrers;
+import land.oras.Registry;
+import land.oras.auth.RetryPolicy;
+import land.oras.exception.OrasException;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+import org.junit.jupiter.api.parallel.Execution;
+import org.junit.jupiter.api.parallel.ExecutionMode;
+
+@Execution(ExecutionMode.CONCURRENT)
+class FakeRegistryTest {
+
+    @Test
+    void shouldPushAndPullArtifact(@TempDir Path dir) throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Registry registry = Registry.builder().withInsecure(true).build();
+            ContainerRef ref = ContainerRef.parse("%s/library/artifact:1.0".formatted(fake.getRegistry()));
+            Path file = dir.resolve("hello.txt");
+            Files.writeString(file, "hello");
+            Manifest manifest = registry.pushArtifact(ref, LocalPath.of(file));
+            registry.pushArtifact(ref.withTag("2.0"), LocalPath.of(file));
+
+            Path target = dir.resolve("pulled");
+            Files.createDirectories(target);
+            registry.pullArtifact(ref, target, false);
+
+            // Assertion
+            assertEquals("hello", Files.readString(target.resolve("hello.txt")));
+            assertEquals(List.of("1.0", "2.0"), registry.getTags(ref).tags());
+            assertEquals(List.of("1.0", "2.0"), fake.getTags("library/artifact"));
+            assertTrue(fake.hasBlob("library/artifact", manifest.getLayers().get(0).getDigest()));
+            assertEquals(List.of("library/artifact"), registry.getRepositories().repositories());
+        }
+    }
+
+    @Test
+    void shouldPushChunkedAndMountBlob(@TempDir Path dir) throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Registry registry = Registry.builder().withInsecure(true).build();
+            ContainerRef source = ContainerRef.parse("%s/library/source".formatted(fake.getRegistry()));
+            Path file = dir.resolve("blob");
+            Files.writeString(file, "content uploaded in small chunks");
+            Layer layer = registry.pushBlobChunked(source, file, 4);
+
+            ContainerRef target =
+                    ContainerRef.parse("%s/library/target".formatted(fake.getRegistry())).withDigest(layer.getDigest());
+
+            // Assertion
+            assertEquals(SupportedAlgorithm.SHA256.digest(file), layer.getDigest());
+            assertTrue(registry.mountBlob(source.withDigest(layer.getDigest()), target));
+            assertEquals("content uploaded in small chunks", new String(registry.getBlob(target)));
+        }
+    }
+
+    @Test
+    void shouldListReferrers(@TempDir Path dir) throws Exception {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            Registry registry = Registry.builder().withInsecure(true).build();
+            ContainerRef ref = ContainerRef.parse("%s/library/subject:latest".formatted(fake.getRegistry()));
+            Path file = dir.resolve("file.txt");
+            Files.writeString(file, "subject");
+            registry.pushArtifact(ref, LocalPath.of(file));
+            Path signature = dir.resolve("signature.txt");
+            Files.writeString(signature, "signature");
+            Manifest attached = registry.attachArtifact(
+                    ref,
+                    ArtifactType.from("application/vnd.test.signature"),
+                    Annotations.empty(),
+                    LocalPath.of(signature));
+            registry.attachArtifact(
+                    ref, ArtifactType.from("application/vnd.test.sbom"), Annotations.empty(), LocalPath.of(signature));
+
+            Referrers referrers = registry.getReferrers(ref, ArtifactType.from("application/vnd.test.signature"));
+
+            // Assertion
+            assertEquals(1, referrers.getManifests().size());
+            assertEquals(attached.getDigest(), referrers.getManifests().get(0).getDigest());
+            assertEquals(2, registry.getReferrers(ref, null).getManifests().size());
+        }
+    }
+
+    @Test
+    void shouldInjectFailures() {
+        try (FakeRegistry fake = FakeRegistry.start()) {
+            ContainerRef ref = ContainerRef.parse("%s/library/failing:latest".formatted(fake.getRegistry()));
+            Registry registry = Registry.builder()
+                    .withInsecure(true)
+                    .withRetryPolicy(RetryPolicy.defaults().withInitialDelay(Duration.ofMillis(1)))
+                    .build();
+            registry.pushBlob(ref, "data".getBytes());
+
+            // Rate limited request is retried
+            fake.failNext(429);
+            int before = fake.getRequestCount();
+            registry.pushBlob(ref, "other".getBytes());
+
+            // Assertion
+            assertTrue(fake.getRequestCount() > before + 1);
+            fake.failNext(401);
+            OrasException e = assertThrows(OrasException.class, () -> registry.getManifest(ref));
+            assertEquals(401, e.getStatusCode());
+            OrasException unknown = assertThrows(OrasException.class, () -> registry.getManifest(ref));
+            assertEquals(404, unknown.getStatusCode());
+            assertThrows(IllegalArgumentException.class, () -> fake.failNext(200));
+        }
+    }
+
+    @Test
+    void shouldDelayResponses() {
+        try (FakeRegistry fake = FakeRegistry.start().withDelay(Duration.ofSeconds(2))) {
+            Registry registry = Registry.builder()
+                    .withInsecure(true)
+                    .withRetryPolicy(RetryPolicy.none())
+                    .withReadTimeout(Duration.ofMillis(200))
+                    .build();
+
+            // Assertion
+            assertThrows(
+                    OrasException.class,
+                    () -> registry.getManifest(
+                            ContainerRef.parse("%s/library/slow:latest".formatted(fake.getRegistry()))));
+        }
+    }
+}
@@ -22,6 +22,7 @@ package land.oras.utils;
 
 import static org.junit.jupiter.api.Assertions.assertEquals;
 import static org.junit.jupiter.api.Assertions.assertThrows;
+import static org.junit.jupiter.api.Assertions.assertTrue;
 
 import java.io.ByteArrayInputStream;
 import java.io.FileReader;
@@ -34,6 +35,8 @@ import java.nio.file.Files;
 import java.nio.file.Path;
 import java.util.LinkedHashMap;
 import java.util.List;
+import java.util.Map;
+import java.util.concurrent.atomic.AtomicInteger;
 import land.oras.exception.OrasException;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.io.CleanupMode;
@@ -61,6 +64,19 @@ class JsonUtilsTest {
         assertThrows(OrasException.class, () -> JsonUtils.fromJson(invalidInputStream, Object.class));
     }
 
+    @Test
+    void shouldWriteCanonicalJson() {
+        Map<String, String> annotations = new LinkedHashMap<>();
+        annotations.put("zeta", "<b>&</b>");
+        annotations.put("alpha", "line\u2028separator");
+
+        // Assertion
+        assertEquals(
+                "{\"alpha\":\"line\\u2028separator\",\"zeta\":\"\\u003cb\\u003e\\u0026\\u003c/b\\u003e\"}",
+                JsonUtils.toJson(annotations));
+        assertEquals(annotations, JsonUtils.fromJson(JsonUtils.toJson(annotations), Map.class));
+    }
+
     @Test
     void failToParseJsonString() {
         assertThrows(OrasException.class, () -> JsonUtils.fromJson("not a json", Object.class));
@@ -142,4 +158,36 @@ class JsonUtilsTest {
                 JsonUtils.fromJson(new FileReader(dir.resolve("valid.json").toFile()), type);
         assertEquals(List.of(1.0, 2.0, 3.0, 4.0), list4);
     }
+
+    @Test
+    void shouldDelegateToJsonBackend() {
+        JsonBackend defaultBackend = JsonUtils.getBackend();
+        assertEquals(JacksonJsonBackend.class, defaultBackend.getClass());
+        AtomicInteger calls = new AtomicInteger();
+        JsonBackend counting = new JsonBackend() {
+            @Override
+            public String toJson(Object object) {
+                calls.incrementAndGet();
+                return defaultBackend.toJson(object);
+            }
+
+            @Override
+            public <T> T fromJson(String json, Type type) {
+                calls.incrementAndGet();
+                return defaultBackend.fromJson(json, type);
+            }
+        };
+        JsonUtils.setBackend(counting);
+        try {
+            assertEquals("{\"a\":\"b\"}", JsonUtils.toJson(Map.of("a", "b")));
+            InputStream is = new ByteArrayInputStream("{\"a\":\"b\"}".getBytes(StandardCharsets.UTF_8));
+            assertEquals(Map.of("a", "b"), JsonUtils.fromJson(is, Map.class));
+            assertThrows(OrasException.class, () -> JsonUtils.fromJson("not a json", Object.class));
+        } finally {
+            JsonUtils.setBackend(defaultBackend);
+        }
+
+        // Assertion, stream is read through the default method of the interface. Concurrent tests might also use it
+        assertTrue(calls.get() >= 3);
+    }
 }
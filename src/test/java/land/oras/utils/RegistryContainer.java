@@ -30,6 +30,10 @@ import org.testcontainers.containers.output.Slf4jLogConsumer;
 import org.testcontainers.containers.wait.strategy.Wait;
 import org.testcontainers.utility.MountableFile;
 
+/**
+ * A CNCF distribution registry container protected by htpasswd authentication.
+ * Credentials are {@code myuser} / {@code mypass}.
+ */
 @NullMarked
 public class RegistryContainer extends GenericContainer<RegistryContainer> {
 
@@ -73,6 +77,10 @@ public class RegistryContainer extends GenericContainer<RegistryContainer> {
         return getHost() + ":" + getMappedPort(5000);
     }
 
+    /**
+     * Forward the container output to the logger
+     * @return The container
+     */
     public RegistryContainer withFollowOutput() {
         followOutput(new Slf4jLogConsumer(LOG));
         return this;
@@ -21,7 +21,7 @@
 package land.oras.utils;
 
 /**
- * Container image references used in tests.
+ * Container image references used by the test fixtures.
  */
 public final class TestImages {
 
@@ -43,7 +43,7 @@ import org.bouncycastle.operator.ContentSigner;
 import org.bouncycastle.operator.jcajce.JcaContentSignerBuilder;
 
 /**
- * Utilities for generating TLS certificates and keys in tests.
+ * Utilities for generating TLS certificates and keys used by the test fixtures.
  */
 public final class TlsUtils {
 
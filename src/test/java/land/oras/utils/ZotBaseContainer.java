@@ -29,13 +29,23 @@ import org.testcontainers.containers.GenericContainer;
 import org.testcontainers.containers.output.Slf4jLogConsumer;
 import org.testcontainers.utility.MountableFile;
 
+/**
+ * Base class for Zot registry containers. Subclasses provide the Zot configuration.
+ * @param <T> The container type
+ */
 @NullMarked
 public abstract class ZotBaseContainer<T extends ZotBaseContainer<T>> extends GenericContainer<T> {
 
     private final Logger log = LoggerFactory.getLogger(getClass());
 
-    static final int ZOT_PORT = 5000;
+    /**
+     * The port Zot listens on inside the container
+     */
+    protected static final int ZOT_PORT = 5000;
 
+    /**
+     * Create a new Zot container using the default Zot image
+     */
     protected ZotBaseContainer() {
         super(TestImages.ZOT);
         addExposedPort(ZOT_PORT);
@@ -49,6 +59,10 @@ public abstract class ZotBaseContainer<T extends ZotBaseContainer<T>> extends Ge
         return getHost() + ":" + getMappedPort(ZOT_PORT);
     }
 
+    /**
+     * Forward the container output to the logger
+     * @return The container
+     */
     @SuppressWarnings("unchecked")
     public T withFollowOutput() {
         followOutput(new Slf4jLogConsumer(log));
@@ -25,6 +25,10 @@ import java.nio.file.Path;
 import org.jspecify.annotations.NullMarked;
 import org.testcontainers.containers.wait.strategy.Wait;
 
+/**
+ * A Zot registry container protected by htpasswd authentication.
+ * Credentials are {@code myuser} / {@code mypass}.
+ */
 @NullMarked
 public class ZotContainer extends ZotBaseContainer<ZotContainer> {
 
@@ -27,6 +27,10 @@ import java.security.cert.X509Certificate;
 import org.jspecify.annotations.NullMarked;
 import org.testcontainers.containers.wait.strategy.Wait;
 
+/**
+ * A Zot registry container serving HTTPS with a generated self-signed CA.
+ * Use {@link #getCaCertPath()} to trust the CA from the client.
+ */
 @NullMarked
 public class ZotTlsContainer extends ZotBaseContainer<ZotTlsContainer> {
 
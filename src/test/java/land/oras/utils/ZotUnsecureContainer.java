@@ -23,6 +23,9 @@ package land.oras.utils;
 import org.jspecify.annotations.NullMarked;
 import org.testcontainers.containers.wait.strategy.Wait;
 
+/**
+ * A Zot registry container serving plain HTTP without authentication.
+ */
 @NullMarked
 public class ZotUnsecureContainer extends ZotBaseContainer<ZotUnsecureContainer> {
 